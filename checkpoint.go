@@ -0,0 +1,95 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+)
+
+// ErrCheckpointNotSupported is the error of checkpointing a container with a Docker daemon which doesn't support it,
+// i.e. without its experimental features enabled, or without CRIU installed on its host.
+var ErrCheckpointNotSupported = errors.New("checkpoints not supported by the Docker daemon")
+
+// Checkpoint saves the state of the processes of the running container under the given name, including their memory
+// and the contents of their tmpfs mounts, with CRIU, so that the container can be started from it with StartFromCheckpoint,
+// e.g. to reset a warmed-up JVM or an in-memory database in milliseconds between test groups instead of starting it again.
+// The container keeps running. Checkpointing the container with the name of an existing checkpoint replaces it.
+// The filesystem of the container is not part of the checkpoint: it can be saved with Snapshot.
+// It returns an error wrapping ErrCheckpointNotSupported if the experimental features of the Docker daemon are disabled,
+// or if CRIU is not installed on its host.
+func (c *DockerContainer) Checkpoint(ctx context.Context, name string) error {
+	if !snapshotNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid checkpoint name %q: it must be made of letters, digits, '_', '.' and '-'", name)
+	}
+
+	info, err := c.provider.client.Info(ctx)
+	if err != nil {
+		return fmt.Errorf("checkpoint %s: %w", name, err)
+	}
+	defer c.provider.Close()
+
+	if !info.ExperimentalBuild {
+		return fmt.Errorf("checkpoint %s: %w: the experimental features of the daemon are disabled", name, ErrCheckpointNotSupported)
+	}
+
+	checkpoints, err := c.provider.client.CheckpointList(ctx, c.ID, checkpoint.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("checkpoint %s: %w", name, err)
+	}
+
+	for _, cp := range checkpoints {
+		if cp.Name != name {
+			continue
+		}
+
+		if err := c.provider.client.CheckpointDelete(ctx, c.ID, checkpoint.DeleteOptions{CheckpointID: name}); err != nil {
+			return fmt.Errorf("replace checkpoint %s: %w", name, err)
+		}
+	}
+
+	err = c.provider.client.CheckpointCreate(ctx, c.ID, checkpoint.CreateOptions{CheckpointID: name})
+	if err != nil {
+		if strings.Contains(err.Error(), `"criu": executable file not found`) {
+			return fmt.Errorf("checkpoint %s: %w: %w", name, ErrCheckpointNotSupported, err)
+		}
+
+		return fmt.Errorf("checkpoint %s: %w", name, c.diedError(ctx, err))
+	}
+
+	return nil
+}
+
+// StartFromCheckpoint kills the container, if it's running, and starts it from the checkpoint with the given name,
+// saved with Checkpoint, restoring the state of its processes. The lifecycle hooks of the container run as with Start,
+// so it returns once the wait strategy of the container is satisfied again. The container keeps its ID and its
+// filesystem, and the checkpoint can be restored again, e.g. before each test group.
+// Please note that the ports exposed to the host could be mapped to different ports once the container is restored,
+// so they must be retrieved again with the MappedPort method.
+func (c *DockerContainer) StartFromCheckpoint(ctx context.Context, name string) error {
+	state, err := c.State(ctx)
+	if err != nil {
+		return fmt.Errorf("start from checkpoint %s: %w", name, err)
+	}
+
+	// the state of the processes is discarded, so there is no need to stop them gracefully
+	if state.Running {
+		var timeout time.Duration
+		if err := c.Stop(ctx, &timeout); err != nil {
+			return fmt.Errorf("stop container %s: %w", c.ID, err)
+		}
+	}
+
+	ctx, span := startSpan(ctx, "start", containerSpanAttrs(c)...)
+	err = c.start(ctx, container.StartOptions{CheckpointID: name})
+	if err != nil {
+		err = c.collectDebugBundle(ctx, fmt.Errorf("start from checkpoint %s: %w", name, err))
+	}
+	endSpan(span, err)
+
+	return err
+}
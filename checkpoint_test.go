@@ -0,0 +1,162 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// checkpointClient is a mock implementation of client.APIClient, checkpointing a running container
+// with a daemon whose experimental features are enabled or not.
+type checkpointClient struct {
+	client.APIClient
+
+	experimental bool
+	createErr    error
+	checkpoints  []string
+	running      bool
+	deleted      []string
+	starts       []container.StartOptions
+}
+
+func (m *checkpointClient) Info(context.Context) (system.Info, error) {
+	return system.Info{ExperimentalBuild: m.experimental}, nil
+}
+
+func (m *checkpointClient) CheckpointList(context.Context, string, checkpoint.ListOptions) ([]checkpoint.Summary, error) {
+	summaries := make([]checkpoint.Summary, 0, len(m.checkpoints))
+	for _, name := range m.checkpoints {
+		summaries = append(summaries, checkpoint.Summary{Name: name})
+	}
+
+	return summaries, nil
+}
+
+func (m *checkpointClient) CheckpointDelete(_ context.Context, _ string, options checkpoint.DeleteOptions) error {
+	m.deleted = append(m.deleted, options.CheckpointID)
+	return nil
+}
+
+func (m *checkpointClient) CheckpointCreate(_ context.Context, _ string, options checkpoint.CreateOptions) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+
+	m.checkpoints = append(m.checkpoints, options.CheckpointID)
+	return nil
+}
+
+func (m *checkpointClient) ContainerInspect(_ context.Context, containerID string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: containerID, State: &types.ContainerState{Running: m.running}},
+	}, nil
+}
+
+func (m *checkpointClient) ContainerStop(context.Context, string, container.StopOptions) error {
+	m.running = false
+	return nil
+}
+
+func (m *checkpointClient) ContainerStart(_ context.Context, _ string, options container.StartOptions) error {
+	m.starts = append(m.starts, options)
+	m.running = true
+	return nil
+}
+
+func (m *checkpointClient) Close() error {
+	return nil
+}
+
+func TestCheckpoint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("checkpoint and restore", func(t *testing.T) {
+		cli := &checkpointClient{experimental: true, running: true, checkpoints: []string{"warm"}}
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}, logger: TestLogger(t)}
+
+		// the existing checkpoint is replaced
+		require.NoError(t, c.Checkpoint(ctx, "warm"))
+		require.Equal(t, []string{"warm"}, cli.deleted)
+
+		require.NoError(t, c.StartFromCheckpoint(ctx, "warm"))
+		require.Equal(t, []container.StartOptions{{CheckpointID: "warm"}}, cli.starts)
+		require.True(t, c.IsRunning())
+	})
+
+	t.Run("experimental features disabled", func(t *testing.T) {
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: &checkpointClient{running: true}}}
+
+		err := c.Checkpoint(ctx, "warm")
+		require.ErrorIs(t, err, ErrCheckpointNotSupported)
+		require.EqualError(t, err, "checkpoint warm: checkpoints not supported by the Docker daemon: the experimental features of the daemon are disabled")
+	})
+
+	t.Run("CRIU missing", func(t *testing.T) {
+		cli := &checkpointClient{experimental: true, running: true, createErr: errors.New(`Cannot checkpoint container: exec: "criu": executable file not found in $PATH`)}
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}}
+
+		require.ErrorIs(t, c.Checkpoint(ctx, "warm"), ErrCheckpointNotSupported)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: &checkpointClient{}}}
+
+		require.EqualError(t, c.Checkpoint(ctx, "../warm"), `invalid checkpoint name "../warm": it must be made of letters, digits, '_', '.' and '-'`)
+	})
+}
+
+func TestContainerCheckpoint(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := Run(ctx, "redis:7-alpine",
+		WithCmd("redis-server", "--save", ""),
+		WithExposedPorts("6379/tcp"),
+		WithWaitStrategy(wait.ForLog("Ready to accept connections")),
+	)
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	// Checkpoint and StartFromCheckpoint are not part of the Container interface
+	redis := c.(*DockerContainer)
+
+	redisCLI := func(args ...string) string {
+		t.Helper()
+
+		_, r, err := redis.Exec(ctx, append([]string{"redis-cli"}, args...), tcexec.Multiplexed())
+		require.NoError(t, err)
+
+		bs, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return strings.TrimSpace(string(bs))
+	}
+
+	redisCLI("SET", "state", "warm")
+
+	// checkpointContainer {
+	err = redis.Checkpoint(ctx, "warm")
+	// }
+	if errors.Is(err, ErrCheckpointNotSupported) {
+		t.Skip(err)
+	}
+	require.NoError(t, err)
+
+	redisCLI("SET", "state", "modified")
+
+	// startFromCheckpoint {
+	err = redis.StartFromCheckpoint(ctx, "warm")
+	// }
+	require.NoError(t, err)
+	require.Equal(t, "warm", redisCLI("GET", "state"))
+}
@@ -0,0 +1,118 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DefaultFaketimeLibrary is the path of libfaketime in the image of a container with a fake clock,
+// where the install of its sources puts it.
+const DefaultFaketimeLibrary = "/usr/local/lib/faketime/libfaketime.so.1"
+
+// faketimeFile is the file of the container read by libfaketime for the fake time, so that it can be changed while the container runs.
+const faketimeFile = "/tmp/testcontainers-faketime.rc"
+
+// ErrNoFakeClock is the error of changing the clock of a container started without a fake clock.
+var ErrNoFakeClock = errors.New("the container has no fake clock: start it with WithClockOffset or WithFrozenClock")
+
+// clockOptions are the options of the fake clock of a container.
+type clockOptions struct {
+	library string
+}
+
+// ClockOption is an option of WithClockOffset and WithFrozenClock.
+type ClockOption func(*clockOptions)
+
+// WithFaketimeLibrary sets the path of libfaketime in the image, e.g. /usr/lib/x86_64-linux-gnu/faketime/libfaketime.so.1
+// for the faketime package of Debian and Ubuntu, or /usr/lib/faketime/libfaketime.so.1 for the libfaketime package of Alpine.
+func WithFaketimeLibrary(path string) ClockOption {
+	return func(o *clockOptions) {
+		o.library = path
+	}
+}
+
+// WithClockOffset starts the container with its clock shifted by the given offset from the real time, with a precision of a second,
+// e.g. a year ahead to test the expiry of certificates. The clock is faked with libfaketime, which must be installed in the image,
+// at DefaultFaketimeLibrary unless set with WithFaketimeLibrary, and which fakes the time of the dynamically linked programs only.
+// The clock can be changed while the container runs with SetClockOffset and FreezeClock.
+func WithClockOffset(offset time.Duration, opts ...ClockOption) CustomizeRequestOption {
+	return withFakeClock(clockOffsetSpec(offset), opts)
+}
+
+// WithFrozenClock starts the container with its clock frozen at the given time, e.g. the time of a scheduled job,
+// faked with libfaketime as WithClockOffset does. The time is set in UTC, which is the time zone of the containers
+// unless their TZ environment variable is set.
+func WithFrozenClock(t time.Time, opts ...ClockOption) CustomizeRequestOption {
+	return withFakeClock(frozenClockSpec(t), opts)
+}
+
+// withFakeClock preloads libfaketime in the processes of the container, reading the fake time from the faketime file,
+// which is written with the given specification once the container is created.
+func withFakeClock(spec string, opts []ClockOption) CustomizeRequestOption {
+	o := clockOptions{library: DefaultFaketimeLibrary}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(req *GenericContainerRequest) {
+		if req.Env == nil {
+			req.Env = map[string]string{}
+		}
+
+		preload := o.library
+		if existing := req.Env["LD_PRELOAD"]; existing != "" {
+			preload += ":" + existing
+		}
+		req.Env["LD_PRELOAD"] = preload
+		req.Env["FAKETIME_TIMESTAMP_FILE"] = faketimeFile
+		// the file is read at every call, so that the changes of the clock are seen immediately
+		req.Env["FAKETIME_NO_CACHE"] = "1"
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PostCreates: []ContainerHook{
+				func(ctx context.Context, c Container) error {
+					return c.CopyToContainer(ctx, []byte(spec+"\n"), faketimeFile, 0o644)
+				},
+			},
+		})
+	}
+}
+
+// clockOffsetSpec returns the libfaketime specification of a clock shifted by the offset, e.g. "+3600s".
+func clockOffsetSpec(offset time.Duration) string {
+	return fmt.Sprintf("%+ds", int64(offset/time.Second))
+}
+
+// frozenClockSpec returns the libfaketime specification of a clock frozen at the time, e.g. "2024-01-02 03:04:05".
+func frozenClockSpec(t time.Time) string {
+	return t.UTC().Format(time.DateTime)
+}
+
+// SetClockOffset shifts the clock of the container, started with WithClockOffset or WithFrozenClock, by the given offset
+// from the real time, e.g. to move a running service past the expiry of its certificates. It returns ErrNoFakeClock otherwise.
+func (c *DockerContainer) SetClockOffset(ctx context.Context, offset time.Duration) error {
+	return c.setClock(ctx, clockOffsetSpec(offset))
+}
+
+// FreezeClock freezes the clock of the container, started with WithClockOffset or WithFrozenClock, at the given time,
+// e.g. to trigger a scheduled job. It returns ErrNoFakeClock otherwise.
+func (c *DockerContainer) FreezeClock(ctx context.Context, t time.Time) error {
+	return c.setClock(ctx, frozenClockSpec(t))
+}
+
+// setClock writes the libfaketime specification in the faketime file of the container.
+func (c *DockerContainer) setClock(ctx context.Context, spec string) error {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if inspect.Config == nil || !slices.Contains(inspect.Config.Env, "FAKETIME_TIMESTAMP_FILE="+faketimeFile) {
+		return ErrNoFakeClock
+	}
+
+	return c.CopyToContainer(ctx, []byte(spec+"\n"), faketimeFile, 0o644)
+}
@@ -0,0 +1,149 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+func TestClockSpecs(t *testing.T) {
+	require.Equal(t, "+3600s", clockOffsetSpec(time.Hour))
+	require.Equal(t, "-86400s", clockOffsetSpec(-24*time.Hour))
+	require.Equal(t, "+1s", clockOffsetSpec(1500*time.Millisecond))
+	require.Equal(t, "+0s", clockOffsetSpec(0))
+
+	paris := time.FixedZone("CET", 3600)
+	require.Equal(t, "2024-01-02 03:04:05", frozenClockSpec(time.Date(2024, 1, 2, 4, 4, 5, 0, paris)))
+}
+
+func TestWithClockOffset(t *testing.T) {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Env: map[string]string{"LD_PRELOAD": "/usr/lib/libjemalloc.so.2"},
+		},
+	}
+
+	WithClockOffset(time.Hour, WithFaketimeLibrary("/usr/lib/faketime/libfaketime.so.1"))(&req)
+
+	require.Equal(t, map[string]string{
+		"LD_PRELOAD":              "/usr/lib/faketime/libfaketime.so.1:/usr/lib/libjemalloc.so.2",
+		"FAKETIME_TIMESTAMP_FILE": faketimeFile,
+		"FAKETIME_NO_CACHE":       "1",
+	}, req.Env)
+	require.Len(t, req.LifecycleHooks, 1)
+	require.Len(t, req.LifecycleHooks[0].PostCreates, 1)
+
+	req = GenericContainerRequest{}
+	WithFrozenClock(time.Now())(&req)
+	require.Equal(t, DefaultFaketimeLibrary, req.Env["LD_PRELOAD"])
+}
+
+// clockClient is a mock implementation of client.APIClient, recording the files copied to a container with the given environment.
+type clockClient struct {
+	client.APIClient
+
+	env    []string
+	copied []string
+}
+
+func (m *clockClient) ContainerInspect(_ context.Context, containerID string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: containerID},
+		Config:            &container.Config{Env: m.env},
+	}, nil
+}
+
+func (m *clockClient) CopyToContainer(_ context.Context, _ string, _ string, content io.Reader, _ types.CopyToContainerOptions) error {
+	gz, err := gzip.NewReader(content)
+	if err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(gz)
+	if _, err := tr.Next(); err != nil {
+		return err
+	}
+
+	b, err := io.ReadAll(tr)
+	if err != nil {
+		return err
+	}
+
+	m.copied = append(m.copied, string(b))
+	return nil
+}
+
+func (m *clockClient) Close() error {
+	return nil
+}
+
+func TestDockerContainer_SetClockOffset(t *testing.T) {
+	ctx := context.Background()
+
+	cli := &clockClient{env: []string{"FAKETIME_TIMESTAMP_FILE=" + faketimeFile}}
+	c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}}
+
+	require.NoError(t, c.SetClockOffset(ctx, 48*time.Hour))
+	require.NoError(t, c.FreezeClock(ctx, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.Len(t, cli.copied, 2)
+	require.Equal(t, []string{"+172800s\n", "2030-01-01 00:00:00\n"}, cli.copied)
+
+	cli = &clockClient{}
+	c = &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}}
+	require.ErrorIs(t, c.SetClockOffset(ctx, time.Hour), ErrNoFakeClock)
+	require.Empty(t, cli.copied)
+}
+
+func TestFakeClock(t *testing.T) {
+	ctx := context.Background()
+
+	// withFakeClock {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:    "testdata",
+				Dockerfile: "faketime.Dockerfile",
+			},
+		},
+		Started: true,
+	}
+
+	// the clock of the container is frozen on New Year's Eve
+	WithFrozenClock(time.Date(2029, 12, 31, 23, 59, 59, 0, time.UTC),
+		WithFaketimeLibrary("/usr/lib/faketime/libfaketime.so.1")).Customize(&req)
+
+	c, err := GenericContainer(ctx, req)
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	date := func() string {
+		_, r, err := c.Exec(ctx, []string{"date", "-u", "+%Y-%m-%d %H:%M:%S"}, tcexec.Multiplexed())
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return strings.TrimSpace(string(b))
+	}
+
+	require.Equal(t, "2029-12-31 23:59:59", date())
+
+	// shiftClock {
+	dc := c.(*DockerContainer)
+	err = dc.SetClockOffset(ctx, 365*24*time.Hour)
+	// }
+	require.NoError(t, err)
+
+	require.Equal(t, time.Now().UTC().Add(365*24*time.Hour).Format("2006"), date()[:4])
+}
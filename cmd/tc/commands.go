@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// labelFilter returns the filter of the resources created by Testcontainers for Go, of the given session if it's not empty.
+func labelFilter(session string) filters.Args {
+	args := filters.NewArgs(filters.Arg("label", core.LabelBase+"=true"))
+	if session != "" {
+		args.Add("label", core.LabelSessionID+"="+session)
+	}
+
+	return args
+}
+
+// list writes a table of the containers, networks and volumes created by Testcontainers for Go, of the given session if it's not empty.
+func list(ctx context.Context, cli client.APIClient, w io.Writer, session string) error {
+	f := labelFilter(session)
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: f})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: f})
+	if err != nil {
+		return fmt.Errorf("list networks: %w", err)
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: f})
+	if err != nil {
+		return fmt.Errorf("list volumes: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tID\tNAME\tSESSION\tTEST\tSTATUS")
+	for _, c := range containers {
+		var name string
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		fmt.Fprintf(tw, "container\t%s\t%s\t%s\t%s\t%s\n", shortID(c.ID), name, c.Labels[core.LabelSessionID], c.Labels[core.LabelTest], c.Status)
+	}
+	for _, n := range networks {
+		fmt.Fprintf(tw, "network\t%s\t%s\t%s\t%s\t%s\n", shortID(n.ID), n.Name, n.Labels[core.LabelSessionID], n.Labels[core.LabelTest], "created "+n.Created.Format(time.RFC3339))
+	}
+	for _, v := range volumes.Volumes {
+		fmt.Fprintf(tw, "volume\t-\t%s\t%s\t%s\t%s\n", v.Name, v.Labels[core.LabelSessionID], v.Labels[core.LabelTest], "created "+v.CreatedAt)
+	}
+
+	return tw.Flush()
+}
+
+// shortID returns the short form of an ID of the Docker daemon.
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+
+	return id
+}
+
+// logs writes the logs of the container, following them if requested, from the given number of lines from their end.
+func logs(ctx context.Context, cli client.APIClient, stdout io.Writer, stderr io.Writer, containerID string, follow bool, tail string) error {
+	c, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("inspect container: %w", err)
+	}
+
+	rc, err := cli.ContainerLogs(ctx, c.ID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     follow,
+		Tail:       tail,
+	})
+	if err != nil {
+		return fmt.Errorf("logs of container %s: %w", containerID, err)
+	}
+	defer rc.Close()
+
+	// the logs of the containers without a TTY are multiplexed
+	if c.Config != nil && c.Config.Tty {
+		_, err = io.Copy(stdout, rc)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, rc)
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("logs of container %s: %w", containerID, err)
+	}
+
+	return nil
+}
+
+// clean removes the containers, networks and volumes created by Testcontainers for Go, of the given session if it's not empty,
+// writing the removed ones, and removing as many of them as possible in case of errors.
+func clean(ctx context.Context, cli client.APIClient, w io.Writer, session string) error {
+	f := labelFilter(session)
+
+	var errs []error
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: f})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("list containers: %w", err))
+	}
+	for _, c := range containers {
+		if err := cli.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true, RemoveVolumes: true}); err != nil {
+			errs = append(errs, fmt.Errorf("remove container %s: %w", shortID(c.ID), err))
+			continue
+		}
+		fmt.Fprintf(w, "removed container %s\n", shortID(c.ID))
+	}
+
+	// the networks and volumes are removed once their containers are gone
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: f})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("list networks: %w", err))
+	}
+	for _, n := range networks {
+		if err := cli.NetworkRemove(ctx, n.ID); err != nil {
+			errs = append(errs, fmt.Errorf("remove network %s: %w", n.Name, err))
+			continue
+		}
+		fmt.Fprintf(w, "removed network %s\n", n.Name)
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: f})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("list volumes: %w", err))
+	}
+	for _, v := range volumes.Volumes {
+		if err := cli.VolumeRemove(ctx, v.Name, true); err != nil {
+			errs = append(errs, fmt.Errorf("remove volume %s: %w", v.Name, err))
+			continue
+		}
+		fmt.Fprintf(w, "removed volume %s\n", v.Name)
+	}
+
+	return errors.Join(errs...)
+}
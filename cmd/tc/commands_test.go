@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// sessionClient is a mock implementation of client.APIClient, with the resources of a test session.
+type sessionClient struct {
+	client.APIClient
+
+	filters []string
+	removed []string
+}
+
+func (m *sessionClient) ContainerList(_ context.Context, options container.ListOptions) ([]types.Container, error) {
+	m.filters = options.Filters.Get("label")
+	return []types.Container{
+		{
+			ID:     "0123456789abcdef",
+			Names:  []string{"/redis"},
+			Status: "Up 5 minutes",
+			Labels: map[string]string{core.LabelSessionID: "session", core.LabelTest: "TestRedis"},
+		},
+	}, nil
+}
+
+func (m *sessionClient) NetworkList(context.Context, types.NetworkListOptions) ([]types.NetworkResource, error) {
+	return []types.NetworkResource{
+		{
+			ID:      "fedcba9876543210",
+			Name:    "my-network",
+			Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Labels:  map[string]string{core.LabelSessionID: "session"},
+		},
+	}, nil
+}
+
+func (m *sessionClient) VolumeList(context.Context, volume.ListOptions) (volume.ListResponse, error) {
+	return volume.ListResponse{
+		Volumes: []*volume.Volume{
+			{Name: "my-volume", CreatedAt: "2024-01-02T03:04:05Z", Labels: map[string]string{core.LabelSessionID: "session"}},
+		},
+	}, nil
+}
+
+func (m *sessionClient) ContainerRemove(_ context.Context, containerID string, _ container.RemoveOptions) error {
+	m.removed = append(m.removed, containerID)
+	return nil
+}
+
+func (m *sessionClient) NetworkRemove(context.Context, string) error {
+	return errors.New("network has active endpoints")
+}
+
+func (m *sessionClient) VolumeRemove(_ context.Context, volumeID string, _ bool) error {
+	m.removed = append(m.removed, volumeID)
+	return nil
+}
+
+func (m *sessionClient) ContainerInspect(_ context.Context, containerID string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: containerID},
+		Config:            &container.Config{},
+	}, nil
+}
+
+func (m *sessionClient) ContainerLogs(context.Context, string, container.LogsOptions) (io.ReadCloser, error) {
+	var logs bytes.Buffer
+	_, _ = stdcopy.NewStdWriter(&logs, stdcopy.Stdout).Write([]byte("Ready to accept connections\n"))
+	_, _ = stdcopy.NewStdWriter(&logs, stdcopy.Stderr).Write([]byte("WARNING: overcommit_memory\n"))
+
+	return io.NopCloser(&logs), nil
+}
+
+func (m *sessionClient) Close() error {
+	return nil
+}
+
+func TestList(t *testing.T) {
+	cli := &sessionClient{}
+
+	var out bytes.Buffer
+	require.NoError(t, list(context.Background(), cli, &out, "session"))
+	require.ElementsMatch(t, []string{core.LabelBase + "=true", core.LabelSessionID + "=session"}, cli.filters)
+
+	expected := `TYPE       ID            NAME        SESSION  TEST       STATUS
+container  0123456789ab  redis       session  TestRedis  Up 5 minutes
+network    fedcba987654  my-network  session             created 2024-01-02T03:04:05Z
+volume     -             my-volume   session             created 2024-01-02T03:04:05Z
+`
+	require.Equal(t, expected, out.String())
+}
+
+func TestLogs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, logs(context.Background(), &sessionClient{}, &stdout, &stderr, "redis", false, "all"))
+	require.Equal(t, "Ready to accept connections\n", stdout.String())
+	require.Equal(t, "WARNING: overcommit_memory\n", stderr.String())
+}
+
+func TestClean(t *testing.T) {
+	cli := &sessionClient{}
+
+	var out bytes.Buffer
+	err := clean(context.Background(), cli, &out, "")
+	// the resources are removed as much as possible
+	require.EqualError(t, err, "remove network my-network: network has active endpoints")
+	require.Equal(t, []string{"0123456789abcdef", "my-volume"}, cli.removed)
+	require.Equal(t, []string{core.LabelBase + "=true"}, cli.filters)
+	require.Equal(t, "removed container 0123456789ab\nremoved volume my-volume\n", out.String())
+}
+
+func TestRun(t *testing.T) {
+	connect := func(context.Context) (client.APIClient, error) {
+		return &sessionClient{}, nil
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		err    error
+		stdout string
+		stderr string
+	}{
+		{name: "no command", err: errUsage, stderr: "Usage: tc <command>"},
+		{name: "unknown command", args: []string{"ps"}, err: errUsage, stderr: `unknown command "ps"`},
+		{name: "help", args: []string{"help"}, stdout: "Usage: tc <command>"},
+		{name: "list", args: []string{"list", "-session", "session"}, stdout: "TestRedis"},
+		{name: "logs without container", args: []string{"logs", "-follow"}, err: errUsage, stderr: "a container ID or name is required"},
+		{name: "clean without session", args: []string{"clean"}, err: errUsage, stderr: "either -session or -all is required"},
+		{name: "clean with session and all", args: []string{"clean", "-session", "session", "-all"}, err: errUsage, stderr: "either -session or -all is required"},
+		{name: "invalid flag", args: []string{"list", "-all"}, err: errors.New("flag provided but not defined: -all"), stderr: "flag provided but not defined: -all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(context.Background(), tt.args, &stdout, &stderr, connect)
+			switch {
+			case tt.err == nil:
+				require.NoError(t, err)
+			case errors.Is(tt.err, errUsage):
+				require.ErrorIs(t, err, errUsage)
+			default:
+				require.EqualError(t, err, tt.err.Error())
+			}
+			require.True(t, strings.Contains(stdout.String(), tt.stdout), stdout.String())
+			require.True(t, strings.Contains(stderr.String(), tt.stderr), stderr.String())
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Command tc inspects the containers, networks and volumes created by Testcontainers for Go, by their labels,
+// tails the logs of their containers, and removes the resources of a test session, e.g. the ones a wedged CI job left behind.
+//
+// Usage:
+//
+//	tc list [-session <id>]
+//	tc logs [-follow] [-tail <lines>] <container>
+//	tc clean (-session <id> | -all)
+//
+// It connects to the Docker host detected by Testcontainers for Go, with the same configuration as the tests.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/docker/docker/client"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const usage = `Usage: tc <command> [flags]
+
+Commands:
+  list    list the containers, networks and volumes created by Testcontainers for Go
+  logs    print the logs of a container
+  clean   remove the containers, networks and volumes of a test session, or of all of them
+
+Run "tc <command> -h" for the flags of a command.
+`
+
+// errUsage is the error of an invalid command line, whose usage is already printed.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr, func(ctx context.Context) (client.APIClient, error) {
+		return testcontainers.NewDockerClientWithOpts(ctx)
+	})
+	switch {
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "tc: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command of the arguments, connecting to the Docker host with the given function.
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer, connect func(context.Context) (client.APIClient, error)) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	var cmd func(context.Context, client.APIClient) error
+	fs := flag.NewFlagSet("tc "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	switch args[0] {
+	case "list":
+		session := fs.String("session", "", "only list the resources of the test session with this ID")
+		cmd = func(ctx context.Context, cli client.APIClient) error {
+			return list(ctx, cli, stdout, *session)
+		}
+	case "logs":
+		follow := fs.Bool("follow", false, "follow the logs until the container stops")
+		tail := fs.String("tail", "all", "number of lines to print from the end of the logs")
+		cmd = func(ctx context.Context, cli client.APIClient) error {
+			if fs.NArg() != 1 {
+				fmt.Fprintln(stderr, "tc logs: a container ID or name is required")
+				return errUsage
+			}
+			return logs(ctx, cli, stdout, stderr, fs.Arg(0), *follow, *tail)
+		}
+	case "clean":
+		session := fs.String("session", "", "remove the resources of the test session with this ID")
+		all := fs.Bool("all", false, "remove the resources of all the test sessions")
+		cmd = func(ctx context.Context, cli client.APIClient) error {
+			if (*session == "") == !*all {
+				fmt.Fprintln(stderr, "tc clean: either -session or -all is required")
+				return errUsage
+			}
+			return clean(ctx, cli, stdout, *session)
+		}
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		fmt.Fprintf(stderr, "tc: unknown command %q\n\n%s", args[0], usage)
+		return errUsage
+	}
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	cli, err := connect(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to the Docker host: %w", err)
+	}
+	defer cli.Close()
+
+	return cmd(ctx, cli)
+}
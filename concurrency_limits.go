@@ -0,0 +1,200 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// runningSlotPollInterval is the interval between the checks of the number of running containers,
+// once their maximum is reached.
+var runningSlotPollInterval = time.Second
+
+// ConcurrencyLimits are the limits of the operations on a Docker daemon, e.g. to stay within the quota of a shared
+// CI runner or of a remote daemon. They are shared by all the providers of the test process using the same daemon.
+// A zero limit is read from the configuration, and means no limit if it's not configured either.
+type ConcurrencyLimits struct {
+	// MaxConcurrentCreates is the maximum number of images pulled or built, and of containers created or started,
+	// at the same time by the test process.
+	MaxConcurrentCreates int
+	// MaxRunningContainers is the maximum number of containers created by Testcontainers, and not stopped yet,
+	// on the Docker daemon, including the ones of the other test processes, but not the reaper. Once it's reached,
+	// the creation of a container waits for another one to stop, or for its context to be done.
+	MaxRunningContainers int
+}
+
+// daemonLimiter limits the operations of the providers on a Docker daemon.
+type daemonLimiter struct {
+	// createsMutex guards creates, the tokens of the operations in progress by maximum, as the providers
+	// of the process can be configured with different maximums.
+	createsMutex sync.Mutex
+	creates      map[int]chan struct{}
+
+	// runningMutex serializes the checks of the number of running containers, and guards pending,
+	// the number of containers being created, which are not listed by the daemon yet.
+	runningMutex sync.Mutex
+	pending      int
+}
+
+var (
+	daemonLimitersMutex sync.Mutex
+	daemonLimiters      = map[string]*daemonLimiter{}
+)
+
+// limiterOf returns the limiter of the Docker daemon with the given host.
+func limiterOf(host string) *daemonLimiter {
+	daemonLimitersMutex.Lock()
+	defer daemonLimitersMutex.Unlock()
+
+	l, ok := daemonLimiters[host]
+	if !ok {
+		l = &daemonLimiter{creates: map[int]chan struct{}{}}
+		daemonLimiters[host] = l
+	}
+
+	return l
+}
+
+// createTokens returns the tokens of the operations in progress for the given maximum.
+func (l *daemonLimiter) createTokens(limit int) chan struct{} {
+	l.createsMutex.Lock()
+	defer l.createsMutex.Unlock()
+
+	tokens, ok := l.creates[limit]
+	if !ok {
+		tokens = make(chan struct{}, limit)
+		l.creates[limit] = tokens
+	}
+
+	return tokens
+}
+
+// concurrencyLimits returns the limits of the provider, the ones set with WithConcurrencyLimits taking precedence
+// over the configuration.
+func (p *DockerProvider) concurrencyLimits() ConcurrencyLimits {
+	var limits ConcurrencyLimits
+	if p.DockerProviderOptions != nil && p.GenericProviderOptions != nil {
+		limits = p.ConcurrencyLimits
+	}
+
+	if limits.MaxConcurrentCreates <= 0 {
+		limits.MaxConcurrentCreates = p.config.Config.MaxConcurrentCreates
+	}
+	if limits.MaxRunningContainers <= 0 {
+		limits.MaxRunningContainers = p.config.Config.MaxRunningContainers
+	}
+
+	return limits
+}
+
+// acquireCreateSlot waits until the number of images pulled or built, and of containers created or started,
+// is under its maximum, and returns the function releasing the slot, once the operation is done.
+func (p *DockerProvider) acquireCreateSlot(ctx context.Context, image string) (func(), error) {
+	limit := p.concurrencyLimits().MaxConcurrentCreates
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	tokens := limiterOf(p.host).createTokens(limit)
+	release := func() { <-tokens }
+
+	select {
+	case tokens <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	logf(ctx, p.Logger, slog.LevelInfo, []slog.Attr{slog.String(LogKeyImage, image), slog.String(LogKeyOperation, "create")},
+		"⏳ Waiting for one of the %d concurrent creations to complete", limit)
+
+	select {
+	case tokens <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for a concurrent creation to complete: %w", ctx.Err())
+	}
+}
+
+// acquireRunningSlot waits until the number of containers of Testcontainers running on the Docker daemon,
+// including the ones being created by the process, is under its maximum, and returns the function releasing
+// the slot, once the container is created and listed by the daemon.
+func (p *DockerProvider) acquireRunningSlot(ctx context.Context, image string) (func(), error) {
+	limit := p.concurrencyLimits().MaxRunningContainers
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	l := limiterOf(p.host)
+	release := func() {
+		l.runningMutex.Lock()
+		defer l.runningMutex.Unlock()
+		l.pending--
+	}
+
+	logged := false
+	for {
+		ok, running, err := l.reserveRunningSlot(ctx, p, limit)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return release, nil
+		}
+
+		if !logged {
+			logged = true
+			logf(ctx, p.Logger, slog.LevelInfo, []slog.Attr{slog.String(LogKeyImage, image), slog.String(LogKeyOperation, "create")},
+				"⏳ Waiting for one of the %d running containers to stop, out of a maximum of %d", running, limit)
+		}
+
+		select {
+		case <-time.After(runningSlotPollInterval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for a running container to stop: %w", ctx.Err())
+		}
+	}
+}
+
+// reserveRunningSlot counts the containers of Testcontainers running on the Docker daemon, and the ones being
+// created by the process, and reserves a slot if their number is under the maximum, returning whether it did.
+func (l *daemonLimiter) reserveRunningSlot(ctx context.Context, p *DockerProvider, limit int) (bool, int, error) {
+	l.runningMutex.Lock()
+	defer l.runningMutex.Unlock()
+
+	containers, err := p.client.ContainerList(ctx, container.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", core.LabelBase+"=true"),
+			filters.Arg("status", "created"),
+			filters.Arg("status", "restarting"),
+			filters.Arg("status", "running"),
+			filters.Arg("status", "paused"),
+		),
+	})
+	if err != nil {
+		return false, 0, fmt.Errorf("count running containers: %w", daemonUnavailableError(err))
+	}
+
+	running := l.pending
+	for _, c := range containers {
+		if c.Labels[core.LabelReaper] == "true" || c.Labels[core.LabelRyuk] == "true" {
+			continue
+		}
+		running++
+	}
+
+	if running >= limit {
+		return false, running, nil
+	}
+
+	l.pending++
+
+	return true, running, nil
+}
@@ -0,0 +1,207 @@
+package testcontainers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// runningClient is a mock implementation of client.APIClient, listing the containers of Testcontainers
+// running on the daemon.
+type runningClient struct {
+	client.APIClient
+
+	mutex      sync.Mutex
+	containers []types.Container
+}
+
+func (m *runningClient) ContainerList(_ context.Context, options container.ListOptions) ([]types.Container, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !options.Filters.ExactMatch("label", core.LabelBase+"=true") {
+		return nil, nil
+	}
+
+	return append([]types.Container(nil), m.containers...), nil
+}
+
+func (m *runningClient) stop(id string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i, c := range m.containers {
+		if c.ID == id {
+			m.containers = append(m.containers[:i], m.containers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (m *runningClient) Close() error {
+	return nil
+}
+
+// newLimitedProvider returns a provider of the daemon with the given host, a distinct one per test,
+// as the limits are shared by the providers of the process using the same daemon.
+func newLimitedProvider(t *testing.T, cli client.APIClient, limits ConcurrencyLimits, cfg config.Config) *DockerProvider {
+	t.Helper()
+
+	return &DockerProvider{
+		DockerProviderOptions: &DockerProviderOptions{
+			GenericProviderOptions: &GenericProviderOptions{Logger: TestLogger(t), ConcurrencyLimits: limits},
+		},
+		client: cli,
+		host:   "tcp://" + t.Name() + ":2375",
+		config: TestcontainersConfig{Config: cfg},
+	}
+}
+
+func TestConcurrencyLimits(t *testing.T) {
+	t.Run("option takes precedence over the configuration", func(t *testing.T) {
+		p := newLimitedProvider(t, nil, ConcurrencyLimits{MaxConcurrentCreates: 2}, config.Config{MaxConcurrentCreates: 4, MaxRunningContainers: 10})
+
+		require.Equal(t, ConcurrencyLimits{MaxConcurrentCreates: 2, MaxRunningContainers: 10}, p.concurrencyLimits())
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		p := newLimitedProvider(t, nil, ConcurrencyLimits{}, config.Config{})
+
+		for i := 0; i < 10; i++ {
+			_, err := p.acquireCreateSlot(context.Background(), "nginx:alpine")
+			require.NoError(t, err)
+
+			// the daemon is not queried
+			_, err = p.acquireRunningSlot(context.Background(), "nginx:alpine")
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestConcurrencyLimits_creates(t *testing.T) {
+	p := newLimitedProvider(t, nil, ConcurrencyLimits{MaxConcurrentCreates: 2}, config.Config{})
+
+	release, err := p.acquireCreateSlot(context.Background(), "nginx:alpine")
+	require.NoError(t, err)
+	_, err = p.acquireCreateSlot(context.Background(), "nginx:alpine")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = p.acquireCreateSlot(ctx, "nginx:alpine")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the slots are shared with the other providers of the daemon
+	other := newLimitedProvider(t, nil, ConcurrencyLimits{MaxConcurrentCreates: 2}, config.Config{})
+	acquired := make(chan error)
+	go func() {
+		_, err := other.acquireCreateSlot(context.Background(), "nginx:alpine")
+		acquired <- err
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("the slot was acquired before one was released")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release()
+	require.NoError(t, <-acquired)
+}
+
+func TestConcurrencyLimits_running(t *testing.T) {
+	restore := runningSlotPollInterval
+	runningSlotPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { runningSlotPollInterval = restore })
+
+	cli := &runningClient{containers: []types.Container{
+		{ID: "reaper", Labels: map[string]string{core.LabelBase: "true", core.LabelReaper: "true", core.LabelRyuk: "true"}},
+		{ID: "postgres", Labels: map[string]string{core.LabelBase: "true"}},
+		{ID: "redis", Labels: map[string]string{core.LabelBase: "true"}},
+	}}
+	p := newLimitedProvider(t, cli, ConcurrencyLimits{MaxRunningContainers: 3}, config.Config{})
+
+	// the reaper is not counted
+	release, err := p.acquireRunningSlot(context.Background(), "nginx:alpine")
+	require.NoError(t, err)
+
+	// the container being created is counted until it's listed by the daemon
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = p.acquireRunningSlot(ctx, "nginx:alpine")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	cli.mutex.Lock()
+	cli.containers = append(cli.containers, types.Container{ID: "nginx", Labels: map[string]string{core.LabelBase: "true"}})
+	cli.mutex.Unlock()
+	release()
+
+	acquired := make(chan error)
+	go func() {
+		_, err := p.acquireRunningSlot(context.Background(), "nginx:alpine")
+		acquired <- err
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("the slot was acquired before a container stopped")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cli.stop("redis")
+	require.NoError(t, <-acquired)
+}
+
+func TestWithConcurrencyLimits(t *testing.T) {
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// concurrencyLimits {
+			c, err := GenericContainer(ctx, GenericContainerRequest{
+				ContainerRequest: ContainerRequest{
+					Image:        nginxAlpineImage,
+					ExposedPorts: []string{"80/tcp"},
+					WaitingFor:   wait.ForListeningPort("80/tcp"),
+				},
+				// two images pulled, or containers created or started, at the same time,
+				// and three containers running on the Docker daemon, at most
+				ConcurrencyLimits: ConcurrencyLimits{MaxConcurrentCreates: 2, MaxRunningContainers: 3},
+				Started:           true,
+			})
+			// }
+			terminateContainerOnEnd(t, ctx, c)
+			errs <- err
+			if err != nil {
+				return
+			}
+
+			// a container must stop for the last one to be created
+			stopTimeout := time.Duration(0)
+			errs <- c.Stop(ctx, &stopTimeout)
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -82,6 +85,7 @@ type ImageBuildInfo interface {
 type FromDockerfile struct {
 	Context        string                         // the path to the context of the docker build
 	ContextArchive io.Reader                      // the tar archive file to send to docker that contains the build context
+	ContextFS      fs.FS                          // the file system of the build context, e.g. an embed.FS, honoring its .dockerignore file
 	Dockerfile     string                         // the path from the context to the Dockerfile for the image, defaults to "Dockerfile"
 	Repo           string                         // the repo label for image, defaults to UUID
 	Tag            string                         // the tag label for image, defaults to UUID
@@ -98,20 +102,33 @@ type FromDockerfile struct {
 	BuildOptionsModifier func(*types.ImageBuildOptions)
 }
 
+// ContainerFile represents a file or a directory to be copied into the container before it's started.
+// To copy the content of a reader into a running container, use the CopyReaderToContainer method of the DockerContainer.
 type ContainerFile struct {
-	HostFilePath      string
-	ContainerFilePath string
-	FileMode          int64
+	HostFilePath      string    // the path of the file or directory in the host. It's ignored if Reader or Source is set
+	Reader            io.Reader // the content of the file, e.g. generated by the test. It takes precedence over HostFilePath
+	Source            fs.FS     // the file system of the file or directory, e.g. an embed.FS of fixtures. It takes precedence over HostFilePath
+	Path              string    // the path of the file or directory in Source, its root if empty
+	ContainerFilePath string    // the path of the file in the container, or of the directory the content of a directory of Source is copied to
+	FileMode          int64     // the permissions of the file in the container. For the files of Source, their permissions in Source if zero
 }
 
+var (
+	ErrInvalidExposedPort  = errors.New("invalid exposed port")
+	ErrInvalidEnvName      = errors.New("invalid environment variable name")
+	ErrConflictingNetworks = errors.New("conflicting network settings")
+)
+
 // ContainerRequest represents the parameters used to get a running container
 type ContainerRequest struct {
 	FromDockerfile
 	Image                   string
 	ImageSubstitutors       []ImageSubstitutor
+	ImagePolicies           []ImagePolicy // decide whether the container can be started from the image, once it's pulled or built
 	Entrypoint              []string
 	Env                     map[string]string
 	ExposedPorts            []string // allow specifying protocol info
+	ExposedHostPorts        []int    // host ports to be made reachable from the container at HostInternal:<port>
 	Cmd                     []string
 	Labels                  map[string]string
 	Mounts                  ContainerMounts
@@ -144,6 +161,9 @@ type ContainerRequest struct {
 	EnpointSettingsModifier func(map[string]*network.EndpointSettings) // Modifier for the network settings before container creation
 	LifecycleHooks          []ContainerLifecycleHooks                  // define hooks to be executed during container lifecycle
 	LogConsumerCfg          *LogConsumerConfig                         // define the configuration for the log producer and its log consumers to follow the logs
+	Seeders                 []Seeder                                   // load data into the container once it's ready, in order
+	DependsOn               []ContainerDependency                      // containers waited for until they are ready, or exited successfully, before the container is created
+	ChownToRuntimeUser      bool                                       // whether the files copied to the container, and the writable directories mounted in it, are owned by the user the container runs as instead of root. Linux containers only
 }
 
 // containerOptions functional options for a container
@@ -179,17 +199,20 @@ func (c *ContainerRequest) Validate() error {
 		c.validateContextAndImage,
 		c.validateContextOrImageIsSpecified,
 		c.validateMounts,
+		c.validateExposedPorts,
+		c.validateEnv,
+		c.validateNetworks,
 	}
 
-	var err error
+	// run all the validations, so that all the problems of the request are reported at once
+	var errs []error
 	for _, validationMethod := range validationMethods {
-		err = validationMethod()
-		if err != nil {
-			return err
+		if err := validationMethod(); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // GetContext retrieve the build context for the request
@@ -200,6 +223,15 @@ func (c *ContainerRequest) GetContext() (io.Reader, error) {
 		return c.ContextArchive, nil
 	}
 
+	if c.ContextFS != nil {
+		excluded, err := parseDockerIgnoreFS(c.ContextFS)
+		if err != nil {
+			return nil, err
+		}
+
+		return tarFS(c.ContextFS, excluded, ".dockerignore", path.Clean(filepath.ToSlash(c.GetDockerfile())))
+	}
+
 	// always pass context as absolute path
 	abs, err := filepath.Abs(c.Context)
 	if err != nil {
@@ -240,6 +272,22 @@ func parseDockerIgnore(targetDir string) ([]string, error) {
 	return excluded, nil
 }
 
+// parseDockerIgnoreFS returns the patterns of the .dockerignore file at the root of the file system, if any.
+func parseDockerIgnoreFS(fsys fs.FS) ([]string, error) {
+	f, err := fsys.Open(".dockerignore")
+	if err != nil {
+		return nil, nil
+	}
+	defer f.Close()
+
+	excluded, err := ignorefile.ReadAll(f)
+	if err != nil {
+		return excluded, fmt.Errorf("error reading .dockerignore: %w", err)
+	}
+
+	return excluded, nil
+}
+
 // GetBuildArgs returns the env args to be used when creating from Dockerfile
 func (c *ContainerRequest) GetBuildArgs() map[string]*string {
 	return c.FromDockerfile.BuildArgs
@@ -283,7 +331,18 @@ func (c *ContainerRequest) GetAuthConfigs() map[string]registry.AuthConfig {
 
 // getAuthConfigsFromDockerfile returns the auth configs to be able to pull from an authenticated docker registry
 func getAuthConfigsFromDockerfile(c *ContainerRequest) map[string]registry.AuthConfig {
-	images, err := core.ExtractImagesFromDockerfile(filepath.Join(c.Context, c.GetDockerfile()), c.GetBuildArgs())
+	var images []string
+	var err error
+	if c.ContextFS != nil {
+		var f fs.File
+		f, err = c.ContextFS.Open(path.Clean(filepath.ToSlash(c.GetDockerfile())))
+		if err == nil {
+			images, err = core.ExtractImagesFromReader(f, c.GetBuildArgs())
+			f.Close()
+		}
+	} else {
+		images, err = core.ExtractImagesFromDockerfile(filepath.Join(c.Context, c.GetDockerfile()), c.GetBuildArgs())
+	}
 	if err != nil {
 		return map[string]registry.AuthConfig{}
 	}
@@ -302,7 +361,7 @@ func getAuthConfigsFromDockerfile(c *ContainerRequest) map[string]registry.AuthC
 }
 
 func (c *ContainerRequest) ShouldBuildImage() bool {
-	return c.FromDockerfile.Context != "" || c.FromDockerfile.ContextArchive != nil
+	return c.FromDockerfile.Context != "" || c.FromDockerfile.ContextArchive != nil || c.FromDockerfile.ContextFS != nil
 }
 
 func (c *ContainerRequest) ShouldKeepBuiltImage() bool {
@@ -328,7 +387,8 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 
 	// apply mandatory values after the modifier
 	buildOptions.BuildArgs = c.GetBuildArgs()
-	buildOptions.Dockerfile = c.GetDockerfile()
+	// the daemon expects a path with forward slashes, also when the tests run on Windows
+	buildOptions.Dockerfile = filepath.ToSlash(c.GetDockerfile())
 
 	buildContext, err := c.GetContext()
 	if err != nil {
@@ -360,7 +420,7 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 }
 
 func (c *ContainerRequest) validateContextAndImage() error {
-	if c.FromDockerfile.Context != "" && c.Image != "" {
+	if (c.FromDockerfile.Context != "" || c.FromDockerfile.ContextArchive != nil || c.FromDockerfile.ContextFS != nil) && c.Image != "" {
 		return errors.New("you cannot specify both an Image and Context in a ContainerRequest")
 	}
 
@@ -368,13 +428,67 @@ func (c *ContainerRequest) validateContextAndImage() error {
 }
 
 func (c *ContainerRequest) validateContextOrImageIsSpecified() error {
-	if c.FromDockerfile.Context == "" && c.FromDockerfile.ContextArchive == nil && c.Image == "" {
+	if c.FromDockerfile.Context == "" && c.FromDockerfile.ContextArchive == nil && c.FromDockerfile.ContextFS == nil && c.Image == "" {
 		return errors.New("you must specify either a build context or an image")
 	}
 
 	return nil
 }
 
+// validateExposedPorts ensures that the exposed ports are valid port specs,
+// e.g. "8080", "8080/tcp" or "127.0.0.1:8080:80/tcp".
+func (c *ContainerRequest) validateExposedPorts() error {
+	var errs []error
+	for _, port := range c.ExposedPorts {
+		if _, err := nat.ParsePortSpec(port); err != nil {
+			errs = append(errs, fmt.Errorf("%w %q: %w", ErrInvalidExposedPort, port, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateEnv ensures that the names of the environment variables are not empty and don't contain "=".
+func (c *ContainerRequest) validateEnv() error {
+	var invalid []string
+	for key := range c.Env {
+		if key == "" || strings.Contains(key, "=") {
+			invalid = append(invalid, key)
+		}
+	}
+
+	// sort the names, as the order of the keys of the map is random
+	sort.Strings(invalid)
+
+	errs := make([]error, 0, len(invalid))
+	for _, key := range invalid {
+		errs = append(errs, fmt.Errorf("%w: %q", ErrInvalidEnvName, key))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateNetworks ensures that the container is not attached to networks
+// when its network mode is the host's network stack, no network or another container's network stack.
+// It will check the NetworkMode and HostConfigModifier.NetworkMode fields.
+func (c *ContainerRequest) validateNetworks() error {
+	if len(c.Networks) == 0 {
+		return nil
+	}
+
+	hostConfig := container.HostConfig{NetworkMode: c.NetworkMode}
+	if c.HostConfigModifier != nil {
+		c.HostConfigModifier(&hostConfig)
+	}
+
+	mode := hostConfig.NetworkMode
+	if mode.IsHost() || mode.IsNone() || mode.IsContainer() {
+		return fmt.Errorf("%w: network mode %q can't be combined with the networks %v", ErrConflictingNetworks, mode, c.Networks)
+	}
+
+	return nil
+}
+
 // validateMounts ensures that the mounts do not have duplicate targets.
 // It will check the Mounts and HostConfigModifier.Binds fields.
 func (c *ContainerRequest) validateMounts() error {
@@ -400,7 +514,7 @@ func (c *ContainerRequest) validateMounts() error {
 
 	if hostConfig.Binds != nil && len(hostConfig.Binds) > 0 {
 		for _, bind := range hostConfig.Binds {
-			parts := strings.Split(bind, ":")
+			parts := splitBind(bind)
 			if len(parts) != 2 {
 				return fmt.Errorf("%w: %s", ErrInvalidBindMount, bind)
 			}
@@ -4,15 +4,23 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"embed"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -53,6 +61,25 @@ func Test_ContainerValidation(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:          "cannot set both context file system and image",
+			ExpectedError: errors.New("you cannot specify both an Image and Context in a ContainerRequest"),
+			ContainerRequest: ContainerRequest{
+				FromDockerfile: FromDockerfile{
+					ContextFS: fstest.MapFS{},
+				},
+				Image: "redis:latest",
+			},
+		},
+		{
+			Name:          "can set context file system without image",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				FromDockerfile: FromDockerfile{
+					ContextFS: fstest.MapFS{},
+				},
+			},
+		},
 		{
 			Name:          "Can mount same source to multiple targets",
 			ExpectedError: nil,
@@ -83,6 +110,96 @@ func Test_ContainerValidation(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:          "Can mount Windows paths",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				Image: "mcr.microsoft.com/windows/nanoserver:ltsc2022",
+				HostConfigModifier: func(hc *container.HostConfig) {
+					hc.Binds = []string{`C:\data:C:\data`, `D:\data:C:\srv`}
+				},
+			},
+		},
+		{
+			Name:          "Cannot mount multiple sources to same Windows target",
+			ExpectedError: errors.New(`duplicate mount target detected: C:\data`),
+			ContainerRequest: ContainerRequest{
+				Image: "mcr.microsoft.com/windows/nanoserver:ltsc2022",
+				HostConfigModifier: func(hc *container.HostConfig) {
+					hc.Binds = []string{`C:\data:C:\data`, `D:\data:C:\data`}
+				},
+			},
+		},
+		{
+			Name:          "cannot set both context archive and image",
+			ExpectedError: errors.New("you cannot specify both an Image and Context in a ContainerRequest"),
+			ContainerRequest: ContainerRequest{
+				FromDockerfile: FromDockerfile{
+					ContextArchive: bytes.NewReader(nil),
+				},
+				Image: "redis:latest",
+			},
+		},
+		{
+			Name:          "can expose ports with protocol and host bindings",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				Image:        "redis:latest",
+				ExposedPorts: []string{"6379", "6379/tcp", "127.0.0.1:16379:6379/tcp"},
+			},
+		},
+		{
+			Name:          "Invalid exposed port",
+			ExpectedError: errors.New(`invalid exposed port "6379/foo": invalid proto: foo`),
+			ContainerRequest: ContainerRequest{
+				Image:        "redis:latest",
+				ExposedPorts: []string{"6379/foo"},
+			},
+		},
+		{
+			Name:          "Invalid environment variable names",
+			ExpectedError: errors.New(`invalid environment variable name: ""` + "\n" + `invalid environment variable name: "FOO=BAR"`),
+			ContainerRequest: ContainerRequest{
+				Image: "redis:latest",
+				Env: map[string]string{
+					"FOO=BAR": "baz",
+					"":        "baz",
+					"FOO":     "bar",
+				},
+			},
+		},
+		{
+			Name:          "Cannot attach to networks with the host network mode",
+			ExpectedError: errors.New(`conflicting network settings: network mode "host" can't be combined with the networks [my-network]`),
+			ContainerRequest: ContainerRequest{
+				Image:    "redis:latest",
+				Networks: []string{"my-network"},
+				HostConfigModifier: func(hc *container.HostConfig) {
+					hc.NetworkMode = "host"
+				},
+			},
+		},
+		{
+			Name:          "Can attach to networks with the bridge network mode",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				Image:       "redis:latest",
+				Networks:    []string{"my-network"},
+				NetworkMode: "bridge",
+			},
+		},
+		{
+			Name: "all the problems are reported at once",
+			ExpectedError: errors.New("you must specify either a build context or an image" + "\n" +
+				`invalid exposed port "foo": invalid containerPort: foo` + "\n" +
+				`invalid environment variable name: "FOO=BAR"`),
+			ContainerRequest: ContainerRequest{
+				ExposedPorts: []string{"foo"},
+				Env: map[string]string{
+					"FOO=BAR": "baz",
+				},
+			},
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -143,11 +260,17 @@ func Test_GetDockerfile(t *testing.T) {
 	}
 }
 
+// echoContext is the build context of the echo Dockerfile, embedded in the test binary.
+//
+//go:embed testdata/echo.Dockerfile
+var echoContext embed.FS
+
 func Test_BuildImageWithContexts(t *testing.T) {
 	type TestCase struct {
 		Name               string
 		ContextPath        string
 		ContextArchive     func() (io.Reader, error)
+		ContextFS          fs.FS
 		ExpectedEchoOutput string
 		Dockerfile         string
 		ExpectedError      error
@@ -259,6 +382,17 @@ func Test_BuildImageWithContexts(t *testing.T) {
 				return nil, nil
 			},
 		},
+		{
+			Name: "test building from a context file system",
+			// fromDockerfileWithContextFS {
+			ContextFS:  echoContext,
+			Dockerfile: "testdata/echo.Dockerfile",
+			// }
+			ExpectedEchoOutput: "this is from the echo test Dockerfile",
+			ContextArchive: func() (io.Reader, error) {
+				return nil, nil
+			},
+		},
 		{
 			Name:        "it should error if neither a context nor a context archive are specified",
 			ContextPath: "",
@@ -281,6 +415,7 @@ func Test_BuildImageWithContexts(t *testing.T) {
 			req := ContainerRequest{
 				FromDockerfile: FromDockerfile{
 					ContextArchive: a,
+					ContextFS:      testCase.ContextFS,
 					Context:        testCase.ContextPath,
 					Dockerfile:     testCase.Dockerfile,
 				},
@@ -513,6 +648,46 @@ func TestParseDockerIgnore(t *testing.T) {
 	}
 }
 
+func TestParseDockerIgnoreFS(t *testing.T) {
+	excluded, err := parseDockerIgnoreFS(os.DirFS(filepath.Join("testdata", "dockerignore")))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vendor", "foo", "bar"}, excluded)
+
+	excluded, err = parseDockerIgnoreFS(fstest.MapFS{})
+	require.NoError(t, err)
+	assert.Empty(t, excluded)
+}
+
+func TestGetContext_fileSystem(t *testing.T) {
+	req := ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			ContextFS: fstest.MapFS{
+				".dockerignore":        {Data: []byte("*\n")},
+				"build/app.Dockerfile": {Data: []byte("FROM alpine\n")},
+				"secret.txt":           {Data: []byte("secret")},
+			},
+			Dockerfile: "build/app.Dockerfile",
+		},
+	}
+	require.True(t, req.ShouldBuildImage())
+
+	buildContext, err := req.GetContext()
+	require.NoError(t, err)
+
+	var names []string
+	tr := tar.NewReader(buildContext)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+	// the Dockerfile and the .dockerignore file are sent, even if they are ignored
+	assert.Equal(t, []string{".dockerignore", "build/app.Dockerfile"}, names)
+}
+
 func ExampleGenericContainer_withSubstitutors() {
 	ctx := context.Background()
 
@@ -544,3 +719,170 @@ func ExampleGenericContainer_withSubstitutors() {
 
 	// Output: docker.io/alpine:latest
 }
+
+// inspectCountingClient is a mock implementation of client.APIClient, which is handy for
+// counting the round-trips to the Docker daemon needed to inspect a container.
+type inspectCountingClient struct {
+	client.APIClient
+	inspections  int
+	hostPort     string
+	hostPortIPv6 string
+}
+
+func (m *inspectCountingClient) ContainerInspect(_ context.Context, containerID string) (types.ContainerJSON, error) {
+	m.inspections++
+
+	bindings := []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: m.hostPort}}
+	if m.hostPortIPv6 != "" {
+		bindings = append(bindings, nat.PortBinding{HostIP: "::", HostPort: m.hostPortIPv6})
+	}
+
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:         containerID,
+			Name:       "/inspected",
+			HostConfig: &container.HostConfig{},
+			State:      &types.ContainerState{Running: true},
+		},
+		NetworkSettings: &types.NetworkSettings{
+			NetworkSettingsBase: types.NetworkSettingsBase{
+				Ports: nat.PortMap{
+					"80/tcp": bindings,
+				},
+			},
+		},
+	}, nil
+}
+
+func (m *inspectCountingClient) ContainerPause(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *inspectCountingClient) Close() error {
+	return nil
+}
+
+func TestDockerContainer_Info(t *testing.T) {
+	ctx := context.Background()
+
+	cli := &inspectCountingClient{hostPort: "32768"}
+	c := &DockerContainer{
+		ID:       "container-id",
+		provider: &DockerProvider{client: cli},
+	}
+
+	t.Run("lookups use the cached information", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			port, err := c.MappedPort(ctx, "80/tcp")
+			require.NoError(t, err)
+			assert.Equal(t, nat.Port("32768/tcp"), port)
+		}
+
+		name, err := c.Name(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "/inspected", name)
+
+		info, err := c.Info(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "container-id", info.ID)
+
+		assert.Equal(t, 1, cli.inspections)
+	})
+
+	t.Run("refresh inspects the container again", func(t *testing.T) {
+		cli.hostPort = "32769"
+
+		port, err := c.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		assert.Equal(t, nat.Port("32768/tcp"), port)
+
+		require.NoError(t, c.Refresh(ctx))
+
+		port, err = c.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		assert.Equal(t, nat.Port("32769/tcp"), port)
+
+		assert.Equal(t, 2, cli.inspections)
+	})
+
+	t.Run("state changes discard the cached information", func(t *testing.T) {
+		require.NoError(t, c.Pause(ctx))
+
+		_, err := c.Info(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, cli.inspections)
+	})
+
+	t.Run("unknown ports are looked up again", func(t *testing.T) {
+		_, err := c.MappedPort(ctx, "8080/tcp")
+		require.Error(t, err)
+
+		assert.Equal(t, 4, cli.inspections)
+	})
+
+	t.Run("state is always inspected", func(t *testing.T) {
+		state, err := c.State(ctx)
+		require.NoError(t, err)
+		assert.True(t, state.Running)
+
+		assert.Equal(t, 5, cli.inspections)
+	})
+}
+
+func TestDockerContainer_MappedPortIPv6(t *testing.T) {
+	ctx := context.Background()
+
+	cli := &inspectCountingClient{hostPort: "32768"}
+	c := &DockerContainer{
+		ID:       "container-id",
+		provider: &DockerProvider{client: cli},
+	}
+
+	// the cached information predates the binding of the port on the IPv6 interfaces
+	_, err := c.MappedPort(ctx, "80/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, 1, cli.inspections)
+
+	cli.hostPortIPv6 = "32769"
+
+	port, err := c.MappedPortIPv6(ctx, "80/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, nat.Port("32769/tcp"), port)
+	assert.Equal(t, 2, cli.inspections)
+
+	// the port is found in the refreshed information
+	port, err = c.MappedPortIPv6(ctx, "80/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, nat.Port("32769/tcp"), port)
+	assert.Equal(t, 2, cli.inspections)
+}
+
+func TestNewContainerStatus(t *testing.T) {
+	inspect := &types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:           "container-id",
+			RestartCount: 2,
+			State: &types.ContainerState{
+				Running:    true,
+				Health:     &types.Health{Status: types.Healthy},
+				StartedAt:  "2024-01-02T03:04:05.123456789Z",
+				FinishedAt: "0001-01-01T00:00:00Z",
+			},
+		},
+	}
+
+	status, err := newContainerStatus(inspect)
+	require.NoError(t, err)
+
+	assert.True(t, status.Running)
+	assert.False(t, status.Paused)
+	assert.Equal(t, types.Healthy, status.Health)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC), status.StartedAt)
+	assert.True(t, status.FinishedAt.IsZero())
+	assert.Equal(t, 2, status.RestartCount)
+
+	inspect.State.StartedAt = "yesterday"
+	_, err = newContainerStatus(inspect)
+	require.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	// debugBundleLogLines is the number of lines of the logs of the container in a debug bundle.
+	debugBundleLogLines = "200"
+
+	// debugBundleTimeout is the time to collect a debug bundle, which is collected even if the context
+	// of the failed operation is done, e.g. because the wait strategy timed out.
+	debugBundleTimeout = 30 * time.Second
+)
+
+// DebugBundleError is the error of a container that failed to start, along with the directory
+// of the debug bundle collected for the failure.
+type DebugBundleError struct {
+	// Dir is the directory of the debug bundle.
+	Dir string
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DebugBundleError) Error() string {
+	return fmt.Sprintf("%s (debug bundle: %s)", e.Err, e.Dir)
+}
+
+// Unwrap returns the error of the failed startup.
+func (e *DebugBundleError) Unwrap() error {
+	return e.Err
+}
+
+// collectDebugBundle writes a debug bundle for the failed startup of the container, if the debug.bundle.dir
+// property of the configuration is set, returning the cause wrapped in a DebugBundleError. Otherwise,
+// or if the directory of the bundle can't be created, the cause is returned as is. The bundle contains:
+//
+//   - error.txt: the error of the failed startup.
+//   - inspect.json: the inspect information of the container.
+//   - diagnostics.txt: how the main process of the container exited, if it did, e.g. OOM-killed, and its peak memory usage.
+//   - logs.txt: the last lines of the logs of the container.
+//   - events.json: the events of the container since it was created, one per line.
+//   - daemon.json: the information of the Docker daemon.
+//
+// The parts of the bundle that can't be collected are replaced with a file with the .error extension.
+func (c *DockerContainer) collectDebugBundle(ctx context.Context, cause error) error {
+	root := c.provider.config.Config.DebugBundleDir
+	if root == "" {
+		return cause
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), debugBundleTimeout)
+	defer cancel()
+
+	dir := filepath.Join(root, fmt.Sprintf("%s-%s", shortID(c.ID), time.Now().Format("20060102T150405.000")))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logf(ctx, c.logger, slog.LevelWarn, containerAttrs(c, "debug"), "failed creating the debug bundle directory %s: %v", dir, err)
+		return cause
+	}
+
+	writeDebugBundleFile(dir, "error.txt", func() ([]byte, error) {
+		return []byte(cause.Error() + "\n"), nil
+	})
+
+	var created time.Time
+	var tty bool
+	writeDebugBundleFile(dir, "inspect.json", func() ([]byte, error) {
+		inspect, err := c.provider.client.ContainerInspect(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		created, _ = time.Parse(time.RFC3339Nano, inspect.Created)
+		tty = inspect.Config != nil && inspect.Config.Tty
+
+		return json.MarshalIndent(inspect, "", "  ")
+	})
+
+	writeDebugBundleFile(dir, "diagnostics.txt", func() ([]byte, error) {
+		d, err := c.Diagnostics(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(d.String() + "\n"), nil
+	})
+
+	writeDebugBundleFile(dir, "logs.txt", func() ([]byte, error) {
+		return c.debugBundleLogs(ctx, tty)
+	})
+
+	writeDebugBundleFile(dir, "events.json", func() ([]byte, error) {
+		return c.debugBundleEvents(ctx, created)
+	})
+
+	writeDebugBundleFile(dir, "daemon.json", func() ([]byte, error) {
+		info, err := c.provider.client.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.MarshalIndent(info, "", "  ")
+	})
+
+	logf(ctx, c.logger, slog.LevelError, containerAttrs(c, "debug"), "📦 Debug bundle of container %s written to %s", shortID(c.ID), dir)
+
+	return &DebugBundleError{Dir: dir, Err: cause}
+}
+
+// debugBundleLogs returns the last lines of the logs of the container, with their timestamps,
+// demultiplexing the standard output and error if the container has no TTY.
+func (c *DockerContainer) debugBundleLogs(ctx context.Context, tty bool) ([]byte, error) {
+	rc, err := c.provider.client.ContainerLogs(ctx, c.ID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+		Tail:       debugBundleLogLines,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	// the logs of a container with a TTY are not multiplexed
+	if tty {
+		return io.ReadAll(rc)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := stdcopy.StdCopy(buf, buf, rc); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// debugBundleEvents returns the events of the container since the given time, as JSON lines.
+func (c *DockerContainer) debugBundleEvents(ctx context.Context, since time.Time) ([]byte, error) {
+	if since.IsZero() {
+		since = time.Now().Add(-time.Hour)
+	}
+
+	messages, errs := c.provider.client.Events(ctx, types.EventsOptions{
+		Filters: filters.NewArgs(filters.Arg("container", c.ID)),
+		Since:   strconv.FormatInt(since.Unix(), 10),
+		Until:   strconv.FormatInt(time.Now().Unix()+1, 10),
+	})
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for {
+		select {
+		case msg := <-messages:
+			if err := encoder.Encode(msg); err != nil {
+				return nil, err
+			}
+		case err := <-errs:
+			// the stream of events ends with io.EOF once the until time is reached
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+
+			return buf.Bytes(), nil
+		}
+	}
+}
+
+// writeDebugBundleFile writes the content returned by the given function to the named file of the bundle,
+// or its error to a file with the same name and the .error extension.
+func writeDebugBundleFile(dir string, name string, content func() ([]byte, error)) {
+	b, err := content()
+	if err != nil {
+		name = name + ".error"
+		b = []byte(err.Error() + "\n")
+	}
+
+	_ = os.WriteFile(filepath.Join(dir, name), b, 0o644)
+}
@@ -0,0 +1,124 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// unreachableClient is a mock implementation of client.APIClient, failing every request to the Docker daemon.
+type unreachableClient struct {
+	client.APIClient
+}
+
+var errUnreachable = errors.New("daemon unreachable")
+
+func (m *unreachableClient) ContainerInspect(_ context.Context, _ string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{}, errUnreachable
+}
+
+func (m *unreachableClient) ContainerLogs(_ context.Context, _ string, _ container.LogsOptions) (io.ReadCloser, error) {
+	return nil, errUnreachable
+}
+
+func (m *unreachableClient) Events(_ context.Context, _ types.EventsOptions) (<-chan events.Message, <-chan error) {
+	errs := make(chan error, 1)
+	errs <- errUnreachable
+
+	return make(chan events.Message), errs
+}
+
+func (m *unreachableClient) Info(_ context.Context) (system.Info, error) {
+	return system.Info{}, errUnreachable
+}
+
+func (m *unreachableClient) Close() error {
+	return nil
+}
+
+func TestDebugBundleError(t *testing.T) {
+	cause := errors.New("container exited with code 1")
+	err := &DebugBundleError{Dir: "/tmp/bundles/1234567890ab", Err: cause}
+
+	require.EqualError(t, err, "container exited with code 1 (debug bundle: /tmp/bundles/1234567890ab)")
+	require.ErrorIs(t, err, cause)
+}
+
+func TestCollectDebugBundle_shortID(t *testing.T) {
+	dir := t.TempDir()
+	cause := errors.New("container exited with code 1")
+
+	// the ID of the container is shorter than the short form of the IDs of the Docker daemon
+	c := &DockerContainer{
+		ID:       "abc",
+		provider: &DockerProvider{client: &unreachableClient{}, config: TestcontainersConfig{Config: config.Config{DebugBundleDir: dir}}},
+		logger:   TestLogger(t),
+	}
+
+	err := c.collectDebugBundle(context.Background(), cause)
+
+	var bundleErr *DebugBundleError
+	require.ErrorAs(t, err, &bundleErr)
+	require.ErrorIs(t, err, cause)
+	require.Equal(t, dir, filepath.Dir(bundleErr.Dir))
+	require.Regexp(t, `^abc-`, filepath.Base(bundleErr.Dir))
+
+	// the parts that can't be collected are replaced with their errors
+	for _, name := range []string{"inspect.json.error", "diagnostics.txt.error", "logs.txt.error", "events.json.error", "daemon.json.error"} {
+		require.FileExists(t, filepath.Join(bundleErr.Dir, name))
+	}
+}
+
+func TestCollectDebugBundle(t *testing.T) {
+	// debugBundle {
+	dir := t.TempDir()
+	t.Setenv("TESTCONTAINERS_DEBUG_BUNDLE_DIR", dir)
+	// }
+	config.Reset()
+	t.Cleanup(config.Reset)
+
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			Cmd:        []string{"sh", "-c", "echo 'starting' && exit 1"},
+			WaitingFor: wait.ForLog("never printed").WithStartupTimeout(5 * time.Second),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, c)
+
+	var bundleErr *DebugBundleError
+	require.ErrorAs(t, err, &bundleErr)
+	require.Equal(t, dir, filepath.Dir(bundleErr.Dir))
+
+	// the container exited before its wait strategy passed
+	require.ErrorIs(t, err, ErrContainerDied)
+
+	for _, name := range []string{"error.txt", "inspect.json", "diagnostics.txt", "logs.txt", "events.json", "daemon.json"} {
+		require.FileExists(t, filepath.Join(bundleErr.Dir, name))
+	}
+
+	diagnostics, err := os.ReadFile(filepath.Join(bundleErr.Dir, "diagnostics.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "exit code 1\n", string(diagnostics))
+
+	logs, err := os.ReadFile(filepath.Join(bundleErr.Dir, "logs.txt"))
+	require.NoError(t, err)
+	require.Contains(t, string(logs), "starting")
+}
@@ -0,0 +1,188 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// ErrDependencyFailed is the error of a container whose dependency exited with an error before it was created.
+var ErrDependencyFailed = errors.New("dependency failed")
+
+// dependencyPollInterval is the interval between the checks of the state of a dependency which is not running yet.
+const dependencyPollInterval = 100 * time.Millisecond
+
+// ContainerDependency is a container the container of a request depends on: it's waited for until it's ready,
+// or until it exits successfully, e.g. a container running the migrations of a database, before the container is created.
+type ContainerDependency struct {
+	Container Container
+	// Env are the environment variables of the dependent container computed from the dependency once it's ready,
+	// as text/template templates of its DependencyEndpoints, e.g. "{{ .Host }}:{{ .MappedPort "5432/tcp" }}".
+	Env map[string]string
+}
+
+// WithDependsOn makes the container depend on the given containers, which are waited for before it's created,
+// e.g. when they are started concurrently, until they are running and their wait strategy passes,
+// or until they exit successfully. A dependency exiting with an error fails the creation of the container.
+func WithDependsOn(containers ...Container) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		for _, c := range containers {
+			req.DependsOn = append(req.DependsOn, ContainerDependency{Container: c})
+		}
+	}
+}
+
+// WithDependencyEnv makes the container depend on the given container, as WithDependsOn does, setting the given
+// environment variables from the templates of its DependencyEndpoints once it's ready, e.g.
+// "postgres://postgres:postgres@{{ .Host }}:{{ .MappedPort "5432/tcp" }}/db" for a client on the host network,
+// or "{{ .Alias "backend" }}:5432" for a client sharing the backend network with the dependency.
+func WithDependencyEnv(dependency Container, env map[string]string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.DependsOn = append(req.DependsOn, ContainerDependency{Container: dependency, Env: env})
+	}
+}
+
+// DependencyEndpoints are the endpoints of a dependency, available to the templates of the environment
+// variables of the dependent container.
+type DependencyEndpoints struct {
+	ctx       context.Context
+	container Container
+}
+
+// Host returns the host of the dependency, to be used with its mapped ports.
+func (d DependencyEndpoints) Host() (string, error) {
+	return d.container.Host(d.ctx)
+}
+
+// MappedPort returns the host port mapped to the given port of the dependency, e.g. "5432/tcp", or "5432" for a TCP port.
+func (d DependencyEndpoints) MappedPort(port string) (string, error) {
+	if !strings.Contains(port, "/") {
+		port += "/tcp"
+	}
+
+	p, err := d.container.MappedPort(d.ctx, nat.Port(port))
+	if err != nil {
+		return "", err
+	}
+
+	return p.Port(), nil
+}
+
+// Name returns the name of the dependency, which resolves to it in the user-defined networks.
+func (d DependencyEndpoints) Name() (string, error) {
+	name, err := d.container.Name(d.ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(name, "/"), nil
+}
+
+// Alias returns the first network alias of the dependency in the given network.
+func (d DependencyEndpoints) Alias(network string) (string, error) {
+	aliases, err := d.container.NetworkAliases(d.ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if len(aliases[network]) == 0 {
+		return "", fmt.Errorf("no alias in network %s", network)
+	}
+
+	return aliases[network][0], nil
+}
+
+// ContainerIP returns the IP of the dependency in its network.
+func (d DependencyEndpoints) ContainerIP() (string, error) {
+	return d.container.ContainerIP(d.ctx)
+}
+
+// waitForDependencies waits for the dependencies of the request, one after the other, as they get ready concurrently,
+// and sets the environment variables computed from them in the request.
+func waitForDependencies(ctx context.Context, logging Logging, req *ContainerRequest) error {
+	if len(req.DependsOn) == 0 {
+		return nil
+	}
+
+	// the environment may be shared with the request of the caller
+	req.Env = maps.Clone(req.Env)
+	if req.Env == nil {
+		req.Env = map[string]string{}
+	}
+
+	attrs := []slog.Attr{slog.String(LogKeyImage, req.Image), slog.String(LogKeyOperation, "create")}
+	for i, dep := range req.DependsOn {
+		if dep.Container == nil {
+			return fmt.Errorf("dependency %d is nil", i)
+		}
+
+		id := shortID(dep.Container.GetContainerID())
+		logf(ctx, logging, slog.LevelInfo, attrs, "⏳ Waiting for the dependency %s", id)
+
+		if err := waitForDependency(ctx, dep.Container); err != nil {
+			return fmt.Errorf("dependency %s: %w", id, err)
+		}
+
+		endpoints := DependencyEndpoints{ctx: ctx, container: dep.Container}
+		for key, text := range dep.Env {
+			tmpl, err := template.New(key).Option("missingkey=error").Parse(text)
+			if err != nil {
+				return fmt.Errorf("dependency %s: parse the template of %s: %w", id, key, err)
+			}
+
+			var value strings.Builder
+			if err := tmpl.Execute(&value, endpoints); err != nil {
+				return fmt.Errorf("dependency %s: execute the template of %s: %w", id, key, err)
+			}
+
+			req.Env[key] = value.String()
+		}
+	}
+
+	return nil
+}
+
+// waitForDependency waits until the container is running and its wait strategy passes, if it's a Docker container
+// with a wait strategy, or until it exits successfully.
+func waitForDependency(ctx context.Context, dependency Container) error {
+	for {
+		state, err := dependency.State(ctx)
+		if err != nil {
+			return err
+		}
+
+		if state.Running {
+			break
+		}
+
+		switch state.Status {
+		case "exited", "dead":
+			if state.ExitCode != 0 || state.Status == "dead" {
+				return fmt.Errorf("%w: %s with exit code %d", ErrDependencyFailed, state.Status, state.ExitCode)
+			}
+
+			// a one-off dependency, e.g. running migrations, is done
+			return nil
+		}
+
+		// the dependency is created, restarting, or paused
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ctx.Err(), state.Status)
+		case <-time.After(dependencyPollInterval):
+		}
+	}
+
+	if dc, ok := dependency.(*DockerContainer); ok && dc.WaitingFor != nil {
+		return dc.WaitingFor.WaitUntilReady(ctx, dc)
+	}
+
+	return nil
+}
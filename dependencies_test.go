@@ -0,0 +1,188 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// dependencyContainer is a container going through the given states, one per call to State, staying in the last one.
+type dependencyContainer struct {
+	Container
+
+	states []types.ContainerState
+}
+
+func (c *dependencyContainer) GetContainerID() string {
+	return "0123456789abcdef"
+}
+
+func (c *dependencyContainer) State(context.Context) (*types.ContainerState, error) {
+	state := c.states[0]
+	if len(c.states) > 1 {
+		c.states = c.states[1:]
+	}
+
+	return &state, nil
+}
+
+func (c *dependencyContainer) Host(context.Context) (string, error) {
+	return "localhost", nil
+}
+
+func (c *dependencyContainer) MappedPort(_ context.Context, port nat.Port) (nat.Port, error) {
+	if port != "5432/tcp" {
+		return "", errors.New("port not found")
+	}
+
+	return "32768/tcp", nil
+}
+
+func (c *dependencyContainer) Name(context.Context) (string, error) {
+	return "/postgres", nil
+}
+
+func (c *dependencyContainer) NetworkAliases(context.Context) (map[string][]string, error) {
+	return map[string][]string{"backend": {"db", "postgres"}}, nil
+}
+
+func TestWaitForDependencies(t *testing.T) {
+	ctx := context.Background()
+
+	running := types.ContainerState{Status: "running", Running: true}
+
+	t.Run("env", func(t *testing.T) {
+		env := map[string]string{"APP_ENV": "test"}
+		req := ContainerRequest{
+			Env: env,
+			DependsOn: []ContainerDependency{
+				{
+					Container: &dependencyContainer{states: []types.ContainerState{{Status: "created"}, running}},
+					Env: map[string]string{
+						"DATABASE_URL": `postgres://{{ .Host }}:{{ .MappedPort "5432" }}/db`,
+						"DATABASE_DSN": `host={{ .Alias "backend" }} port=5432 name={{ .Name }}`,
+					},
+				},
+			},
+		}
+
+		require.NoError(t, waitForDependencies(ctx, TestLogger(t), &req))
+		require.Equal(t, map[string]string{
+			"APP_ENV":      "test",
+			"DATABASE_URL": "postgres://localhost:32768/db",
+			"DATABASE_DSN": "host=db port=5432 name=postgres",
+		}, req.Env)
+		// the environment of the caller is left as is
+		require.Equal(t, map[string]string{"APP_ENV": "test"}, env)
+	})
+
+	t.Run("exited successfully", func(t *testing.T) {
+		// e.g. a container running the migrations of a database
+		req := ContainerRequest{
+			DependsOn: []ContainerDependency{{Container: &dependencyContainer{states: []types.ContainerState{{Status: "created"}, {Status: "exited"}}}}},
+		}
+
+		require.NoError(t, waitForDependencies(ctx, TestLogger(t), &req))
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		req := ContainerRequest{
+			DependsOn: []ContainerDependency{{Container: &dependencyContainer{states: []types.ContainerState{{Status: "exited", ExitCode: 1}}}}},
+		}
+
+		err := waitForDependencies(ctx, TestLogger(t), &req)
+		require.ErrorIs(t, err, ErrDependencyFailed)
+		require.EqualError(t, err, "dependency 0123456789ab: dependency failed: exited with exit code 1")
+	})
+
+	t.Run("never started", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 3*dependencyPollInterval)
+		defer cancel()
+
+		req := ContainerRequest{
+			DependsOn: []ContainerDependency{{Container: &dependencyContainer{states: []types.ContainerState{{Status: "created"}}}}},
+		}
+
+		err := waitForDependencies(ctx, TestLogger(t), &req)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		req := ContainerRequest{
+			DependsOn: []ContainerDependency{
+				{
+					Container: &dependencyContainer{states: []types.ContainerState{running}},
+					Env:       map[string]string{"DATABASE_URL": `{{ .MappedPort "6379" }}`},
+				},
+			},
+		}
+
+		err := waitForDependencies(ctx, TestLogger(t), &req)
+		require.ErrorContains(t, err, "dependency 0123456789ab: execute the template of DATABASE_URL")
+		require.ErrorContains(t, err, "port not found")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		req := ContainerRequest{DependsOn: []ContainerDependency{{}}}
+		require.EqualError(t, waitForDependencies(ctx, TestLogger(t), &req), "dependency 0 is nil")
+	})
+}
+
+func TestWithDependsOn(t *testing.T) {
+	db := &dependencyContainer{}
+	cache := &dependencyContainer{}
+
+	req := GenericContainerRequest{}
+	WithDependsOn(db).Customize(&req)
+	WithDependencyEnv(cache, map[string]string{"CACHE_HOST": "{{ .Host }}"}).Customize(&req)
+
+	require.Equal(t, []ContainerDependency{
+		{Container: db},
+		{Container: cache, Env: map[string]string{"CACHE_HOST": "{{ .Host }}"}},
+	}, req.DependsOn)
+}
+
+func TestDependsOn(t *testing.T) {
+	ctx := context.Background()
+
+	// dependsOn {
+	redis, err := Run(ctx, "redis:7-alpine",
+		WithExposedPorts("6379/tcp"),
+		WithWaitStrategy(wait.ForLog("Ready to accept connections")),
+	)
+	terminateContainerOnEnd(t, ctx, redis)
+	require.NoError(t, err)
+
+	// the container is created once redis is ready, with its endpoint in its environment
+	app, err := Run(ctx, "alpine:3.19",
+		WithCmd("sh", "-c", "echo connecting to $REDIS_ADDR; sleep 60"),
+		WithDependencyEnv(redis, map[string]string{
+			"REDIS_ADDR": `{{ .Host }}:{{ .MappedPort "6379/tcp" }}`,
+		}),
+		WithWaitStrategy(wait.ForLog("connecting to").WithStartupTimeout(10*time.Second)),
+	)
+	// }
+	terminateContainerOnEnd(t, ctx, app)
+	require.NoError(t, err)
+
+	host, err := redis.Host(ctx)
+	require.NoError(t, err)
+	port, err := redis.MappedPort(ctx, "6379/tcp")
+	require.NoError(t, err)
+
+	logs, err := app.Logs(ctx)
+	require.NoError(t, err)
+	defer logs.Close()
+
+	output, err := io.ReadAll(logs)
+	require.NoError(t, err)
+	require.Contains(t, string(output), "connecting to "+host+":"+port.Port())
+}
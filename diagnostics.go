@@ -0,0 +1,215 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-units"
+)
+
+// ErrContainerDied is the error of an operation on a container whose main process exited on its own,
+// e.g. because it ran out of memory, instead of being stopped.
+var ErrContainerDied = errors.New("container died")
+
+// exitSignals are the names of the signals commonly killing the main process of a container, by number.
+var exitSignals = map[int]string{
+	1:  "SIGHUP",
+	2:  "SIGINT",
+	3:  "SIGQUIT",
+	4:  "SIGILL",
+	6:  "SIGABRT",
+	7:  "SIGBUS",
+	8:  "SIGFPE",
+	9:  "SIGKILL",
+	11: "SIGSEGV",
+	13: "SIGPIPE",
+	15: "SIGTERM",
+}
+
+// ContainerDiagnostics describes how the main process of a container exited, from its state,
+// and the memory it used, from the samples of its resource usage.
+type ContainerDiagnostics struct {
+	// Running is true if the main process of the container is running.
+	Running bool
+	// Status is the status of the container, e.g. "exited".
+	Status string
+	// ExitCode is the exit code of the main process, if it has exited.
+	ExitCode int
+	// Signal is the name of the signal which killed the main process, e.g. "SIGKILL", derived from an exit code greater than 128.
+	Signal string
+	// OOMKilled is true if the main process was killed because the container ran out of memory.
+	OOMKilled bool
+	// Error is the error reported by the Docker daemon when running the container, if any.
+	Error string
+	// FinishedAt is the time the main process exited, or the zero time if it never exited.
+	FinishedAt time.Time
+	// PeakMemory is the highest memory usage of the container seen in the samples of its resource usage, in bytes,
+	// or zero if it was never sampled, e.g. with the Stats method or the WithMemoryTracking option.
+	PeakMemory uint64
+	// MemoryLimit is the memory limit of the container seen in the samples of its resource usage, in bytes.
+	MemoryLimit uint64
+}
+
+// String returns a summary of the diagnostics, e.g. "OOM-killed, exit code 137 (SIGKILL), peak memory 64MiB of 64MiB".
+func (d ContainerDiagnostics) String() string {
+	var parts []string
+	if d.Running {
+		parts = append(parts, "running")
+	} else {
+		if d.OOMKilled {
+			parts = append(parts, "OOM-killed")
+		}
+
+		exit := fmt.Sprintf("exit code %d", d.ExitCode)
+		if d.Signal != "" {
+			exit += fmt.Sprintf(" (%s)", d.Signal)
+		}
+		parts = append(parts, exit)
+	}
+
+	if d.PeakMemory > 0 {
+		memory := "peak memory " + units.BytesSize(float64(d.PeakMemory))
+		if d.MemoryLimit > 0 {
+			memory += " of " + units.BytesSize(float64(d.MemoryLimit))
+		}
+		parts = append(parts, memory)
+	}
+
+	if d.Error != "" {
+		parts = append(parts, "error: "+d.Error)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// ContainerDiedError is the error of an operation on a container whose main process exited on its own,
+// along with how it exited. It matches ErrContainerDied with errors.Is.
+type ContainerDiedError struct {
+	// ID is the ID of the container.
+	ID          string
+	Diagnostics ContainerDiagnostics
+	Err         error
+}
+
+// Error implements the error interface.
+func (e *ContainerDiedError) Error() string {
+	return fmt.Sprintf("container %s died (%s): %s", shortID(e.ID), e.Diagnostics, e.Err)
+}
+
+// Unwrap returns the error of the operation.
+func (e *ContainerDiedError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes the error match ErrContainerDied.
+func (e *ContainerDiedError) Is(target error) bool {
+	return target == ErrContainerDied
+}
+
+// memoryUsage is the memory usage of a container seen in the samples of its resource usage.
+type memoryUsage struct {
+	peak  atomic.Uint64
+	limit atomic.Uint64
+}
+
+// record updates the peak memory usage with the sample, using the maximum usage reported with cgroup v1,
+// which accounts for the peaks between the samples.
+func (m *memoryUsage) record(s types.StatsJSON, stats ContainerStats) {
+	usage := max(stats.MemoryUsage, s.MemoryStats.MaxUsage)
+	for {
+		peak := m.peak.Load()
+		if usage <= peak || m.peak.CompareAndSwap(peak, usage) {
+			break
+		}
+	}
+
+	if stats.MemoryLimit > 0 {
+		m.limit.Store(stats.MemoryLimit)
+	}
+}
+
+// Diagnostics inspects the container, returning how its main process exited, if it did, and the peak memory
+// usage of the container, if its resource usage was sampled, e.g. to tell whether it ran out of memory.
+func (c *DockerContainer) Diagnostics(ctx context.Context) (*ContainerDiagnostics, error) {
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state := inspect.State
+	d := &ContainerDiagnostics{
+		Running:     state.Running,
+		Status:      state.Status,
+		ExitCode:    state.ExitCode,
+		OOMKilled:   state.OOMKilled,
+		Error:       state.Error,
+		PeakMemory:  c.memory.peak.Load(),
+		MemoryLimit: c.memory.limit.Load(),
+	}
+
+	if !state.Running && state.ExitCode > 128 {
+		d.Signal = exitSignals[state.ExitCode-128]
+	}
+
+	if d.FinishedAt, err = parseStateTime(state.FinishedAt); err != nil {
+		return nil, fmt.Errorf("parse finish time of container %s: %w", inspect.ID, err)
+	}
+
+	return d, nil
+}
+
+// diedError wraps the error of an operation on the container in a ContainerDiedError if the container was running,
+// as far as the operations made with it tell, but its main process exited on its own, e.g. because it ran out of memory,
+// so that the error tells why, instead of e.g. "port not mapped". Otherwise, the error is returned as is.
+func (c *DockerContainer) diedError(ctx context.Context, err error) error {
+	if err == nil || !c.isRunning || errors.Is(err, ErrContainerDied) {
+		return err
+	}
+
+	// the context of the operation may be done
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	d, inspectErr := c.Diagnostics(ctx)
+	if inspectErr != nil || d.Running {
+		return err
+	}
+
+	return &ContainerDiedError{ID: c.ID, Diagnostics: *d, Err: err}
+}
+
+// WithMemoryTracking samples the resource usage of the container every second while it runs, so that its peak
+// memory usage is reported if it dies, e.g. in the ContainerDiedError of the operations on the container
+// and in its debug bundle. Without it, the peak memory usage is the one seen by the calls to the Stats methods.
+func WithMemoryTracking() CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PostStarts: []ContainerHook{
+				func(ctx context.Context, c Container) error {
+					dc, ok := c.(*DockerContainer)
+					if !ok {
+						return nil
+					}
+
+					// the stream is closed by the Docker daemon when the container stops
+					ch, err := dc.StatsStream(context.WithoutCancel(ctx))
+					if err != nil {
+						return fmt.Errorf("track the memory usage: %w", err)
+					}
+
+					go func() {
+						for range ch {
+						}
+					}()
+
+					return nil
+				},
+			},
+		})
+	}
+}
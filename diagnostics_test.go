@@ -0,0 +1,163 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+)
+
+// diedClient is a mock implementation of client.APIClient, inspecting a container in the given state.
+type diedClient struct {
+	client.APIClient
+
+	state types.ContainerState
+}
+
+func (m *diedClient) ContainerInspect(_ context.Context, containerID string) (types.ContainerJSON, error) {
+	state := m.state
+	return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{ID: containerID, State: &state}}, nil
+}
+
+func (m *diedClient) Close() error {
+	return nil
+}
+
+func TestContainerDiagnostics_String(t *testing.T) {
+	testCases := []struct {
+		name        string
+		diagnostics ContainerDiagnostics
+		expected    string
+	}{
+		{
+			name:        "running",
+			diagnostics: ContainerDiagnostics{Running: true, PeakMemory: 32 * 1024 * 1024},
+			expected:    "running, peak memory 32MiB",
+		},
+		{
+			name:        "exited",
+			diagnostics: ContainerDiagnostics{ExitCode: 1},
+			expected:    "exit code 1",
+		},
+		{
+			name:        "OOM-killed",
+			diagnostics: ContainerDiagnostics{ExitCode: 137, Signal: "SIGKILL", OOMKilled: true, PeakMemory: 64 * 1024 * 1024, MemoryLimit: 64 * 1024 * 1024},
+			expected:    "OOM-killed, exit code 137 (SIGKILL), peak memory 64MiB of 64MiB",
+		},
+		{
+			name:        "daemon error",
+			diagnostics: ContainerDiagnostics{ExitCode: 127, Error: "exec: \"postgres\": executable file not found in $PATH"},
+			expected:    "exit code 127, error: exec: \"postgres\": executable file not found in $PATH",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.diagnostics.String())
+		})
+	}
+}
+
+func TestMemoryUsage(t *testing.T) {
+	var m memoryUsage
+
+	m.record(types.StatsJSON{}, ContainerStats{MemoryUsage: 10, MemoryLimit: 100})
+	m.record(types.StatsJSON{}, ContainerStats{MemoryUsage: 30, MemoryLimit: 100})
+	m.record(types.StatsJSON{}, ContainerStats{MemoryUsage: 20, MemoryLimit: 100})
+	require.Equal(t, uint64(30), m.peak.Load())
+	require.Equal(t, uint64(100), m.limit.Load())
+
+	// the maximum usage reported with cgroup v1 accounts for the peaks between the samples
+	var s types.StatsJSON
+	s.MemoryStats.MaxUsage = 50
+	m.record(s, ContainerStats{MemoryUsage: 20})
+	require.Equal(t, uint64(50), m.peak.Load())
+	require.Equal(t, uint64(100), m.limit.Load())
+}
+
+func TestDiedError(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("port not found")
+
+	t.Run("OOM-killed", func(t *testing.T) {
+		cli := &diedClient{state: types.ContainerState{Status: "exited", ExitCode: 137, OOMKilled: true, FinishedAt: "2024-05-01T10:00:00Z"}}
+		c := &DockerContainer{ID: "0123456789abcdef", isRunning: true, provider: &DockerProvider{client: cli}}
+		c.memory.record(types.StatsJSON{}, ContainerStats{MemoryUsage: 64 * 1024 * 1024, MemoryLimit: 64 * 1024 * 1024})
+
+		err := c.diedError(ctx, cause)
+		require.ErrorIs(t, err, ErrContainerDied)
+		require.ErrorIs(t, err, cause)
+		require.EqualError(t, err, "container 0123456789ab died (OOM-killed, exit code 137 (SIGKILL), peak memory 64MiB of 64MiB): port not found")
+
+		var died *ContainerDiedError
+		require.ErrorAs(t, err, &died)
+		require.Equal(t, "SIGKILL", died.Diagnostics.Signal)
+		require.Equal(t, 2024, died.Diagnostics.FinishedAt.Year())
+
+		// the error is not wrapped twice
+		require.Equal(t, err, c.diedError(ctx, err))
+	})
+
+	t.Run("running", func(t *testing.T) {
+		cli := &diedClient{state: types.ContainerState{Status: "running", Running: true}}
+		c := &DockerContainer{ID: "0123456789abcdef", isRunning: true, provider: &DockerProvider{client: cli}}
+
+		require.Equal(t, cause, c.diedError(ctx, cause))
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		cli := &diedClient{state: types.ContainerState{Status: "exited", ExitCode: 0}}
+		c := &DockerContainer{ID: "0123456789abcdef", provider: &DockerProvider{client: cli}}
+
+		require.Equal(t, cause, c.diedError(ctx, cause))
+	})
+}
+
+func TestWithMemoryTracking(t *testing.T) {
+	req := GenericContainerRequest{}
+	WithMemoryTracking().Customize(&req)
+
+	require.Len(t, req.LifecycleHooks, 1)
+	require.Len(t, req.LifecycleHooks[0].PostStarts, 1)
+}
+
+func TestContainerDied_outOfMemory(t *testing.T) {
+	ctx := context.Background()
+
+	// containerDied {
+	c, err := Run(ctx, "alpine:3.19",
+		// reading /dev/zero without a newline fills the memory
+		WithCmd("sh", "-c", "sleep 2; tail /dev/zero"),
+		WithHostConfigModifier(func(hc *container.HostConfig) {
+			hc.Memory = 32 * 1024 * 1024
+			hc.MemorySwap = hc.Memory
+		}),
+		WithMemoryTracking(),
+	)
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		status, err := c.(*DockerContainer).Status(ctx)
+		return err == nil && !status.Running
+	}, 30*time.Second, 500*time.Millisecond)
+
+	// containerDiedError {
+	_, _, err = c.Exec(ctx, []string{"echo", "hello"})
+
+	var died *ContainerDiedError
+	if errors.As(err, &died) && died.Diagnostics.OOMKilled {
+		t.Logf("the container ran out of memory: %s", died.Diagnostics)
+	}
+	// }
+	require.ErrorIs(t, err, ErrContainerDied)
+	require.True(t, died.Diagnostics.OOMKilled)
+	require.Equal(t, 137, died.Diagnostics.ExitCode)
+	require.Equal(t, uint64(32*1024*1024), died.Diagnostics.MemoryLimit)
+}
@@ -0,0 +1,227 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-units"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ErrDiskUsageLimitExceeded is the error of a disk usage report exceeding the limit set with WithDiskUsageLimit.
+var ErrDiskUsageLimitExceeded = errors.New("disk usage limit exceeded")
+
+// DiskUsageItem is the disk usage of an image, a container or a volume.
+type DiskUsageItem struct {
+	// ID is the ID of the image or the container, or the name of the volume.
+	ID string
+	// Name is the name of the container, or the first tag of the image.
+	Name string
+	// Size is the size in bytes: the size of the image, the size of the writable layer of the container,
+	// or the size of the content of the volume.
+	Size int64
+}
+
+// DiskUsageReport is the disk space consumed by the images, containers and volumes of the test session.
+type DiskUsageReport struct {
+	// Images are the images built by the session, and the images of the containers of the session.
+	Images []DiskUsageItem
+	// Containers are the containers of the session.
+	Containers []DiskUsageItem
+	// Volumes are the volumes of the session.
+	Volumes []DiskUsageItem
+}
+
+// Total returns the disk space consumed by all the images, containers and volumes of the report, in bytes.
+func (r DiskUsageReport) Total() int64 {
+	var total int64
+	for _, items := range [][]DiskUsageItem{r.Images, r.Containers, r.Volumes} {
+		for _, item := range items {
+			total += item.Size
+		}
+	}
+
+	return total
+}
+
+// Print writes a table of the images, containers and volumes of the report to the given writer, sorted from the largest
+// to the smallest in each group, e.g. at the end of TestMain, to find out what a test suite leaves behind.
+func (r DiskUsageReport) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tID\tNAME\tSIZE")
+
+	groups := []struct {
+		kind  string
+		items []DiskUsageItem
+	}{
+		{"image", r.Images},
+		{"container", r.Containers},
+		{"volume", r.Volumes},
+	}
+	for _, g := range groups {
+		items := slices.Clone(g.items)
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Size > items[j].Size
+		})
+
+		for _, item := range items {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", g.kind, shortID(item.ID), item.Name, units.BytesSize(float64(item.Size)))
+		}
+	}
+	fmt.Fprintf(tw, "total\t\t\t%s\n", units.BytesSize(float64(r.Total())))
+
+	return tw.Flush()
+}
+
+// shortID returns the short form of an ID of the Docker daemon, without its algorithm.
+func shortID(id string) string {
+	if _, digest, found := strings.Cut(id, ":"); found {
+		id = digest
+	}
+
+	if len(id) > 12 {
+		return id[:12]
+	}
+
+	return id
+}
+
+// diskUsageOptions are the options of a disk usage report.
+type diskUsageOptions struct {
+	warning int64
+	limit   int64
+}
+
+// DiskUsageOption is an option of DiskUsageReport.
+type DiskUsageOption func(*diskUsageOptions)
+
+// WithDiskUsageWarning logs a warning if the session consumes more than the given megabytes (MiB).
+func WithDiskUsageWarning(megabytes int64) DiskUsageOption {
+	return func(o *diskUsageOptions) {
+		o.warning = megabytes
+	}
+}
+
+// WithDiskUsageLimit makes DiskUsageReport return an error wrapping ErrDiskUsageLimitExceeded, along with the report,
+// if the session consumes more than the given megabytes (MiB), e.g. to fail a test suite leaving too much behind.
+func WithDiskUsageLimit(megabytes int64) DiskUsageOption {
+	return func(o *diskUsageOptions) {
+		o.limit = megabytes
+	}
+}
+
+// DiskUsageReport summarizes the disk space consumed by the images, containers and volumes of the test session,
+// which are the ones with the session label, and the images built by the session or used by its containers.
+// Called once the tests are done, e.g. at the end of TestMain, it reports what the test suite leaves behind.
+func (p *DockerProvider) DiskUsageReport(ctx context.Context, opts ...DiskUsageOption) (DiskUsageReport, error) {
+	var o diskUsageOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	du, err := p.client.DiskUsage(ctx, types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{types.ContainerObject, types.ImageObject, types.VolumeObject},
+	})
+	if err != nil {
+		return DiskUsageReport{}, fmt.Errorf("disk usage: %w", err)
+	}
+	defer p.Close()
+
+	report := newDiskUsageReport(du, core.SessionID(), builtImageTags())
+
+	total := report.Total() / units.MiB
+	switch {
+	case o.limit > 0 && total > o.limit:
+		return report, fmt.Errorf("%w: the session consumes %d MiB, over the limit of %d MiB", ErrDiskUsageLimitExceeded, total, o.limit)
+	case o.warning > 0 && total > o.warning:
+		p.Logger.Printf("⚠️ The session consumes %d MiB of disk space, over the warning threshold of %d MiB", total, o.warning)
+	}
+
+	return report, nil
+}
+
+// newDiskUsageReport returns the report of the disk usage of the given session, attributing it the images with the given tags.
+func newDiskUsageReport(du types.DiskUsage, sessionID string, tags []string) DiskUsageReport {
+	var report DiskUsageReport
+
+	images := map[string]bool{}
+	for _, c := range du.Containers {
+		if c == nil || c.Labels[core.LabelSessionID] != sessionID {
+			continue
+		}
+
+		var name string
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		report.Containers = append(report.Containers, DiskUsageItem{ID: c.ID, Name: name, Size: c.SizeRw})
+		images[c.ImageID] = true
+	}
+
+	for _, img := range du.Images {
+		if img == nil {
+			continue
+		}
+
+		built := slices.ContainsFunc(img.RepoTags, func(tag string) bool {
+			return slices.Contains(tags, tag)
+		})
+		if !built && !images[img.ID] && img.Labels[core.LabelSessionID] != sessionID {
+			continue
+		}
+
+		var name string
+		if len(img.RepoTags) > 0 {
+			name = img.RepoTags[0]
+		}
+		report.Images = append(report.Images, DiskUsageItem{ID: img.ID, Name: name, Size: img.Size})
+	}
+
+	for _, v := range du.Volumes {
+		if v == nil || v.Labels[core.LabelSessionID] != sessionID {
+			continue
+		}
+
+		var size int64
+		// the size is -1 if it's not available, e.g. for the volumes of other drivers than local
+		if v.UsageData != nil && v.UsageData.Size > 0 {
+			size = v.UsageData.Size
+		}
+		report.Volumes = append(report.Volumes, DiskUsageItem{ID: v.Name, Name: v.Name, Size: size})
+	}
+
+	return report
+}
+
+// builtImages are the tags of the images built by the process, which are not labeled with the session.
+var (
+	builtImagesMu sync.Mutex
+	builtImages   []string
+)
+
+// recordBuiltImage records the tag of an image built by the process, for the disk usage report of the session.
+func recordBuiltImage(tag string) {
+	builtImagesMu.Lock()
+	defer builtImagesMu.Unlock()
+
+	if !slices.Contains(builtImages, tag) {
+		builtImages = append(builtImages, tag)
+	}
+}
+
+// builtImageTags returns the tags of the images built by the process.
+func builtImageTags() []string {
+	builtImagesMu.Lock()
+	defer builtImagesMu.Unlock()
+
+	return slices.Clone(builtImages)
+}
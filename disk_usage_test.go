@@ -0,0 +1,103 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func TestNewDiskUsageReport(t *testing.T) {
+	session := map[string]string{core.LabelSessionID: "session"}
+	other := map[string]string{core.LabelSessionID: "other"}
+
+	du := types.DiskUsage{
+		Images: []*image.Summary{
+			{ID: "sha256:0123456789abcdef", RepoTags: []string{"nginx:alpine"}, Size: 40 << 20},
+			{ID: "sha256:built", RepoTags: []string{"testcontainers/built:latest"}, Size: 10 << 20},
+			{ID: "sha256:labeled", Labels: session, Size: 5 << 20},
+			{ID: "sha256:unused", RepoTags: []string{"redis:7"}, Size: 100 << 20},
+		},
+		Containers: []*types.Container{
+			{ID: "c1", Names: []string{"/nginx"}, ImageID: "sha256:0123456789abcdef", SizeRw: 1 << 20, Labels: session},
+			{ID: "c2", Names: []string{"/redis"}, ImageID: "sha256:unused", SizeRw: 2 << 20, Labels: other},
+		},
+		Volumes: []*volume.Volume{
+			{Name: "data", Labels: session, UsageData: &volume.UsageData{Size: 3 << 20}},
+			{Name: "unknown", Labels: session, UsageData: &volume.UsageData{Size: -1}},
+			{Name: "other", Labels: other, UsageData: &volume.UsageData{Size: 4 << 20}},
+		},
+	}
+
+	report := newDiskUsageReport(du, "session", []string{"testcontainers/built:latest"})
+
+	require.Equal(t, []DiskUsageItem{
+		{ID: "sha256:0123456789abcdef", Name: "nginx:alpine", Size: 40 << 20},
+		{ID: "sha256:built", Name: "testcontainers/built:latest", Size: 10 << 20},
+		{ID: "sha256:labeled", Size: 5 << 20},
+	}, report.Images)
+	require.Equal(t, []DiskUsageItem{{ID: "c1", Name: "nginx", Size: 1 << 20}}, report.Containers)
+	require.Equal(t, []DiskUsageItem{
+		{ID: "data", Name: "data", Size: 3 << 20},
+		{ID: "unknown", Name: "unknown"},
+	}, report.Volumes)
+	require.Equal(t, int64(59<<20), report.Total())
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, report.Print(buf))
+	require.Equal(t, `TYPE       ID            NAME                         SIZE
+image      0123456789ab  nginx:alpine                 40MiB
+image      built         testcontainers/built:latest  10MiB
+image      labeled                                    5MiB
+container  c1            nginx                        1MiB
+volume     data          data                         3MiB
+volume     unknown       unknown                      0B
+total                                                 59MiB
+`, buf.String())
+}
+
+func TestDockerProvider_DiskUsageReport(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	// diskUsageReport {
+	report, err := provider.DiskUsageReport(ctx, WithDiskUsageWarning(1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// e.g. at the end of TestMain
+	if err := report.Print(os.Stdout); err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	require.True(t, slices.ContainsFunc(report.Containers, func(item DiskUsageItem) bool {
+		return item.ID == nginx.GetContainerID()
+	}))
+	require.NotEmpty(t, report.Images)
+
+	_, err = provider.DiskUsageReport(ctx, WithDiskUsageLimit(1))
+	require.ErrorIs(t, err, ErrDiskUsageLimitExceeded)
+}
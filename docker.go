@@ -11,10 +11,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"log/slog"
+	"math"
+	"net"
 	"net/url"
 	"os"
-	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -25,9 +30,11 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 	"github.com/moby/term"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
@@ -44,6 +51,7 @@ var _ Container = (*DockerContainer)(nil)
 const (
 	Bridge        = "bridge" // Bridge network name (as well as driver)
 	Podman        = "podman"
+	Nat           = "nat"            // Default network name of the daemons in Windows mode
 	ReaperDefault = "reaper_default" // Default network name when bridge is not available
 	packagePath   = "github.com/testcontainers/testcontainers-go"
 
@@ -68,13 +76,33 @@ type DockerContainer struct {
 	terminationSignal    chan bool
 	consumers            []LogConsumer
 	raw                  *types.ContainerJSON
+	rawMutex             sync.Mutex
 	stopLogProductionCh  chan bool
 	logProductionDone    chan bool
 	logProductionError   chan error
 	logProductionMutex   sync.Mutex
 	logProductionTimeout *time.Duration
-	logger               Logging
-	lifecycleHooks       []ContainerLifecycleHooks
+	// logProductionSince is the time the log production was last stopped, in the format of the Since option of the logs,
+	// so that the log production resumes where it stopped when the container is started again.
+	logProductionSince string
+	logger             Logging
+	lifecycleHooks     []ContainerLifecycleHooks
+	// snapshots are the images of the snapshots of the container, by name.
+	snapshots      map[string]string
+	snapshotsMutex sync.Mutex
+	// timings is the time spent in each phase of the startup of the container.
+	timings      StartupTimings
+	timingsMutex sync.Mutex
+	// tunnels are the local tunnels to the ports of the container, by address of the Docker host, when a transport is set.
+	tunnels      map[string]*portTunnel
+	tunnelsMutex sync.Mutex
+	// shell is the POSIX shell of the container running the scripts of ExecShell, once detected.
+	shell      string
+	shellMutex sync.Mutex
+	// memory is the memory usage of the container seen in the samples of its resource usage.
+	memory memoryUsage
+	// chownToRuntimeUser makes the files copied to the container owned by the user it runs as.
+	chownToRuntimeUser bool
 }
 
 // SetLogger sets the logger for the container
@@ -113,8 +141,9 @@ func (c *DockerContainer) Endpoint(ctx context.Context, proto string) (string, e
 	return c.PortEndpoint(ctx, firstPort, proto)
 }
 
-// PortEndpoint gets proto://host:port string for the given exposed port
+// PortEndpoint gets proto://host:port string for the given exposed port, enclosing IPv6 hosts in square brackets
 // Will returns just host:port if proto is ""
+// Use EndpointURL to build URLs with credentials, a path or a query
 func (c *DockerContainer) PortEndpoint(ctx context.Context, port nat.Port, proto string) (string, error) {
 	host, err := c.Host(ctx)
 	if err != nil {
@@ -131,13 +160,19 @@ func (c *DockerContainer) PortEndpoint(ctx context.Context, port nat.Port, proto
 		protoFull = fmt.Sprintf("%s://", proto)
 	}
 
-	return fmt.Sprintf("%s%s:%s", protoFull, host, outerPort.Port()), nil
+	return protoFull + net.JoinHostPort(host, outerPort.Port()), nil
 }
 
 // Host gets host (ip or name) of the docker daemon where the container port is exposed
 // Warning: this is based on your Docker host setting. Will fail if using an SSH tunnel
-// You can use the "TC_HOST" env variable to set this yourself
+// You can use the WithHostOverride option, the TESTCONTAINERS_HOST_OVERRIDE env variable, or the host.override property, to set this yourself
+// If a transport is set with SetTransport, it's the local host of the tunnels to the ports of the container,
+// so it must be combined with the ports returned by MappedPort, PortEndpoint or Ports, which are the local ports of the tunnels.
 func (c *DockerContainer) Host(ctx context.Context) (string, error) {
+	if currentTransport() != nil {
+		return tunnelHost, nil
+	}
+
 	host, err := c.provider.DaemonHost(ctx)
 	if err != nil {
 		return "", err
@@ -146,19 +181,56 @@ func (c *DockerContainer) Host(ctx context.Context) (string, error) {
 }
 
 // MappedPort gets externally mapped port for a container port
+// If a transport is set with SetTransport, it's the local port of the tunnel to the mapped port,
+// and it returns ErrPortNotTunneled for the ports which are not tunneled, i.e. the UDP ones.
 func (c *DockerContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Port, error) {
-	inspect, err := c.inspectContainer(ctx)
+	p, err := c.daemonMappedPort(ctx, port)
+	if err != nil {
+		return "", c.diedError(ctx, err)
+	}
+
+	return c.tunnelPort(ctx, p)
+}
+
+// daemonMappedPort gets the port of the Docker host a container port is mapped to.
+func (c *DockerContainer) daemonMappedPort(ctx context.Context, port nat.Port) (nat.Port, error) {
+	p, ok, err := c.lookupPort(ctx, port, mappedPort)
 	if err != nil {
 		return "", err
 	}
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrPortNotMapped, port)
+	}
+
+	return p, nil
+}
+
+// lookupPort looks up a container port in the port map of the container with the given function, which is the port
+// itself if the container uses the network of the host. The cached information of the container is used first,
+// inspecting the container again if the port is not found, as the cached snapshot could predate the binding of the port.
+func (c *DockerContainer) lookupPort(ctx context.Context, port nat.Port, lookup func(nat.PortMap, nat.Port) (nat.Port, bool)) (nat.Port, bool, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return "", false, err
+	}
 	if inspect.ContainerJSONBase.HostConfig.NetworkMode == "host" {
-		return port, nil
+		return port, true, nil
 	}
-	ports, err := c.Ports(ctx)
+	if p, ok := lookup(inspect.NetworkSettings.Ports, port); ok {
+		return p, true, nil
+	}
+
+	inspect, err = c.inspectRawContainer(ctx)
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
+	p, ok := lookup(inspect.NetworkSettings.Ports, port)
 
+	return p, ok, nil
+}
+
+// mappedPort looks up the host port the given container port is bound to in the port map.
+func mappedPort(ports nat.PortMap, port nat.Port) (nat.Port, bool) {
 	for k, p := range ports {
 		if k.Port() != port.Port() {
 			continue
@@ -169,19 +241,70 @@ func (c *DockerContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Po
 		if len(p) == 0 {
 			continue
 		}
-		return nat.NewPort(k.Proto(), p[0].HostPort)
+		if mapped, err := nat.NewPort(k.Proto(), p[0].HostPort); err == nil {
+			return mapped, true
+		}
+	}
+
+	return "", false
+}
+
+// MappedPortIPv6 gets the host port the given container port is published to on the IPv6 interfaces of the host.
+// It returns an error if the port is not published on any IPv6 interface, e.g. when IPv6 is disabled on the host.
+// If a transport is set with SetTransport, it returns ErrPortNotTunneled, as the tunnels listen on the local IPv4 host.
+func (c *DockerContainer) MappedPortIPv6(ctx context.Context, port nat.Port) (nat.Port, error) {
+	if currentTransport() != nil {
+		return "", fmt.Errorf("%w on IPv6 interfaces: %s", ErrPortNotTunneled, port)
+	}
+
+	p, ok, err := c.lookupPort(ctx, port, mappedPortIPv6)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("%w on IPv6 interfaces: %s", ErrPortNotMapped, port)
+	}
+
+	return p, nil
+}
+
+// mappedPortIPv6 looks up the host port the given container port is bound to on an IPv6 interface in the port map.
+func mappedPortIPv6(ports nat.PortMap, port nat.Port) (nat.Port, bool) {
+	for k, bindings := range ports {
+		if k.Port() != port.Port() {
+			continue
+		}
+		if port.Proto() != "" && k.Proto() != port.Proto() {
+			continue
+		}
+		for _, b := range bindings {
+			if !isIPv6(b.HostIP) {
+				continue
+			}
+			if mapped, err := nat.NewPort(k.Proto(), b.HostPort); err == nil {
+				return mapped, true
+			}
+		}
 	}
 
-	return "", errors.New("port not found")
+	return "", false
+}
+
+// isIPv6 returns true if the given address is a valid IPv6 address, including the unspecified address "::".
+func isIPv6(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && ip.To4() == nil
 }
 
 // Ports gets the exposed ports for the container.
+// If a transport is set with SetTransport, the TCP ports are bound to the local ports of their tunnels,
+// and the other ports have no bindings, as they're not tunneled.
 func (c *DockerContainer) Ports(ctx context.Context) (nat.PortMap, error) {
 	inspect, err := c.inspectContainer(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return inspect.NetworkSettings.Ports, nil
+	return c.tunnelPorts(ctx, inspect.NetworkSettings.Ports)
 }
 
 // SessionID gets the current session id
@@ -189,18 +312,47 @@ func (c *DockerContainer) SessionID() string {
 	return c.sessionID
 }
 
-// Start will start an already created container
+// Start will start an already created container, or a container stopped with Stop.
+// It runs the lifecycle hooks of the container, so it returns once the wait strategy is satisfied.
+// The ports exposed to the host could be mapped to different ports after the container is stopped and started again.
 func (c *DockerContainer) Start(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "start", containerSpanAttrs(c)...)
+	err := c.start(ctx, container.StartOptions{})
+	if err != nil {
+		err = c.collectDebugBundle(ctx, c.diedError(ctx, err))
+	}
+	endSpan(span, err)
+
+	return err
+}
+
+// start starts the container with the given options, e.g. from a checkpoint, running its lifecycle hooks.
+func (c *DockerContainer) start(ctx context.Context, options container.StartOptions) error {
+	startedAt := time.Now()
+	c.updateTimings(func(t *StartupTimings) {
+		t.Start, t.Readiness = 0, 0
+	})
+
 	err := c.startingHook(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err := c.provider.client.ContainerStart(ctx, c.ID, container.StartOptions{}); err != nil {
+	release, err := c.provider.acquireCreateSlot(ctx, c.Image)
+	if err != nil {
 		return err
 	}
+
+	err = c.provider.client.ContainerStart(ctx, c.ID, options)
+	release()
+	if err != nil {
+		return c.provider.portConflictError(ctx, privilegedPortError(err))
+	}
 	defer c.provider.Close()
 
+	// the ports are bound when the container starts
+	c.invalidateInfo()
+
 	err = c.startedHook(ctx)
 	if err != nil {
 		return err
@@ -213,6 +365,12 @@ func (c *DockerContainer) Start(ctx context.Context) error {
 		return err
 	}
 
+	// the wait strategy runs in the post-start hooks, so its time is not part of the start timing
+	c.updateTimings(func(t *StartupTimings) {
+		t.Start = time.Since(startedAt) - t.Readiness
+	})
+	recordStartup(c)
+
 	return nil
 }
 
@@ -234,7 +392,7 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 	var options container.StopOptions
 
 	if timeout != nil {
-		timeoutSeconds := int(timeout.Seconds())
+		timeoutSeconds := stopTimeoutSeconds(*timeout)
 		options.Timeout = &timeoutSeconds
 	}
 
@@ -243,6 +401,8 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 	}
 	defer c.provider.Close()
 
+	c.invalidateInfo()
+
 	c.isRunning = false
 
 	err = c.stoppedHook(ctx)
@@ -253,8 +413,180 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 	return nil
 }
 
+// stopTimeoutSeconds converts the timeout to stop a container to the seconds expected by the Docker API,
+// rounding up, so that a sub-second timeout still gives the container the chance to stop gracefully.
+// A negative timeout means no timeout.
+func stopTimeoutSeconds(timeout time.Duration) int {
+	if timeout < 0 {
+		return -1
+	}
+
+	return int(math.Ceil(timeout.Seconds()))
+}
+
+// Restart stops the container, within the given timeout as in Stop, and starts it again, running the lifecycle hooks
+// of both operations. Therefore, Restart returns once the wait strategy of the container is satisfied again.
+// Please note that the ports exposed to the host could be mapped to different ports after the restart,
+// so they must be retrieved again with the MappedPort method.
+func (c *DockerContainer) Restart(ctx context.Context, timeout *time.Duration) error {
+	if err := c.Stop(ctx, timeout); err != nil {
+		return fmt.Errorf("stop container %s: %w", c.ID, err)
+	}
+
+	if err := c.Start(ctx); err != nil {
+		return fmt.Errorf("start container %s: %w", c.ID, err)
+	}
+
+	return nil
+}
+
+// Kill sends the signal to the main process of the container, e.g. "SIGTERM", "SIGKILL" or "SIGHUP",
+// so that tests can verify how the process handles it, e.g. a graceful shutdown versus a hard kill.
+// An empty signal sends SIGKILL. The lifecycle hooks are not run, as the process could ignore the signal.
+func (c *DockerContainer) Kill(ctx context.Context, signal string) error {
+	defer c.provider.Close()
+	if err := c.provider.client.ContainerKill(ctx, c.ID, signal); err != nil {
+		return fmt.Errorf("send signal %s to container %s: %w", signal, c.ID, err)
+	}
+
+	c.invalidateInfo()
+
+	return nil
+}
+
+// Commit creates an image from the current state of the container, e.g. a database seeded once in TestMain,
+// so that other containers can start from it instead of seeding again. It returns the reference of the image,
+// in the repository:tag form, to be used as the image of a container request. An empty tag defaults to "latest".
+// The container is paused while the image is created. Please note that the data stored in volumes,
+// including the anonymous volumes declared by the image, is not part of the new image.
+// The image inherits the labels of the container, so it's removed by Ryuk when the test session ends.
+func (c *DockerContainer) Commit(ctx context.Context, repository string, tag string) (string, error) {
+	return c.commit(ctx, repository, tag, nil)
+}
+
+// commit creates an image from the current state of the container, adding the given labels
+// to the ones inherited from the container.
+func (c *DockerContainer) commit(ctx context.Context, repository string, tag string, labels map[string]string) (string, error) {
+	if tag == "" {
+		tag = "latest"
+	}
+	reference := repository + ":" + tag
+
+	options := container.CommitOptions{
+		Reference: reference,
+		Pause:     true,
+	}
+	if len(labels) > 0 {
+		options.Config = &container.Config{Labels: labels}
+	}
+
+	defer c.provider.Close()
+	_, err := c.provider.client.ContainerCommit(ctx, c.ID, options)
+	if err != nil {
+		return "", fmt.Errorf("commit container %s to image %s: %w", c.ID, reference, err)
+	}
+
+	return reference, nil
+}
+
+// Pause freezes all the processes of the container, which keeps its network connections open
+// but stops responding, e.g. to simulate an unresponsive peer. Use Unpause to resume them.
+func (c *DockerContainer) Pause(ctx context.Context) error {
+	defer c.provider.Close()
+	if err := c.provider.client.ContainerPause(ctx, c.ID); err != nil {
+		return fmt.Errorf("pause container %s: %w", c.ID, err)
+	}
+
+	c.invalidateInfo()
+
+	return nil
+}
+
+// Unpause resumes all the processes of a container frozen with Pause.
+func (c *DockerContainer) Unpause(ctx context.Context) error {
+	defer c.provider.Close()
+	if err := c.provider.client.ContainerUnpause(ctx, c.ID); err != nil {
+		return fmt.Errorf("unpause container %s: %w", c.ID, err)
+	}
+
+	c.invalidateInfo()
+
+	return nil
+}
+
+// UpdateResources updates the resource limits of the running container, as the "docker update" command does,
+// e.g. to shrink the memory available to a dependency in the middle of a test, and verify how the application
+// behaves when the dependency is under pressure. Only the non-zero fields of the resources are updated.
+// Please note that the memory limit can't be lower than the memory the container is using,
+// and that the swap limit, if set, must be updated along with it.
+func (c *DockerContainer) UpdateResources(ctx context.Context, resources container.Resources) error {
+	defer c.provider.Close()
+	resp, err := c.provider.client.ContainerUpdate(ctx, c.ID, container.UpdateConfig{
+		Resources: resources,
+	})
+	if err != nil {
+		return fmt.Errorf("update resources of container %s: %w", c.ID, err)
+	}
+
+	for _, warning := range resp.Warnings {
+		logf(ctx, c.logger, slog.LevelWarn, containerAttrs(c, "update"), "⚠️ Updating resources of container %s: %s", c.ID, warning)
+	}
+
+	c.invalidateInfo()
+
+	return nil
+}
+
+// ContainerExit describes how the main process of a container exited.
+type ContainerExit struct {
+	// ExitCode is the exit code of the main process.
+	ExitCode int
+	// OOMKilled is true if the process was killed because the container ran out of memory.
+	OOMKilled bool
+	// Error is the error reported by the Docker daemon when running the container, if any.
+	Error string
+}
+
+// WaitForExit blocks until the main process of the container exits, or the context is done, returning how it exited.
+// It's meant for one-shot containers, e.g. running migrations or a batch job, asserted by their result
+// instead of by their logs. It returns immediately if the container is not running.
+func (c *DockerContainer) WaitForExit(ctx context.Context) (*ContainerExit, error) {
+	defer c.provider.Close()
+	statusCh, errCh := c.provider.client.ContainerWait(ctx, c.ID, container.WaitConditionNotRunning)
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for container %s to exit: %w", c.ID, ctx.Err())
+	case err := <-errCh:
+		return nil, fmt.Errorf("wait for container %s to exit: %w", c.ID, err)
+	case <-statusCh:
+	}
+
+	c.isRunning = false
+
+	state, err := c.State(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("inspect exited container %s: %w", c.ID, err)
+	}
+
+	return &ContainerExit{
+		ExitCode:  state.ExitCode,
+		OOMKilled: state.OOMKilled,
+		Error:     state.Error,
+	}, nil
+}
+
 // Terminate is used to kill the container. It is usually triggered by as defer function.
 func (c *DockerContainer) Terminate(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "terminate", containerSpanAttrs(c)...)
+	err := c.terminate(ctx)
+	endSpan(span, err)
+
+	return err
+}
+
+// terminate removes the container, its image if it was built, and the images of its snapshots, running its lifecycle hooks.
+func (c *DockerContainer) terminate(ctx context.Context) error {
 	select {
 	// close reaper if it was created
 	case c.terminationSignal <- true:
@@ -276,6 +608,8 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 		return err
 	}
 
+	c.closeTunnels()
+
 	err = c.terminatedHook(ctx)
 	if err != nil {
 		return err
@@ -291,11 +625,31 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 		}
 	}
 
+	if err := c.removeSnapshots(ctx); err != nil {
+		return err
+	}
+
 	c.sessionID = ""
 	c.isRunning = false
 	return nil
 }
 
+// Info returns a snapshot of the inspect information of the container, cached since the last time it was inspected.
+// Most of the methods of the container, e.g. MappedPort or ContainerIP, read it, so that tests looking up many ports
+// don't make a round-trip to the Docker daemon for each of them. The snapshot is discarded by the operations
+// of the container changing its state, e.g. Stop or ConnectToNetwork, but not by changes made outside of it,
+// e.g. the container exiting on its own: use Refresh to inspect the container again.
+// The returned value is shared, so it must not be modified.
+func (c *DockerContainer) Info(ctx context.Context) (*types.ContainerJSON, error) {
+	return c.inspectContainer(ctx)
+}
+
+// Refresh inspects the container again, replacing the snapshot returned by Info.
+func (c *DockerContainer) Refresh(ctx context.Context) error {
+	_, err := c.inspectRawContainer(ctx)
+	return err
+}
+
 // update container raw info
 func (c *DockerContainer) inspectRawContainer(ctx context.Context) (*types.ContainerJSON, error) {
 	defer c.provider.Close()
@@ -304,18 +658,30 @@ func (c *DockerContainer) inspectRawContainer(ctx context.Context) (*types.Conta
 		return nil, err
 	}
 
+	c.rawMutex.Lock()
+	defer c.rawMutex.Unlock()
 	c.raw = &inspect
 	return c.raw, nil
 }
 
+// inspectContainer returns the cached raw info, inspecting the container if there is none.
 func (c *DockerContainer) inspectContainer(ctx context.Context) (*types.ContainerJSON, error) {
-	defer c.provider.Close()
-	inspect, err := c.provider.client.ContainerInspect(ctx, c.ID)
-	if err != nil {
-		return nil, err
+	c.rawMutex.Lock()
+	raw := c.raw
+	c.rawMutex.Unlock()
+
+	if raw != nil {
+		return raw, nil
 	}
 
-	return &inspect, nil
+	return c.inspectRawContainer(ctx)
+}
+
+// invalidateInfo discards the cached raw info, so that the next lookup inspects the container again.
+func (c *DockerContainer) invalidateInfo() {
+	c.rawMutex.Lock()
+	defer c.rawMutex.Unlock()
+	c.raw = nil
 }
 
 // Logs will fetch both STDOUT and STDERR from the current container. Returns a
@@ -396,6 +762,8 @@ func (c *DockerContainer) Name(ctx context.Context) (string, error) {
 func (c *DockerContainer) State(ctx context.Context) (*types.ContainerState, error) {
 	inspect, err := c.inspectRawContainer(ctx)
 	if err != nil {
+		c.rawMutex.Lock()
+		defer c.rawMutex.Unlock()
 		if c.raw != nil {
 			return c.raw.State, err
 		}
@@ -404,6 +772,96 @@ func (c *DockerContainer) State(ctx context.Context) (*types.ContainerState, err
 	return inspect.State, nil
 }
 
+// ContainerStatus is the typed status of a container, parsed from its inspect information.
+type ContainerStatus struct {
+	// Running is true if the main process of the container is running, even if it's paused.
+	Running bool
+	// Paused is true if the processes of the container are frozen.
+	Paused bool
+	// ExitCode is the exit code of the main process, if it has exited.
+	ExitCode int
+	// Health is the health status of the container, i.e. "starting", "healthy" or "unhealthy",
+	// or empty if the container has no health check.
+	Health string
+	// StartedAt is the time the container was last started, or the zero time if it was never started.
+	StartedAt time.Time
+	// FinishedAt is the time the container last exited, or the zero time if it never exited.
+	FinishedAt time.Time
+	// RestartCount is the number of times the container was restarted by the Docker daemon, per its restart policy.
+	RestartCount int
+}
+
+// Status inspects the container, returning its typed status. Unlike the IsRunning method, which reflects the
+// operations made with the container, e.g. Start or Stop, it also detects the changes made outside of it,
+// e.g. the container exiting on its own.
+func (c *DockerContainer) Status(ctx context.Context) (*ContainerStatus, error) {
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newContainerStatus(inspect)
+}
+
+// newContainerStatus parses the status of a container from its inspect information.
+func newContainerStatus(inspect *types.ContainerJSON) (*ContainerStatus, error) {
+	state := inspect.State
+
+	status := &ContainerStatus{
+		Running:      state.Running,
+		Paused:       state.Paused,
+		ExitCode:     state.ExitCode,
+		RestartCount: inspect.RestartCount,
+	}
+
+	if state.Health != nil {
+		status.Health = state.Health.Status
+	}
+
+	var err error
+	if status.StartedAt, err = parseStateTime(state.StartedAt); err != nil {
+		return nil, fmt.Errorf("parse start time of container %s: %w", inspect.ID, err)
+	}
+	if status.FinishedAt, err = parseStateTime(state.FinishedAt); err != nil {
+		return nil, fmt.Errorf("parse finish time of container %s: %w", inspect.ID, err)
+	}
+
+	return status, nil
+}
+
+// parseStateTime parses a time of the state of a container, which the Docker daemon sets to the zero time, or leaves empty, if unknown.
+func parseStateTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if t.IsZero() {
+		return time.Time{}, nil
+	}
+
+	return t, nil
+}
+
+// IsHealthy inspects the container, returning true if its health check passes.
+// It returns an error if the container has no health check, e.g. defined by the HEALTHCHECK instruction of its image.
+func (c *DockerContainer) IsHealthy(ctx context.Context) (bool, error) {
+	status, err := c.Status(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if status.Health == "" {
+		return false, fmt.Errorf("container %s has no health check", c.ID)
+	}
+
+	return status.Health == types.Healthy, nil
+}
+
 // Networks gets the names of the networks the container is attached to.
 func (c *DockerContainer) Networks(ctx context.Context) ([]string, error) {
 	inspect, err := c.inspectContainer(ctx)
@@ -460,6 +918,43 @@ func (c *DockerContainer) ContainerIPs(ctx context.Context) ([]string, error) {
 	return ips, nil
 }
 
+// ContainerIPInNetwork gets the IP address of the container in the given network, identified by its name,
+// so that other containers attached to the same network can reach it by IP.
+func (c *DockerContainer) ContainerIPInNetwork(ctx context.Context, networkName string) (string, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	settings, ok := inspect.NetworkSettings.Networks[networkName]
+	if !ok {
+		return "", fmt.Errorf("container %s is not attached to network %s", c.ID, networkName)
+	}
+
+	return settings.IPAddress, nil
+}
+
+// ContainerIPv6s gets the global IPv6 addresses of all the IPv6-enabled networks within the container.
+func (c *DockerContainer) ContainerIPv6s(ctx context.Context) ([]string, error) {
+	ips := make([]string, 0)
+
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := inspect.NetworkSettings.Networks
+	for _, nw := range networks {
+		if nw.GlobalIPv6Address == "" {
+			// IPv6 is not enabled on this network
+			continue
+		}
+		ips = append(ips, nw.GlobalIPv6Address)
+	}
+
+	return ips, nil
+}
+
 // NetworkAliases gets the aliases of the container for the networks it is attached to.
 func (c *DockerContainer) NetworkAliases(ctx context.Context) (map[string][]string, error) {
 	inspect, err := c.inspectContainer(ctx)
@@ -478,7 +973,54 @@ func (c *DockerContainer) NetworkAliases(ctx context.Context) (map[string][]stri
 	return a, nil
 }
 
+// ConnectToNetwork attaches the running container to the given network, identified by its name or ID,
+// setting the given aliases for the container in that network.
+func (c *DockerContainer) ConnectToNetwork(ctx context.Context, networkName string, aliases ...string) error {
+	defer c.provider.Close()
+	err := c.provider.client.NetworkConnect(ctx, networkName, c.ID, &network.EndpointSettings{
+		Aliases: aliases,
+	})
+	if err != nil {
+		return fmt.Errorf("connect container %s to network %s: %w", c.ID, networkName, err)
+	}
+
+	c.invalidateInfo()
+
+	return nil
+}
+
+// DisconnectFromNetwork detaches the running container from the given network, identified by its name or ID,
+// e.g. to simulate a network partition. Use ConnectToNetwork to attach it again.
+func (c *DockerContainer) DisconnectFromNetwork(ctx context.Context, networkName string) error {
+	defer c.provider.Close()
+	err := c.provider.client.NetworkDisconnect(ctx, networkName, c.ID, false)
+	if err != nil {
+		return fmt.Errorf("disconnect container %s from network %s: %w", c.ID, networkName, err)
+	}
+
+	c.invalidateInfo()
+
+	return nil
+}
+
+// Exec executes the command in the container, returning its exit code and a reader with its output.
+// The options of the exec package customize how the command is run, e.g. its user, working directory or environment,
+// and how its output is returned.
 func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	ctx, span := startSpan(ctx, "exec", containerSpanAttrs(c)...)
+	exitCode, reader, err := c.exec(ctx, cmd, options...)
+	if err == nil {
+		span.SetAttributes(spanKeyExitCode.Int(exitCode))
+	} else {
+		err = c.diedError(ctx, err)
+	}
+	endSpan(span, err)
+
+	return exitCode, reader, err
+}
+
+// exec executes the command in the container.
+func (c *DockerContainer) exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
 	cli := c.provider.client
 
 	processOptions := tcexec.NewProcessOptions(cmd)
@@ -494,11 +1036,29 @@ func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tce
 		return 0, nil, err
 	}
 
+	if processOptions.ExecConfig.Detach {
+		// the command runs in the background, so there is no output nor exit code to wait for
+		err := cli.ContainerExecStart(ctx, response.ID, types.ExecStartCheck{Detach: true})
+		if err != nil {
+			return 0, nil, err
+		}
+
+		return 0, bytes.NewReader(nil), nil
+	}
+
 	hijack, err := cli.ContainerExecAttach(ctx, response.ID, types.ExecStartCheck{})
 	if err != nil {
 		return 0, nil, err
 	}
 
+	if processOptions.Stdin != nil {
+		// writing in the background, as the command could need its output to be read before consuming all its input
+		go func() {
+			_, _ = io.Copy(hijack.Conn, processOptions.Stdin)
+			_ = hijack.CloseWrite()
+		}()
+	}
+
 	processOptions.Reader = hijack.Reader
 
 	// second loop to process the multiplexed option, as now we have a reader
@@ -562,6 +1122,66 @@ func (c *DockerContainer) CopyFileFromContainer(ctx context.Context, filePath st
 	return ret, nil
 }
 
+// CopyDirFromContainer copies the contents of a directory of the container to a directory of the host,
+// which is created if it does not exist, e.g. to retrieve the reports or the files produced by the container.
+func (c *DockerContainer) CopyDirFromContainer(ctx context.Context, containerDirPath string, hostDirPath string) error {
+	r, stat, err := c.provider.client.CopyFromContainer(ctx, c.ID, containerDirPath)
+	if err != nil {
+		return err
+	}
+	defer c.provider.Close()
+	defer r.Close()
+
+	if !stat.Mode.IsDir() {
+		return fmt.Errorf("path %s is not a directory", containerDirPath)
+	}
+
+	if err := os.MkdirAll(hostDirPath, 0o755); err != nil {
+		return err
+	}
+
+	return extractTar(r, hostDirPath)
+}
+
+// ReadFile returns the contents of a file of the container, e.g. an export or a log file written by the service,
+// so that they can be asserted without running commands in the container. It returns an error if the path is a directory.
+func (c *DockerContainer) ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	r, stat, err := c.provider.client.CopyFromContainer(ctx, c.ID, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", filePath, err)
+	}
+	defer c.provider.Close()
+	defer r.Close()
+
+	if stat.Mode.IsDir() {
+		return nil, fmt.Errorf("path %s is a directory", filePath)
+	}
+
+	tr := tar.NewReader(r)
+	if _, err := tr.Next(); err != nil {
+		return nil, fmt.Errorf("read file %s: %w", filePath, err)
+	}
+
+	return io.ReadAll(tr)
+}
+
+// ListDir returns the entries of a directory of the container, sorted by name, without the contents of its subdirectories.
+// The whole directory is copied from the container to list it, so it's meant for directories of a reasonable size.
+func (c *DockerContainer) ListDir(ctx context.Context, dirPath string) ([]fs.FileInfo, error) {
+	r, stat, err := c.provider.client.CopyFromContainer(ctx, c.ID, dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("list directory %s: %w", dirPath, err)
+	}
+	defer c.provider.Close()
+	defer r.Close()
+
+	if !stat.Mode.IsDir() {
+		return nil, fmt.Errorf("path %s is not a directory", dirPath)
+	}
+
+	return listTar(r)
+}
+
 // CopyDirToContainer copies the contents of a directory to a parent path in the container. This parent path must exist in the container first
 // as we cannot create it
 func (c *DockerContainer) CopyDirToContainer(ctx context.Context, hostDirPath string, containerParentPath string, fileMode int64) error {
@@ -580,10 +1200,10 @@ func (c *DockerContainer) CopyDirToContainer(ctx context.Context, hostDirPath st
 		return err
 	}
 
-	// create the directory under its parent
-	parent := filepath.Dir(containerParentPath)
+	// create the directory under its parent, which is a Windows path for Windows containers
+	parent := containerPathDir(containerParentPath)
 
-	err = c.provider.client.CopyToContainer(ctx, c.ID, parent, buff, types.CopyToContainerOptions{})
+	err = c.provider.client.CopyToContainer(ctx, c.ID, parent, buff, c.copyToContainerOptions())
 	if err != nil {
 		return err
 	}
@@ -616,7 +1236,7 @@ func (c *DockerContainer) CopyToContainer(ctx context.Context, fileContent []byt
 		return err
 	}
 
-	err = c.provider.client.CopyToContainer(ctx, c.ID, "/", buffer, types.CopyToContainerOptions{})
+	err = c.provider.client.CopyToContainer(ctx, c.ID, "/", buffer, c.copyToContainerOptions())
 	if err != nil {
 		return err
 	}
@@ -625,6 +1245,17 @@ func (c *DockerContainer) CopyToContainer(ctx context.Context, fileContent []byt
 	return nil
 }
 
+// CopyReaderToContainer copies the content read from the reader, e.g. generated by the test, to a file in the container.
+// The reader is read until EOF before the file is copied.
+func (c *DockerContainer) CopyReaderToContainer(ctx context.Context, reader io.Reader, containerFilePath string, fileMode int64) error {
+	fileContent, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("read content of %s: %w", containerFilePath, err)
+	}
+
+	return c.CopyToContainer(ctx, fileContent, containerFilePath, fileMode)
+}
+
 type LogProductionOption func(*DockerContainer)
 
 // WithLogProductionTimeout is a functional option that sets the timeout for the log production.
@@ -680,20 +1311,20 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 	c.logProductionDone = make(chan bool)
 	c.logProductionError = make(chan error, 1)
 
-	go func(stop <-chan bool, done chan<- bool, errorCh chan error) {
+	go func(stop chan bool, done chan<- bool, errorCh chan error, since string) {
 		// signal the log production is done once go routine exits, this prevents race conditions around start/stop
-		// set c.stopLogProductionCh to nil so that it can be started again
 		defer func() {
-			defer c.logProductionMutex.Unlock()
-			close(done)
-			close(errorCh)
-			{
-				c.logProductionMutex.Lock()
+			// set c.stopLogProductionCh to nil so that it can be started again, unless it was already stopped
+			c.logProductionMutex.Lock()
+			if c.stopLogProductionCh == stop {
 				c.stopLogProductionCh = nil
 			}
+			c.logProductionMutex.Unlock()
+
+			close(done)
+			close(errorCh)
 		}()
 
-		since := ""
 		// if the socket is closed we will make additional logs request with updated Since timestamp
 	BEGIN:
 		options := container.LogsOptions{
@@ -724,8 +1355,7 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 				if err != nil {
 					// proper type matching requires https://go-review.googlesource.com/c/go/+/250357/ (go 1.16)
 					if strings.Contains(err.Error(), "use of closed network connection") {
-						now := time.Now()
-						since = fmt.Sprintf("%d.%09d", now.Unix(), int64(now.Nanosecond()))
+						since = logsSince(time.Now())
 						goto BEGIN
 					}
 					if errors.Is(err, context.DeadlineExceeded) {
@@ -772,11 +1402,16 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 				}
 			}
 		}
-	}(c.stopLogProductionCh, c.logProductionDone, c.logProductionError)
+	}(c.stopLogProductionCh, c.logProductionDone, c.logProductionError, c.logProductionSince)
 
 	return nil
 }
 
+// logsSince formats the time as expected by the Since option of the logs.
+func logsSince(t time.Time) string {
+	return fmt.Sprintf("%d.%09d", t.Unix(), int64(t.Nanosecond()))
+}
+
 // Deprecated: it will be removed in the next major release.
 func (c *DockerContainer) StopLogProducer() error {
 	return c.stopLogProduction()
@@ -785,17 +1420,23 @@ func (c *DockerContainer) StopLogProducer() error {
 // StopLogProducer will stop the concurrent process that is reading logs
 // and sending them to each added LogConsumer
 func (c *DockerContainer) stopLogProduction() error {
+	// the mutex is not held while waiting for the log production, which needs it to exit
 	c.logProductionMutex.Lock()
-	defer c.logProductionMutex.Unlock()
-	if c.stopLogProductionCh != nil {
-		c.stopLogProductionCh <- true
-		// block until the log production is actually done in order to avoid strange races
-		<-c.logProductionDone
-		c.stopLogProductionCh = nil
-		c.logProductionDone = nil
-		return <-c.logProductionError
+	stop, done, errorCh := c.stopLogProductionCh, c.logProductionDone, c.logProductionError
+	c.stopLogProductionCh = nil
+	c.logProductionSince = logsSince(time.Now())
+	c.logProductionMutex.Unlock()
+
+	if stop == nil {
+		return nil
 	}
-	return nil
+
+	// closing the channel never blocks, even if the log production already exited on its own
+	close(stop)
+	// block until the log production is actually done in order to avoid strange races
+	<-done
+
+	return <-errorCh
 }
 
 // GetLogProductionErrorChannel exposes the only way for the consumer
@@ -826,6 +1467,14 @@ func (n *DockerNetwork) Remove(ctx context.Context) error {
 	return n.provider.client.NetworkRemove(ctx, n.ID)
 }
 
+// Inspect returns the low-level information about the network, such as its IPAM configuration,
+// driver options or connected containers.
+func (n *DockerNetwork) Inspect(ctx context.Context) (types.NetworkResource, error) {
+	return n.provider.client.NetworkInspect(ctx, n.ID, types.NetworkInspectOptions{
+		Verbose: true,
+	})
+}
+
 // DockerProvider implements the ContainerProvider interface
 type DockerProvider struct {
 	*DockerProviderOptions
@@ -858,6 +1507,19 @@ var _ ContainerProvider = (*DockerProvider)(nil)
 
 // BuildImage will build and image from context and Dockerfile, then return the tag
 func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (string, error) {
+	ctx, span := startSpan(ctx, "build")
+	tag, err := p.buildImage(ctx, img)
+	if err == nil {
+		span.SetAttributes(spanKeyImage.String(tag))
+		recordBuiltImage(tag)
+	}
+	endSpan(span, err)
+
+	return tag, err
+}
+
+// buildImage builds the image, returning its tag.
+func (p *DockerProvider) buildImage(ctx context.Context, img ImageBuildInfo) (string, error) {
 	buildOptions, err := img.BuildOptions()
 
 	var buildError error
@@ -903,104 +1565,291 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 	return buildOptions.Tags[0], nil
 }
 
-// CreateContainer fulfills a request for a container without starting it
-func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
-	var err error
+// CreateContainer fulfills a request for a container without starting it
+func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	ctx, span := startSpan(ctx, "create", spanKeyImage.String(req.Image))
+	c, err := p.createContainer(ctx, req)
+	if c != nil {
+		span.SetAttributes(spanKeyContainerID.String(c.GetContainerID()))
+	}
+	endSpan(span, err)
+
+	return c, err
+}
+
+// createContainer creates the container of the request, running its lifecycle hooks.
+func (p *DockerProvider) createContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	var err error
+
+	// defer the close of the Docker client connection the soonest
+	defer p.Close()
+
+	// validate the request before any call to the daemon, reporting all its problems at once
+	if err = req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Windows containers are attached to the nat network, and don't support the reaper
+	// nor the Linux-only settings of the host config
+	windows := p.isWindowsDaemon(ctx)
+	if windows && p.defaultBridgeNetworkName == Bridge {
+		p.defaultBridgeNetworkName = Nat
+	}
+
+	// Make sure that bridge network exists
+	// In case it is disabled we will create reaper_default network
+	if p.DefaultNetwork == "" {
+		p.DefaultNetwork, err = p.getDefaultNetwork(ctx, p.client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// If default network is not bridge make sure it is attached to the request
+	// as container won't be attached to it automatically
+	// in case of Podman the bridge network is called 'podman' as 'bridge' would conflict
+	if p.DefaultNetwork != p.defaultBridgeNetworkName {
+		isAttached := false
+		for _, net := range req.Networks {
+			if net == p.DefaultNetwork {
+				isAttached = true
+				break
+			}
+		}
+
+		if !isAttached {
+			req.Networks = append(req.Networks, p.DefaultNetwork)
+		}
+	}
+
+	imageName := req.Image
+
+	if req.Labels == nil {
+		req.Labels = make(map[string]string)
+	}
+
+	tcConfig := p.Config().Config
+
+	// the reaper does not need to start a reaper for itself
+	isReaperContainer := strings.HasSuffix(imageName, config.ReaperDefaultImage)
+
+	// the reaper image is a Linux one
+	if windows && !tcConfig.RyukDisabled && !isReaperContainer {
+		windowsReaperWarning.Do(func() {
+			p.Logger.Printf("⚠️ The reaper is not supported by Docker daemons in Windows mode, the containers won't be removed if the test process dies")
+		})
+	}
+
+	// always append the hub substitutor after the user-defined ones
+	req.ImageSubstitutors = append(req.ImageSubstitutors, newPrependHubRegistry(tcConfig.HubImageNamePrefix))
+
+	imageName, err = substituteImage(p.Logger, req.ImageSubstitutors, imageName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the reaper and the image are independent, so they are prepared concurrently,
+	// which saves the round-trips of one of them to remote daemons
+	var termSignal chan bool
+	var reaperErr error
+	reaperDone := make(chan struct{})
+	go func() {
+		defer close(reaperDone)
+		if !tcConfig.RyukDisabled && !isReaperContainer && !windows {
+			termSignal, reaperErr = p.connectReaper(ctx)
+		}
+	}()
+
+	imageName, platform, timings, err := p.prepareImage(ctx, &req, imageName)
+	<-reaperDone
+
+	// Cleanup on error, otherwise set termSignal to nil before successful return.
+	defer func() {
+		if termSignal != nil {
+			termSignal <- true
+		}
+	}()
+
+	if reaperErr != nil {
+		return nil, reaperErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the dependencies get ready while the image is prepared, and their endpoints may be set in the environment
+	if err = waitForDependencies(ctx, p.Logger, &req); err != nil {
+		return nil, err
+	}
+
+	if !isReaperContainer {
+		// add the labels that the reaper will use to terminate the container to the request
+		for k, v := range core.DefaultLabels(core.SessionID()) {
+			req.Labels[k] = v
+		}
+	}
+
+	dockerInput, hostConfig := newContainerConfigs(req, imageName)
+
+	networkingConfig := &network.NetworkingConfig{}
+
+	// the host ports are exposed before the pre-create hook is defined, as it modifies the request
+	var sshd *sshdContainer
+	var sshdHooks ContainerLifecycleHooks
+	if len(req.ExposedHostPorts) > 0 {
+		sshd, sshdHooks, err = p.exposeHostPorts(ctx, &req)
+		if err != nil {
+			return nil, fmt.Errorf("%w: exposing host ports failed", err)
+		}
+
+		// Cleanup on error, otherwise set sshd to nil before successful return.
+		defer func() {
+			if sshd != nil {
+				_ = sshd.Terminate(context.Background())
+			}
+		}()
+	}
+
+	// default hooks include logger hook and pre-create hook
+	defaultHooks := []ContainerLifecycleHooks{
+		DefaultLoggingHook(p.Logger),
+		defaultPreCreateHook(ctx, p, req, dockerInput, hostConfig, networkingConfig),
+		defaultChownMountPointsHook(req.ChownToRuntimeUser),
+		defaultCopyFileToContainerHook(req.Files),
+		defaultLogConsumersHook(req.LogConsumerCfg),
+		defaultReadinessHook(),
+		sshdHooks,
+	}
+
+	// the seeders go before the user-defined hooks, which can rely on the data
+	userHooks := append([]ContainerLifecycleHooks{seedersHook(req.Seeders)}, req.LifecycleHooks...)
+	req.LifecycleHooks = []ContainerLifecycleHooks{combineContainerHooks(defaultHooks, withRegisteredHooks(userHooks))}
 
-	// defer the close of the Docker client connection the soonest
-	defer p.Close()
+	createStart := time.Now()
 
-	// Make sure that bridge network exists
-	// In case it is disabled we will create reaper_default network
-	if p.DefaultNetwork == "" {
-		p.DefaultNetwork, err = p.getDefaultNetwork(ctx, p.client)
-		if err != nil {
-			return nil, err
-		}
+	err = req.creatingHook(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// If default network is not bridge make sure it is attached to the request
-	// as container won't be attached to it automatically
-	// in case of Podman the bridge network is called 'podman' as 'bridge' would conflict
-	if p.DefaultNetwork != p.defaultBridgeNetworkName {
-		isAttached := false
-		for _, net := range req.Networks {
-			if net == p.DefaultNetwork {
-				isAttached = true
-				break
-			}
+	if windows {
+		if removed := removeLinuxOnlySettings(hostConfig); len(removed) > 0 {
+			p.Logger.Printf("⚠️ Ignoring the settings not supported by Windows containers: %s", strings.Join(removed, ", "))
 		}
+	}
 
-		if !isAttached {
-			req.Networks = append(req.Networks, p.DefaultNetwork)
+	// the reaper is not limited, as the containers of the session can't be removed without it
+	releaseRunning := func() {}
+	if !isReaperContainer {
+		releaseRunning, err = p.acquireRunningSlot(ctx, req.Image)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	imageName := req.Image
-
-	env := []string{}
-	for envKey, envVar := range req.Env {
-		env = append(env, envKey+"="+envVar)
+	releaseCreate, err := p.acquireCreateSlot(ctx, req.Image)
+	if err != nil {
+		releaseRunning()
+		return nil, err
 	}
 
-	if req.Labels == nil {
-		req.Labels = make(map[string]string)
+	resp, err := p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
+	releaseCreate()
+	releaseRunning()
+	if err != nil {
+		return nil, err
 	}
 
-	tcConfig := p.Config().Config
-
-	var termSignal chan bool
-	// the reaper does not need to start a reaper for itself
-	isReaperContainer := strings.HasSuffix(imageName, config.ReaperDefaultImage)
-	if !tcConfig.RyukDisabled && !isReaperContainer {
-		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), core.SessionID(), p)
-		if err != nil {
-			return nil, fmt.Errorf("%w: creating reaper failed", err)
-		}
-		termSignal, err = r.Connect()
-		if err != nil {
-			return nil, fmt.Errorf("%w: connecting to reaper failed", err)
+	// #248: If there is more than one network specified in the request attach newly created container to them one by one
+	if len(req.Networks) > 1 {
+		for _, n := range req.Networks[1:] {
+			nw, err := p.GetNetwork(ctx, NetworkRequest{
+				Name: n,
+			})
+			if err == nil {
+				endpointSetting := network.EndpointSettings{
+					Aliases: req.NetworkAliases[n],
+				}
+				err = p.client.NetworkConnect(ctx, nw.ID, resp.ID, &endpointSetting)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 
-	// Cleanup on error, otherwise set termSignal to nil before successful return.
-	defer func() {
-		if termSignal != nil {
-			termSignal <- true
-		}
-	}()
+	c := &DockerContainer{
+		ID:                  resp.ID,
+		WaitingFor:          req.WaitingFor,
+		Image:               imageName,
+		imageWasBuilt:       req.ShouldBuildImage(),
+		keepBuiltImage:      req.ShouldKeepBuiltImage(),
+		sessionID:           core.SessionID(),
+		provider:            p,
+		terminationSignal:   termSignal,
+		stopLogProductionCh: nil,
+		logger:              p.Logger,
+		lifecycleHooks:      req.LifecycleHooks,
+		timings:             timings,
+		chownToRuntimeUser:  req.ChownToRuntimeUser,
+	}
 
-	if err = req.Validate(); err != nil {
+	err = c.createdHook(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	// always append the hub substitutor after the user-defined ones
-	req.ImageSubstitutors = append(req.ImageSubstitutors, newPrependHubRegistry(tcConfig.HubImageNamePrefix))
+	c.updateTimings(func(t *StartupTimings) {
+		t.Create = time.Since(createStart)
+	})
 
-	for _, is := range req.ImageSubstitutors {
-		modifiedTag, err := is.Substitute(imageName)
-		if err != nil {
-			return nil, fmt.Errorf("failed to substitute image %s with %s: %w", imageName, is.Description(), err)
-		}
+	// Disable cleanup on success
+	termSignal = nil
+	sshd = nil
 
-		if modifiedTag != imageName {
-			p.Logger.Printf("✍🏼 Replacing image with %s. From: %s to %s\n", is.Description(), imageName, modifiedTag)
-			imageName = modifiedTag
-		}
+	return c, nil
+}
+
+// connectReaper connects to the reaper of the session, creating it if needed, returning the channel
+// to signal the reaper once the container is created.
+func (p *DockerProvider) connectReaper(ctx context.Context) (chan bool, error) {
+	r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), core.SessionID(), p)
+	if err != nil {
+		return nil, fmt.Errorf("%w: creating reaper failed", err)
 	}
 
+	termSignal, err := r.Connect()
+	if err != nil {
+		return nil, fmt.Errorf("%w: connecting to reaper failed", err)
+	}
+
+	return termSignal, nil
+}
+
+// prepareImage builds the image of the request, or pulls it if it's not present, returning the name
+// of the image to create the container from, its platform, if any, and the time spent building or pulling it.
+func (p *DockerProvider) prepareImage(ctx context.Context, req *ContainerRequest, imageName string) (string, *specs.Platform, StartupTimings, error) {
 	var platform *specs.Platform
+	var timings StartupTimings
+
+	release, err := p.acquireCreateSlot(ctx, imageName)
+	if err != nil {
+		return "", nil, timings, err
+	}
+	defer release()
 
 	if req.ShouldBuildImage() {
-		imageName, err = p.BuildImage(ctx, &req)
+		buildStart := time.Now()
+		imageName, err = p.BuildImage(ctx, req)
 		if err != nil {
-			return nil, err
+			return "", nil, timings, err
 		}
+		timings.Build = time.Since(buildStart)
 	} else {
 		if req.ImagePlatform != "" {
 			p, err := platforms.Parse(req.ImagePlatform)
 			if err != nil {
-				return nil, fmt.Errorf("invalid platform %s: %w", req.ImagePlatform, err)
+				return "", nil, timings, fmt.Errorf("invalid platform %s: %w", req.ImagePlatform, err)
 			}
 			platform = &p
 		}
@@ -1015,7 +1864,7 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 				if client.IsErrNotFound(err) {
 					shouldPullImage = true
 				} else {
-					return nil, err
+					return "", nil, timings, err
 				}
 			}
 			if platform != nil && (image.Architecture != platform.Architecture || image.Os != platform.OS) {
@@ -1041,18 +1890,47 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 				}
 			}
 
+			pullStart := time.Now()
 			if err := p.attemptToPullImage(ctx, imageName, pullOpt); err != nil {
-				return nil, err
+				return "", nil, timings, err
 			}
+			timings.Pull = time.Since(pullStart)
 		}
 	}
 
-	if !isReaperContainer {
-		// add the labels that the reaper will use to terminate the container to the request
-		for k, v := range core.DefaultLabels(core.SessionID()) {
-			req.Labels[k] = v
+	if err := p.checkImagePolicies(ctx, imageName, req.ShouldBuildImage(), req.ImagePolicies); err != nil {
+		return "", nil, timings, err
+	}
+
+	return imageName, platform, timings, nil
+}
+
+// substituteImage returns the image resulting from applying the given substitutors, in order, to the given image.
+func substituteImage(logger Logging, substitutors []ImageSubstitutor, imageName string) (string, error) {
+	for _, is := range substitutors {
+		modifiedTag, err := is.Substitute(imageName)
+		if err != nil {
+			return "", fmt.Errorf("failed to substitute image %s with %s: %w", imageName, is.Description(), err)
 		}
+
+		if modifiedTag != imageName {
+			logger.Printf("✍🏼 Replacing image with %s. From: %s to %s\n", is.Description(), imageName, modifiedTag)
+			imageName = modifiedTag
+		}
+	}
+
+	return imageName, nil
+}
+
+// newContainerConfigs returns the configs to create the container of the request with the given image,
+// before the pre-create hook applies the rest of the request to them.
+func newContainerConfigs(req ContainerRequest, imageName string) (*container.Config, *container.HostConfig) {
+	env := []string{}
+	for envKey, envVar := range req.Env {
+		env = append(env, envKey+"="+envVar)
 	}
+	// the order of the keys of the map is random
+	sort.Strings(env)
 
 	dockerInput := &container.Config{
 		Entrypoint: req.Entrypoint,
@@ -1071,70 +1949,7 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		Tmpfs:      req.Tmpfs,
 	}
 
-	networkingConfig := &network.NetworkingConfig{}
-
-	// default hooks include logger hook and pre-create hook
-	defaultHooks := []ContainerLifecycleHooks{
-		DefaultLoggingHook(p.Logger),
-		defaultPreCreateHook(ctx, p, req, dockerInput, hostConfig, networkingConfig),
-		defaultCopyFileToContainerHook(req.Files),
-		defaultLogConsumersHook(req.LogConsumerCfg),
-		defaultReadinessHook(),
-	}
-
-	req.LifecycleHooks = []ContainerLifecycleHooks{combineContainerHooks(defaultHooks, req.LifecycleHooks)}
-
-	err = req.creatingHook(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
-	if err != nil {
-		return nil, err
-	}
-
-	// #248: If there is more than one network specified in the request attach newly created container to them one by one
-	if len(req.Networks) > 1 {
-		for _, n := range req.Networks[1:] {
-			nw, err := p.GetNetwork(ctx, NetworkRequest{
-				Name: n,
-			})
-			if err == nil {
-				endpointSetting := network.EndpointSettings{
-					Aliases: req.NetworkAliases[n],
-				}
-				err = p.client.NetworkConnect(ctx, nw.ID, resp.ID, &endpointSetting)
-				if err != nil {
-					return nil, err
-				}
-			}
-		}
-	}
-
-	c := &DockerContainer{
-		ID:                  resp.ID,
-		WaitingFor:          req.WaitingFor,
-		Image:               imageName,
-		imageWasBuilt:       req.ShouldBuildImage(),
-		keepBuiltImage:      req.ShouldKeepBuiltImage(),
-		sessionID:           core.SessionID(),
-		provider:            p,
-		terminationSignal:   termSignal,
-		stopLogProductionCh: nil,
-		logger:              p.Logger,
-		lifecycleHooks:      req.LifecycleHooks,
-	}
-
-	err = c.createdHook(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	// Disable cleanup on success
-	termSignal = nil
-
-	return c, nil
+	return dockerInput, hostConfig
 }
 
 func (p *DockerProvider) findContainerByName(ctx context.Context, name string) (*types.Container, error) {
@@ -1224,7 +2039,8 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 		terminationSignal:   termSignal,
 		stopLogProductionCh: nil,
 		logger:              p.Logger,
-		lifecycleHooks:      []ContainerLifecycleHooks{combineContainerHooks(defaultHooks, req.LifecycleHooks)},
+		lifecycleHooks:      []ContainerLifecycleHooks{combineContainerHooks(defaultHooks, withRegisteredHooks(req.LifecycleHooks))},
+		chownToRuntimeUser:  req.ChownToRuntimeUser,
 	}
 
 	err = dc.startedHook(ctx)
@@ -1244,19 +2060,25 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 
 // attemptToPullImage tries to pull the image while respecting the ctx cancellations.
 // Besides, if the image cannot be pulled due to ErrorNotFound then no need to retry but terminate immediately.
-func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt types.ImagePullOptions) error {
+func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt types.ImagePullOptions) (err error) {
+	ctx, span := startSpan(ctx, "pull", spanKeyImage.String(tag))
+	defer func() {
+		endSpan(span, err)
+	}()
+
 	var (
-		err  error
-		pull io.ReadCloser
+		pull    io.ReadCloser
+		pullErr error
 	)
 	err = backoff.Retry(func() error {
 		pull, err = p.client.ImagePull(ctx, tag, pullOpt)
+		pullErr = err
 		if err != nil {
 			var enf errdefs.ErrNotFound
 			if errors.As(err, &enf) {
 				return backoff.Permanent(err)
 			}
-			Logger.Printf("Failed to pull image: %s, will retry", err)
+			logf(ctx, Logger, slog.LevelWarn, []slog.Attr{slog.String(LogKeyImage, tag), slog.String(LogKeyOperation, "pull")}, "Failed to pull image: %s, will retry", err)
 			return err
 		}
 		defer p.Close()
@@ -1264,13 +2086,20 @@ func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pul
 		return nil
 	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
 	if err != nil {
-		return err
+		// the retries return the error of the context once it's done, instead of the one of the last pull
+		if pullErr != nil && !errors.Is(err, pullErr) {
+			err = fmt.Errorf("%w: %w", err, pullErr)
+		}
+		return fmt.Errorf("%w: %s: %w", ErrImagePullFailed, tag, daemonUnavailableError(err))
 	}
 	defer pull.Close()
 
 	// download of docker image finishes at EOF of the pull request
-	_, err = io.ReadAll(pull)
-	return err
+	if _, err = io.ReadAll(pull); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrImagePullFailed, tag, err)
+	}
+
+	return nil
 }
 
 // Health measure the healthiness of the provider. Right now we leverage the
@@ -1279,7 +2108,7 @@ func (p *DockerProvider) Health(ctx context.Context) error {
 	_, err := p.client.Info(ctx)
 	defer p.Close()
 
-	return err
+	return daemonUnavailableError(err)
 }
 
 // RunContainer takes a RequestContainer as input and it runs a container via the docker sdk
@@ -1296,6 +2125,63 @@ func (p *DockerProvider) RunContainer(ctx context.Context, req ContainerRequest)
 	return c, nil
 }
 
+// RunOneShot creates and starts a container for a one-shot command, e.g. a client CLI like redis-cli or psql
+// used to drive a test, waits for it to exit, and removes it, returning its exit code and its standard output and error.
+// A non-zero exit code is not an error: the error is only returned if the container can't be run.
+func (p *DockerProvider) RunOneShot(ctx context.Context, req ContainerRequest) (int, []byte, []byte, error) {
+	c, err := p.RunContainer(ctx, req)
+	if err != nil {
+		if c != nil {
+			err = errors.Join(err, c.Terminate(ctx))
+		}
+		return 0, nil, nil, err
+	}
+
+	// the containers of the Docker provider are DockerContainers
+	exitCode, stdout, stderr, err := p.oneShotResult(ctx, c.(*DockerContainer))
+	if err != nil {
+		return 0, nil, nil, errors.Join(err, c.Terminate(ctx))
+	}
+
+	if err := c.Terminate(ctx); err != nil {
+		return 0, nil, nil, err
+	}
+
+	return exitCode, stdout, stderr, nil
+}
+
+// oneShotResult waits for the container of a one-shot command to exit, returning its exit code and its output.
+func (p *DockerProvider) oneShotResult(ctx context.Context, c *DockerContainer) (int, []byte, []byte, error) {
+	exit, err := c.WaitForExit(ctx)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	info, err := p.client.ContainerInspect(ctx, c.GetContainerID())
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("inspect container %s: %w", c.GetContainerID(), err)
+	}
+
+	logs, err := p.client.ContainerLogs(ctx, c.GetContainerID(), container.LogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("get output of container %s: %w", c.GetContainerID(), err)
+	}
+	defer logs.Close()
+
+	var stdout, stderr bytes.Buffer
+	if info.Config.Tty {
+		// the output of a terminal is not multiplexed, and has no standard error
+		_, err = io.Copy(&stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(&stdout, &stderr, logs)
+	}
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("read output of container %s: %w", c.GetContainerID(), err)
+	}
+
+	return exit.ExitCode, stdout.Bytes(), stderr.Bytes(), nil
+}
+
 // Config provides the TestcontainersConfig read from $HOME/.testcontainers.properties or
 // the environment variables
 func (p *DockerProvider) Config() TestcontainersConfig {
@@ -1304,7 +2190,7 @@ func (p *DockerProvider) Config() TestcontainersConfig {
 
 // DaemonHost gets the host or ip of the Docker daemon where ports are exposed on
 // Warning: this is based on your Docker host setting. Will fail if using an SSH tunnel
-// You can use the "TC_HOST" env variable to set this yourself
+// You can use the WithHostOverride option, the TESTCONTAINERS_HOST_OVERRIDE env variable, or the host.override property, to set this yourself
 func (p *DockerProvider) DaemonHost(ctx context.Context) (string, error) {
 	return daemonHost(ctx, p)
 }
@@ -1314,10 +2200,12 @@ func daemonHost(ctx context.Context, p *DockerProvider) (string, error) {
 		return p.hostCache, nil
 	}
 
-	host, exists := os.LookupEnv("TC_HOST")
-	if exists {
-		p.hostCache = host
-		return p.hostCache, nil
+	// the option of the provider takes precedence over the configuration
+	for _, host := range []string{p.HostOverride, p.config.Config.HostOverride} {
+		if host != "" {
+			p.hostCache = host
+			return p.hostCache, nil
+		}
 	}
 
 	// infer from Docker host
@@ -1380,6 +2268,7 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 		Attachable:     req.Attachable,
 		Labels:         req.Labels,
 		IPAM:           req.IPAM,
+		Options:        req.Options,
 	}
 
 	sessionID := core.SessionID()
@@ -1467,6 +2356,42 @@ func (p *DockerProvider) GetGatewayIP(ctx context.Context) (string, error) {
 	return ip, nil
 }
 
+// HostInternalAddress returns the address a container can use to reach the services listening on the host
+// running the tests, e.g. a server started by the test, depending on the Docker environment:
+//
+//   - "host.docker.internal" with Docker Desktop, on macOS, Windows and WSL2, where the containers run in a VM.
+//   - "host.containers.internal" with Podman.
+//   - "10.0.2.2" with rootless Docker, which is only reachable if the host loopback is enabled in RootlessKit,
+//     with the DOCKERD_ROOTLESS_ROOTLESSKIT_DISABLE_HOST_LOOPBACK=false environment variable of the daemon.
+//   - Else, the gateway IP of the default network, e.g. on Linux, or with the Docker Engine installed in WSL2.
+//
+// The services must listen on all the interfaces, and not only on the loopback interface, to be reachable at the gateway IP.
+func (p *DockerProvider) HostInternalAddress(ctx context.Context) (string, error) {
+	info, err := p.client.Info(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return hostInternalAddress(info, p.defaultBridgeNetworkName == Podman, func() (string, error) {
+		return p.GetGatewayIP(ctx)
+	})
+}
+
+// hostInternalAddress returns the address to reach the host from the containers, for the given daemon info,
+// calling the gateway function when the address is the gateway IP of the default network.
+func hostInternalAddress(info system.Info, podman bool, gateway func() (string, error)) (string, error) {
+	switch {
+	case info.OperatingSystem == "Docker Desktop":
+		return "host.docker.internal", nil
+	case podman:
+		return "host.containers.internal", nil
+	case slices.Contains(info.SecurityOptions, "name=rootless"):
+		return "10.0.2.2", nil
+	default:
+		return gateway()
+	}
+}
+
 func (p *DockerProvider) getDefaultNetwork(ctx context.Context, cli client.APIClient) (string, error) {
 	// Get list of available networks
 	networkResources, err := cli.NetworkList(ctx, types.NetworkListOptions{})
@@ -1511,6 +2436,14 @@ func containerFromDockerResponse(ctx context.Context, response types.Container)
 		return nil, err
 	}
 
+	return provider.ContainerFromDockerResponse(ctx, response)
+}
+
+// ContainerFromDockerResponse builds a Docker container struct from the response of the Docker API
+// when listing containers, using the provider to interact with the container.
+// It allows getting a fully functional container for containers not created by Testcontainers,
+// such as the services of a compose stack.
+func (p *DockerProvider) ContainerFromDockerResponse(ctx context.Context, response types.Container) (*DockerContainer, error) {
 	container := DockerContainer{}
 
 	container.ID = response.ID
@@ -1518,11 +2451,11 @@ func containerFromDockerResponse(ctx context.Context, response types.Container)
 	container.Image = response.Image
 	container.imageWasBuilt = false
 
-	container.logger = provider.Logger
+	container.logger = p.Logger
 	container.lifecycleHooks = []ContainerLifecycleHooks{
 		DefaultLoggingHook(container.logger),
 	}
-	container.provider = provider
+	container.provider = p
 
 	container.sessionID = core.SessionID()
 	container.consumers = []LogConsumer{}
@@ -1533,7 +2466,7 @@ func containerFromDockerResponse(ctx context.Context, response types.Container)
 	container.terminationSignal = nil
 
 	// populate the raw representation of the container
-	_, err = container.inspectRawContainer(ctx)
+	_, err := container.inspectRawContainer(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -21,9 +21,8 @@ type DockerClient struct {
 }
 
 var (
-	// dockerInfo stores the docker info to be reused in the Info method
-	dockerInfo     system.Info
-	dockerInfoSet  bool
+	// dockerInfos stores the docker info of each daemon, by daemon host, to be reused in the Info method
+	dockerInfos    = map[string]system.Info{}
 	dockerInfoLock sync.Mutex
 )
 
@@ -36,21 +35,23 @@ func (c *DockerClient) Events(ctx context.Context, options types.EventsOptions)
 }
 
 // Info returns information about the docker server. The result of Info is cached
-// and reused every time Info is called.
+// for each daemon host, and reused every time Info is called by a client of the same daemon,
+// so that the providers of different daemons don't share it.
 // It will also print out the docker server info, and the resolved Docker paths, to the default logger.
 func (c *DockerClient) Info(ctx context.Context) (system.Info, error) {
 	dockerInfoLock.Lock()
 	defer dockerInfoLock.Unlock()
-	if dockerInfoSet {
+
+	host := c.Client.DaemonHost()
+	if dockerInfo, ok := dockerInfos[host]; ok {
 		return dockerInfo, nil
 	}
 
-	info, err := c.Client.Info(ctx)
+	dockerInfo, err := c.Client.Info(ctx)
 	if err != nil {
-		return info, fmt.Errorf("failed to retrieve docker info: %w", err)
+		return dockerInfo, fmt.Errorf("failed to retrieve docker info: %w", daemonUnavailableError(err))
 	}
-	dockerInfo = info
-	dockerInfoSet = true
+	dockerInfos[host] = dockerInfo
 
 	infoMessage := `%v - Connected to docker: 
   Server Version: %v
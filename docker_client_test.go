@@ -2,9 +2,16 @@ package testcontainers
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/client"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,3 +46,42 @@ func TestGetDockerInfo(t *testing.T) {
 		wg.Wait()
 	})
 }
+
+func TestGetDockerInfo_cachedByDaemon(t *testing.T) {
+	// newDaemon returns a client of a fake daemon answering the info requests with the given name,
+	// along with the number of requests it received
+	newDaemon := func(name string) (*DockerClient, *atomic.Int32) {
+		requests := &atomic.Int32{}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/info") {
+				http.NotFound(w, r)
+				return
+			}
+			requests.Add(1)
+			_ = json.NewEncoder(w).Encode(system.Info{Name: name})
+		}))
+		t.Cleanup(srv.Close)
+
+		cli, err := client.NewClientWithOpts(client.WithHost("tcp://"+srv.Listener.Addr().String()), client.WithVersion("1.43"))
+		require.NoError(t, err)
+
+		return &DockerClient{Client: cli}, requests
+	}
+
+	ctx := context.Background()
+	first, firstRequests := newDaemon("first")
+	second, secondRequests := newDaemon("second")
+
+	for i := 0; i < 3; i++ {
+		info, err := first.Info(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "first", info.Name)
+
+		info, err = second.Info(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "second", info.Name)
+	}
+
+	require.Equal(t, int32(1), firstRequests.Load())
+	require.Equal(t, int32(1), secondRequests.Load())
+}
@@ -1,10 +1,12 @@
 package testcontainers
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -161,3 +163,133 @@ func TestExecWithNonMultiplexedResponse(t *testing.T) {
 	str := string(b)
 	require.True(t, strings.HasSuffix(str, "html\n"))
 }
+
+func TestExecDetached(t *testing.T) {
+	ctx := context.Background()
+	req := ContainerRequest{
+		Image: nginxAlpineImage,
+	}
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, container)
+
+	// execDetached {
+	code, _, err := container.Exec(ctx,
+		[]string{"sh", "-c", "sleep 2 && touch done"},
+		tcexec.WithWorkingDir("/tmp"),
+		tcexec.WithUser("nginx"),
+		tcexec.Detached(),
+	)
+	// }
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	// the command has not finished yet
+	code, _, err = container.Exec(ctx, []string{"ls", "/tmp/done"})
+	require.NoError(t, err)
+	require.NotZero(t, code)
+
+	require.Eventually(t, func() bool {
+		code, _, err := container.Exec(ctx, []string{"ls", "/tmp/done"})
+		return err == nil && code == 0
+	}, 10*time.Second, 500*time.Millisecond)
+}
+
+func TestExecWithDemux(t *testing.T) {
+	ctx := context.Background()
+	req := ContainerRequest{
+		Image: nginxAlpineImage,
+	}
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, container)
+
+	// execWithDemux {
+	var stdout, stderr bytes.Buffer
+	code, reader, err := container.Exec(ctx,
+		[]string{"sh", "-c", "echo out && echo err >&2"},
+		tcexec.WithDemux(&stdout, &stderr),
+	)
+	// }
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	require.Equal(t, "out\n", stdout.String())
+	require.Equal(t, "err\n", stderr.String())
+
+	combined, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Contains(t, string(combined), "out\n")
+	require.Contains(t, string(combined), "err\n")
+}
+
+func TestExecWithStdin(t *testing.T) {
+	ctx := context.Background()
+	req := ContainerRequest{
+		Image: nginxAlpineImage,
+	}
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, container)
+
+	// execWithStdin {
+	code, _, err := container.Exec(ctx,
+		[]string{"sh", "-c", "cat > /tmp/fixture.txt"},
+		tcexec.WithStdin(strings.NewReader("hello from stdin\n")),
+	)
+	// }
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	code, reader, err := container.Exec(ctx, []string{"cat", "/tmp/fixture.txt"}, tcexec.Multiplexed())
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	b, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "hello from stdin\n", string(b))
+}
+
+func TestExecShell_quoting(t *testing.T) {
+	ctx := context.Background()
+	req := ContainerRequest{
+		Image: nginxAlpineImage,
+	}
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, container)
+
+	// execShell {
+	name := "it's a test"
+
+	exitCode, output, err := container.(*DockerContainer).ExecShell(ctx,
+		"echo "+ShellQuote(name)+" > /tmp/name.txt && wc -c < /tmp/name.txt")
+	// }
+	require.NoError(t, err)
+	require.Zero(t, exitCode)
+	require.Equal(t, "12", strings.TrimSpace(output))
+}
@@ -2,7 +2,11 @@ package testcontainers_test
 
 import (
 	"context"
+	"embed"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,6 +47,66 @@ func TestCopyFileToContainer(t *testing.T) {
 	require.NoError(t, container.Terminate(ctx))
 }
 
+func TestCopyFileToContainer_fromReader(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	// copyFileFromReaderOnCreate {
+	script := strings.NewReader("echo hello from a reader\necho done\n")
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash",
+			Files: []testcontainers.ContainerFile{
+				{
+					Reader:            script,
+					ContainerFilePath: "/hello.sh",
+					FileMode:          0o700,
+				},
+			},
+			Cmd:        []string{"bash", "/hello.sh"},
+			WaitingFor: wait.ForLog("done"),
+		},
+		Started: true,
+	})
+	// }
+
+	require.NoError(t, err)
+	require.NoError(t, container.Terminate(ctx))
+}
+
+// fixtures are the files of the tests embedded in the test binary.
+//
+//go:embed testdata/hello.sh
+var fixtures embed.FS
+
+func TestCopyFileToContainer_fromFS(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	// copyFileFromFSOnCreate {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash",
+			Files: []testcontainers.ContainerFile{
+				{
+					Source:            fixtures,
+					Path:              "testdata/hello.sh",
+					ContainerFilePath: "/hello.sh",
+					FileMode:          0o700,
+				},
+			},
+			Cmd:        []string{"bash", "/hello.sh"},
+			WaitingFor: wait.ForLog("done"),
+		},
+		Started: true,
+	})
+	// }
+
+	require.NoError(t, err)
+	require.NoError(t, container.Terminate(ctx))
+}
+
 func TestCopyFileToRunningContainer(t *testing.T) {
 	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cnl()
@@ -88,6 +152,45 @@ func TestCopyFileToRunningContainer(t *testing.T) {
 	require.NoError(t, container.Terminate(ctx))
 }
 
+func TestCopyReaderToRunningContainer(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	waitForPath, err := filepath.Abs(filepath.Join(".", "testdata", "waitForHello.sh"))
+	require.NoError(t, err)
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash:5.2.26",
+			Files: []testcontainers.ContainerFile{
+				{
+					HostFilePath:      waitForPath,
+					ContainerFilePath: "/waitForHello.sh",
+					FileMode:          0o700,
+				},
+			},
+			Cmd: []string{"bash", "/waitForHello.sh"},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(context.Background()))
+	})
+
+	// copyReaderAfterCreate {
+	script := strings.NewReader("echo hello from a reader\necho done\n")
+
+	// CopyReaderToContainer is not part of the Container interface
+	err = container.(*testcontainers.DockerContainer).CopyReaderToContainer(ctx, script, "/scripts/hello.sh", 0o700)
+	// }
+	require.NoError(t, err)
+
+	// Give some time to the wait script to catch the hello script being created
+	err = wait.ForLog("done").WithStartupTimeout(2*time.Second).WaitUntilReady(ctx, container)
+	require.NoError(t, err)
+}
+
 func TestCopyDirectoryToContainer(t *testing.T) {
 	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cnl()
@@ -220,3 +323,130 @@ func TestCopyDirectoryToRunningContainerAsDir(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, container.Terminate(ctx))
 }
+
+func TestCopyDirFromContainer(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash",
+			Cmd: []string{"bash", "-c", "mkdir -p /reports/nested && echo passed > /reports/summary.txt && " +
+				"echo ok > /reports/nested/details.txt && echo done && sleep infinity"},
+			WaitingFor: wait.ForLog("done"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(context.Background()))
+	})
+
+	// copyDirFromContainer {
+	reportsDir := filepath.Join(t.TempDir(), "reports")
+	// CopyDirFromContainer is not part of the Container interface
+	err = container.(*testcontainers.DockerContainer).CopyDirFromContainer(ctx, "/reports", reportsDir)
+	// }
+	require.NoError(t, err)
+
+	bs, err := os.ReadFile(filepath.Join(reportsDir, "summary.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "passed\n", string(bs))
+
+	bs, err = os.ReadFile(filepath.Join(reportsDir, "nested", "details.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "ok\n", string(bs))
+
+	// copyFileFromContainer {
+	reader, err := container.CopyFileFromContainer(ctx, "/reports/summary.txt")
+	// }
+	require.NoError(t, err)
+	defer reader.Close()
+
+	bs, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "passed\n", string(bs))
+
+	err = container.(*testcontainers.DockerContainer).CopyDirFromContainer(ctx, "/reports/summary.txt", t.TempDir())
+	require.Error(t, err)
+}
+
+func TestReadFileAndListDir(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash",
+			Cmd: []string{"bash", "-c", "mkdir -p /exports/archive && echo 'id,name' > /exports/users.csv && " +
+				"echo old > /exports/archive/users.csv && echo done && sleep infinity"},
+			WaitingFor: wait.ForLog("done"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(context.Background()))
+	})
+
+	// readFile {
+	// ReadFile and ListDir are not part of the Container interface
+	dockerContainer := container.(*testcontainers.DockerContainer)
+
+	content, err := dockerContainer.ReadFile(ctx, "/exports/users.csv")
+	// }
+	require.NoError(t, err)
+	require.Equal(t, "id,name\n", string(content))
+
+	// listDir {
+	entries, err := dockerContainer.ListDir(ctx, "/exports")
+	// }
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "archive", entries[0].Name())
+	require.True(t, entries[0].IsDir())
+	require.Equal(t, "users.csv", entries[1].Name())
+	require.Equal(t, int64(len("id,name\n")), entries[1].Size())
+
+	_, err = dockerContainer.ReadFile(ctx, "/exports")
+	require.Error(t, err)
+
+	_, err = dockerContainer.ListDir(ctx, "/exports/users.csv")
+	require.Error(t, err)
+
+	_, err = dockerContainer.ReadFile(ctx, "/exports/missing.csv")
+	require.Error(t, err)
+}
+
+func TestSyncDirFromContainer(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	// a soak test writing a line to its log every 100 milliseconds
+	container, err := testcontainers.Run(ctx, "docker.io/bash",
+		testcontainers.WithCmd("bash", "-c", "mkdir -p /soak && for i in $(seq 1 1000); do echo iteration $i >> /soak/soak.log; sleep 0.1; done"),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(context.Background()))
+	})
+
+	// syncDir {
+	// SyncDir is not part of the Container interface
+	dockerContainer := container.(*testcontainers.DockerContainer)
+
+	soakDir := t.TempDir()
+	dirSync, err := dockerContainer.SyncDir(ctx, "/soak", soakDir, testcontainers.WithSyncInterval(200*time.Millisecond))
+	require.NoError(t, err)
+	// copy the last changes once the test is done
+	defer func() {
+		require.NoError(t, dirSync.Stop(ctx))
+	}()
+	// }
+
+	// the log is copied to the host as it grows
+	require.Eventually(t, func() bool {
+		bs, err := os.ReadFile(filepath.Join(soakDir, "soak.log"))
+		return err == nil && strings.Contains(string(bs), "iteration 5\n")
+	}, 10*time.Second, 100*time.Millisecond)
+}
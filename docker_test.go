@@ -7,22 +7,28 @@ import (
 	"io"
 	"log"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/strslice"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-units"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -2057,3 +2063,573 @@ func TestImageBuiltFromDockerfile_KeepBuiltImage(t *testing.T) {
 		})
 	}
 }
+
+func TestIsIPv6(t *testing.T) {
+	tests := []struct {
+		address  string
+		expected bool
+	}{
+		{address: "::", expected: true},
+		{address: "::1", expected: true},
+		{address: "fd00::2", expected: true},
+		{address: "0.0.0.0", expected: false},
+		{address: "127.0.0.1", expected: false},
+		{address: "::ffff:127.0.0.1", expected: false},
+		{address: "", expected: false},
+		{address: "localhost", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isIPv6(tt.address))
+		})
+	}
+}
+
+func TestContainerPauseUnpause(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	endpoint, err := nginx.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+
+	httpClient := &http.Client{Timeout: time.Second}
+
+	// pauseContainer {
+	// Pause and Unpause are not part of the Container interface
+	err = nginx.(*DockerContainer).Pause(ctx)
+	// }
+	require.NoError(t, err)
+
+	state, err := nginx.State(ctx)
+	require.NoError(t, err)
+	assert.True(t, state.Paused)
+
+	// the connection is accepted, but nginx does not respond
+	_, err = httpClient.Get(endpoint)
+	require.Error(t, err)
+
+	// unpauseContainer {
+	err = nginx.(*DockerContainer).Unpause(ctx)
+	// }
+	require.NoError(t, err)
+
+	resp, err := httpClient.Get(endpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// restartLogConsumer collects the logs of a container, to be read concurrently.
+type restartLogConsumer struct {
+	mtx  sync.Mutex
+	msgs []string
+}
+
+func (c *restartLogConsumer) Accept(l Log) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.msgs = append(c.msgs, string(l.Content))
+}
+
+func (c *restartLogConsumer) count(msg string) int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var n int
+	for _, m := range c.msgs {
+		if m == msg {
+			n++
+		}
+	}
+	return n
+}
+
+func TestContainerRestart(t *testing.T) {
+	ctx := context.Background()
+
+	consumer := &restartLogConsumer{}
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:    "./testdata/",
+				Dockerfile: "echoserver.Dockerfile",
+			},
+			ExposedPorts: []string{"8080/tcp"},
+			WaitingFor:   wait.ForLog("ready"),
+			LogConsumerCfg: &LogConsumerConfig{
+				Consumers: []LogConsumer{consumer},
+			},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	// restartContainer {
+	timeout := 10 * time.Second
+	// Restart is not part of the Container interface
+	err = c.(*DockerContainer).Restart(ctx, &timeout)
+	// }
+	require.NoError(t, err)
+	require.True(t, c.IsRunning())
+
+	// the mapped port could have changed, so it's retrieved again
+	endpoint, err := c.Endpoint(ctx, "http")
+	require.NoError(t, err)
+
+	resp, err := http.Get(endpoint + "/stdout?echo=restarted")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// the log consumer is still following the logs, only once
+	require.Eventually(t, func() bool {
+		return consumer.count("echo restarted\n") > 0
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, 1, consumer.count("echo restarted\n"))
+}
+
+func TestStopTimeoutSeconds(t *testing.T) {
+	tests := []struct {
+		timeout  time.Duration
+		expected int
+	}{
+		{timeout: 0, expected: 0},
+		{timeout: 500 * time.Millisecond, expected: 1},
+		{timeout: time.Second, expected: 1},
+		{timeout: 1500 * time.Millisecond, expected: 2},
+		{timeout: 10 * time.Second, expected: 10},
+		{timeout: -time.Second, expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeout.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, stopTimeoutSeconds(tt.timeout))
+		})
+	}
+}
+
+func TestContainerStopStart(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForHTTP("/").WithPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	// stopStartContainer {
+	timeout := 5 * time.Second
+	err = nginx.Stop(ctx, &timeout)
+	require.NoError(t, err)
+
+	// the wait strategy is checked again
+	err = nginx.Start(ctx)
+	require.NoError(t, err)
+
+	// the port could be mapped to a different one
+	endpoint, err := nginx.PortEndpoint(ctx, nginxDefaultPort, "http")
+	// }
+	require.NoError(t, err)
+
+	resp, err := http.Get(endpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestContainerKill(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			WaitingFor: wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	// nginx reloads its configuration on SIGHUP, so it keeps running
+	// Kill is not part of the Container interface
+	err = nginx.(*DockerContainer).Kill(ctx, "SIGHUP")
+	require.NoError(t, err)
+
+	state, err := nginx.State(ctx)
+	require.NoError(t, err)
+	assert.True(t, state.Running)
+
+	// killContainer {
+	err = nginx.(*DockerContainer).Kill(ctx, "SIGKILL")
+	// }
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		state, err := nginx.State(ctx)
+		return err == nil && !state.Running
+	}, 10*time.Second, 100*time.Millisecond)
+
+	state, err = nginx.State(ctx)
+	require.NoError(t, err)
+	// 128 + 9 (SIGKILL)
+	assert.Equal(t, 137, state.ExitCode)
+}
+
+func TestContainerCommit(t *testing.T) {
+	ctx := context.Background()
+
+	seeded, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "alpine:3.17",
+			Cmd:   []string{"sleep", "infinity"},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, seeded)
+
+	code, _, err := seeded.Exec(ctx, []string{"sh", "-c", "echo seeded > /seed.txt"})
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	// commitContainer {
+	image, err := seeded.(*DockerContainer).Commit(ctx, "testcontainers/seeded-alpine", uuid.NewString())
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		cli, err := NewDockerClientWithOpts(ctx)
+		require.NoError(t, err)
+		defer cli.Close()
+
+		_, err = cli.ImageRemove(ctx, image, types.ImageRemoveOptions{Force: true})
+		require.NoError(t, err)
+	})
+
+	// startFromCommittedImage {
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: image,
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	code, reader, err := c.Exec(ctx, []string{"cat", "/seed.txt"}, tcexec.Multiplexed())
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	bs, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "seeded\n", string(bs))
+}
+
+func TestContainerWaitForExit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("exit code", func(t *testing.T) {
+		// waitForExit {
+		job, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "alpine:3.17",
+				Cmd:   []string{"sh", "-c", "sleep 1; exit 3"},
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		terminateContainerOnEnd(t, ctx, job)
+
+		// WaitForExit is not part of the Container interface
+		exit, err := job.(*DockerContainer).WaitForExit(ctx)
+		// }
+		require.NoError(t, err)
+		assert.Equal(t, 3, exit.ExitCode)
+		assert.False(t, exit.OOMKilled)
+		assert.Empty(t, exit.Error)
+
+		// the container is not running anymore, so it returns immediately
+		exit, err = job.(*DockerContainer).WaitForExit(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, exit.ExitCode)
+	})
+
+	t.Run("out of memory", func(t *testing.T) {
+		job, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "alpine:3.17",
+				// tail buffers its whole input, as it contains no newlines
+				Cmd: []string{"sh", "-c", "head -c 512m /dev/zero | tail"},
+				HostConfigModifier: func(hostConfig *container.HostConfig) {
+					hostConfig.Memory = 32 * 1024 * 1024
+					hostConfig.MemorySwap = hostConfig.Memory
+				},
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		terminateContainerOnEnd(t, ctx, job)
+
+		exit, err := job.(*DockerContainer).WaitForExit(ctx)
+		require.NoError(t, err)
+		assert.True(t, exit.OOMKilled)
+		assert.NotZero(t, exit.ExitCode)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		sleeper, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "alpine:3.17",
+				Cmd:   []string{"sleep", "infinity"},
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		terminateContainerOnEnd(t, ctx, sleeper)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		_, err = sleeper.(*DockerContainer).WaitForExit(timeoutCtx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestContainerUpdateResources(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			WaitingFor: wait.ForListeningPort(nginxDefaultPort),
+			HostConfigModifier: func(hostConfig *container.HostConfig) {
+				hostConfig.Memory = 256 * 1024 * 1024
+				hostConfig.MemorySwap = hostConfig.Memory
+			},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	// updateResources {
+	// UpdateResources is not part of the Container interface
+	err = nginx.(*DockerContainer).UpdateResources(ctx, container.Resources{
+		Memory:     64 * 1024 * 1024,
+		MemorySwap: 64 * 1024 * 1024,
+		NanoCPUs:   500_000_000, // half a CPU
+	})
+	// }
+	require.NoError(t, err)
+
+	info, err := nginx.(*DockerContainer).Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(64*1024*1024), info.HostConfig.Memory)
+	assert.Equal(t, int64(500_000_000), info.HostConfig.NanoCPUs)
+
+	state, err := nginx.State(ctx)
+	require.NoError(t, err)
+	assert.True(t, state.Running)
+}
+
+func TestContainerStatus(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+			ConfigModifier: func(config *container.Config) {
+				config.Healthcheck = &container.HealthConfig{
+					Test:     []string{"CMD", "wget", "-q", "-O", "/dev/null", "http://localhost"},
+					Interval: time.Second,
+				}
+			},
+			WaitingFor: wait.ForHealthCheck(),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	// containerStatus {
+	// Status and IsHealthy are not part of the Container interface
+	status, err := nginx.(*DockerContainer).Status(ctx)
+	require.NoError(t, err)
+
+	healthy, err := nginx.(*DockerContainer).IsHealthy(ctx)
+	// }
+	require.NoError(t, err)
+	assert.True(t, healthy)
+
+	assert.True(t, status.Running)
+	assert.Equal(t, types.Healthy, status.Health)
+	assert.False(t, status.StartedAt.IsZero())
+	assert.True(t, status.FinishedAt.IsZero())
+
+	// Kill does not run the lifecycle hooks, so only Status detects that the container exited
+	err = nginx.(*DockerContainer).Kill(ctx, "SIGKILL")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		status, err := nginx.(*DockerContainer).Status(ctx)
+		return err == nil && !status.Running
+	}, 10*time.Second, 100*time.Millisecond)
+
+	alpine, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "alpine:3.17",
+			Cmd:   []string{"sleep", "infinity"},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, alpine)
+
+	_, err = alpine.(*DockerContainer).IsHealthy(ctx)
+	require.Error(t, err)
+}
+
+func TestDockerProvider_RunOneShot(t *testing.T) {
+	ctx := context.Background()
+
+	// runOneShot {
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	exitCode, stdout, stderr, err := provider.RunOneShot(ctx, ContainerRequest{
+		Image: "alpine:3.17",
+		Cmd:   []string{"sh", "-c", "echo out; echo err >&2; exit 2"},
+	})
+	// }
+	require.NoError(t, err)
+	assert.Equal(t, 2, exitCode)
+	assert.Equal(t, "out\n", string(stdout))
+	assert.Equal(t, "err\n", string(stderr))
+
+	t.Run("tty", func(t *testing.T) {
+		exitCode, stdout, stderr, err := provider.RunOneShot(ctx, ContainerRequest{
+			Image: "alpine:3.17",
+			Cmd:   []string{"echo", "out"},
+			ConfigModifier: func(config *container.Config) {
+				config.Tty = true
+			},
+		})
+		require.NoError(t, err)
+		assert.Zero(t, exitCode)
+		assert.Equal(t, "out\r\n", string(stdout))
+		assert.Empty(t, stderr)
+	})
+
+	t.Run("invalid image", func(t *testing.T) {
+		_, _, _, err := provider.RunOneShot(ctx, ContainerRequest{
+			Image: "testcontainers/not-found:latest",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestHostInternalAddress(t *testing.T) {
+	ctx := context.Background()
+
+	// the server must listen on all the interfaces to be reachable at the gateway IP
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("hello from the host"))
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	// hostInternalAddress {
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	address, err := provider.HostInternalAddress(ctx)
+	require.NoError(t, err)
+
+	exitCode, stdout, _, err := provider.RunOneShot(ctx, ContainerRequest{
+		Image: "alpine:3.17",
+		Cmd:   []string{"wget", "-qO-", fmt.Sprintf("http://%s/", net.JoinHostPort(address, strconv.Itoa(port)))},
+	})
+	// }
+	require.NoError(t, err)
+	require.Zero(t, exitCode)
+	require.Equal(t, "hello from the host", string(stdout))
+}
+
+func TestHostInternalAddressByEnvironment(t *testing.T) {
+	gateway := func() (string, error) {
+		return "172.17.0.1", nil
+	}
+
+	tests := []struct {
+		name     string
+		info     system.Info
+		podman   bool
+		expected string
+	}{
+		{
+			name:     "Docker Desktop",
+			info:     system.Info{OperatingSystem: "Docker Desktop"},
+			expected: "host.docker.internal",
+		},
+		{
+			name:     "Podman",
+			info:     system.Info{OperatingSystem: "fedora", SecurityOptions: []string{"name=rootless"}},
+			podman:   true,
+			expected: "host.containers.internal",
+		},
+		{
+			name:     "rootless Docker",
+			info:     system.Info{OperatingSystem: "Ubuntu 22.04.3 LTS", SecurityOptions: []string{"name=seccomp,profile=builtin", "name=rootless"}},
+			expected: "10.0.2.2",
+		},
+		{
+			name:     "Docker Engine",
+			info:     system.Info{OperatingSystem: "Ubuntu 22.04.3 LTS", SecurityOptions: []string{"name=seccomp,profile=builtin"}},
+			expected: "172.17.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := hostInternalAddress(tt.info, tt.podman, gateway)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, address)
+		})
+	}
+}
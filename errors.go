@@ -0,0 +1,41 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/client"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var (
+	// ErrImagePullFailed is the error of an image which can't be pulled, once the transient failures are retried,
+	// wrapping the error of the Docker daemon, e.g. a missing image or a denied access to its registry.
+	ErrImagePullFailed = errors.New("image pull failed")
+
+	// ErrDaemonUnavailable is the error of an operation which can't reach the Docker daemon, e.g. because it's not running,
+	// wrapping the error of the Docker client.
+	ErrDaemonUnavailable = errors.New("docker daemon unavailable")
+
+	// ErrPortNotMapped is the error of a container port which is not published on the host, e.g. because it's not exposed.
+	ErrPortNotMapped = errors.New("port not mapped")
+
+	// ErrPortNotTunneled is the error of a container port which can't be reached through the transport set with SetTransport,
+	// e.g. a UDP port, as only the TCP ports are tunneled.
+	ErrPortNotTunneled = errors.New("port not tunneled")
+
+	// ErrWaitTimeout is the error of a wait strategy which timed out before the container was ready, the error being
+	// a *wait.TimeoutError with the strategy, the time it waited for and the error of its last probe.
+	ErrWaitTimeout = wait.ErrTimeout
+)
+
+// daemonUnavailableError wraps the error of the Docker client with ErrDaemonUnavailable if it can't connect
+// to the Docker daemon. Otherwise, the error is returned as is.
+func daemonUnavailableError(err error) error {
+	if err == nil || !client.IsErrConnectionFailed(err) || errors.Is(err, ErrDaemonUnavailable) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrDaemonUnavailable, err)
+}
@@ -0,0 +1,122 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// unavailableClient is a mock implementation of client.APIClient which can't pull images,
+// inspecting containers without published ports.
+type unavailableClient struct {
+	client.APIClient
+
+	pullErr error
+}
+
+func (m *unavailableClient) ImagePull(context.Context, string, types.ImagePullOptions) (io.ReadCloser, error) {
+	return nil, m.pullErr
+}
+
+func (m *unavailableClient) ContainerInspect(_ context.Context, containerID string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: containerID, HostConfig: &container.HostConfig{}, State: &types.ContainerState{Running: true}},
+		NetworkSettings:   &types.NetworkSettings{},
+	}, nil
+}
+
+func (m *unavailableClient) Close() error {
+	return nil
+}
+
+func TestDaemonUnavailableError(t *testing.T) {
+	connErr := client.ErrorConnectionFailed("unix:///var/run/docker.sock")
+
+	err := daemonUnavailableError(connErr)
+	require.ErrorIs(t, err, ErrDaemonUnavailable)
+	require.True(t, client.IsErrConnectionFailed(err))
+
+	// the error is wrapped once
+	require.Equal(t, err, daemonUnavailableError(err))
+
+	// the other errors are returned as is
+	otherErr := errors.New("No such container: redis")
+	require.Equal(t, otherErr, daemonUnavailableError(otherErr))
+	require.NoError(t, daemonUnavailableError(nil))
+}
+
+func TestImagePullFailed(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		notFound := errdefs.NotFound(errors.New("pull access denied for unknown, repository does not exist"))
+		p := &DockerProvider{client: &unavailableClient{pullErr: notFound}}
+
+		err := p.PullImage(ctx, "unknown:latest")
+		require.ErrorIs(t, err, ErrImagePullFailed)
+		require.ErrorIs(t, err, notFound)
+		require.NotErrorIs(t, err, ErrDaemonUnavailable)
+		require.EqualError(t, err, "image pull failed: unknown:latest: pull access denied for unknown, repository does not exist")
+	})
+
+	t.Run("daemon unavailable", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		// the pull is not retried once the context is done
+		cancel()
+
+		p := &DockerProvider{client: &unavailableClient{pullErr: client.ErrorConnectionFailed("unix:///var/run/docker.sock")}}
+
+		err := p.PullImage(ctx, "nginx:alpine")
+		require.ErrorIs(t, err, ErrImagePullFailed)
+		require.ErrorIs(t, err, ErrDaemonUnavailable)
+	})
+}
+
+func TestPortNotMapped(t *testing.T) {
+	c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: &unavailableClient{}}}
+
+	_, err := c.MappedPort(context.Background(), "5432/tcp")
+	require.ErrorIs(t, err, ErrPortNotMapped)
+	require.EqualError(t, err, "port not mapped: 5432/tcp")
+
+	_, err = c.MappedPortIPv6(context.Background(), "5432/tcp")
+	require.ErrorIs(t, err, ErrPortNotMapped)
+	require.EqualError(t, err, "port not mapped on IPv6 interfaces: 5432/tcp")
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+
+	// branchOnErrors {
+	_, err := Run(ctx, "testcontainers/does-not-exist:latest")
+	if errors.Is(err, ErrImagePullFailed) {
+		// e.g. a missing image, or a denied access to its registry
+		t.Log(err)
+	}
+	// }
+	require.ErrorIs(t, err, ErrImagePullFailed)
+
+	// waitTimeout {
+	c, err := Run(ctx, nginxAlpineImage,
+		WithWaitStrategy(wait.ForLog("never logged").WithStartupTimeout(2*time.Second)),
+	)
+	var timeoutErr *wait.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		// the strategy which timed out, the time it waited for, and the error of its last probe, if any
+		t.Logf("%T, %s, %v", timeoutErr.Strategy, timeoutErr.Elapsed, timeoutErr.LastProbeErr)
+	}
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+	require.ErrorIs(t, err, ErrWaitTimeout)
+	require.ErrorAs(t, err, &timeoutErr)
+}
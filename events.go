@@ -0,0 +1,160 @@
+package testcontainers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// containerEventActions are the actions of the events surfaced by SubscribeEvents.
+var containerEventActions = []events.Action{
+	events.ActionStart,
+	events.ActionRestart,
+	events.ActionDie,
+	events.ActionOOM,
+	events.ActionHealthStatus,
+}
+
+// ContainerEvent is an event of the Docker daemon about a container.
+type ContainerEvent struct {
+	// Action is the action of the event, i.e. "start", "restart", "die", "oom" or "health_status".
+	Action string
+	// Time is the time of the event.
+	Time time.Time
+	// ExitCode is the exit code of the main process, for the "die" events.
+	ExitCode int
+	// HealthStatus is the new health status of the container, i.e. "healthy" or "unhealthy", for the "health_status" events.
+	HealthStatus string
+	// Attributes are the attributes of the event, e.g. the name, the image and the labels of the container.
+	Attributes map[string]string
+}
+
+// newContainerEvent parses an event message of the Docker daemon.
+func newContainerEvent(msg events.Message) ContainerEvent {
+	event := ContainerEvent{
+		Action:     string(msg.Action),
+		Time:       time.Unix(0, msg.TimeNano),
+		Attributes: msg.Actor.Attributes,
+	}
+
+	// the health status is part of the action, e.g. "health_status: healthy"
+	if action, status, ok := strings.Cut(event.Action, ":"); ok {
+		event.Action = action
+		event.HealthStatus = strings.TrimSpace(status)
+	}
+
+	if exitCode, ok := msg.Actor.Attributes["exitCode"]; ok {
+		event.ExitCode, _ = strconv.Atoi(exitCode)
+	}
+
+	return event
+}
+
+// SubscribeEvents returns a channel receiving the start, restart, die, oom and health_status events of the container,
+// from the moment it's called, so that tests can react to crashes instead of polling the state of the container.
+// The channel is closed when the context is done, or when the events stream of the Docker daemon fails.
+func (c *DockerContainer) SubscribeEvents(ctx context.Context) (<-chan ContainerEvent, error) {
+	args := filters.NewArgs(
+		filters.Arg("type", string(events.ContainerEventType)),
+		filters.Arg("container", c.ID),
+	)
+	for _, action := range containerEventActions {
+		args.Add("event", string(action))
+	}
+
+	messages, errs := c.provider.client.Events(ctx, types.EventsOptions{Filters: args})
+
+	ch := make(chan ContainerEvent)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				if ctx.Err() == nil {
+					c.logger.Printf("Stopping events subscription of container %s: %v", c.ID, err)
+				}
+				return
+			case msg := <-messages:
+				select {
+				case ch <- newContainerEvent(msg):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// Event is an event of the Docker daemon about a resource of the test session, e.g. a container dying.
+// The exit code and the health status of the embedded ContainerEvent are only set for the events of containers.
+type Event struct {
+	// Type is the type of the resource, e.g. "container" or "volume".
+	Type string
+	// ID is the ID of the resource.
+	ID string
+	ContainerEvent
+}
+
+// newEvent parses an event message of the Docker daemon.
+func newEvent(msg events.Message) Event {
+	return Event{
+		Type:           string(msg.Type),
+		ID:             msg.Actor.ID,
+		ContainerEvent: newContainerEvent(msg),
+	}
+}
+
+// Events returns a channel receiving the events of the resources labeled with the ID of the test session, e.g. its containers,
+// from the moment it's called, matching the given filters, e.g. filters.Arg("event", "die"), so that watchdogs can fail a test
+// as soon as any of its dependencies dies, instead of waiting for a client timeout. The channel is closed when the context
+// is done, or when the events stream of the Docker daemon fails.
+func (p *DockerProvider) Events(ctx context.Context, eventFilters filters.Args) (<-chan Event, error) {
+	// the filters are copied, so that the ones of the caller are not modified
+	args := filters.NewArgs(filters.Arg("label", core.LabelSessionID+"="+core.SessionID()))
+	for _, key := range eventFilters.Keys() {
+		for _, value := range eventFilters.Get(key) {
+			args.Add(key, value)
+		}
+	}
+
+	messages, errs := p.client.Events(ctx, types.EventsOptions{Filters: args})
+
+	ch := make(chan Event)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				if ctx.Err() == nil {
+					p.Logger.Printf("Stopping events subscription of the session %s: %v", core.SessionID(), err)
+				}
+				return
+			case msg := <-messages:
+				select {
+				case ch <- newEvent(msg):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
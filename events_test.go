@@ -0,0 +1,140 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContainerEvent(t *testing.T) {
+	now := time.Now()
+
+	t.Run("die", func(t *testing.T) {
+		event := newContainerEvent(events.Message{
+			Action:   events.ActionDie,
+			TimeNano: now.UnixNano(),
+			Actor: events.Actor{
+				ID:         "container-id",
+				Attributes: map[string]string{"exitCode": "137", "name": "nginx"},
+			},
+		})
+
+		assert.Equal(t, "die", event.Action)
+		assert.True(t, now.Equal(event.Time))
+		assert.Equal(t, 137, event.ExitCode)
+		assert.Empty(t, event.HealthStatus)
+		assert.Equal(t, "nginx", event.Attributes["name"])
+	})
+
+	t.Run("health status", func(t *testing.T) {
+		event := newContainerEvent(events.Message{
+			Action: events.ActionHealthStatusUnhealthy,
+		})
+
+		assert.Equal(t, "health_status", event.Action)
+		assert.Equal(t, "unhealthy", event.HealthStatus)
+		assert.Zero(t, event.ExitCode)
+	})
+}
+
+func TestContainerSubscribeEvents(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	// subscribeEvents {
+	eventsCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// SubscribeEvents is not part of the Container interface
+	containerEvents, err := nginx.(*DockerContainer).SubscribeEvents(eventsCtx)
+	// }
+	require.NoError(t, err)
+
+	err = nginx.(*DockerContainer).Kill(ctx, "SIGKILL")
+	require.NoError(t, err)
+
+	// waitForCrash {
+	for event := range containerEvents {
+		if event.Action == "die" {
+			assert.Equal(t, 137, event.ExitCode)
+			return
+		}
+	}
+	// }
+
+	t.Fatal("the die event was not received")
+}
+
+func TestNewEvent(t *testing.T) {
+	event := newEvent(events.Message{
+		Type:   events.ContainerEventType,
+		Action: events.ActionDie,
+		Actor: events.Actor{
+			ID:         "container-id",
+			Attributes: map[string]string{"exitCode": "1"},
+		},
+	})
+
+	assert.Equal(t, "container", event.Type)
+	assert.Equal(t, "container-id", event.ID)
+	assert.Equal(t, "die", event.Action)
+	assert.Equal(t, 1, event.ExitCode)
+}
+
+func TestDockerProvider_Events(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	// providerEvents {
+	watchdogCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	sessionEvents, err := provider.Events(watchdogCtx, filters.NewArgs(
+		filters.Arg("type", "container"),
+		filters.Arg("event", "die"),
+	))
+	// }
+	require.NoError(t, err)
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	err = nginx.(*DockerContainer).Kill(ctx, "SIGKILL")
+	require.NoError(t, err)
+
+	// watchdog {
+	for event := range sessionEvents {
+		if event.ID == nginx.GetContainerID() {
+			assert.Equal(t, "die", event.Action)
+			assert.Equal(t, 137, event.ExitCode)
+			return
+		}
+	}
+	// }
+
+	t.Fatal("the die event was not received")
+}
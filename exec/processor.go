@@ -12,6 +12,8 @@ import (
 type ProcessOptions struct {
 	ExecConfig types.ExecConfig
 	Reader     io.Reader
+	// Stdin is the reader the standard input of the command is read from, if set
+	Stdin io.Reader
 }
 
 // NewProcessOptions returns a new ProcessOptions instance
@@ -42,24 +44,49 @@ func (fn ProcessOptionFunc) Apply(opts *ProcessOptions) {
 	fn(opts)
 }
 
+// WithUser sets the user, and optionally the group, running the command, e.g. "nginx" or "1000:1000".
 func WithUser(user string) ProcessOption {
 	return ProcessOptionFunc(func(opts *ProcessOptions) {
 		opts.ExecConfig.User = user
 	})
 }
 
+// WithWorkingDir sets the working directory of the command.
 func WithWorkingDir(workingDir string) ProcessOption {
 	return ProcessOptionFunc(func(opts *ProcessOptions) {
 		opts.ExecConfig.WorkingDir = workingDir
 	})
 }
 
+// WithEnv sets the environment variables of the command, in the KEY=VALUE form.
 func WithEnv(env []string) ProcessOption {
 	return ProcessOptionFunc(func(opts *ProcessOptions) {
 		opts.ExecConfig.Env = env
 	})
 }
 
+// WithStdin attaches the standard input of the command to the reader, e.g. to pipe a SQL script to "psql -f -"
+// or the content of a file to "sh -c 'cat > /file'". The standard input is closed once the reader is exhausted.
+func WithStdin(stdin io.Reader) ProcessOption {
+	return ProcessOptionFunc(func(opts *ProcessOptions) {
+		opts.Stdin = stdin
+		opts.ExecConfig.AttachStdin = true
+	})
+}
+
+// Detached runs the command in the background, without waiting for it to exit.
+// The Exec function returns immediately, with a zero exit code and an empty reader,
+// so it's useful to start long-running processes, e.g. a server or a load generator.
+func Detached() ProcessOption {
+	return ProcessOptionFunc(func(opts *ProcessOptions) {
+		opts.ExecConfig.Detach = true
+		opts.ExecConfig.AttachStdout = false
+		opts.ExecConfig.AttachStderr = false
+	})
+}
+
+// Multiplexed demultiplexes the output of the command, returning a reader with the standard error
+// if the command wrote to it, or with the standard output otherwise.
 func Multiplexed() ProcessOption {
 	return ProcessOptionFunc(func(opts *ProcessOptions) {
 		// returning fast to bypass those options with a nil reader,
@@ -89,3 +116,30 @@ func Multiplexed() ProcessOption {
 		}
 	})
 }
+
+// WithDemux demultiplexes the output of the command, writing its standard output and its standard error,
+// without the headers of the Docker streams, to the given writers, which can be nil to discard them.
+// The reader returned by the Exec function contains both streams, in the order they were written.
+func WithDemux(stdout io.Writer, stderr io.Writer) ProcessOption {
+	return ProcessOptionFunc(func(opts *ProcessOptions) {
+		// returning fast to bypass those options with a nil reader,
+		// which could be the case when other options are used
+		// to configure the exec creation.
+		if opts.Reader == nil {
+			return
+		}
+
+		if stdout == nil {
+			stdout = io.Discard
+		}
+		if stderr == nil {
+			stderr = io.Discard
+		}
+
+		var combined bytes.Buffer
+		// the error is ignored, as in the Multiplexed option, keeping the output read so far
+		_, _ = stdcopy.StdCopy(io.MultiWriter(&combined, stdout), io.MultiWriter(&combined, stderr), opts.Reader)
+
+		opts.Reader = &combined
+	})
+}
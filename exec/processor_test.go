@@ -0,0 +1,60 @@
+package exec
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/require"
+)
+
+// multiplexedReader returns a reader with the output of a command as it's returned by the Docker API,
+// prefixing each write with the header of its stream.
+func multiplexedReader(t *testing.T) io.Reader {
+	t.Helper()
+
+	var buff bytes.Buffer
+	stdout := stdcopy.NewStdWriter(&buff, stdcopy.Stdout)
+	stderr := stdcopy.NewStdWriter(&buff, stdcopy.Stderr)
+
+	_, err := stdout.Write([]byte("out 1\n"))
+	require.NoError(t, err)
+	_, err = stderr.Write([]byte("err 1\n"))
+	require.NoError(t, err)
+	_, err = stdout.Write([]byte("out 2\n"))
+	require.NoError(t, err)
+
+	return &buff
+}
+
+func TestWithDemux(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	opts := &ProcessOptions{Reader: multiplexedReader(t)}
+	WithDemux(&stdout, &stderr).Apply(opts)
+
+	require.Equal(t, "out 1\nout 2\n", stdout.String())
+	require.Equal(t, "err 1\n", stderr.String())
+
+	combined, err := io.ReadAll(opts.Reader)
+	require.NoError(t, err)
+	require.Equal(t, "out 1\nerr 1\nout 2\n", string(combined))
+}
+
+func TestWithDemux_nilWriters(t *testing.T) {
+	opts := &ProcessOptions{Reader: multiplexedReader(t)}
+	WithDemux(nil, nil).Apply(opts)
+
+	combined, err := io.ReadAll(opts.Reader)
+	require.NoError(t, err)
+	require.Equal(t, "out 1\nerr 1\nout 2\n", string(combined))
+}
+
+func TestWithDemux_nilReader(t *testing.T) {
+	// the options are applied before the exec is created, when there is no reader yet
+	opts := &ProcessOptions{}
+	WithDemux(nil, nil).Apply(opts)
+
+	require.Nil(t, opts.Reader)
+}
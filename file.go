@@ -6,9 +6,14 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+
+	"github.com/moby/patternmatcher"
 )
 
 func isDir(path string) (bool, error) {
@@ -139,3 +144,234 @@ func tarFile(fileContent []byte, basePath string, fileMode int64) (*bytes.Buffer
 
 	return buffer, nil
 }
+
+// tarFS archives the file system using the tar algorithm, as a build context, skipping the files matching the excluded
+// patterns of a .dockerignore file, except the included ones, e.g. the Dockerfile. Only the regular files and the directories
+// are archived: the symlinks, which most of the file systems don't support, are skipped.
+func tarFS(fsys fs.FS, excluded []string, includes ...string) (*bytes.Buffer, error) {
+	pm, err := patternmatcher.New(excluded)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing .dockerignore: %w", err)
+	}
+
+	buffer := &bytes.Buffer{}
+	tw := tar.NewWriter(buffer)
+
+	err = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, errFn error) error {
+		if errFn != nil {
+			return fmt.Errorf("error traversing the file system: %w", errFn)
+		}
+
+		if p == "." {
+			return nil
+		}
+
+		if !slices.Contains(includes, p) {
+			skip, err := pm.MatchesOrParentMatches(p)
+			if err != nil {
+				return fmt.Errorf("error matching %s: %w", p, err)
+			}
+
+			if skip {
+				// the files of an excluded directory can be included again by an exception, e.g. !dir/file, or be included
+				if d.IsDir() && !pm.Exclusions() && !slices.ContainsFunc(includes, func(include string) bool {
+					return strings.HasPrefix(include, p+"/")
+				}) {
+					return fs.SkipDir
+				}
+
+				return nil
+			}
+		}
+
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("error getting file info: %w", err)
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return fmt.Errorf("error getting file info header: %w", err)
+		}
+
+		header.Name = p
+		if d.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("error writing header: %w", err)
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		data, err := fsys.Open(p)
+		if err != nil {
+			return fmt.Errorf("error opening file: %w", err)
+		}
+		defer data.Close()
+
+		if _, err := io.Copy(tw, data); err != nil {
+			return fmt.Errorf("error archiving file: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return buffer, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return buffer, fmt.Errorf("error closing tar file: %w", err)
+	}
+
+	return buffer, nil
+}
+
+// extractTar extracts the tar stream returned by the Docker API when copying a directory from a container
+// into the dst directory of the host, which is created if it does not exist. The first element of the paths
+// in the stream, which is the name of the copied directory, is removed, so only its contents are extracted.
+func extractTar(r io.Reader, dst string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading tar file: %w", err)
+		}
+
+		target := filepath.Join(dst, copiedDirEntry(header.Name))
+		if !isWithinDir(dst, target) {
+			return fmt.Errorf("invalid path in tar file: %s", header.Name)
+		}
+
+		// a previous entry could be a symlink, e.g. "x -> ../etc", so that writing "x/passwd" escapes the directory
+		if err := checkNoSymlinkInPath(dst, target); err != nil {
+			return fmt.Errorf("invalid path in tar file: %s: %w", header.Name, err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("error creating directory: %w", err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return fmt.Errorf("error creating directory: %w", err)
+			}
+
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return fmt.Errorf("error creating file: %w", err)
+			}
+
+			_, err = io.Copy(f, tr)
+			// closing here, as deferring would keep all the files open until all of them are extracted
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("error extracting file: %w", err)
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) || !isWithinDir(dst, filepath.Join(filepath.Dir(target), header.Linkname)) {
+				return fmt.Errorf("invalid symlink in tar file: %s -> %s", header.Name, header.Linkname)
+			}
+
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return fmt.Errorf("error creating symlink: %w", err)
+			}
+		default:
+			Logger.Printf(">> skipping unsupported file type in tar file: %s\n", header.Name)
+		}
+	}
+}
+
+// copiedDirEntry returns the path of an entry of the tar stream returned by the Docker API when copying a directory
+// from a container, relative to the copied directory, i.e. without its name, which is the first element of the path.
+func copiedDirEntry(name string) string {
+	name = filepath.FromSlash(name)
+	if i := strings.IndexRune(name, filepath.Separator); i >= 0 {
+		return name[i+1:]
+	}
+
+	return ""
+}
+
+// isWithinDir returns true if the path is the dir itself or is inside it, once both are cleaned.
+func isWithinDir(dir string, path string) bool {
+	dir = filepath.Clean(dir)
+	path = filepath.Clean(path)
+
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// checkNoSymlinkInPath returns an error if the target, or any of its existing parents below dir, is a symlink,
+// so that extracting a file never follows a symlink created by a previous entry of a tar stream.
+func checkNoSymlinkInPath(dir string, target string) error {
+	dir = filepath.Clean(dir)
+
+	rel, err := filepath.Rel(dir, target)
+	if err != nil || rel == "." {
+		return err
+	}
+
+	current := dir
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			// the rest of the path is created by the extraction
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", current)
+		}
+	}
+
+	return nil
+}
+
+// listTar lists the direct entries of the directory copied in the tar stream returned by the Docker API,
+// sorted by name, without the contents of its subdirectories. The names of the entries are their base names.
+func listTar(r io.Reader) ([]fs.FileInfo, error) {
+	tr := tar.NewReader(r)
+
+	entries := []fs.FileInfo{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading tar file: %w", err)
+		}
+
+		// the paths in the stream start with the name of the copied directory,
+		// so the direct entries have exactly two elements, e.g. "dir/file" or "dir/subdir/"
+		parts := strings.Split(strings.TrimSuffix(header.Name, "/"), "/")
+		if len(parts) != 2 {
+			continue
+		}
+
+		entries = append(entries, header.FileInfo())
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	return entries, nil
+}
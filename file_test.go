@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -202,3 +203,218 @@ func untar(dst string, r io.Reader) error {
 		}
 	}
 }
+
+func Test_ExtractTar(t *testing.T) {
+	writeTar := func(t *testing.T, entries map[string]string) io.Reader {
+		t.Helper()
+
+		buffer := &bytes.Buffer{}
+		tw := tar.NewWriter(buffer)
+
+		// the directory entry comes first in the streams of the Docker API
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "reports/", Typeflag: tar.TypeDir, Mode: 0o755}))
+
+		for name, content := range entries {
+			require.NoError(t, tw.WriteHeader(&tar.Header{
+				Name:     name,
+				Typeflag: tar.TypeReg,
+				Mode:     0o644,
+				Size:     int64(len(content)),
+			}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, tw.Close())
+		return buffer
+	}
+
+	t.Run("contents of the directory", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "copied")
+
+		err := extractTar(writeTar(t, map[string]string{
+			"reports/report.xml":        "<report/>",
+			"reports/nested/report.txt": "ok",
+		}), dst)
+		require.NoError(t, err)
+
+		bs, err := os.ReadFile(filepath.Join(dst, "report.xml"))
+		require.NoError(t, err)
+		assert.Equal(t, "<report/>", string(bs))
+
+		bs, err = os.ReadFile(filepath.Join(dst, "nested", "report.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(bs))
+	})
+
+	t.Run("path traversal", func(t *testing.T) {
+		err := extractTar(writeTar(t, map[string]string{
+			"reports/../../evil.txt": "evil",
+		}), filepath.Join(t.TempDir(), "copied"))
+		require.Error(t, err)
+	})
+
+	writeTarWithSymlink := func(t *testing.T, linkname string, entry string) io.Reader {
+		t.Helper()
+
+		buffer := &bytes.Buffer{}
+		tw := tar.NewWriter(buffer)
+
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "reports/", Typeflag: tar.TypeDir, Mode: 0o755}))
+
+		if linkname != "" {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: "reports/link", Typeflag: tar.TypeSymlink, Linkname: linkname}))
+		}
+
+		if entry != "" {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: entry, Typeflag: tar.TypeReg, Mode: 0o644, Size: 4}))
+			_, err := tw.Write([]byte("evil"))
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, tw.Close())
+		return buffer
+	}
+
+	t.Run("symlink inside the directory", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "copied")
+
+		err := extractTar(writeTarWithSymlink(t, "summary.txt", ""), dst)
+		require.NoError(t, err)
+
+		linkname, err := os.Readlink(filepath.Join(dst, "link"))
+		require.NoError(t, err)
+		assert.Equal(t, "summary.txt", linkname)
+	})
+
+	t.Run("absolute symlink", func(t *testing.T) {
+		err := extractTar(writeTarWithSymlink(t, "/etc", ""), filepath.Join(t.TempDir(), "copied"))
+		require.Error(t, err)
+	})
+
+	t.Run("relative symlink outside the directory", func(t *testing.T) {
+		err := extractTar(writeTarWithSymlink(t, "../../outside", ""), filepath.Join(t.TempDir(), "copied"))
+		require.Error(t, err)
+	})
+
+	t.Run("file written through a symlink", func(t *testing.T) {
+		err := extractTar(writeTarWithSymlink(t, ".", "reports/link/evil.txt"), filepath.Join(t.TempDir(), "copied"))
+		require.Error(t, err)
+	})
+
+	t.Run("file written through an existing symlink", func(t *testing.T) {
+		tmp := t.TempDir()
+		dst := filepath.Join(tmp, "copied")
+
+		require.NoError(t, os.MkdirAll(dst, 0o755))
+		require.NoError(t, os.Symlink(tmp, filepath.Join(dst, "link")))
+
+		err := extractTar(writeTarWithSymlink(t, "", "reports/link/evil.txt"), dst)
+		require.Error(t, err)
+
+		_, err = os.Stat(filepath.Join(tmp, "evil.txt"))
+		require.True(t, os.IsNotExist(err))
+	})
+}
+
+func Test_ListTar(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	tw := tar.NewWriter(buffer)
+
+	headers := []*tar.Header{
+		{Name: "exports/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "exports/users.csv", Typeflag: tar.TypeReg, Mode: 0o644, Size: 2},
+		{Name: "exports/archive/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "exports/archive/users.csv", Typeflag: tar.TypeReg, Mode: 0o644, Size: 2},
+		{Name: "exports/latest", Typeflag: tar.TypeSymlink, Linkname: "users.csv"},
+	}
+	for _, header := range headers {
+		require.NoError(t, tw.WriteHeader(header))
+		if header.Size > 0 {
+			_, err := tw.Write([]byte("ok"))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	entries, err := listTar(buffer)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"archive", "latest", "users.csv"}, names)
+
+	assert.True(t, entries[0].IsDir())
+	assert.Equal(t, os.ModeSymlink, entries[1].Mode().Type())
+	assert.Equal(t, int64(2), entries[2].Size())
+}
+
+func Test_TarFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		".dockerignore":     {Data: []byte("*.md\nvendor\ndocs\n!docs/keep.txt\n")},
+		"Dockerfile":        {Data: []byte("FROM alpine\n")},
+		"README.md":         {Data: []byte("readme")},
+		"app/main.go":       {Data: []byte("package main"), Mode: 0o644},
+		"app/run.sh":        {Data: []byte("echo run"), Mode: 0o755},
+		"vendor/dep/dep.go": {Data: []byte("package dep")},
+		"docs/guide.txt":    {Data: []byte("guide")},
+		"docs/keep.txt":     {Data: []byte("keep")},
+	}
+
+	// readTar returns the content of the files of the tar archive, and the permissions of the executable ones
+	readTar := func(t *testing.T, r io.Reader) (map[string]string, []string) {
+		t.Helper()
+
+		files := map[string]string{}
+		var executables []string
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				return files, executables
+			}
+			require.NoError(t, err)
+
+			bs, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files[hdr.Name] = string(bs)
+			if hdr.Typeflag == tar.TypeReg && hdr.Mode&0o111 != 0 {
+				executables = append(executables, hdr.Name)
+			}
+		}
+	}
+
+	t.Run("dockerignore", func(t *testing.T) {
+		excluded, err := parseDockerIgnoreFS(fsys)
+		require.NoError(t, err)
+
+		buff, err := tarFS(fsys, excluded, ".dockerignore", "Dockerfile")
+		require.NoError(t, err)
+
+		files, executables := readTar(t, buff)
+		require.Equal(t, map[string]string{
+			".dockerignore": "*.md\nvendor\ndocs\n!docs/keep.txt\n",
+			"Dockerfile":    "FROM alpine\n",
+			"app/":          "",
+			"app/main.go":   "package main",
+			"app/run.sh":    "echo run",
+			"docs/keep.txt": "keep",
+		}, files)
+		require.Equal(t, []string{"app/run.sh"}, executables)
+	})
+
+	t.Run("included files", func(t *testing.T) {
+		buff, err := tarFS(fsys, []string{"*"}, "Dockerfile")
+		require.NoError(t, err)
+
+		files, _ := readTar(t, buff)
+		require.Equal(t, map[string]string{"Dockerfile": "FROM alpine\n"}, files)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := tarFS(fsys, []string{"["})
+		require.Error(t, err)
+	})
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/docker/errdefs"
 
 	"github.com/testcontainers/testcontainers-go/internal/core"
 )
@@ -16,11 +21,17 @@ var (
 
 // GenericContainerRequest represents parameters to a generic container
 type GenericContainerRequest struct {
-	ContainerRequest              // embedded request for provider
-	Started          bool         // whether to auto-start the container
-	ProviderType     ProviderType // which provider to use, Docker if empty
-	Logger           Logging      // provide a container specific Logging - use default global logger if empty
-	Reuse            bool         // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+	ContainerRequest                       // embedded request for provider
+	Started              bool              // whether to auto-start the container
+	ProviderType         ProviderType      // which provider to use, Docker if empty
+	Logger               Logging           // provide a container specific Logging - use default global logger if empty
+	Reuse                bool              // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+	Deadline             time.Time         // the time by which the container must be created and started, including the image pull and the wait strategy. No deadline if zero
+	StartupAttempts      int               // the number of attempts to create and start the container, terminating it between attempts. One attempt if zero. Reused containers are not retried
+	PortConflictRetries  int               // the number of times the container is created again with the next free host port when one of its host ports is in use. Not retried if zero, nor if reused
+	RemapPrivilegedPorts bool              // whether the container is created again with a random host port when the rootless container runtime can't publish one of its privileged host ports. Not retried if reused
+	HostOverride         string            // the host where the ports of the container are exposed, e.g. the IP of the VM running the Docker daemon. Derived from the Docker host, or from the configuration, if empty
+	ConcurrencyLimits    ConcurrencyLimits // the limits of the operations on the Docker daemon, shared with the other containers of the process. Read from the configuration if zero
 }
 
 // Deprecated: will be removed in the future.
@@ -45,6 +56,39 @@ func GenericNetwork(ctx context.Context, req GenericNetworkRequest) (Network, er
 	return network, nil
 }
 
+// Run creates and starts a container from the given image, customizing the request with the given options,
+// e.g. WithExposedPorts, WithEnv and WithWaitStrategy, as an alternative to filling a ContainerRequest.
+// The options can be grouped in slices, to be shared across tests and modules. As in GenericContainer,
+// the container may be returned along with an error, to be terminated by the caller.
+func Run(ctx context.Context, img string, opts ...ContainerCustomizer) (Container, error) {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: img,
+		},
+		Started: true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&req)
+	}
+
+	return GenericContainer(ctx, req)
+}
+
+// RunContainer creates a container for the given request, as GenericContainer does, and wraps it with the given function
+// into the container type of a module, e.g. a container with a ConnectionString method, so that the modules
+// don't need to handle the errors of GenericContainer themselves. If the container is returned along with an error,
+// it's wrapped as well, so it can be terminated by the caller.
+func RunContainer[T Container](ctx context.Context, req GenericContainerRequest, wrap func(Container) T) (T, error) {
+	container, err := GenericContainer(ctx, req)
+	if container == nil {
+		var zero T
+		return zero, err
+	}
+
+	return wrap(container), err
+}
+
 // GenericContainer creates a generic container with parameters
 func GenericContainer(ctx context.Context, req GenericContainerRequest) (Container, error) {
 	if req.Reuse && req.Name == "" {
@@ -55,36 +99,126 @@ func GenericContainer(ctx context.Context, req GenericContainerRequest) (Contain
 	if logging == nil {
 		logging = Logger
 	}
-	provider, err := req.ProviderType.GetProvider(WithLogger(logging))
+	providerOpts := []GenericProviderOption{WithLogger(logging)}
+	if req.HostOverride != "" {
+		providerOpts = append(providerOpts, WithHostOverride(req.HostOverride))
+	}
+	if req.ConcurrencyLimits != (ConcurrencyLimits{}) {
+		providerOpts = append(providerOpts, WithConcurrencyLimits(req.ConcurrencyLimits))
+	}
+
+	provider, err := req.ProviderType.GetProvider(providerOpts...)
 	if err != nil {
 		return nil, err
 	}
 	defer provider.Close()
 
-	var c Container
+	if !req.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, req.Deadline)
+		defer cancel()
+	}
+
 	if req.Reuse {
 		// we must protect the reusability of the container in the case it's invoked
 		// in a parallel execution, via ParallelContainers or t.Parallel()
 		reuseContainerMx.Lock()
 		defer reuseContainerMx.Unlock()
+	}
+
+	attempts := req.StartupAttempts
+	if attempts < 1 || req.Reuse {
+		// a reused container can't be terminated to retry, as it could be used by other tests
+		attempts = 1
+	}
+
+	if attempts > 1 {
+		// an invalid request is not retried
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	retryBackOff := backoff.WithContext(backoff.NewExponentialBackOff(), ctx)
 
+	for attempt := 1; ; attempt++ {
+		c, err := createAndStartContainerOnFreePorts(ctx, provider, logging, req)
+		if err == nil || attempt == attempts || !isRetryableStartupError(ctx, err) {
+			// At this point `c` might not be nil. Give the caller an opportunity to call Destroy on the container.
+			return c, err
+		}
+
+		logf(ctx, logging, slog.LevelWarn, []slog.Attr{slog.String(LogKeyImage, req.Image), slog.String(LogKeyOperation, "start")},
+			"🔁 Attempt %d of %d to start the container failed, retrying: %s", attempt, attempts, err)
+
+		if c != nil {
+			if termErr := c.Terminate(ctx); termErr != nil {
+				return c, errors.Join(err, fmt.Errorf("terminate container to retry: %w", termErr))
+			}
+		}
+
+		next := retryBackOff.NextBackOff()
+		if next == backoff.Stop {
+			return nil, deadlineError(ctx, req.Deadline, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, deadlineError(ctx, req.Deadline, err)
+		case <-time.After(next):
+		}
+	}
+}
+
+// createAndStartContainer creates the container of the request, starting it if needed, in a single attempt.
+func createAndStartContainer(ctx context.Context, provider GenericProvider, req GenericContainerRequest) (Container, error) {
+	var (
+		c   Container
+		err error
+	)
+	if req.Reuse {
 		c, err = provider.ReuseOrCreateContainer(ctx, req.ContainerRequest)
 	} else {
 		c, err = provider.CreateContainer(ctx, req.ContainerRequest)
 	}
 	if err != nil {
-		// At this point `c` might not be nil. Give the caller an opportunity to call Destroy on the container.
-		return c, fmt.Errorf("%w: failed to create container", err)
+		return c, fmt.Errorf("%w: failed to create container", deadlineError(ctx, req.Deadline, daemonUnavailableError(err)))
 	}
 
 	if req.Started && !c.IsRunning() {
 		if err := c.Start(ctx); err != nil {
-			return c, fmt.Errorf("failed to start container: %w", err)
+			return c, fmt.Errorf("failed to start container: %w", deadlineError(ctx, req.Deadline, daemonUnavailableError(err)))
 		}
 	}
 	return c, nil
 }
 
+// isRetryableStartupError returns false for the errors that won't go away by retrying to start the container:
+// a missing image, or a done context, e.g. because of an exceeded deadline. The timeout of a wait strategy is retried.
+func isRetryableStartupError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var notFound errdefs.ErrNotFound
+	return !errors.As(err, &notFound)
+}
+
+// deadlineError adds the startup deadline to the given error, if the deadline was exceeded,
+// so that it's not mistaken with the timeout of a wait strategy.
+func deadlineError(ctx context.Context, deadline time.Time, err error) error {
+	if deadline.IsZero() || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	// the deadline of the caller's context may be the one that was exceeded
+	if ctxDeadline, ok := ctx.Deadline(); !ok || !ctxDeadline.Equal(deadline) {
+		return err
+	}
+
+	return fmt.Errorf("startup deadline %s exceeded: %w", deadline.Format(time.RFC3339), err)
+}
+
 // GenericProvider represents an abstraction for container and network providers
 type GenericProvider interface {
 	ContainerProvider
@@ -3,6 +3,7 @@ package testcontainers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/exec"
@@ -11,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/docker/errdefs"
 	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -123,6 +125,93 @@ func TestGenericContainerShouldReturnRefOnError(t *testing.T) {
 	terminateContainerOnEnd(t, context.Background(), c)
 }
 
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	// runWithOptions {
+	// the options can be grouped to be shared across tests
+	nginxOptions := []ContainerCustomizer{
+		WithExposedPorts(nginxDefaultPort),
+		WithWaitStrategy(wait.ForHTTP("/").WithPort(nginxDefaultPort)),
+	}
+
+	c, err := Run(ctx, nginxAlpineImage,
+		append(nginxOptions,
+			WithEnv(map[string]string{"NGINX_ENTRYPOINT_QUIET_LOGS": "1"}),
+			WithLabels(map[string]string{"com.example.team": "checkout"}),
+		)...,
+	)
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	require.True(t, c.IsRunning())
+
+	inspect, err := c.(*DockerContainer).Info(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "checkout", inspect.Config.Labels["com.example.team"])
+	require.Contains(t, inspect.Config.Env, "NGINX_ENTRYPOINT_QUIET_LOGS=1")
+
+	endpoint, err := c.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+
+	resp, err := http.Get(endpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// nginxContainer is the container type of a module, wrapping the generic container.
+type nginxContainer struct {
+	Container
+}
+
+func (c *nginxContainer) URL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, nginxDefaultPort, "http")
+}
+
+func TestRunContainer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no container", func(t *testing.T) {
+		c, err := RunContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Image: nginxAlpineImage},
+			Reuse:            true,
+		}, func(c Container) *nginxContainer {
+			t.Fatal("the wrap function must not be called without a container")
+			return nil
+		})
+		require.ErrorIs(t, err, ErrReuseEmptyName)
+		require.Nil(t, c)
+	})
+
+	// runTypedContainer {
+	c, err := RunContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForHTTP("/").WithPort(nginxDefaultPort),
+		},
+		Started: true,
+	}, func(c Container) *nginxContainer {
+		return &nginxContainer{Container: c}
+	})
+	// }
+	if c != nil {
+		// a nil *nginxContainer would not be a nil Container
+		terminateContainerOnEnd(t, ctx, c)
+	}
+	require.NoError(t, err)
+
+	url, err := c.URL(ctx)
+	require.NoError(t, err)
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func TestGenericReusableContainerInSubprocess(t *testing.T) {
 	wg := sync.WaitGroup{}
 	wg.Add(10)
@@ -189,3 +278,98 @@ func TestHelperContainerStarterProcess(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
+
+func TestGenericContainerStartupDeadline(t *testing.T) {
+	ctx := context.Background()
+
+	// withStartupDeadline {
+	c, err := Run(ctx, nginxAlpineImage,
+		WithExposedPorts(nginxDefaultPort),
+		// the log is never printed, so the container fails to start once the deadline is exceeded,
+		// before the startup timeout of the wait strategy
+		WithWaitStrategy(wait.ForLog("never printed").WithStartupTimeout(time.Minute)),
+		WithStartupDeadline(time.Now().Add(5*time.Second)),
+	)
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+	require.ErrorContains(t, err, "startup deadline")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDeadlineError(t *testing.T) {
+	errStart := errors.New("start failed")
+	deadline := time.Now().Add(-time.Second)
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		err := deadlineError(ctx, deadline, errStart)
+		require.ErrorIs(t, err, errStart)
+		require.ErrorContains(t, err, "startup deadline "+deadline.Format(time.RFC3339)+" exceeded")
+	})
+
+	t.Run("no deadline", func(t *testing.T) {
+		require.Equal(t, errStart, deadlineError(context.Background(), time.Time{}, errStart))
+	})
+
+	t.Run("deadline of the caller's context exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		require.Equal(t, errStart, deadlineError(ctx, deadline.Add(time.Minute), errStart))
+	})
+}
+
+func TestGenericContainerStartupAttempts(t *testing.T) {
+	ctx := context.Background()
+
+	var attempts int
+	failFirstAttempt := ContainerLifecycleHooks{
+		PostStarts: []ContainerHook{
+			func(_ context.Context, _ Container) error {
+				attempts++
+				if attempts == 1 {
+					return errors.New("transient failure")
+				}
+				return nil
+			},
+		},
+	}
+
+	// withStartupAttempts {
+	c, err := Run(ctx, nginxAlpineImage,
+		WithExposedPorts(nginxDefaultPort),
+		WithLifecycleHooks(failFirstAttempt),
+		WithStartupAttempts(3),
+	)
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+	require.True(t, c.IsRunning())
+
+	t.Run("invalid request is not retried", func(t *testing.T) {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			StartupAttempts: 3,
+			Started:         true,
+		})
+		require.Nil(t, c)
+		require.EqualError(t, err, "you must specify either a build context or an image")
+	})
+}
+
+func TestIsRetryableStartupError(t *testing.T) {
+	errStart := errors.New("start failed")
+
+	require.True(t, isRetryableStartupError(context.Background(), errStart))
+
+	// the timeout of a wait strategy is retried
+	require.True(t, isRetryableStartupError(context.Background(), fmt.Errorf("wait: %w", context.DeadlineExceeded)))
+
+	require.False(t, isRetryableStartupError(context.Background(), fmt.Errorf("pull: %w", errdefs.NotFound(errStart))))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.False(t, isRetryableStartupError(ctx, errStart))
+}
@@ -0,0 +1,99 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ErrImageRejected is the error of an image rejected by an image policy.
+var ErrImageRejected = errors.New("image rejected by policy")
+
+// PolicyImage is the image of a container, once it's pulled or built, submitted to the image policies.
+type PolicyImage struct {
+	// Name is the name of the image, after its substitution, or the tag of the built image.
+	Name string
+	// Built is true if the image was built from a Dockerfile.
+	Built bool
+	// Inspect is the result of the inspection of the image, with its ID, digests, labels and config.
+	Inspect types.ImageInspect
+}
+
+// ImagePolicy decides whether a container can be started from an image, returning an error to veto it,
+// e.g. to allow only the images of an approved registry, or the ones without known vulnerabilities according
+// to an external scanner, called with the name or the ID of the image.
+type ImagePolicy func(ctx context.Context, image PolicyImage) error
+
+// registeredImagePolicies are the image policies registered with RegisterImagePolicy, by registration ID.
+var (
+	registeredImagePoliciesMu sync.RWMutex
+	registeredImagePoliciesID int
+	registeredImagePolicies   = map[int]ImagePolicy{}
+)
+
+// RegisterImagePolicy registers an image policy applied to all the containers created by the process,
+// before the image policies of their requests, e.g. in TestMain, to enforce the images approved by a security team
+// without changing every call site.
+// It returns a function to unregister the policy, e.g. in the cleanup of a test. It's safe for concurrent use.
+func RegisterImagePolicy(policy ImagePolicy) (unregister func()) {
+	registeredImagePoliciesMu.Lock()
+	defer registeredImagePoliciesMu.Unlock()
+
+	registeredImagePoliciesID++
+	id := registeredImagePoliciesID
+	registeredImagePolicies[id] = policy
+
+	return func() {
+		registeredImagePoliciesMu.Lock()
+		defer registeredImagePoliciesMu.Unlock()
+
+		delete(registeredImagePolicies, id)
+	}
+}
+
+// withRegisteredImagePolicies returns the registered image policies, in the order of registration, followed by the given ones.
+func withRegisteredImagePolicies(policies []ImagePolicy) []ImagePolicy {
+	registeredImagePoliciesMu.RLock()
+	defer registeredImagePoliciesMu.RUnlock()
+
+	ids := make([]int, 0, len(registeredImagePolicies))
+	for id := range registeredImagePolicies {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	all := make([]ImagePolicy, 0, len(ids)+len(policies))
+	for _, id := range ids {
+		all = append(all, registeredImagePolicies[id])
+	}
+
+	return append(all, policies...)
+}
+
+// checkImagePolicies inspects the image and submits it to the registered and the given image policies, in order,
+// returning an error wrapping ErrImageRejected and the error of the first policy rejecting it.
+// The image is not inspected if there are no policies.
+func (p *DockerProvider) checkImagePolicies(ctx context.Context, imageName string, built bool, policies []ImagePolicy) error {
+	policies = withRegisteredImagePolicies(policies)
+	if len(policies) == 0 {
+		return nil
+	}
+
+	inspect, _, err := p.client.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return fmt.Errorf("inspect image %s: %w", imageName, err)
+	}
+
+	image := PolicyImage{Name: imageName, Built: built, Inspect: inspect}
+	for _, policy := range policies {
+		if err := policy(ctx, image); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrImageRejected, imageName, err)
+		}
+	}
+
+	return nil
+}
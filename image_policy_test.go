@@ -0,0 +1,132 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+)
+
+// imageInspectClient is a mock implementation of client.APIClient, which inspects an image labeled with its approval.
+type imageInspectClient struct {
+	client.APIClient
+	inspected int
+}
+
+func (m *imageInspectClient) ImageInspectWithRaw(_ context.Context, imageName string) (types.ImageInspect, []byte, error) {
+	m.inspected++
+	return types.ImageInspect{
+		ID:          "sha256:" + imageName,
+		RepoDigests: []string{imageName + "@sha256:0123"},
+		Config: &container.Config{
+			Labels: map[string]string{"approved": "true"},
+		},
+	}, nil, nil
+}
+
+func TestRegisterImagePolicy(t *testing.T) {
+	policy := func(name string) ImagePolicy {
+		return func(context.Context, PolicyImage) error {
+			return errors.New(name)
+		}
+	}
+
+	unregisterFirst := RegisterImagePolicy(policy("first"))
+	unregisterSecond := RegisterImagePolicy(policy("second"))
+	t.Cleanup(unregisterSecond)
+
+	// the registered policies go before the policies of the request, in the order of registration
+	policies := withRegisteredImagePolicies([]ImagePolicy{policy("request")})
+	require.Len(t, policies, 3)
+	for i, expected := range []string{"first", "second", "request"} {
+		require.EqualError(t, policies[i](context.Background(), PolicyImage{}), expected)
+	}
+
+	unregisterFirst()
+	unregisterFirst()
+
+	policies = withRegisteredImagePolicies(nil)
+	require.Len(t, policies, 1)
+	require.EqualError(t, policies[0](context.Background(), PolicyImage{}), "second")
+}
+
+func TestCheckImagePolicies(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no policies", func(t *testing.T) {
+		cli := &imageInspectClient{}
+		p := &DockerProvider{client: cli}
+
+		require.NoError(t, p.checkImagePolicies(ctx, "redis:7-alpine", false, nil))
+		// the image is not inspected for nothing
+		require.Zero(t, cli.inspected)
+	})
+
+	t.Run("approved", func(t *testing.T) {
+		p := &DockerProvider{client: &imageInspectClient{}}
+
+		var submitted []PolicyImage
+		policy := func(_ context.Context, image PolicyImage) error {
+			submitted = append(submitted, image)
+			return nil
+		}
+
+		require.NoError(t, p.checkImagePolicies(ctx, "my-app:test", true, []ImagePolicy{policy, policy}))
+		require.Len(t, submitted, 2)
+		require.Equal(t, "my-app:test", submitted[0].Name)
+		require.True(t, submitted[0].Built)
+		require.Equal(t, "sha256:my-app:test", submitted[0].Inspect.ID)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		p := &DockerProvider{client: &imageInspectClient{}}
+
+		var called []string
+		policy := func(name string, err error) ImagePolicy {
+			return func(context.Context, PolicyImage) error {
+				called = append(called, name)
+				return err
+			}
+		}
+
+		err := p.checkImagePolicies(ctx, "redis:7-alpine", false, []ImagePolicy{
+			policy("first", nil),
+			policy("second", errors.New("unapproved registry")),
+			policy("third", nil),
+		})
+		require.ErrorIs(t, err, ErrImageRejected)
+		require.EqualError(t, err, "image rejected by policy: redis:7-alpine: unapproved registry")
+		// the policies after the rejecting one are not called
+		require.Equal(t, []string{"first", "second"}, called)
+	})
+}
+
+func TestImagePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	// imagePolicy {
+	unregister := RegisterImagePolicy(func(ctx context.Context, image PolicyImage) error {
+		// the config of the image is inspected, and its ID or name can be passed to an external scanner
+		if image.Inspect.Config == nil || image.Inspect.Config.User == "" || image.Inspect.Config.User == "root" {
+			return fmt.Errorf("the image %s runs as root", image.Name)
+		}
+		return nil
+	})
+	t.Cleanup(unregister)
+	// }
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: "redis:7-alpine",
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, c)
+	require.ErrorIs(t, err, ErrImageRejected)
+	require.ErrorContains(t, err, "the image redis:7-alpine runs as root")
+}
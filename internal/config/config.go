@@ -25,13 +25,22 @@ type Config struct {
 	Host                    string        `properties:"docker.host,default="`
 	TLSVerify               int           `properties:"docker.tls.verify,default=0"`
 	CertPath                string        `properties:"docker.cert.path,default="`
+	DebugBundleDir          string        `properties:"debug.bundle.dir,default="`
+	DockerSocketOverride    string        `properties:"docker.socket.override,default="`
+	HostOverride            string        `properties:"host.override,default="`
 	HubImageNamePrefix      string        `properties:"hub.image.name.prefix,default="`
+	LogsDisabled            bool          `properties:"logs.disabled,default=false"`
+	MaxConcurrentCreates    int           `properties:"max.concurrent.creates,default=0"`
+	MaxRunningContainers    int           `properties:"max.running.containers,default=0"`
 	RyukDisabled            bool          `properties:"ryuk.disabled,default=false"`
 	RyukPrivileged          bool          `properties:"ryuk.container.privileged,default=false"`
 	RyukReconnectionTimeout time.Duration `properties:"ryuk.reconnection.timeout,default=10s"`
 	RyukConnectionTimeout   time.Duration `properties:"ryuk.connection.timeout,default=1m"`
 	RyukVerbose             bool          `properties:"ryuk.verbose,default=false"`
+	SSHTunnel               string        `properties:"ssh.tunnel,default="`
+	StartupTimeout          time.Duration `properties:"startup.timeout,default=0s"`
 	TestcontainersHost      string        `properties:"tc.host,default="`
+	TestcontainersHostToken string        `properties:"tc.host.token,default="`
 }
 
 // }
@@ -66,26 +75,29 @@ func Reset() {
 func read() Config {
 	config := Config{}
 
+	// the environment variables take precedence over the properties file
 	applyEnvironmentConfiguration := func(config Config) Config {
-		ryukDisabledEnv := os.Getenv("TESTCONTAINERS_RYUK_DISABLED")
-		if parseBool(ryukDisabledEnv) {
-			config.RyukDisabled = ryukDisabledEnv == "true"
-		}
+		envString("TESTCONTAINERS_HUB_IMAGE_NAME_PREFIX", &config.HubImageNamePrefix)
+		envString("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", &config.DockerSocketOverride)
+		envString("TESTCONTAINERS_DEBUG_BUNDLE_DIR", &config.DebugBundleDir)
+		envString("TESTCONTAINERS_HOST_TOKEN", &config.TestcontainersHostToken)
+		envString("TESTCONTAINERS_SSH_TUNNEL", &config.SSHTunnel)
 
-		hubImageNamePrefix := os.Getenv("TESTCONTAINERS_HUB_IMAGE_NAME_PREFIX")
-		if hubImageNamePrefix != "" {
-			config.HubImageNamePrefix = hubImageNamePrefix
-		}
+		// TC_HOST is supported for backwards compatibility, TESTCONTAINERS_HOST_OVERRIDE takes precedence
+		envString("TC_HOST", &config.HostOverride)
+		envString("TESTCONTAINERS_HOST_OVERRIDE", &config.HostOverride)
 
-		ryukPrivilegedEnv := os.Getenv("TESTCONTAINERS_RYUK_CONTAINER_PRIVILEGED")
-		if parseBool(ryukPrivilegedEnv) {
-			config.RyukPrivileged = ryukPrivilegedEnv == "true"
-		}
+		envBool("TESTCONTAINERS_RYUK_DISABLED", &config.RyukDisabled)
+		envBool("TESTCONTAINERS_RYUK_CONTAINER_PRIVILEGED", &config.RyukPrivileged)
+		envBool("TESTCONTAINERS_RYUK_VERBOSE", &config.RyukVerbose)
+		envBool("TESTCONTAINERS_LOGS_DISABLED", &config.LogsDisabled)
 
-		ryukVerboseEnv := os.Getenv("TESTCONTAINERS_RYUK_VERBOSE")
-		if parseBool(ryukVerboseEnv) {
-			config.RyukVerbose = ryukVerboseEnv == "true"
-		}
+		envDuration("TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT", &config.RyukConnectionTimeout)
+		envDuration("TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT", &config.RyukReconnectionTimeout)
+		envDuration("TESTCONTAINERS_STARTUP_TIMEOUT", &config.StartupTimeout)
+
+		envInt("TESTCONTAINERS_MAX_CONCURRENT_CREATES", &config.MaxConcurrentCreates)
+		envInt("TESTCONTAINERS_MAX_RUNNING_CONTAINERS", &config.MaxRunningContainers)
 
 		return config
 	}
@@ -110,7 +122,30 @@ func read() Config {
 	return applyEnvironmentConfiguration(config)
 }
 
-func parseBool(input string) bool {
-	_, err := strconv.ParseBool(input)
-	return err == nil
+// envString sets the value to the given environment variable, if it's not empty.
+func envString(name string, value *string) {
+	if v := os.Getenv(name); v != "" {
+		*value = v
+	}
+}
+
+// envBool sets the value to the given environment variable, if it's a valid boolean, e.g. "true" or "1".
+func envBool(name string, value *bool) {
+	if v, err := strconv.ParseBool(os.Getenv(name)); err == nil {
+		*value = v
+	}
+}
+
+// envDuration sets the value to the given environment variable, if it's a valid positive duration, e.g. "30s".
+func envDuration(name string, value *time.Duration) {
+	if v, err := time.ParseDuration(os.Getenv(name)); err == nil && v > 0 {
+		*value = v
+	}
+}
+
+// envInt sets the value to the given environment variable, if it's a valid positive integer.
+func envInt(name string, value *int) {
+	if v, err := strconv.Atoi(os.Getenv(name)); err == nil && v > 0 {
+		*value = v
+	}
 }
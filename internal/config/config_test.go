@@ -23,6 +23,18 @@ func resetTestEnv(t *testing.T) {
 	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "")
 	t.Setenv("TESTCONTAINERS_RYUK_CONTAINER_PRIVILEGED", "")
 	t.Setenv("TESTCONTAINERS_RYUK_VERBOSE", "")
+	t.Setenv("TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_STARTUP_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_MAX_CONCURRENT_CREATES", "")
+	t.Setenv("TESTCONTAINERS_MAX_RUNNING_CONTAINERS", "")
+	t.Setenv("TESTCONTAINERS_LOGS_DISABLED", "")
+	t.Setenv("TESTCONTAINERS_HOST_OVERRIDE", "")
+	t.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", "")
+	t.Setenv("TESTCONTAINERS_DEBUG_BUNDLE_DIR", "")
+	t.Setenv("TESTCONTAINERS_HOST_TOKEN", "")
+	t.Setenv("TESTCONTAINERS_SSH_TUNNEL", "")
+	t.Setenv("TC_HOST", "")
 }
 
 func TestReadConfig(t *testing.T) {
@@ -480,6 +492,202 @@ func TestReadTCConfig(t *testing.T) {
 					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
 				},
 			},
+			{
+				"With Ryuk container timeouts set as env vars and properties: Env vars win",
+				`ryuk.connection.timeout=12s
+	ryuk.reconnection.timeout=13s`,
+				map[string]string{
+					"TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT":   "22s",
+					"TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT": "23s",
+				},
+				Config{
+					RyukConnectionTimeout:   22 * time.Second,
+					RyukReconnectionTimeout: 23 * time.Second,
+				},
+			},
+			{
+				"With invalid Ryuk container timeouts set as env vars",
+				``,
+				map[string]string{
+					"TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT":   "foo",
+					"TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT": "foo",
+				},
+				defaultConfig,
+			},
+			{
+				"With startup timeout configured using properties",
+				`startup.timeout=2m`,
+				map[string]string{},
+				Config{
+					StartupTimeout:          2 * time.Minute,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With startup timeout set as env var and properties: Env var wins",
+				`startup.timeout=2m`,
+				map[string]string{
+					"TESTCONTAINERS_STARTUP_TIMEOUT": "90s",
+				},
+				Config{
+					StartupTimeout:          90 * time.Second,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With concurrency limits configured using properties",
+				`max.concurrent.creates=4
+	max.running.containers=20`,
+				map[string]string{},
+				Config{
+					MaxConcurrentCreates:    4,
+					MaxRunningContainers:    20,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With concurrency limits set as env vars and properties: Env vars win",
+				`max.concurrent.creates=4
+	max.running.containers=20`,
+				map[string]string{
+					"TESTCONTAINERS_MAX_CONCURRENT_CREATES": "2",
+					"TESTCONTAINERS_MAX_RUNNING_CONTAINERS": "10",
+				},
+				Config{
+					MaxConcurrentCreates:    2,
+					MaxRunningContainers:    10,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With invalid concurrency limits set as env vars",
+				``,
+				map[string]string{
+					"TESTCONTAINERS_MAX_CONCURRENT_CREATES": "foo",
+					"TESTCONTAINERS_MAX_RUNNING_CONTAINERS": "-1",
+				},
+				defaultConfig,
+			},
+			{
+				"With logs disabled using properties",
+				`logs.disabled=true`,
+				map[string]string{},
+				Config{
+					LogsDisabled:            true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With logs disabled using properties and enabled using an env var",
+				`logs.disabled=true`,
+				map[string]string{
+					"TESTCONTAINERS_LOGS_DISABLED": "false",
+				},
+				defaultConfig,
+			},
+			{
+				"With Ryuk disabled using a numeric env var",
+				``,
+				map[string]string{
+					"TESTCONTAINERS_RYUK_DISABLED": "1",
+				},
+				Config{
+					RyukDisabled:            true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With host override set as env var and properties: Env var wins",
+				`host.override=props.example.com`,
+				map[string]string{
+					"TESTCONTAINERS_HOST_OVERRIDE": "env.example.com",
+				},
+				Config{
+					HostOverride:            "env.example.com",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With host override set as TC_HOST env var",
+				`host.override=props.example.com`,
+				map[string]string{
+					"TC_HOST": "tc-host.example.com",
+				},
+				Config{
+					HostOverride:            "tc-host.example.com",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With host override set as TC_HOST and TESTCONTAINERS_HOST_OVERRIDE env vars: TESTCONTAINERS_HOST_OVERRIDE wins",
+				``,
+				map[string]string{
+					"TC_HOST":                      "tc-host.example.com",
+					"TESTCONTAINERS_HOST_OVERRIDE": "env.example.com",
+				},
+				Config{
+					HostOverride:            "env.example.com",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With Docker socket override set as env var and properties: Env var wins",
+				`docker.socket.override=/props/docker.sock`,
+				map[string]string{
+					"TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE": "/env/docker.sock",
+				},
+				Config{
+					DockerSocketOverride:    "/env/docker.sock",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With debug bundle dir set as env var and properties: Env var wins",
+				`debug.bundle.dir=/props/bundles`,
+				map[string]string{
+					"TESTCONTAINERS_DEBUG_BUNDLE_DIR": "/env/bundles",
+				},
+				Config{
+					DebugBundleDir:          "/env/bundles",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With host token set as env var and properties: Env var wins",
+				"tc.host=unix:///tmp/agent.sock\ntc.host.token=props-token",
+				map[string]string{
+					"TESTCONTAINERS_HOST_TOKEN": "env-token",
+				},
+				Config{
+					TestcontainersHost:      "unix:///tmp/agent.sock",
+					TestcontainersHostToken: "env-token",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
+			{
+				"With SSH tunnel set as env var and properties: Env var wins",
+				`ssh.tunnel=props@docker.example.com`,
+				map[string]string{
+					"TESTCONTAINERS_SSH_TUNNEL": "env@docker.example.com:2222",
+				},
+				Config{
+					SSHTunnel:               "env@docker.example.com:2222",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReonnectionTimeout,
+				},
+			},
 		}
 		for _, tt := range tests {
 			t.Run(fmt.Sprintf(tt.name), func(t *testing.T) {
@@ -30,13 +30,19 @@ func NewClient(ctx context.Context, ops ...client.Opt) (*client.Client, error) {
 		}
 	}
 
-	opts = append(opts, client.WithHTTPHeaders(
-		map[string]string{
-			"x-tc-pp":    ProjectPath(),
-			"x-tc-sid":   SessionID(),
-			"User-Agent": "tc-go/" + internal.Version,
-		}),
-	)
+	headers := map[string]string{
+		"x-tc-pp":    ProjectPath(),
+		"x-tc-sid":   SessionID(),
+		"User-Agent": "tc-go/" + internal.Version,
+	}
+
+	// the remote container-running services of tc.host, e.g. reached through the socket of a local agent,
+	// authenticate the requests with a bearer token
+	if tcConfig.TestcontainersHostToken != "" {
+		headers["Authorization"] = "Bearer " + tcConfig.TestcontainersHostToken
+	}
+
+	opts = append(opts, client.WithHTTPHeaders(headers))
 
 	// passed options have priority over the default ones
 	opts = append(opts, ops...)
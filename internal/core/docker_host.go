@@ -81,7 +81,7 @@ func ExtractDockerHost(ctx context.Context) string {
 // The possible alternatives are:
 //
 //  1. Docker host from the "tc.host" property in the ~/.testcontainers.properties file.
-//  2. The TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE environment variable.
+//  2. The TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE environment variable, or the docker.socket.override property.
 //  3. Using a Docker client, check if the Info().OperativeSystem is "Docker Desktop" and return the default docker socket path for rootless docker.
 //  4. Else, Get the current Docker Host from the existing strategies: see ExtractDockerHost.
 //  5. If the socket contains the unix schema, the schema is removed (e.g. unix:///var/run/docker.sock -> /var/run/docker.sock)
@@ -219,9 +219,9 @@ func dockerHostFromProperties(ctx context.Context) (string, error) {
 }
 
 // dockerSocketOverridePath returns the docker socket from the TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE environment variable,
-// if it's not empty
+// or the docker.socket.override property, if it's not empty
 func dockerSocketOverridePath(ctx context.Context) (string, error) {
-	if dockerHostPath, exists := os.LookupEnv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE"); exists {
+	if dockerHostPath := config.Read().DockerSocketOverride; dockerHostPath != "" {
 		return dockerHostPath, nil
 	}
 
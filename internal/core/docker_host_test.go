@@ -36,8 +36,10 @@ func init() {
 	tmpSchema = DockerSocketSchema
 }
 
+// resetSocketOverrideFn restores the override, resetting the configuration, which reads it just once
 var resetSocketOverrideFn = func() {
 	os.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", originalDockerSocketOverride)
+	config.Reset()
 }
 
 func TestExtractDockerHost(t *testing.T) {
@@ -186,6 +188,7 @@ func TestExtractDockerHost(t *testing.T) {
 			tmpDir := t.TempDir()
 			tmpSocket := filepath.Join(tmpDir, "docker.sock")
 			t.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", tmpSocket)
+			config.Reset()
 			err := createTmpDockerSocket(tmpDir)
 			require.NoError(t, err)
 
@@ -198,6 +201,7 @@ func TestExtractDockerHost(t *testing.T) {
 			t.Cleanup(resetSocketOverrideFn)
 
 			os.Unsetenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE")
+			config.Reset()
 
 			socket, err := dockerSocketOverridePath(context.Background())
 			require.ErrorIs(t, err, ErrDockerSocketOverrideNotSet)
@@ -301,6 +305,7 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 
 		t.Cleanup(resetSocketOverrideFn)
 		t.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", "/path/to/docker.sock")
+		config.Reset()
 
 		socket := extractDockerSocketFromClient(context.Background(), mockCli{OS: "foo"})
 		assert.Equal(t, DockerSocketPath, socket)
@@ -312,6 +317,7 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 		t.Cleanup(resetSocketOverrideFn)
 
 		t.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", "/path/to/docker.sock")
+		config.Reset()
 		host := extractDockerSocketFromClient(context.Background(), mockCli{OS: "foo"})
 
 		assert.Equal(t, "/path/to/docker.sock", host)
@@ -323,10 +329,12 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 		t.Cleanup(resetSocketOverrideFn)
 
 		t.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", DockerSocketSchema+"/path/to/docker.sock")
+		config.Reset()
 		host := extractDockerSocketFromClient(context.Background(), mockCli{OS: "foo"})
 		assert.Equal(t, "/path/to/docker.sock", host)
 
 		t.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", testRemoteHost)
+		config.Reset()
 		host = extractDockerSocketFromClient(context.Background(), mockCli{OS: "foo"})
 		assert.Equal(t, DockerSocketPath, host)
 	})
@@ -343,6 +351,7 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 
 		ctx := context.Background()
 		os.Unsetenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE")
+		config.Reset()
 		t.Setenv("DOCKER_HOST", DockerSocketSchema+"/this/is/a/sample.sock")
 
 		socket := extractDockerSocketFromClient(ctx, mockCli{OS: "Docker Desktop"})
@@ -358,6 +367,7 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 
 		ctx := context.Background()
 		os.Unsetenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE")
+		config.Reset()
 		t.Setenv("DOCKER_HOST", DockerSocketSchema+"/this/is/a/sample.sock")
 
 		socket := extractDockerSocketFromClient(ctx, mockCli{OS: "Docker Desktop"})
@@ -372,6 +382,7 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 
 		ctx := context.Background()
 		os.Unsetenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE")
+		config.Reset()
 		t.Setenv("DOCKER_HOST", DockerSocketSchema+"/this/is/a/sample.sock")
 
 		socket := extractDockerSocketFromClient(ctx, mockCli{OS: "Ubuntu"})
@@ -386,6 +397,7 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 
 		ctx := context.Background()
 		os.Unsetenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE")
+		config.Reset()
 
 		t.Setenv("DOCKER_HOST", DockerSocketSchema+"/this/is/a/sample.sock")
 		socket := extractDockerSocketFromClient(ctx, mockCli{OS: "Ubuntu"})
@@ -405,6 +417,7 @@ func TestExtractDockerSocketFromClient(t *testing.T) {
 
 		ctx := context.Background()
 		os.Unsetenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE")
+		config.Reset()
 		os.Unsetenv("DOCKER_HOST")
 
 		socket := extractDockerSocketFromClient(ctx, mockCli{OS: "Ubuntu"})
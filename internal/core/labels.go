@@ -10,6 +10,7 @@ const (
 	LabelReaper    = LabelBase + ".reaper"
 	LabelRyuk      = LabelBase + ".ryuk"
 	LabelSessionID = LabelBase + ".sessionId"
+	LabelTest      = LabelBase + ".test"
 	LabelVersion   = LabelBase + ".version"
 )
 
@@ -4,7 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
+	"log/slog"
+	"path"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
@@ -48,6 +53,52 @@ type ContainerLifecycleHooks struct {
 	PostTerminates []ContainerHook
 }
 
+// registeredHooks are the lifecycle hooks registered with RegisterLifecycleHook, by registration ID.
+var (
+	registeredHooksMu sync.RWMutex
+	registeredHooksID int
+	registeredHooks   = map[int]ContainerLifecycleHooks{}
+)
+
+// RegisterLifecycleHook registers lifecycle hooks applied to all the containers created by the process,
+// before the lifecycle hooks of their requests, e.g. to enforce an image policy, or the labels of the organization,
+// returning an error from a PreCreates hook, or to audit the operations on the containers, in one place.
+// It returns a function to unregister the hooks, e.g. in the cleanup of a test. It's safe for concurrent use.
+func RegisterLifecycleHook(hooks ContainerLifecycleHooks) (unregister func()) {
+	registeredHooksMu.Lock()
+	defer registeredHooksMu.Unlock()
+
+	registeredHooksID++
+	id := registeredHooksID
+	registeredHooks[id] = hooks
+
+	return func() {
+		registeredHooksMu.Lock()
+		defer registeredHooksMu.Unlock()
+
+		delete(registeredHooks, id)
+	}
+}
+
+// withRegisteredHooks returns the registered lifecycle hooks, in the order of registration, followed by the given ones.
+func withRegisteredHooks(hooks []ContainerLifecycleHooks) []ContainerLifecycleHooks {
+	registeredHooksMu.RLock()
+	defer registeredHooksMu.RUnlock()
+
+	ids := make([]int, 0, len(registeredHooks))
+	for id := range registeredHooks {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	all := make([]ContainerLifecycleHooks, 0, len(ids)+len(hooks))
+	for _, id := range ids {
+		all = append(all, registeredHooks[id])
+	}
+
+	return append(all, hooks...)
+}
+
 // DefaultLoggingHook is a hook that will log the container lifecycle events
 var DefaultLoggingHook = func(logger Logging) ContainerLifecycleHooks {
 	shortContainerID := func(c Container) string {
@@ -57,55 +108,55 @@ var DefaultLoggingHook = func(logger Logging) ContainerLifecycleHooks {
 	return ContainerLifecycleHooks{
 		PreCreates: []ContainerRequestHook{
 			func(ctx context.Context, req ContainerRequest) error {
-				logger.Printf("🐳 Creating container for image %s", req.Image)
+				logf(ctx, logger, slog.LevelInfo, []slog.Attr{slog.String(LogKeyImage, req.Image), slog.String(LogKeyOperation, "create")}, "🐳 Creating container for image %s", req.Image)
 				return nil
 			},
 		},
 		PostCreates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container created: %s", shortContainerID(c))
+				logf(ctx, logger, slog.LevelInfo, containerAttrs(c, "create"), "✅ Container created: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PreStarts: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Starting container: %s", shortContainerID(c))
+				logf(ctx, logger, slog.LevelInfo, containerAttrs(c, "start"), "🐳 Starting container: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PostStarts: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container started: %s", shortContainerID(c))
+				logf(ctx, logger, slog.LevelInfo, containerAttrs(c, "start"), "✅ Container started: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PostReadies: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🔔 Container is ready: %s", shortContainerID(c))
+				logf(ctx, logger, slog.LevelInfo, containerAttrs(c, "wait"), "🔔 Container is ready: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PreStops: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Stopping container: %s", shortContainerID(c))
+				logf(ctx, logger, slog.LevelInfo, containerAttrs(c, "stop"), "🐳 Stopping container: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PostStops: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container stopped: %s", shortContainerID(c))
+				logf(ctx, logger, slog.LevelInfo, containerAttrs(c, "stop"), "✅ Container stopped: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PreTerminates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Terminating container: %s", shortContainerID(c))
+				logf(ctx, logger, slog.LevelInfo, containerAttrs(c, "terminate"), "🐳 Terminating container: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PostTerminates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🚫 Container terminated: %s", shortContainerID(c))
+				logf(ctx, logger, slog.LevelInfo, containerAttrs(c, "terminate"), "🚫 Container terminated: %s", shortContainerID(c))
 				return nil
 			},
 		},
@@ -131,6 +182,28 @@ var defaultCopyFileToContainerHook = func(files []ContainerFile) ContainerLifecy
 			// copy files to container after it's created
 			func(ctx context.Context, c Container) error {
 				for _, f := range files {
+					if f.Reader != nil {
+						bs, err := io.ReadAll(f.Reader)
+						if err != nil {
+							return fmt.Errorf("can't read from reader: %w", err)
+						}
+
+						err = c.CopyToContainer(ctx, bs, f.ContainerFilePath, f.FileMode)
+						if err != nil {
+							return fmt.Errorf("can't copy reader to %s in container: %w", f.ContainerFilePath, err)
+						}
+
+						continue
+					}
+
+					if f.Source != nil {
+						if err := copyFSToContainer(ctx, c, f); err != nil {
+							return err
+						}
+
+						continue
+					}
+
 					err := c.CopyFileToContainer(ctx, f.HostFilePath, f.ContainerFilePath, f.FileMode)
 					if err != nil {
 						return fmt.Errorf("can't copy %s to container: %w", f.HostFilePath, err)
@@ -143,8 +216,59 @@ var defaultCopyFileToContainerHook = func(files []ContainerFile) ContainerLifecy
 	}
 }
 
+// copyFSToContainer copies the file, or the files of the directory, at the path of the file system of the container file
+// to the container, keeping their permissions in the file system unless the file mode is set.
+func copyFSToContainer(ctx context.Context, c Container, f ContainerFile) error {
+	root := f.Path
+	if root == "" {
+		root = "."
+	}
+
+	err := fs.WalkDir(f.Source, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		// the files of a directory are copied under the container file path, keeping their path in the directory
+		target := f.ContainerFilePath
+		if root == "." {
+			target = path.Join(f.ContainerFilePath, p)
+		} else if p != root {
+			target = path.Join(f.ContainerFilePath, strings.TrimPrefix(p, root+"/"))
+		}
+
+		mode := f.FileMode
+		if mode == 0 {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			mode = int64(info.Mode().Perm())
+		}
+
+		bs, err := fs.ReadFile(f.Source, p)
+		if err != nil {
+			return err
+		}
+
+		return c.CopyToContainer(ctx, bs, target, mode)
+	})
+	if err != nil {
+		return fmt.Errorf("can't copy %s of the file system to container: %w", root, err)
+	}
+
+	return nil
+}
+
 // defaultLogConsumersHook is a hook that will start log consumers after the container is started
 var defaultLogConsumersHook = func(cfg *LogConsumerConfig) ContainerLifecycleHooks {
+	// the consumers must be followed only once, as the container could be started again after being stopped
+	var following bool
+
 	return ContainerLifecycleHooks{
 		PostStarts: []ContainerHook{
 			// first post-start hook is to produce logs and start log consumers
@@ -155,16 +279,33 @@ var defaultLogConsumersHook = func(cfg *LogConsumerConfig) ContainerLifecycleHoo
 					return nil
 				}
 
-				for _, consumer := range cfg.Consumers {
-					dockerContainer.followOutput(consumer)
+				if !following {
+					for _, consumer := range cfg.Consumers {
+						dockerContainer.followOutput(consumer)
+					}
+					following = true
 				}
 
 				if len(cfg.Consumers) > 0 {
-					return dockerContainer.startLogProduction(ctx, cfg.Opts...)
+					// the logs are followed until the container is stopped, even if the context used to start it is done,
+					// e.g. because of the startup deadline of the request
+					return dockerContainer.startLogProduction(context.WithoutCancel(ctx), cfg.Opts...)
 				}
 				return nil
 			},
 		},
+		PostStops: []ContainerHook{
+			// the log production is started again if the container is started again
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				dockerContainer := c.(*DockerContainer)
+
+				return dockerContainer.stopLogProduction()
+			},
+		},
 		PreTerminates: []ContainerHook{
 			// first pre-terminate hook is to stop the log production
 			func(ctx context.Context, c Container) error {
@@ -190,11 +331,18 @@ var defaultReadinessHook = func() ContainerLifecycleHooks {
 
 				// if a Wait Strategy has been specified, wait before returning
 				if dockerContainer.WaitingFor != nil {
-					dockerContainer.logger.Printf(
+					logf(ctx, dockerContainer.logger, slog.LevelInfo, containerAttrs(c, "wait"),
 						"🚧 Waiting for container id %s image: %s. Waiting for: %+v",
 						dockerContainer.ID[:12], dockerContainer.Image, dockerContainer.WaitingFor,
 					)
-					if err := dockerContainer.WaitingFor.WaitUntilReady(ctx, c); err != nil {
+					ctx, span := startSpan(ctx, "wait", containerSpanAttrs(dockerContainer)...)
+					waitStart := time.Now()
+					err := dockerContainer.WaitingFor.WaitUntilReady(ctx, c)
+					dockerContainer.updateTimings(func(t *StartupTimings) {
+						t.Readiness = time.Since(waitStart)
+					})
+					endSpan(span, err)
+					if err != nil {
 						return err
 					}
 				}
@@ -275,17 +423,17 @@ func (c *DockerContainer) readiedHook(ctx context.Context) error {
 func (c *DockerContainer) printLogs(ctx context.Context, cause error) {
 	reader, err := c.Logs(ctx)
 	if err != nil {
-		c.logger.Printf("failed accessing container logs: %v\n", err)
+		logf(ctx, c.logger, slog.LevelWarn, containerAttrs(c, "logs"), "failed accessing container logs: %v\n", err)
 		return
 	}
 
 	b, err := io.ReadAll(reader)
 	if err != nil {
-		c.logger.Printf("failed reading container logs: %v\n", err)
+		logf(ctx, c.logger, slog.LevelWarn, containerAttrs(c, "logs"), "failed reading container logs: %v\n", err)
 		return
 	}
 
-	c.logger.Printf("container logs (%s):\n%s", cause, b)
+	logf(ctx, c.logger, slog.LevelError, containerAttrs(c, "logs"), "container logs (%s):\n%s", cause, b)
 }
 
 // stoppingHook is a hook that will be called before a container is stopped
@@ -404,6 +552,43 @@ func (c ContainerLifecycleHooks) Terminated(ctx context.Context) func(container
 }
 
 func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req ContainerRequest, dockerInput *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) error {
+	networkID := func(name string) (string, bool) {
+		nw, err := p.GetNetwork(ctx, NetworkRequest{
+			Name: name,
+		})
+		if err != nil {
+			return "", false
+		}
+		return nw.ID, true
+	}
+
+	imageExposedPorts := func(imageName string) ([]string, error) {
+		image, _, err := p.client.ImageInspectWithRaw(ctx, imageName)
+		if err != nil {
+			return nil, err
+		}
+
+		exposedPorts := []string{}
+		for p := range image.Config.ExposedPorts {
+			exposedPorts = append(exposedPorts, string(p))
+		}
+		return exposedPorts, nil
+	}
+
+	return resolveContainerConfigs(req, dockerInput, hostConfig, networkingConfig, networkID, imageExposedPorts)
+}
+
+// resolveContainerConfigs applies the request to the configs used to create the container, looking up the ID of the
+// first network of the request, which is skipped if it's not found, and the exposed ports of the image, which are
+// only exposed if the request does not expose any port, with the given functions.
+func resolveContainerConfigs(
+	req ContainerRequest,
+	dockerInput *container.Config,
+	hostConfig *container.HostConfig,
+	networkingConfig *network.NetworkingConfig,
+	networkID func(name string) (string, bool),
+	imageExposedPorts func(imageName string) ([]string, error),
+) error {
 	// prepare mounts
 	hostConfig.Mounts = mapToDockerMounts(req.Mounts)
 
@@ -415,17 +600,14 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 	if len(req.Networks) > 0 {
 		attachContainerTo := req.Networks[0]
 
-		nw, err := p.GetNetwork(ctx, NetworkRequest{
-			Name: attachContainerTo,
-		})
-		if err == nil {
+		if id, ok := networkID(attachContainerTo); ok {
 			aliases := []string{}
 			if _, ok := req.NetworkAliases[attachContainerTo]; ok {
 				aliases = req.NetworkAliases[attachContainerTo]
 			}
 			endpointSetting := network.EndpointSettings{
 				Aliases:   aliases,
-				NetworkID: nw.ID,
+				NetworkID: id,
 			}
 			endpointSettings[attachContainerTo] = &endpointSetting
 		}
@@ -449,13 +631,11 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 	exposedPorts := req.ExposedPorts
 	// this check must be done after the pre-creation Modifiers are called, so the network mode is already set
 	if len(exposedPorts) == 0 && !hostConfig.NetworkMode.IsContainer() {
-		image, _, err := p.client.ImageInspectWithRaw(ctx, dockerInput.Image)
+		imagePorts, err := imageExposedPorts(dockerInput.Image)
 		if err != nil {
 			return err
 		}
-		for p := range image.Config.ExposedPorts {
-			exposedPorts = append(exposedPorts, string(p))
-		}
+		exposedPorts = append(exposedPorts, imagePorts...)
 	}
 
 	exposedPortSet, exposedPortMap, err := nat.ParsePortSpecs(exposedPorts)
@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io/fs"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
@@ -749,6 +751,63 @@ func TestCombineLifecycleHooks(t *testing.T) {
 	}
 }
 
+func TestRegisterLifecycleHook(t *testing.T) {
+	hook := func(name string) ContainerLifecycleHooks {
+		return ContainerLifecycleHooks{
+			PreCreates: []ContainerRequestHook{
+				func(ctx context.Context, req ContainerRequest) error {
+					return fmt.Errorf("%s pre-create", name)
+				},
+			},
+		}
+	}
+
+	unregisterFirst := RegisterLifecycleHook(hook("first"))
+	unregisterSecond := RegisterLifecycleHook(hook("second"))
+	t.Cleanup(unregisterSecond)
+
+	// the registered hooks go before the hooks of the request, in the order of registration
+	hooks := withRegisteredHooks([]ContainerLifecycleHooks{hook("request")})
+	require.Len(t, hooks, 3)
+	for i, expected := range []string{"first", "second", "request"} {
+		require.EqualError(t, hooks[i].PreCreates[0](context.Background(), ContainerRequest{}), expected+" pre-create")
+	}
+
+	unregisterFirst()
+	unregisterFirst()
+
+	hooks = withRegisteredHooks(nil)
+	require.Len(t, hooks, 1)
+	require.EqualError(t, hooks[0].PreCreates[0](context.Background(), ContainerRequest{}), "second pre-create")
+}
+
+func TestRegisterLifecycleHook_ImagePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	// registerLifecycleHook {
+	unregister := RegisterLifecycleHook(ContainerLifecycleHooks{
+		PreCreates: []ContainerRequestHook{
+			func(ctx context.Context, req ContainerRequest) error {
+				if !strings.HasPrefix(req.Image, "nginx:") {
+					return fmt.Errorf("image %s is not allowed", req.Image)
+				}
+				return nil
+			},
+		},
+	})
+	t.Cleanup(unregister)
+	// }
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: "redis:7-alpine",
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, c)
+	require.ErrorContains(t, err, "image redis:7-alpine is not allowed")
+}
+
 func TestLifecycleHooks_WithMultipleHooks(t *testing.T) {
 	ctx := context.Background()
 
@@ -885,3 +944,56 @@ func lifecycleHooksIsHonouredFn(t *testing.T, ctx context.Context, prints []stri
 	assert.True(t, strings.HasPrefix(prints[22], "post-terminate hook 1: "))
 	assert.True(t, strings.HasPrefix(prints[23], "post-terminate hook 2: "))
 }
+
+// copiedFile is a file copied to a container with CopyToContainer.
+type copiedFile struct {
+	content string
+	mode    int64
+}
+
+// copyingContainer is a container recording the files copied with CopyToContainer, by their path in the container.
+type copyingContainer struct {
+	Container
+
+	files map[string]copiedFile
+}
+
+func (c *copyingContainer) CopyToContainer(_ context.Context, fileContent []byte, containerFilePath string, fileMode int64) error {
+	c.files[containerFilePath] = copiedFile{content: string(fileContent), mode: fileMode}
+	return nil
+}
+
+func TestCopyFileToContainerHook_fileSystem(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fixtures/users.sql":        {Data: []byte("INSERT INTO users"), Mode: 0o644},
+		"fixtures/scripts/init.sh":  {Data: []byte("echo init"), Mode: 0o755},
+		"config/app.yaml":           {Data: []byte("port: 8080"), Mode: 0o600},
+		"config/ignored/other.yaml": {Data: []byte("port: 8081")},
+	}
+
+	c := &copyingContainer{files: map[string]copiedFile{}}
+	hooks := defaultCopyFileToContainerHook([]ContainerFile{
+		{Source: fsys, Path: "fixtures", ContainerFilePath: "/docker-entrypoint-initdb.d"},
+		{Source: fsys, Path: "config/app.yaml", ContainerFilePath: "/etc/app.yaml", FileMode: 0o644},
+	})
+	require.NoError(t, hooks.PostCreates[0](context.Background(), c))
+
+	require.Equal(t, map[string]copiedFile{
+		"/docker-entrypoint-initdb.d/users.sql":       {content: "INSERT INTO users", mode: 0o644},
+		"/docker-entrypoint-initdb.d/scripts/init.sh": {content: "echo init", mode: 0o755},
+		"/etc/app.yaml": {content: "port: 8080", mode: 0o644},
+	}, c.files)
+
+	t.Run("root", func(t *testing.T) {
+		c := &copyingContainer{files: map[string]copiedFile{}}
+		hooks := defaultCopyFileToContainerHook([]ContainerFile{{Source: fstest.MapFS{"a/b.txt": {Data: []byte("b"), Mode: 0o644}}, ContainerFilePath: "/data"}})
+		require.NoError(t, hooks.PostCreates[0](context.Background(), c))
+		require.Equal(t, map[string]copiedFile{"/data/a/b.txt": {content: "b", mode: 0o644}}, c.files)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		hooks := defaultCopyFileToContainerHook([]ContainerFile{{Source: fsys, Path: "missing", ContainerFilePath: "/data"}})
+		err := hooks.PostCreates[0](context.Background(), &copyingContainer{files: map[string]copiedFile{}})
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
@@ -1,16 +1,20 @@
 package testcontainers
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -508,3 +512,97 @@ func Test_StartLogProductionStillStartsWithTooHighTimeout(t *testing.T) {
 
 	terminateContainerOnEnd(t, ctx, c)
 }
+
+// logsClient is a mock implementation of client.APIClient, which serves a single line of logs
+// and records the options of the logs requests. The logs end once released, like when the container stops.
+type logsClient struct {
+	client.APIClient
+	mtx      sync.Mutex
+	requests []container.LogsOptions
+	release  chan struct{}
+}
+
+func (m *logsClient) ContainerLogs(ctx context.Context, _ string, options container.LogsOptions) (io.ReadCloser, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.requests = append(m.requests, options)
+
+	line := []byte("hello\n")
+	header := make([]byte, 8)
+	header[0] = 1
+	binary.BigEndian.PutUint32(header[4:], uint32(len(line)))
+
+	return io.NopCloser(io.MultiReader(bytes.NewReader(header), bytes.NewReader(line), &releasedReader{ctx: ctx, release: m.release})), nil
+}
+
+func (m *logsClient) Close() error {
+	return nil
+}
+
+// releasedReader blocks until it's released, then it returns io.EOF.
+type releasedReader struct {
+	ctx     context.Context
+	release <-chan struct{}
+}
+
+func (r *releasedReader) Read(_ []byte) (int, error) {
+	select {
+	case <-r.release:
+		return 0, io.EOF
+	case <-r.ctx.Done():
+		return 0, r.ctx.Err()
+	}
+}
+
+func Test_StopAndStartLogProduction(t *testing.T) {
+	ctx := context.Background()
+
+	cli := &logsClient{release: make(chan struct{})}
+	g := TestLogConsumer{
+		Msgs:     []string{},
+		Done:     make(chan bool),
+		Accepted: make(chan string, 1),
+	}
+
+	c := &DockerContainer{
+		ID:        "container-id",
+		provider:  &DockerProvider{client: cli},
+		consumers: []LogConsumer{&g},
+	}
+
+	require.NoError(t, c.startLogProduction(ctx))
+	assert.Equal(t, "hello\n", <-g.Accepted)
+
+	// the logs end while the log production is being stopped, like when the container is stopped
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- c.stopLogProduction()
+	}()
+	time.Sleep(100 * time.Millisecond)
+	close(cli.release)
+
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the log production was not stopped")
+	}
+
+	beforeStart := logsSince(time.Now())
+
+	// the logs are resumed from the time the log production was stopped
+	cli.release = make(chan struct{})
+	require.NoError(t, c.startLogProduction(ctx))
+	assert.Equal(t, "hello\n", <-g.Accepted)
+
+	close(cli.release)
+	require.NoError(t, c.stopLogProduction())
+
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+
+	require.Len(t, cli.requests, 2)
+	assert.Empty(t, cli.requests[0].Since)
+	assert.NotEmpty(t, cli.requests[1].Since)
+	assert.LessOrEqual(t, cli.requests[1].Since, beforeStart)
+}
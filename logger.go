@@ -2,19 +2,28 @@ package testcontainers
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/client"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
 )
 
-// Logger is the default log instance
-var Logger Logging = log.New(os.Stderr, "", log.LstdFlags)
+// Logger is the default log instance, writing to the standard error,
+// unless the logs are disabled with the logs.disabled property of the configuration
+var Logger Logging = defaultLogger{Logger: log.New(os.Stderr, "", log.LstdFlags)}
 
 // Validate our types implement the required interfaces.
 var (
 	_ Logging               = (*log.Logger)(nil)
+	_ Logging               = defaultLogger{}
+	_ StructuredLogging     = slogLogger{}
 	_ ContainerCustomizer   = LoggerOption{}
 	_ GenericProviderOption = LoggerOption{}
 	_ DockerProviderOption  = LoggerOption{}
@@ -25,6 +34,80 @@ type Logging interface {
 	Printf(format string, v ...interface{})
 }
 
+// The keys of the attributes of the structured logs.
+const (
+	LogKeyContainerID = "container.id"
+	LogKeyImage       = "image"
+	LogKeySessionID   = "session.id"
+	LogKeyOperation   = "operation"
+)
+
+// StructuredLogging is implemented by the loggers accepting a level and structured attributes along with the message,
+// e.g. the ID of the container and the operation on it, so the logs can be filtered and parsed. The library logs
+// with the Log method of the loggers implementing it, and with the Printf method of the rest of them.
+type StructuredLogging interface {
+	Logging
+	Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr)
+}
+
+// SlogLogger returns a StructuredLogging implementation writing to the given slog logger, e.g. with a JSON handler
+// and a minimum level to filter the logs of the library, adding the ID of the test session to all of them.
+// The logs written with Printf are written with the info level.
+func SlogLogger(logger *slog.Logger) StructuredLogging {
+	return slogLogger{logger: logger.With(slog.String(LogKeySessionID, core.SessionID()))}
+}
+
+// slogLogger is the StructuredLogging implementation writing to a slog logger.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// Printf implements Logging.
+func (l slogLogger) Printf(format string, v ...interface{}) {
+	l.logger.Info(strings.TrimSuffix(fmt.Sprintf(format, v...), "\n"))
+}
+
+// Log implements StructuredLogging.
+func (l slogLogger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logf logs the formatted message with the given level and attributes if the logger is a StructuredLogging,
+// or with its Printf method otherwise.
+func logf(ctx context.Context, logger Logging, level slog.Level, attrs []slog.Attr, format string, v ...interface{}) {
+	if l, ok := logger.(StructuredLogging); ok {
+		l.Log(ctx, level, strings.TrimSuffix(fmt.Sprintf(format, v...), "\n"), attrs...)
+		return
+	}
+
+	logger.Printf(format, v...)
+}
+
+// containerAttrs returns the attributes of the structured logs of an operation on the given container.
+func containerAttrs(c Container, operation string) []slog.Attr {
+	attrs := []slog.Attr{slog.String(LogKeyContainerID, c.GetContainerID())}
+	if dc, ok := c.(*DockerContainer); ok && dc.Image != "" {
+		attrs = append(attrs, slog.String(LogKeyImage, dc.Image))
+	}
+
+	return append(attrs, slog.String(LogKeyOperation, operation))
+}
+
+// defaultLogger is the Logging implementation of the default log instance,
+// which discards the logs when they are disabled in the configuration.
+type defaultLogger struct {
+	*log.Logger
+}
+
+// Printf implements Logging.
+func (l defaultLogger) Printf(format string, v ...interface{}) {
+	if config.Read().LogsDisabled {
+		return
+	}
+
+	l.Logger.Printf(format, v...)
+}
+
 // Deprecated: this function will be removed in a future release
 // LogDockerServerInfo logs the docker server info using the provided logger and Docker client
 func LogDockerServerInfo(ctx context.Context, client client.APIClient, logger Logging) {
@@ -1,9 +1,16 @@
 package testcontainers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"log/slog"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
 )
 
 func TestWithLogger(t *testing.T) {
@@ -29,3 +36,39 @@ func TestWithLogger(t *testing.T) {
 		require.Equal(t, logger, opts.Logger)
 	})
 }
+
+func TestSlogLogger(t *testing.T) {
+	ctx := context.Background()
+
+	// slogLogger {
+	buf := &bytes.Buffer{}
+	logger := SlogLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	// }
+
+	c := &DockerContainer{ID: "1234567890abcdef", Image: "nginx:alpine"}
+
+	// the info logs are filtered out by the level of the handler
+	logf(ctx, logger, slog.LevelInfo, containerAttrs(c, "start"), "🐳 Starting container: %s", c.ID[:12])
+	logger.Printf("creating TAR file\n")
+	require.Empty(t, buf.String())
+
+	logf(ctx, logger, slog.LevelWarn, containerAttrs(c, "update"), "⚠️ Updating resources of container %s: %s\n", c.ID, "no swap")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "WARN", entry["level"])
+	require.Equal(t, "⚠️ Updating resources of container 1234567890abcdef: no swap", entry["msg"])
+	require.Equal(t, "1234567890abcdef", entry[LogKeyContainerID])
+	require.Equal(t, "nginx:alpine", entry[LogKeyImage])
+	require.Equal(t, "update", entry[LogKeyOperation])
+	require.Equal(t, core.SessionID(), entry[LogKeySessionID])
+}
+
+func TestLogf(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, "", 0)
+
+	// the attributes and the level are dropped by the loggers without structured logging
+	logf(context.Background(), logger, slog.LevelWarn, []slog.Attr{slog.String(LogKeyOperation, "pull")}, "Failed to pull image: %s, will retry", "timeout")
+	require.Equal(t, "Failed to pull image: timeout, will retry\n", buf.String())
+}
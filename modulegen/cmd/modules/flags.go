@@ -1,7 +1,9 @@
 package modules
 
 const (
-	imageFlag = "image"
-	nameFlag  = "name"
-	titleFlag = "title"
+	imageFlag      = "image"
+	modulePathFlag = "module-path"
+	nameFlag       = "name"
+	outputDirFlag  = "output-dir"
+	titleFlag      = "title"
 )
@@ -20,6 +20,10 @@ func init() {
 	newModuleCmd.Flags().StringVarP(&tcModuleVar.NameTitle, titleFlag, "t", "", "(Optional) Title of the module name, used to override the name in the case of mixed casing (Mongodb -> MongoDB). Use camel-case when needed. Only alphabetical characters are allowed.")
 	newModuleCmd.Flags().StringVarP(&tcModuleVar.Image, imageFlag, "i", "", "Fully-qualified name of the Docker image to be used by the module")
 
+	newModuleCmd.Flags().StringVarP(&tcModuleVar.ModulePath, modulePathFlag, "m", "", "(Optional) Go module path of a module hosted outside the project, e.g. an in-house module (github.com/acme/testcontainers/foodb). The project files are not updated.")
+	newModuleCmd.Flags().StringVarP(&tcModuleVar.OutputDir, outputDirFlag, "o", "", "Directory in which the module hosted outside the project is generated, in a subdirectory named after the module. Required with the module-path flag.")
+
 	_ = newModuleCmd.MarkFlagRequired(imageFlag)
 	_ = newModuleCmd.MarkFlagRequired(nameFlag)
+	newModuleCmd.MarkFlagsRequiredTogether(modulePathFlag, outputDirFlag)
 }
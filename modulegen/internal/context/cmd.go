@@ -4,4 +4,7 @@ type TestcontainersModuleVar struct {
 	Name      string
 	NameTitle string
 	Image     string
+	// ModulePath and OutputDir are only set for the modules hosted outside the project
+	ModulePath string
+	OutputDir  string
 }
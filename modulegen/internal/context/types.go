@@ -7,6 +7,7 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"golang.org/x/mod/module"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -17,6 +18,9 @@ type TestcontainersModule struct {
 	Name      string
 	TitleName string // title of the name: m.g. "mongodb" -> "MongoDB"
 	TCVersion string // Testcontainers for Go version
+	// ModulePath is the Go module path of a module hosted outside the project, e.g. an in-house module:
+	// m.g. "github.com/acme/testcontainers/foodb". It's empty for the modules of the project.
+	ModulePath string
 }
 
 // ContainerName returns the name of the container, which is the lower-cased title of the example
@@ -44,6 +48,22 @@ func (m *TestcontainersModule) Entrypoint() string {
 	return "runContainer"
 }
 
+// ImportPath returns the import path of the package of the module, which is the module path
+// for the modules hosted outside the project
+func (m *TestcontainersModule) ImportPath() string {
+	if m.IsStandalone() {
+		return m.ModulePath
+	}
+
+	return "github.com/testcontainers/testcontainers-go/" + m.ParentDir() + "/" + m.Lower()
+}
+
+// IsStandalone returns true if the module is hosted outside the project, so it's not added
+// to the docs, the CI workflows and the workspace of the project
+func (m *TestcontainersModule) IsStandalone() bool {
+	return m.ModulePath != ""
+}
+
 func (m *TestcontainersModule) Lower() string {
 	return strings.ToLower(m.Name)
 }
@@ -80,5 +100,11 @@ func (m *TestcontainersModule) Validate() error {
 		return fmt.Errorf("invalid title: %s. Only alphanumerical characters are allowed (leading character must be a letter)", m.TitleName)
 	}
 
+	if m.IsStandalone() {
+		if err := module.CheckPath(m.ModulePath); err != nil {
+			return fmt.Errorf("invalid module path: %w", err)
+		}
+	}
+
 	return nil
 }
@@ -1,7 +1,9 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
@@ -21,10 +23,15 @@ func Generate(moduleVar context.TestcontainersModuleVar, isModule bool) error {
 	}
 
 	tcModule := context.TestcontainersModule{
-		Image:     moduleVar.Image,
-		IsModule:  isModule,
-		Name:      moduleVar.Name,
-		TitleName: moduleVar.NameTitle,
+		Image:      moduleVar.Image,
+		IsModule:   isModule,
+		Name:       moduleVar.Name,
+		TitleName:  moduleVar.NameTitle,
+		ModulePath: moduleVar.ModulePath,
+	}
+
+	if tcModule.IsStandalone() {
+		return generateStandalone(ctx, tcModule, moduleVar.OutputDir)
 	}
 
 	err = GenerateFiles(ctx, tcModule)
@@ -53,6 +60,59 @@ func Generate(moduleVar context.TestcontainersModuleVar, isModule bool) error {
 	return nil
 }
 
+// generateStandalone generates a module hosted outside the project, e.g. an in-house module,
+// in a directory named after the module in the given output directory.
+func generateStandalone(ctx context.Context, tcModule context.TestcontainersModule, outputDir string) error {
+	moduleDir, err := GenerateStandaloneFiles(ctx, tcModule, outputDir)
+	if err != nil {
+		return fmt.Errorf(">> error generating the module: %w", err)
+	}
+
+	lintCmds := []func(string) error{
+		tools.GoModTidy,
+		tools.GoVet,
+	}
+
+	for _, lintCmd := range lintCmds {
+		err = lintCmd(moduleDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("Please go to", moduleDir, "directory to check the results, where 'go mod tidy' and 'go vet' were executed.")
+	fmt.Println("Remember to document the module and to define a CI workflow to run its tests.")
+	return nil
+}
+
+// GenerateStandaloneFiles generates the files of a module hosted outside the project, in a directory
+// named after the module in the given output directory, which is returned. Unlike GenerateFiles,
+// it doesn't update the docs, the CI workflows and the workspace of the project.
+func GenerateStandaloneFiles(ctx context.Context, tcModule context.TestcontainersModule, outputDir string) (string, error) {
+	if err := tcModule.Validate(); err != nil {
+		return "", err
+	}
+
+	if outputDir == "" {
+		return "", errors.New("the output directory is required for the modules hosted outside the project")
+	}
+
+	moduleDir, err := filepath.Abs(filepath.Join(outputDir, tcModule.Lower()))
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(moduleDir); err == nil {
+		return "", fmt.Errorf("the %s directory already exists", moduleDir)
+	}
+
+	if err := (module.Generator{}).AddStandaloneModule(ctx, tcModule, moduleDir); err != nil {
+		return "", err
+	}
+
+	return moduleDir, nil
+}
+
 type ProjectGenerator interface {
 	Generate(context.Context) error
 }
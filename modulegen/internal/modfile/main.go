@@ -14,14 +14,30 @@ func GenerateModFile(exampleDir string, rootGoModFilePath string, directory stri
 	moduleStmt := rootGoMod.Module.Mod.Path + directory
 	goStmt := rootGoMod.Go.Version
 	tcPath := rootGoMod.Module.Mod.Path
-	file, err := newModFile(moduleStmt, goStmt, tcPath, tcVersion)
+	file, err := newModFile(moduleStmt, goStmt, tcPath, tcVersion, "../..")
 	if err != nil {
 		return err
 	}
 	return writeModFile(filepath.Join(exampleDir, "go.mod"), file)
 }
 
-func newModFile(moduleStmt string, goStmt string, tcPath string, tcVersion string) (*modfile.File, error) {
+// GenerateStandaloneModFile generates the go.mod file of a module hosted outside the project,
+// with the given module path, requiring the released version of Testcontainers for Go instead of replacing it.
+func GenerateStandaloneModFile(moduleDir string, rootGoModFilePath string, modulePath string, tcVersion string) error {
+	rootGoMod, err := readModFile(rootGoModFilePath)
+	if err != nil {
+		return err
+	}
+	file, err := newModFile(modulePath, rootGoMod.Go.Version, rootGoMod.Module.Mod.Path, tcVersion, "")
+	if err != nil {
+		return err
+	}
+	return writeModFile(filepath.Join(moduleDir, "go.mod"), file)
+}
+
+// newModFile creates a go.mod file requiring the given version of Testcontainers for Go,
+// replaced with the given path, if not empty.
+func newModFile(moduleStmt string, goStmt string, tcPath string, tcVersion string, replacePath string) (*modfile.File, error) {
 	file := &modfile.File{}
 	err := file.AddModuleStmt(moduleStmt)
 	if err != nil {
@@ -35,7 +51,10 @@ func newModFile(moduleStmt string, goStmt string, tcPath string, tcVersion strin
 	if err != nil {
 		return nil, err
 	}
-	err = file.AddReplace(tcPath, "", "../..", "")
+	if replacePath == "" {
+		return file, nil
+	}
+	err = file.AddReplace(tcPath, "", replacePath, "")
 	if err != nil {
 		return nil, err
 	}
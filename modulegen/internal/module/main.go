@@ -24,11 +24,27 @@ func (g Generator) AddModule(ctx context.Context, tcModule context.Testcontainer
 	return generateGoModFile(moduleDir, tcModule)
 }
 
+// AddStandaloneModule creates the Go files and the go.mod file of a module hosted outside the project,
+// e.g. an in-house module, in the given directory
+func (g Generator) AddStandaloneModule(ctx context.Context, tcModule context.TestcontainersModule, moduleDir string) error {
+	err := generateGoFiles(moduleDir, tcModule)
+	if err != nil {
+		return err
+	}
+
+	mkdocsConfig, err := mkdocs.ReadConfig(ctx.MkdocsConfigFile())
+	if err != nil {
+		return err
+	}
+	return modfile.GenerateStandaloneModFile(moduleDir, ctx.GoModFile(), tcModule.ModulePath, mkdocsConfig.Extra.LatestVersion)
+}
+
 func generateGoFiles(moduleDir string, tcModule context.TestcontainersModule) error {
 	funcMap := template.FuncMap{
 		"Entrypoint":    tcModule.Entrypoint,
 		"ContainerName": tcModule.ContainerName,
 		"Image":         func() string { return tcModule.Image },
+		"ImportPath":    tcModule.ImportPath,
 		"ParentDir":     tcModule.ParentDir,
 		"ToLower":       tcModule.Lower,
 		"Title":         tcModule.Title,
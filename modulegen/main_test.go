@@ -144,6 +144,14 @@ func TestModule_Validate(outer *testing.T) {
 			},
 			expectedErr: errors.New("invalid title: 1AmazingDB. Only alphanumerical characters are allowed (leading character must be a letter)"),
 		},
+		{
+			name: "module path of a standalone module",
+			module: context.TestcontainersModule{
+				Name:       "AmazingDB",
+				TitleName:  "AmazingDB",
+				ModulePath: "github.com/acme/testcontainers/amazingdb",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -428,7 +436,8 @@ func assertModuleContent(t *testing.T, module context.TestcontainersModule, exam
 	assert.Equal(t, data[13], "// "+entrypoint+" creates an instance of the "+exampleName+" container type")
 	assert.Equal(t, data[14], "func "+entrypoint+"(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*"+containerName+", error) {")
 	assert.Equal(t, data[16], "\t\tImage: \""+module.Image+"\",")
-	assert.Equal(t, data[33], "\treturn &"+containerName+"{Container: container}, nil")
+	assert.Equal(t, data[28], "\treturn testcontainers.RunContainer(ctx, genericContainerReq, func(container testcontainers.Container) *"+containerName+" {")
+	assert.Equal(t, data[29], "\t\treturn &"+containerName+"{Container: container}")
 }
 
 // assert content GitHub workflow for the module
@@ -509,3 +518,61 @@ func sanitiseContent(bytes []byte) []string {
 
 	return data
 }
+
+func TestGenerateStandaloneModule(t *testing.T) {
+	tmpCtx := context.New(t.TempDir())
+	outputDir := t.TempDir()
+
+	err := copyInitialMkdocsConfig(t, tmpCtx)
+	require.NoError(t, err)
+
+	rootCtx := getTestRootContext(t)
+	goMod, err := os.ReadFile(rootCtx.GoModFile())
+	require.NoError(t, err)
+	err = os.WriteFile(tmpCtx.GoModFile(), goMod, 0o644)
+	require.NoError(t, err)
+
+	originalConfig, err := mkdocs.ReadConfig(tmpCtx.MkdocsConfigFile())
+	require.NoError(t, err)
+
+	module := context.TestcontainersModule{
+		Name:       "foodb",
+		TitleName:  "FooDB",
+		IsModule:   true,
+		Image:      "docker.io/example/foodb:latest",
+		ModulePath: "github.com/acme/testcontainers/foodb",
+	}
+
+	moduleDir, err := internal.GenerateStandaloneFiles(tmpCtx, module, outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(outputDir, "foodb"), moduleDir)
+
+	assertModuleContent(t, module, filepath.Join(moduleDir, "foodb.go"))
+	assertModuleTestContent(t, module, filepath.Join(moduleDir, "foodb_test.go"))
+
+	examplesTest, err := os.ReadFile(filepath.Join(moduleDir, "examples_test.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "\t\"github.com/acme/testcontainers/foodb\"", sanitiseContent(examplesTest)[8])
+
+	goModContent, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"))
+	require.NoError(t, err)
+
+	data := sanitiseContent(goModContent)
+	assert.Equal(t, "module github.com/acme/testcontainers/foodb", data[0])
+	assert.Equal(t, "require github.com/testcontainers/testcontainers-go "+originalConfig.Extra.LatestVersion, data[4])
+	assert.NotContains(t, string(goModContent), "replace")
+
+	// the project files are not generated
+	_, err = os.Stat(filepath.Join(tmpCtx.DocsDir(), "modules", "foodb.md"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(moduleDir, "Makefile"))
+	assert.True(t, os.IsNotExist(err))
+
+	// the module is not overwritten
+	_, err = internal.GenerateStandaloneFiles(tmpCtx, module, outputDir)
+	require.Error(t, err)
+
+	module.ModulePath = "not a module path"
+	_, err = internal.GenerateStandaloneFiles(tmpCtx, module, t.TempDir())
+	require.ErrorContains(t, err, "invalid module path")
+}
@@ -3,6 +3,8 @@ package artemis
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/docker/go-connections/nat"
 
@@ -13,6 +15,9 @@ import (
 const (
 	defaultBrokerPort = "61616/tcp"
 	defaultHTTPPort   = "8161/tcp"
+	// brokerPropertiesPath is the path of the broker properties file, copied to the configuration
+	// of the broker instance when the container starts.
+	brokerPropertiesPath = "/var/lib/artemis-instance/etc-override/broker.properties"
 )
 
 // Container represents the Artemis container type used in the module.
@@ -72,6 +77,36 @@ func WithExtraArgs(args string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithAcceptor adds an acceptor with the given name, listening on the given port for the given protocols,
+// e.g. "AMQP" or "STOMP", or all the protocols if none is given. The port is exposed, so the endpoint of the
+// acceptor is returned by the PortEndpoint method. The option can be used multiple times, to add several acceptors.
+func WithAcceptor(name string, port int, protocols ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		prefix := "acceptorConfigurations." + name + "."
+		properties := prefix + "factoryClassName=org.apache.activemq.artemis.core.remoting.impl.netty.NettyAcceptorFactory\n" +
+			prefix + "params.host=0.0.0.0\n" +
+			prefix + fmt.Sprintf("params.port=%d\n", port)
+		if len(protocols) > 0 {
+			properties += prefix + "params.protocols=" + strings.Join(protocols, ",") + "\n"
+		}
+
+		req.ExposedPorts = append(req.ExposedPorts, fmt.Sprintf("%d/tcp", port))
+
+		for i, f := range req.Files {
+			if f.ContainerFilePath == brokerPropertiesPath {
+				req.Files[i].Reader = io.MultiReader(f.Reader, strings.NewReader(properties))
+				return
+			}
+		}
+
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(properties),
+			ContainerFilePath: brokerPropertiesPath,
+			FileMode:          0o644,
+		})
+	}
+}
+
 // RunContainer creates an instance of the Artemis container type.
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*Container, error) {
 	req := testcontainers.GenericContainerRequest{
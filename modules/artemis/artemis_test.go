@@ -60,6 +60,24 @@ func TestArtemis(t *testing.T) {
 				expectQueue(t, container, "ArgsTestQueue")
 			},
 		},
+		{
+			name: "WithAcceptor",
+			opts: []testcontainers.ContainerCustomizer{
+				// withAcceptor {
+				artemis.WithAcceptor("stomp", 61613, "STOMP"),
+				// }
+			},
+			user: "artemis",
+			pass: "artemis",
+			hook: func(t *testing.T, container *artemis.Container) {
+				host, err := container.PortEndpoint(context.Background(), "61613/tcp", "")
+				require.NoError(t, err)
+
+				conn, err := stomp.Dial("tcp", host, stomp.ConnOpt.Login("artemis", "artemis"))
+				require.NoError(t, err, "failed to connect to the acceptor")
+				require.NoError(t, conn.Disconnect())
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,128 @@
+package azurite
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// apiVersion is the version of the storage REST API used to create the resources.
+const apiVersion = "2021-10-04"
+
+// create creates the resource with the given name, e.g. a blob container, with a PUT request
+// to the service listening on the given port, authorized with the key of the development storage account.
+func create(ctx context.Context, c testcontainers.Container, port nat.Port, name string, query string) error {
+	address, err := serviceURL(ctx, c, port)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(address + "/" + name)
+	if err != nil {
+		return err
+	}
+	u.RawQuery = query
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", apiVersion)
+
+	signature, err := sign(req, AccountKey)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "SharedKey "+AccountName+":"+signature)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// the resource may already exist, e.g. if the option is used twice with the same name
+	if resp.StatusCode == http.StatusConflict {
+		return nil
+	}
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// sign returns the Shared Key signature of the request, for the blob and queue services, which have no body.
+// See https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func sign(req *http.Request, accountKey string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return "", fmt.Errorf("decode account key: %w", err)
+	}
+
+	var headers []string
+	for name := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-ms-") {
+			headers = append(headers, name)
+		}
+	}
+	sort.Strings(headers)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headers {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+
+	// the account name is part of the path, as the URLs of the emulator are path-style
+	canonicalResource := "/" + AccountName + req.URL.EscapedPath()
+
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for param := range query {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	for _, param := range params {
+		values := query[param]
+		sort.Strings(values)
+		canonicalResource += "\n" + strings.ToLower(param) + ":" + strings.Join(values, ",")
+	}
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		"", // Content-Encoding
+		"", // Content-Language
+		"", // Content-Length
+		"", // Content-MD5
+		"", // Content-Type
+		"", // Date
+		"", // If-Modified-Since
+		"", // If-Match
+		"", // If-None-Match
+		"", // If-Unmodified-Since
+		"", // Range
+		canonicalHeaders.String() + canonicalResource,
+	}, "\n")
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,25 @@
+package azurite
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPut, "http://localhost:10000/devstoreaccount1/uploads?restype=container", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("x-ms-date", "Mon, 04 Mar 2024 10:00:00 GMT")
+	req.Header.Set("x-ms-version", "2021-10-04")
+
+	signature, err := sign(req, AccountKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "JMDbZ0tWGU93IXtyxIwcfsvaGHU/62KQCzXMPVgUJAc="
+	if signature != expected {
+		t.Fatalf("expected signature %s, got %s", expected, signature)
+	}
+}
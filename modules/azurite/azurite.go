@@ -0,0 +1,155 @@
+package azurite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "mcr.microsoft.com/azure-storage/azurite:3.29.0"
+
+	// BlobPort is the port of the blob service
+	BlobPort = "10000/tcp"
+	// QueuePort is the port of the queue service
+	QueuePort = "10001/tcp"
+	// TablePort is the port of the table service
+	TablePort = "10002/tcp"
+
+	// AccountName is the name of the well-known development storage account of Azurite.
+	AccountName = "devstoreaccount1"
+	// AccountKey is the key of the well-known development storage account of Azurite.
+	AccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+// AzuriteContainer represents the Azurite container type used in the module
+type AzuriteContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the Azurite container type, running the blob, queue and table services.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*AzuriteContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{BlobPort, QueuePort, TablePort},
+		Cmd:          []string{"azurite", "--blobHost", "0.0.0.0", "--queueHost", "0.0.0.0", "--tableHost", "0.0.0.0"},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("Azurite Blob service is successfully listening"),
+			wait.ForLog("Azurite Queue service is successfully listening"),
+			wait.ForLog("Azurite Table service is successfully listening"),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzuriteContainer{Container: container}, nil
+}
+
+// WithBlobContainers creates the given blob containers once the container is ready.
+func WithBlobContainers(names ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					for _, name := range names {
+						if err := create(ctx, c, BlobPort, name, "restype=container"); err != nil {
+							return fmt.Errorf("create blob container %s: %w", name, err)
+						}
+					}
+
+					return nil
+				},
+			},
+		})
+	}
+}
+
+// WithQueues creates the given queues once the container is ready.
+func WithQueues(names ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					for _, name := range names {
+						if err := create(ctx, c, QueuePort, name, ""); err != nil {
+							return fmt.Errorf("create queue %s: %w", name, err)
+						}
+					}
+
+					return nil
+				},
+			},
+		})
+	}
+}
+
+// BlobServiceURL returns the URL of the blob service of the development storage account,
+// in the http://<host>:<port>/devstoreaccount1 format.
+func (c *AzuriteContainer) BlobServiceURL(ctx context.Context) (string, error) {
+	return serviceURL(ctx, c, BlobPort)
+}
+
+// QueueServiceURL returns the URL of the queue service of the development storage account,
+// in the http://<host>:<port>/devstoreaccount1 format.
+func (c *AzuriteContainer) QueueServiceURL(ctx context.Context) (string, error) {
+	return serviceURL(ctx, c, QueuePort)
+}
+
+// TableServiceURL returns the URL of the table service of the development storage account,
+// in the http://<host>:<port>/devstoreaccount1 format.
+func (c *AzuriteContainer) TableServiceURL(ctx context.Context) (string, error) {
+	return serviceURL(ctx, c, TablePort)
+}
+
+// ConnectionString returns the connection string of the well-known development storage account,
+// with the endpoints of the blob, queue and table services, to be used with the Azure SDKs.
+func (c *AzuriteContainer) ConnectionString(ctx context.Context) (string, error) {
+	blobURL, err := c.BlobServiceURL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	queueURL, err := c.QueueServiceURL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	tableURL, err := c.TableServiceURL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("DefaultEndpointsProtocol=http;AccountName=%s;AccountKey=%s;BlobEndpoint=%s;QueueEndpoint=%s;TableEndpoint=%s;",
+		AccountName, AccountKey, blobURL, queueURL, tableURL), nil
+}
+
+// serviceURL returns the URL of the service listening on the given port, for the development storage account.
+func serviceURL(ctx context.Context, c testcontainers.Container, port nat.Port) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s/%s", host, mappedPort.Port(), AccountName), nil
+}
@@ -0,0 +1,70 @@
+package azurite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azqueue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/azurite"
+)
+
+func TestAzurite(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := azurite.RunContainer(ctx,
+		testcontainers.WithImage("mcr.microsoft.com/azure-storage/azurite:3.29.0"),
+		// withResources {
+		azurite.WithBlobContainers("uploads", "reports"),
+		azurite.WithQueues("orders"),
+		// }
+	)
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+	})
+
+	// connectionString {
+	connectionString, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	blobClient, err := azblob.NewClientFromConnectionString(connectionString, nil)
+	require.NoError(t, err)
+	// }
+
+	t.Run("creates the blob containers", func(t *testing.T) {
+		_, err := blobClient.UploadBuffer(ctx, "uploads", "hello.txt", []byte("hello"), nil)
+		require.NoError(t, err)
+
+		var names []string
+		pager := blobClient.NewListContainersPager(nil)
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			require.NoError(t, err)
+
+			for _, item := range page.ContainerItems {
+				names = append(names, *item.Name)
+			}
+		}
+		assert.ElementsMatch(t, []string{"uploads", "reports"}, names)
+	})
+
+	t.Run("creates the queues", func(t *testing.T) {
+		queueClient, err := azqueue.NewQueueClientFromConnectionString(connectionString, "orders", nil)
+		require.NoError(t, err)
+
+		_, err = queueClient.EnqueueMessage(ctx, "order 42", nil)
+		require.NoError(t, err)
+
+		resp, err := queueClient.DequeueMessage(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, resp.Messages, 1)
+		assert.Equal(t, "order 42", *resp.Messages[0].MessageText)
+	})
+}
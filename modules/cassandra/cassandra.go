@@ -2,9 +2,7 @@ package cassandra
 
 import (
 	"context"
-	"io"
 	"path/filepath"
-	"strings"
 
 	"github.com/docker/go-connections/nat"
 
@@ -21,7 +19,7 @@ type CassandraContainer struct {
 	testcontainers.Container
 }
 
-// ConnectionHost returns the host and port of the cassandra container, using the default, native 9000 port, and
+// ConnectionHost returns the host and port of the cassandra container, using the default, native 9042 port, and
 // obtaining the host and exposed port from the container
 func (c *CassandraContainer) ConnectionHost(ctx context.Context) (string, error) {
 	host, err := c.Host(ctx)
@@ -72,7 +70,8 @@ func WithInitScripts(scripts ...string) testcontainers.CustomizeRequestOption {
 	}
 }
 
-// RunContainer creates an instance of the Cassandra container type
+// RunContainer creates an instance of the Cassandra container type.
+// The container is ready once a query sent over a CQL connection to the native port succeeds.
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*CassandraContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        "cassandra:4.1.3",
@@ -87,10 +86,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		},
 		WaitingFor: wait.ForAll(
 			wait.ForListeningPort(port),
-			wait.ForExec([]string{"cqlsh", "-e", "SELECT bootstrapped FROM system.local"}).WithResponseMatcher(func(body io.Reader) bool {
-				data, _ := io.ReadAll(body)
-				return strings.Contains(string(data), "COMPLETED")
-			}),
+			forCQL(port),
 		),
 	}
 
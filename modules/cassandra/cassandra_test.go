@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/cassandra"
 )
 
@@ -149,3 +150,40 @@ func TestCassandraWithInitScripts(t *testing.T) {
 		assert.Equal(t, Test{Id: 1, Name: "NAME"}, test)
 	})
 }
+
+func TestScyllaDB(t *testing.T) {
+	ctx := context.Background()
+
+	// runScyllaDBContainer {
+	container, err := cassandra.RunContainer(ctx,
+		testcontainers.WithImage("scylladb/scylla:5.4"),
+		testcontainers.CustomizeRequestOption(func(req *testcontainers.GenericContainerRequest) {
+			req.Cmd = []string{"--smp", "1", "--memory", "512M", "--developer-mode", "1"}
+		}),
+		cassandra.WithInitScripts(filepath.Join("testdata", "init.cql")),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	connectionHost, err := container.ConnectionHost(ctx)
+	require.NoError(t, err)
+
+	cluster := gocql.NewCluster(connectionHost)
+	session, err := cluster.CreateSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	var test Test
+	err = session.Query("SELECT id, name FROM test_keyspace.test_table WHERE id=1").Scan(&test.Id, &test.Name)
+	require.NoError(t, err)
+	assert.Equal(t, Test{Id: 1, Name: "NAME"}, test)
+}
@@ -0,0 +1,207 @@
+package cassandra
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// the opcodes of the native protocol used by the wait strategy.
+// See https://github.com/apache/cassandra/blob/trunk/doc/native_protocol_v4.spec
+const (
+	opError        byte = 0x00
+	opStartup      byte = 0x01
+	opReady        byte = 0x02
+	opAuthenticate byte = 0x03
+	opQuery        byte = 0x07
+	opResult       byte = 0x08
+
+	protocolVersion byte = 0x04
+	consistencyOne       = 0x0001
+	readyQuery           = "SELECT release_version FROM system.local"
+)
+
+var (
+	_ wait.Strategy        = (*cqlStrategy)(nil)
+	_ wait.StrategyTimeout = (*cqlStrategy)(nil)
+)
+
+// cqlStrategy waits until the node answers a query sent over a CQL connection to the given port,
+// opened from the host. The log lines of the node are not reliable, as it logs that it's listening
+// for CQL clients before it's able to serve their queries.
+type cqlStrategy struct {
+	port           nat.Port
+	timeout        *time.Duration
+	startupTimeout time.Duration
+	pollInterval   time.Duration
+}
+
+// forCQL constructs a wait strategy running a query over a CQL connection to the given port.
+func forCQL(port nat.Port) *cqlStrategy {
+	return &cqlStrategy{
+		port:           port,
+		startupTimeout: 60 * time.Second,
+		pollInterval:   500 * time.Millisecond,
+	}
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (s *cqlStrategy) WithStartupTimeout(timeout time.Duration) *cqlStrategy {
+	s.timeout = &timeout
+	return s
+}
+
+func (s *cqlStrategy) Timeout() *time.Duration {
+	return s.timeout
+}
+
+// WaitUntilReady repeatedly opens a CQL connection to the node and runs a query, until the query succeeds,
+// or the node asks the client to authenticate, which means that it's ready to serve the queries of
+// the authenticated clients.
+func (s *cqlStrategy) WaitUntilReady(ctx context.Context, target wait.StrategyTarget) error {
+	timeout := s.startupTimeout
+	if s.timeout != nil {
+		timeout = *s.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	var err error
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-ticker.C:
+			state, stateErr := target.State(ctx)
+			if stateErr != nil {
+				return stateErr
+			}
+			if !state.Running {
+				return fmt.Errorf("container is not running: %s", state.Status)
+			}
+
+			if err = s.check(ctx, target); err == nil {
+				return nil
+			}
+		}
+	}
+}
+
+// check opens a CQL connection to the node and runs a query.
+func (s *cqlStrategy) check(ctx context.Context, target wait.StrategyTarget) error {
+	host, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	port, err := target.MappedPort(ctx, s.port)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port.Port()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		return err
+	}
+
+	startup := &bytes.Buffer{}
+	_ = binary.Write(startup, binary.BigEndian, uint16(1))
+	writeString(startup, "CQL_VERSION")
+	writeString(startup, "3.0.0")
+
+	opcode, err := roundTrip(conn, opStartup, startup.Bytes())
+	if err != nil {
+		return fmt.Errorf("startup: %w", err)
+	}
+
+	switch opcode {
+	case opAuthenticate:
+		return nil
+	case opReady:
+	default:
+		return fmt.Errorf("startup: unexpected opcode 0x%02x", opcode)
+	}
+
+	query := &bytes.Buffer{}
+	_ = binary.Write(query, binary.BigEndian, uint32(len(readyQuery)))
+	query.WriteString(readyQuery)
+	_ = binary.Write(query, binary.BigEndian, uint16(consistencyOne))
+	query.WriteByte(0)
+
+	opcode, err = roundTrip(conn, opQuery, query.Bytes())
+	if err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	if opcode != opResult {
+		return fmt.Errorf("query: unexpected opcode 0x%02x", opcode)
+	}
+
+	return nil
+}
+
+// roundTrip sends a request frame with the given opcode and body, and returns the opcode of the response frame.
+// The error frames are returned as errors.
+func roundTrip(conn net.Conn, opcode byte, body []byte) (byte, error) {
+	header := []byte{protocolVersion, 0, 0, 0, opcode, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(header[5:], uint32(len(body)))
+
+	if _, err := conn.Write(append(header, body...)); err != nil {
+		return 0, err
+	}
+
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return 0, err
+	}
+
+	body = make([]byte, binary.BigEndian.Uint32(header[5:]))
+	if _, err := io.ReadFull(conn, body); err != nil {
+		return 0, err
+	}
+
+	if header[4] == opError {
+		return 0, readError(body)
+	}
+
+	return header[4], nil
+}
+
+// readError returns the error carried by the body of an error frame, made of a code and a message.
+func readError(body []byte) error {
+	if len(body) < 6 {
+		return errors.New("malformed error")
+	}
+
+	code := binary.BigEndian.Uint32(body)
+	length := int(binary.BigEndian.Uint16(body[4:]))
+	if len(body) < 6+length {
+		return fmt.Errorf("error 0x%04x", code)
+	}
+
+	return fmt.Errorf("error 0x%04x: %s", code, body[6:6+length])
+}
+
+// writeString writes a string of the native protocol, made of its length as a short and its bytes.
+func writeString(buf *bytes.Buffer, s string) {
+	_ = binary.Write(buf, binary.BigEndian, uint16(len(s)))
+	buf.WriteString(s)
+}
@@ -0,0 +1,114 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/exec"
+)
+
+// fakeTarget is a wait.StrategyTarget exposing the CQL port on the given host port.
+type fakeTarget struct {
+	port int
+}
+
+func (t fakeTarget) Host(context.Context) (string, error) {
+	return "127.0.0.1", nil
+}
+
+func (t fakeTarget) Ports(context.Context) (nat.PortMap, error) {
+	return nil, nil
+}
+
+func (t fakeTarget) MappedPort(context.Context, nat.Port) (nat.Port, error) {
+	return nat.Port(strconv.Itoa(t.port) + "/tcp"), nil
+}
+
+func (t fakeTarget) Logs(context.Context) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (t fakeTarget) Exec(context.Context, []string, ...exec.ProcessOption) (int, io.Reader, error) {
+	return 0, nil, nil
+}
+
+func (t fakeTarget) State(context.Context) (*types.ContainerState, error) {
+	return &types.ContainerState{Running: true, Status: "running"}, nil
+}
+
+// serveCQL accepts a single connection and answers its requests with the frames of the given opcodes and bodies.
+func serveCQL(t *testing.T, responses ...[]byte) fakeTarget {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for _, response := range responses {
+			header := make([]byte, 9)
+			if _, err := io.ReadFull(conn, header); err != nil {
+				return
+			}
+			if _, err := io.CopyN(io.Discard, conn, int64(binary.BigEndian.Uint32(header[5:]))); err != nil {
+				return
+			}
+
+			frame := []byte{0x84, 0, 0, 0, response[0], 0, 0, 0, 0}
+			binary.BigEndian.PutUint32(frame[5:], uint32(len(response)-1))
+			if _, err := conn.Write(append(frame, response[1:]...)); err != nil {
+				return
+			}
+		}
+	}()
+
+	return fakeTarget{port: listener.Addr().(*net.TCPAddr).Port}
+}
+
+func TestCQLStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ready", func(t *testing.T) {
+		target := serveCQL(t, []byte{opReady}, []byte{opResult, 0, 0, 0, 1})
+		require.NoError(t, forCQL(port).check(ctx, target))
+	})
+
+	t.Run("authentication required", func(t *testing.T) {
+		target := serveCQL(t, []byte{opAuthenticate})
+		require.NoError(t, forCQL(port).check(ctx, target))
+	})
+
+	t.Run("not bootstrapped", func(t *testing.T) {
+		// the unavailable error, carrying its code and its message
+		errorBody := append([]byte{opError, 0, 0, 0x10, 0, 0, 11}, "unavailable"...)
+
+		target := serveCQL(t, []byte{opReady}, errorBody)
+		err := forCQL(port).check(ctx, target)
+		require.Error(t, err)
+		assert.Equal(t, "query: error 0x1000: unavailable", err.Error())
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		target := fakeTarget{port: listener.Addr().(*net.TCPAddr).Port}
+		require.NoError(t, listener.Close())
+
+		require.Error(t, forCQL(port).check(ctx, target))
+	})
+}
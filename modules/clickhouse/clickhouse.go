@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -19,11 +22,20 @@ import (
 //go:embed mounts/zk_config.xml.tpl
 var zookeeperConfigTpl string
 
+//go:embed mounts/keeper_config.xml
+var keeperConfig string
+
 const (
 	defaultUser         = "default"
 	defaultDatabaseName = "clickhouse"
 )
 
+// databasesScript is the init script creating the databases of WithDatabases, which runs first.
+const databasesScript = "/docker-entrypoint-initdb.d/00-testcontainers-databases.sql"
+
+// keeperPort is the port of the embedded ClickHouse Keeper enabled by WithKeeper.
+const keeperPort = "9181"
+
 const defaultImage = "clickhouse/clickhouse-server:23.3.8.21-alpine"
 
 const (
@@ -67,16 +79,38 @@ func (c *ClickHouseContainer) ConnectionString(ctx context.Context, args ...stri
 		return "", err
 	}
 
-	extraArgs := ""
-	if len(args) > 0 {
-		extraArgs = strings.Join(args, "&")
+	return c.dsn("clickhouse", host, args), nil
+}
+
+// HTTPConnectionString returns the dsn string for the clickhouse container, using the HTTP 8123 port, and
+// obtaining the host and exposed port from the container. The clickhouse-go v2 driver connects over HTTP
+// when the dsn uses the http scheme, e.g. to go through proxies only supporting HTTP. As with ConnectionString,
+// the extra arguments are appended to the dsn string.
+func (c *ClickHouseContainer) HTTPConnectionString(ctx context.Context, args ...string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
 	}
-	if extraArgs != "" {
-		extraArgs = "?" + extraArgs
+
+	port, err := c.MappedPort(ctx, httpPort)
+	if err != nil {
+		return "", err
 	}
 
-	connectionString := fmt.Sprintf("clickhouse://%s:%s@%s/%s%s", c.User, c.Password, host, c.DbName, extraArgs)
-	return connectionString, nil
+	return c.dsn("http", host+":"+port.Port(), args), nil
+}
+
+// dsn returns the dsn string with the given scheme and address, and the credentials and the database of the container.
+func (c *ClickHouseContainer) dsn(scheme string, address string, args []string) string {
+	u := url.URL{
+		Scheme:   scheme,
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     address,
+		Path:     "/" + c.DbName,
+		RawQuery: strings.Join(args, "&"),
+	}
+
+	return u.String()
 }
 
 // ZookeeperOptions arguments for zookeeper in clickhouse
@@ -126,6 +160,91 @@ func WithZookeeper(host, port string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithKeeper enables the ClickHouse Keeper embedded in the server, and configures the server to use it
+// instead of ZooKeeper, so that replicated tables and distributed DDL queries can be used without running
+// a ZooKeeper container. The "default" cluster and the "shard" and "replica" macros are defined,
+// as with WithZookeeper.
+func WithKeeper() testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		data, err := renderZookeeperConfig(ZookeeperOptions{Host: "localhost", Port: keeperPort})
+		if err != nil {
+			panic(err)
+		}
+
+		req.Files = append(req.Files,
+			testcontainers.ContainerFile{
+				Reader:            strings.NewReader(keeperConfig),
+				ContainerFilePath: "/etc/clickhouse-server/config.d/keeper_config.xml",
+				FileMode:          0o755,
+			},
+			testcontainers.ContainerFile{
+				Reader:            bytes.NewReader(data),
+				ContainerFilePath: "/etc/clickhouse-server/config.d/zookeeper_config.xml",
+				FileMode:          0o755,
+			},
+		)
+	}
+}
+
+// WithUser adds a user with the given password, besides the one of WithUsername, which can connect from any address
+// with the default profile and quota. The username must be a valid XML element name, as it's declared
+// in a configuration file of the users.d directory.
+func WithUser(username string, password string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(renderUserConfig(username, password)),
+			ContainerFilePath: "/etc/clickhouse-server/users.d/" + username + ".xml",
+			FileMode:          0o755,
+		})
+	}
+}
+
+// renderUserConfig generates the configuration of a user with the given password, for the users.d directory.
+func renderUserConfig(username string, password string) []byte {
+	var escaped bytes.Buffer
+	_ = xml.EscapeText(&escaped, []byte(password))
+
+	return []byte(fmt.Sprintf(`<?xml version="1.0"?>
+<clickhouse>
+    <users>
+        <%[1]s>
+            <password>%[2]s</password>
+            <networks>
+                <ip>::/0</ip>
+            </networks>
+            <profile>default</profile>
+            <quota>default</quota>
+        </%[1]s>
+    </users>
+</clickhouse>
+`, username, escaped.String()))
+}
+
+// WithDatabases creates the given databases, besides the one of WithDatabase, when the container starts.
+// They are created by an init script running before the ones of WithInitScripts, so those can use them.
+func WithDatabases(dbNames ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		var script strings.Builder
+		for _, dbName := range dbNames {
+			fmt.Fprintf(&script, "CREATE DATABASE IF NOT EXISTS `%s`;\n", strings.ReplaceAll(dbName, "`", "``"))
+		}
+
+		// the databases of the previous calls are kept
+		for i, f := range req.Files {
+			if f.ContainerFilePath == databasesScript {
+				req.Files[i].Reader = io.MultiReader(f.Reader, strings.NewReader(script.String()))
+				return
+			}
+		}
+
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(script.String()),
+			ContainerFilePath: databasesScript,
+			FileMode:          0o755,
+		})
+	}
+}
+
 // WithInitScripts sets the init scripts to be run when the container starts
 func WithInitScripts(scripts ...string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
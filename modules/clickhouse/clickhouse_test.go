@@ -131,6 +131,84 @@ func TestClickHouseDSN(t *testing.T) {
 	assert.Len(t, data, 1)
 }
 
+func TestClickHouseHTTPDSN(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := clickhouse.RunContainer(ctx, clickhouse.WithUsername(user), clickhouse.WithPassword(password), clickhouse.WithDatabase(dbname))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	// httpConnectionString {
+	connectionString, err := container.HTTPConnectionString(ctx, "dial_timeout=5s")
+	// }
+	require.NoError(t, err)
+
+	opts, err := ch.ParseDSN(connectionString)
+	require.NoError(t, err)
+	assert.Equal(t, ch.HTTP, opts.Protocol)
+
+	conn, err := ch.Open(opts)
+	require.NoError(t, err)
+	assert.NotNil(t, conn)
+	defer conn.Close()
+
+	err = conn.Ping(ctx)
+	require.NoError(t, err)
+}
+
+func TestClickHouseWithUsersAndDatabases(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := clickhouse.RunContainer(ctx,
+		clickhouse.WithUsername(user),
+		clickhouse.WithPassword(password),
+		clickhouse.WithDatabase(dbname),
+		// withUsersAndDatabases {
+		clickhouse.WithUser("reader", "reader-password"),
+		clickhouse.WithDatabases("analytics", "reports"),
+		// }
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	connectionHost, err := container.ConnectionHost(ctx)
+	require.NoError(t, err)
+
+	conn, err := ch.Open(&ch.Options{
+		Addr: []string{connectionHost},
+		Auth: ch.Auth{
+			Database: "analytics",
+			Username: "reader",
+			Password: "reader-password",
+		},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var databases []string
+	rows, err := conn.Query(ctx, "SELECT name FROM system.databases WHERE name IN ('analytics', 'reports') ORDER BY name")
+	require.NoError(t, err)
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		databases = append(databases, name)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"analytics", "reports"}, databases)
+}
+
 func TestClickHouseWithInitScripts(t *testing.T) {
 	ctx := context.Background()
 
@@ -283,6 +361,47 @@ func TestClickHouseWithZookeeper(t *testing.T) {
 	assert.Len(t, data, 1)
 }
 
+func TestClickHouseWithKeeper(t *testing.T) {
+	ctx := context.Background()
+
+	// withKeeper {
+	container, err := clickhouse.RunContainer(ctx,
+		clickhouse.WithUsername(user),
+		clickhouse.WithPassword(password),
+		clickhouse.WithDatabase(dbname),
+		clickhouse.WithKeeper(),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	connectionHost, err := container.ConnectionHost(ctx)
+	require.NoError(t, err)
+
+	conn, err := ch.Open(&ch.Options{
+		Addr: []string{connectionHost},
+		Auth: ch.Auth{
+			Database: dbname,
+			Username: user,
+			Password: password,
+		},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, conn)
+	defer conn.Close()
+
+	// perform assertions
+	data, err := performReplicatedCRUD(conn)
+	require.NoError(t, err)
+	assert.Len(t, data, 1)
+}
+
 func performReplicatedCRUD(conn driver.Conn) ([]Test, error) {
 	var (
 		err error
@@ -0,0 +1,33 @@
+package clickhouse
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestRenderUserConfig(t *testing.T) {
+	config := string(renderUserConfig("reader", `p<a&ss"`))
+
+	assert.Contains(t, config, "<reader>")
+	assert.Contains(t, config, "<password>p&lt;a&amp;ss&#34;</password>")
+	assert.Contains(t, config, "</reader>")
+}
+
+func TestWithDatabases(t *testing.T) {
+	req := &testcontainers.GenericContainerRequest{}
+
+	WithDatabases("analytics", "odd`name")(req)
+	WithDatabases("reports")(req)
+
+	require.Len(t, req.Files, 1)
+	assert.Equal(t, databasesScript, req.Files[0].ContainerFilePath)
+
+	script, err := io.ReadAll(req.Files[0].Reader)
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE DATABASE IF NOT EXISTS `analytics`;\nCREATE DATABASE IF NOT EXISTS `odd``name`;\nCREATE DATABASE IF NOT EXISTS `reports`;\n", string(script))
+}
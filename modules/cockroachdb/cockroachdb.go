@@ -85,6 +85,11 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 							return addTLS(ctx, container, o)
 						},
 					},
+					PostReadies: []testcontainers.ContainerHook{
+						func(ctx context.Context, container testcontainers.Container) error {
+							return runStatements(ctx, container, o)
+						},
+					},
 				},
 			},
 		},
@@ -213,6 +218,49 @@ func addTLS(ctx context.Context, container testcontainers.Container, opts option
 	return nil
 }
 
+// runStatements runs the init statements, in order, over a connection to the database.
+func runStatements(ctx context.Context, container testcontainers.Container, opts options) error {
+	if len(opts.Statements) == 0 {
+		return nil
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	port, err := container.MappedPort(ctx, defaultSQLPort)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := pgx.ParseConfig(connString(opts, host, port))
+	if err != nil {
+		return err
+	}
+
+	if opts.TLS != nil {
+		cfg.TLSConfig, err = connTLS(opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connect to run the init statements: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	for _, statement := range opts.Statements {
+		if _, err := conn.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("run init statement %q: %w", statement, err)
+		}
+	}
+
+	return nil
+}
+
 func connString(opts options, host string, port nat.Port) string {
 	user := url.User(opts.User)
 	if opts.Password != "" {
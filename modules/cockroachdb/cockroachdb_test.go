@@ -144,6 +144,37 @@ func (suite *AuthNSuite) TestQuery() {
 	suite.Equal(523123, id)
 }
 
+func (suite *AuthNSuite) TestWithInitStatements() {
+	ctx := context.Background()
+
+	opts := append([]testcontainers.ContainerCustomizer{}, suite.opts...)
+	opts = append(opts,
+		// withInitStatements {
+		cockroachdb.WithInitStatements(
+			"CREATE TABLE accounts (id INT PRIMARY KEY, balance DECIMAL)",
+			"INSERT INTO accounts (id, balance) VALUES (1, 1000.50)",
+		),
+		// }
+	)
+
+	container, err := cockroachdb.RunContainer(ctx, opts...)
+	suite.Require().NoError(err)
+
+	suite.T().Cleanup(func() {
+		err := container.Terminate(ctx)
+		suite.Require().NoError(err)
+	})
+
+	conn, err := conn(ctx, container)
+	suite.Require().NoError(err)
+	defer conn.Close(ctx)
+
+	var balance string
+	err = conn.QueryRow(ctx, "SELECT balance::STRING FROM accounts WHERE id = 1").Scan(&balance)
+	suite.Require().NoError(err)
+	suite.Equal("1000.50", balance)
+}
+
 func conn(ctx context.Context, container *cockroachdb.CockroachDBContainer) (*pgx.Conn, error) {
 	cfg, err := pgx.ParseConfig(container.MustConnectionString(ctx))
 	if err != nil {
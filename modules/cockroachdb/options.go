@@ -8,6 +8,8 @@ type options struct {
 	Password  string
 	StoreSize string
 	TLS       *TLSConfig
+	// Statements are the SQL statements run once the container is ready.
+	Statements []string
 }
 
 func defaultOptions() options {
@@ -66,3 +68,12 @@ func WithTLS(cfg *TLSConfig) Option {
 		o.TLS = cfg
 	}
 }
+
+// WithInitStatements sets the SQL statements to run, in order, once the container is ready,
+// e.g. to create the schema of the database. The option can be used multiple times,
+// the statements being appended.
+func WithInitStatements(statements ...string) Option {
+	return func(o *options) {
+		o.Statements = append(o.Statements, statements...)
+	}
+}
@@ -3,9 +3,12 @@ package compose
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/cli/cli/command"
@@ -28,6 +31,9 @@ var ErrNoStackConfigured = errors.New("no stack files configured")
 type composeStackOptions struct {
 	Identifier string
 	Paths      []string
+	Readers    []io.Reader
+	EnvFiles   []string
+	Profiles   []string
 	Logger     testcontainers.Logging
 }
 
@@ -42,6 +48,14 @@ type stackUpOptions struct {
 	RemoveOrphans bool
 	// Wait won't return until containers reached the running|healthy state
 	Wait bool
+	// Build forces building the images of services declaring a build section, even if they already exist
+	Build bool
+	// Scale defines the number of replicas per service, overriding the ones defined in the compose files
+	Scale map[string]int
+	// AllHealthyTimeout defines the timeout to wait for all the services to be healthy, if greater than zero
+	AllHealthyTimeout time.Duration
+	// MaxConcurrency limits the number of services that are created and started concurrently, unlimited if not greater than zero
+	MaxConcurrency int
 	// Recreate define the strategy to apply on existing containers
 	Recreate string
 	// RecreateDependencies define the strategy to apply on dependencies services
@@ -68,9 +82,12 @@ type ComposeStack interface {
 	Down(ctx context.Context, opts ...StackDownOption) error
 	Services() []string
 	WaitForService(s string, strategy wait.Strategy) ComposeStack
+	WithLogConsumer(s string, consumers ...testcontainers.LogConsumer) ComposeStack
+	WithLogConsumerForAll(consumers ...testcontainers.LogConsumer) ComposeStack
 	WithEnv(m map[string]string) ComposeStack
 	WithOsEnv() ComposeStack
 	ServiceContainer(ctx context.Context, svcName string) (*testcontainers.DockerContainer, error)
+	ServiceContainers(ctx context.Context, svcName string) ([]*testcontainers.DockerContainer, error)
 }
 
 // Deprecated: DockerCompose is the old shell escape based API
@@ -94,6 +111,37 @@ func WithStackFiles(filePaths ...string) ComposeStackOption {
 	return ComposeStackFiles(filePaths)
 }
 
+// WithStackReaders sets the in-memory contents of the compose stack, read from the given readers.
+// The contents are merged in order, after the stack files, if any, so they can be used to override them.
+func WithStackReaders(readers ...io.Reader) ComposeStackOption {
+	return ComposeStackReaders(readers)
+}
+
+// WithStackStrings sets the in-memory contents of the compose stack.
+// The contents are merged in order, after the stack files, if any, so they can be used to override them.
+func WithStackStrings(contents ...string) ComposeStackOption {
+	readers := make([]io.Reader, len(contents))
+	for i, content := range contents {
+		readers[i] = strings.NewReader(content)
+	}
+
+	return ComposeStackReaders(readers)
+}
+
+// WithEnvFiles sets the env files used to interpolate the compose stack files.
+// If no env file is set, a '.env' file next to the first stack file is used, if present,
+// matching the behaviour of the docker compose CLI.
+func WithEnvFiles(filePaths ...string) ComposeStackOption {
+	return ComposeStackEnvFiles(filePaths)
+}
+
+// WithProfiles sets the compose profiles to be enabled when starting the stack.
+// Services without a profile are always enabled, while services assigned to profiles
+// are only enabled if at least one of their profiles is selected.
+func WithProfiles(profiles ...string) ComposeStackOption {
+	return ComposeProfiles(profiles)
+}
+
 func NewDockerCompose(filePaths ...string) (*dockerCompose, error) {
 	return NewDockerComposeWith(WithStackFiles(filePaths...))
 }
@@ -108,10 +156,19 @@ func NewDockerComposeWith(opts ...ComposeStackOption) (*dockerCompose, error) {
 		opts[i].applyToComposeStack(&composeOptions)
 	}
 
-	if len(composeOptions.Paths) < 1 {
+	if len(composeOptions.Paths) < 1 && len(composeOptions.Readers) < 1 {
 		return nil, ErrNoStackConfigured
 	}
 
+	contents := make([][]byte, len(composeOptions.Readers))
+	for i, r := range composeOptions.Readers {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("read stack content: %w", err)
+		}
+		contents[i] = content
+	}
+
 	dockerCli, err := command.NewDockerCli()
 	if err != nil {
 		return nil, err
@@ -124,10 +181,14 @@ func NewDockerComposeWith(opts ...ComposeStackOption) (*dockerCompose, error) {
 	composeAPI := &dockerCompose{
 		name:           composeOptions.Identifier,
 		configs:        composeOptions.Paths,
+		contents:       contents,
+		envFiles:       composeOptions.EnvFiles,
+		profiles:       composeOptions.Profiles,
 		logger:         composeOptions.Logger,
 		composeService: compose.NewComposeService(dockerCli),
 		dockerClient:   dockerCli.Client(),
 		waitStrategies: make(map[string]wait.Strategy),
+		logConsumers:   make(map[string][]testcontainers.LogConsumer),
 		containers:     make(map[string]*testcontainers.DockerContainer),
 	}
 
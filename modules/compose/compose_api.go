@@ -2,18 +2,28 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/cli"
+	"github.com/compose-spec/compose-go/v2/loader"
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/compose/v2/pkg/api"
+	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
 	"golang.org/x/sync/errgroup"
 
 	testcontainers "github.com/testcontainers/testcontainers-go"
@@ -26,22 +36,55 @@ func (f stackUpOptionFunc) applyToStackUp(o *stackUpOptions) {
 	f(o)
 }
 
-//nolint:unused
-type stackDownOptionFunc func(do *api.DownOptions)
+type stackDownOptionFunc func(do *stackDownOptions)
 
-//nolint:unused
-func (f stackDownOptionFunc) applyToStackDown(do *api.DownOptions) {
+func (f stackDownOptionFunc) applyToStackDown(do *stackDownOptions) {
 	f(do)
 }
 
 // RunServices is comparable to 'docker-compose run' as it only creates a subset of containers
-// instead of all services defined by the project
+// instead of all services defined by the project.
+// The services the given ones depend on are started as well, see Services.
 func RunServices(serviceNames ...string) StackUpOption {
+	return Services(serviceNames...)
+}
+
+// Services starts only the given services instead of all services defined by the project,
+// together with the services they depend on, transitively, like 'docker compose up <service>...' does.
+func Services(serviceNames ...string) StackUpOption {
 	return stackUpOptionFunc(func(o *stackUpOptions) {
 		o.Services = serviceNames
 	})
 }
 
+// WithScale sets the number of replicas of the given service, overriding the scale
+// defined in the compose files, like 'docker compose up --scale' does
+func WithScale(service string, replicas int) StackUpOption {
+	return stackUpOptionFunc(func(o *stackUpOptions) {
+		if o.Scale == nil {
+			o.Scale = make(map[string]int)
+		}
+		o.Scale[service] = replicas
+	})
+}
+
+// WaitForAllHealthy won't return until every service declaring a healthcheck is healthy
+// and every port published by the services is listening, or the timeout is reached.
+// On timeout, the returned error contains the reason for each service that is not healthy.
+func WaitForAllHealthy(timeout time.Duration) StackUpOption {
+	return stackUpOptionFunc(func(o *stackUpOptions) {
+		o.AllHealthyTimeout = timeout
+	})
+}
+
+// MaxConcurrency limits the number of services that are created and started concurrently.
+// By default, all the services whose dependencies are satisfied are started at the same time.
+func MaxConcurrency(parallel int) StackUpOption {
+	return stackUpOptionFunc(func(o *stackUpOptions) {
+		o.MaxConcurrency = parallel
+	})
+}
+
 // IgnoreOrphans - Ignore legacy containers for services that are not defined in the project
 type IgnoreOrphans bool
 
@@ -68,6 +111,17 @@ func (w Wait) applyToStackUp(o *stackUpOptions) {
 	o.Wait = bool(w)
 }
 
+// Build forces building the images of the services declaring a build section before starting the containers,
+// even if the images already exist, like 'docker compose up --build' does.
+// Otherwise, images are only built if they don't exist yet.
+type Build bool
+
+func (b Build) applyToStackUp(o *stackUpOptions) {
+	o.Build = bool(b)
+}
+
+// RemoveVolumes will remove the named volumes declared in the volumes section of the compose model
+// and the anonymous volumes attached to the containers
 type RemoveVolumes bool
 
 func (ro RemoveVolumes) applyToStackDown(o *stackDownOptions) {
@@ -92,6 +146,27 @@ func (f ComposeStackFiles) applyToComposeStack(o *composeStackOptions) {
 	o.Paths = f
 }
 
+// ComposeStackEnvFiles defines the env files used to interpolate the compose stack files
+type ComposeStackEnvFiles []string
+
+func (f ComposeStackEnvFiles) applyToComposeStack(o *composeStackOptions) {
+	o.EnvFiles = f
+}
+
+// ComposeProfiles defines the compose profiles to be enabled in the stack
+type ComposeProfiles []string
+
+func (p ComposeProfiles) applyToComposeStack(o *composeStackOptions) {
+	o.Profiles = p
+}
+
+// ComposeStackReaders defines the in-memory contents of the compose stack, read from readers
+type ComposeStackReaders []io.Reader
+
+func (r ComposeStackReaders) applyToComposeStack(o *composeStackOptions) {
+	o.Readers = append(o.Readers, r...)
+}
+
 type StackIdentifier string
 
 func (f StackIdentifier) applyToComposeStack(o *composeStackOptions) {
@@ -102,6 +177,14 @@ func (f StackIdentifier) String() string {
 	return string(f)
 }
 
+// DownTimeout sets the timeout to wait for the containers of the stack to stop gracefully
+// before they are killed
+func DownTimeout(timeout time.Duration) StackDownOption {
+	return stackDownOptionFunc(func(do *stackDownOptions) {
+		do.Timeout = &timeout
+	})
+}
+
 const (
 	// RemoveImagesAll - remove all images used by the stack
 	RemoveImagesAll RemoveImages = iota
@@ -120,6 +203,18 @@ type dockerCompose struct {
 	// paths to stack files that will be considered when compiling the final compose project
 	configs []string
 
+	// in-memory contents of stack files, considered after the stack files
+	// when compiling the final compose project
+	contents [][]byte
+
+	// paths to env files that will be used to interpolate the stack files
+	// if empty, the '.env' file of the project working directory is used, if present
+	envFiles []string
+
+	// compose profiles to be enabled when compiling the project
+	// services without profiles are always enabled
+	profiles []string
+
 	// used to set logger in DockerContainer
 	logger testcontainers.Logging
 
@@ -127,6 +222,19 @@ type dockerCompose struct {
 	// only one strategy can be added to a service, to use multiple use wait.ForAll(...)
 	waitStrategies map[string]wait.Strategy
 
+	// log consumers that are attached per service when starting the stack
+	logConsumers map[string][]testcontainers.LogConsumer
+
+	// log consumers that are attached to all the services when starting the stack
+	allServicesLogConsumers []testcontainers.LogConsumer
+
+	// cancels the production of logs for the log consumers, and waits for it to finish
+	stopLogProduction func()
+
+	// releases the connection to the reaper, which will then remove the resources of the stack
+	// nil if the reaper is disabled or the stack wasn't started yet
+	terminationSignal chan bool
+
 	// used to synchronise writes to the containers map
 	containersLock sync.RWMutex
 
@@ -149,6 +257,9 @@ type dockerCompose struct {
 	project *types.Project
 }
 
+// ServiceContainer returns the container of the given service, which can be used just like
+// the containers created with testcontainers.GenericContainer, e.g. to get its mapped ports,
+// execute commands, read its logs or copy files.
 func (d *dockerCompose) ServiceContainer(ctx context.Context, svcName string) (*testcontainers.DockerContainer, error) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -156,6 +267,35 @@ func (d *dockerCompose) ServiceContainer(ctx context.Context, svcName string) (*
 	return d.lookupContainer(ctx, svcName)
 }
 
+// ServiceContainers returns the containers of all the replicas of the given service,
+// ordered by their replica number.
+func (d *dockerCompose) ServiceContainers(ctx context.Context, svcName string) ([]*testcontainers.DockerContainer, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	containers, err := d.listServiceContainers(ctx, svcName)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerProvider, err := d.dockerProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := make([]*testcontainers.DockerContainer, 0, len(containers))
+	for _, c := range containers {
+		replica, err := dockerProvider.ContainerFromDockerResponse(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+
+		replicas = append(replicas, replica)
+	}
+
+	return replicas, nil
+}
+
 func (d *dockerCompose) Services() []string {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -177,7 +317,29 @@ func (d *dockerCompose) Down(ctx context.Context, opts ...StackDownOption) error
 		opts[i].applyToStackDown(&options)
 	}
 
-	return d.composeService.Down(ctx, d.name, options.DownOptions)
+	if d.stopLogProduction != nil {
+		d.stopLogProduction()
+		d.stopLogProduction = nil
+	}
+
+	if err := d.composeService.Down(ctx, d.name, options.DownOptions); err != nil {
+		return err
+	}
+
+	select {
+	// close reaper connection if it was created
+	case d.terminationSignal <- true:
+	default:
+	}
+	d.terminationSignal = nil
+
+	// the containers are gone, so the cache must not be used if the stack is started again
+	d.containersLock.Lock()
+	defer d.containersLock.Unlock()
+
+	d.containers = make(map[string]*testcontainers.DockerContainer)
+
+	return nil
 }
 
 func (d *dockerCompose) Up(ctx context.Context, opts ...StackUpOption) error {
@@ -203,18 +365,34 @@ func (d *dockerCompose) Up(ctx context.Context, opts ...StackUpOption) error {
 	}
 
 	if len(upOptions.Services) != len(d.project.Services) {
-		sort.Strings(upOptions.Services)
+		d.project, err = selectServices(d.project, upOptions.Services)
+		if err != nil {
+			return err
+		}
 
-		filteredServices := types.Services{}
+		upOptions.Services = d.project.ServiceNames()
+		upOptions.Project = d.project
+	}
 
-		for _, srv := range upOptions.Services {
-			if srvConfig, ok := d.project.Services[srv]; ok {
-				filteredServices[srv] = srvConfig
-			}
-		}
+	if upOptions.Build {
+		forceBuild(d.project)
+	}
+
+	if err := scaleServices(d.project, upOptions.Scale); err != nil {
+		return err
+	}
+
+	if err := d.connectReaper(ctx); err != nil {
+		return err
+	}
 
-		d.project.Services = filteredServices
+	// services are created and started concurrently, following the dependency graph of the project:
+	// each service waits for its dependencies to meet the condition declared in its depends_on section
+	concurrency := -1
+	if upOptions.MaxConcurrency > 0 {
+		concurrency = upOptions.MaxConcurrency
 	}
+	d.composeService.MaxConcurrency(concurrency)
 
 	err = d.composeService.Up(ctx, d.project, api.UpOptions{
 		Create: api.CreateOptions{
@@ -236,26 +414,146 @@ func (d *dockerCompose) Up(ctx context.Context, opts ...StackUpOption) error {
 		return err
 	}
 
-	if len(d.waitStrategies) == 0 {
+	if err := d.followLogs(ctx, upOptions.Services); err != nil {
+		return err
+	}
+
+	if len(d.waitStrategies) > 0 {
+		errGrp, errGrpCtx := errgroup.WithContext(ctx)
+
+		for svc, strategy := range d.waitStrategies { // pinning the variables
+			if _, ok := d.project.Services[svc]; !ok {
+				// the service was not selected to be started
+				continue
+			}
+
+			svc := svc
+			strategy := strategy
+
+			errGrp.Go(func() error {
+				target, err := d.lookupContainer(errGrpCtx, svc)
+				if err != nil {
+					return err
+				}
+				return strategy.WaitUntilReady(errGrpCtx, target)
+			})
+		}
+
+		if err := errGrp.Wait(); err != nil {
+			return err
+		}
+	}
+
+	if upOptions.AllHealthyTimeout > 0 {
+		return d.waitForAllHealthy(ctx, upOptions.Services, upOptions.AllHealthyTimeout)
+	}
+
+	return nil
+}
+
+// connectReaper makes sure the reaper is running and keeps a connection to it open until the stack is stopped,
+// so that the labelled resources of the stack are removed even if Down is never called.
+func (d *dockerCompose) connectReaper(ctx context.Context) error {
+	if d.terminationSignal != nil {
+		return nil
+	}
+
+	dockerProvider, err := d.dockerProvider()
+	if err != nil {
+		return err
+	}
+
+	if dockerProvider.Config().Config.RyukDisabled {
 		return nil
 	}
 
-	errGrp, errGrpCtx := errgroup.WithContext(ctx)
+	// NewReaper is deprecated for end users, but it is the only way to share the session reaper with a compose stack
+	r, err := testcontainers.NewReaper(ctx, testcontainers.SessionID(), dockerProvider, "") //nolint:staticcheck
+	if err != nil {
+		return fmt.Errorf("%w: creating reaper failed", err)
+	}
+
+	d.terminationSignal, err = r.Connect()
+	if err != nil {
+		return fmt.Errorf("%w: connecting to reaper failed", err)
+	}
+
+	return nil
+}
 
-	for svc, strategy := range d.waitStrategies { // pinning the variables
-		svc := svc
-		strategy := strategy
+// waitForAllHealthy waits for all the given services to be healthy, in parallel.
+// It does not fail fast, so that the returned error contains the reason for each service that is not healthy.
+func (d *dockerCompose) waitForAllHealthy(ctx context.Context, services []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		errsLock sync.Mutex
+		errs     []error
+		wg       sync.WaitGroup
+	)
+
+	for _, svc := range services {
+		strategy := healthStrategy(d.project.Services[svc], timeout)
+		if strategy == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(svc string, strategy wait.Strategy) {
+			defer wg.Done()
+
+			target, err := d.lookupContainer(ctx, svc)
+			if err == nil {
+				err = strategy.WaitUntilReady(ctx, target)
+			}
 
-		errGrp.Go(func() error {
-			target, err := d.lookupContainer(errGrpCtx, svc)
 			if err != nil {
-				return err
+				errsLock.Lock()
+				defer errsLock.Unlock()
+
+				errs = append(errs, fmt.Errorf("service %s: %w", svc, err))
 			}
-			return strategy.WaitUntilReady(errGrpCtx, target)
+		}(svc, strategy)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool {
+			return errs[i].Error() < errs[j].Error()
 		})
+
+		return fmt.Errorf("stack is not healthy after %s: %w", timeout, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// healthStrategy returns the wait strategy checking that the service is healthy, if it declares a healthcheck,
+// and that all its ports are listening. It returns nil if there is nothing to wait for.
+func healthStrategy(s types.ServiceConfig, timeout time.Duration) wait.Strategy {
+	var strategies []wait.Strategy
+
+	if s.HealthCheck != nil && !s.HealthCheck.Disable {
+		strategies = append(strategies, wait.ForHealthCheck().WithStartupTimeout(timeout))
+	}
+
+	for _, p := range s.Ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+
+		port := nat.Port(fmt.Sprintf("%d/%s", p.Target, protocol))
+		strategies = append(strategies, wait.ForListeningPort(port).WithStartupTimeout(timeout))
+	}
+
+	if len(strategies) == 0 {
+		return nil
 	}
 
-	return errGrp.Wait()
+	return wait.ForAll(strategies...).WithDeadline(timeout)
 }
 
 func (d *dockerCompose) WaitForService(s string, strategy wait.Strategy) ComposeStack {
@@ -266,6 +564,26 @@ func (d *dockerCompose) WaitForService(s string, strategy wait.Strategy) Compose
 	return d
 }
 
+// WithLogConsumer attaches the given log consumers to the container of the given service,
+// once the stack is started. The logs are produced until the stack is stopped.
+func (d *dockerCompose) WithLogConsumer(s string, consumers ...testcontainers.LogConsumer) ComposeStack {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.logConsumers[s] = append(d.logConsumers[s], consumers...)
+	return d
+}
+
+// WithLogConsumerForAll attaches the given log consumers to the containers of all the services
+// of the stack, once the stack is started. The logs are produced until the stack is stopped.
+func (d *dockerCompose) WithLogConsumerForAll(consumers ...testcontainers.LogConsumer) ComposeStack {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.allServicesLogConsumers = append(d.allServicesLogConsumers, consumers...)
+	return d
+}
+
 func (d *dockerCompose) WithEnv(m map[string]string) ComposeStack {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -282,6 +600,83 @@ func (d *dockerCompose) WithOsEnv() ComposeStack {
 	return d
 }
 
+// followLogs starts producing the logs of the given services for their log consumers, if any.
+// The production of logs is not bound to the given context, but to the lifecycle of the stack.
+func (d *dockerCompose) followLogs(ctx context.Context, services []string) error {
+	if d.stopLogProduction != nil {
+		d.stopLogProduction()
+		d.stopLogProduction = nil
+	}
+
+	logsCtx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+
+	d.stopLogProduction = func() {
+		cancel()
+		wg.Wait()
+	}
+
+	for _, svc := range services {
+		consumers := make([]testcontainers.LogConsumer, 0, len(d.allServicesLogConsumers)+len(d.logConsumers[svc]))
+		consumers = append(consumers, d.allServicesLogConsumers...)
+		consumers = append(consumers, d.logConsumers[svc]...)
+
+		if len(consumers) == 0 {
+			continue
+		}
+
+		target, err := d.lookupContainer(ctx, svc)
+		if err != nil {
+			return err
+		}
+
+		rc, err := d.dockerClient.ContainerLogs(logsCtx, target.GetContainerID(), container.LogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Follow:     true,
+		})
+		if err != nil {
+			return fmt.Errorf("follow logs of service %s: %w", svc, err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer rc.Close()
+
+			stdout := &logConsumerWriter{logType: testcontainers.StdoutLog, consumers: consumers}
+			stderr := &logConsumerWriter{logType: testcontainers.StderrLog, consumers: consumers}
+
+			// the copy returns once the container stops or the stack is stopped
+			_, _ = stdcopy.StdCopy(stdout, stderr, rc)
+		}()
+	}
+
+	return nil
+}
+
+// logConsumerWriter is an io.Writer sending everything written to it to the log consumers,
+// using the given log type
+type logConsumerWriter struct {
+	logType   string
+	consumers []testcontainers.LogConsumer
+}
+
+func (w *logConsumerWriter) Write(p []byte) (int, error) {
+	// the log consumers could retain the content, so it must not be reused
+	content := make([]byte, len(p))
+	copy(content, p)
+
+	for _, c := range w.consumers {
+		c.Accept(testcontainers.Log{
+			LogType: w.logType,
+			Content: content,
+		})
+	}
+
+	return len(p), nil
+}
+
 func (d *dockerCompose) lookupContainer(ctx context.Context, svcName string) (*testcontainers.DockerContainer, error) {
 	d.containersLock.Lock()
 	defer d.containersLock.Unlock()
@@ -290,6 +685,29 @@ func (d *dockerCompose) lookupContainer(ctx context.Context, svcName string) (*t
 		return container, nil
 	}
 
+	containers, err := d.listServiceContainers(ctx, svcName)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerProvider, err := d.dockerProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := dockerProvider.ContainerFromDockerResponse(ctx, containers[0])
+	if err != nil {
+		return nil, err
+	}
+
+	d.containers[svcName] = container
+
+	return container, nil
+}
+
+// listServiceContainers returns the containers of the given service, ordered by their replica number.
+// It returns an error if no container is found.
+func (d *dockerCompose) listServiceContainers(ctx context.Context, svcName string) ([]dockertypes.Container, error) {
 	listOptions := container.ListOptions{
 		All: true,
 		Filters: filters.NewArgs(
@@ -307,13 +725,26 @@ func (d *dockerCompose) lookupContainer(ctx context.Context, svcName string) (*t
 		return nil, fmt.Errorf("no container found for service name %s", svcName)
 	}
 
-	containerInstance := containers[0]
-	container := &testcontainers.DockerContainer{
-		ID:    containerInstance.ID,
-		Image: containerInstance.Image,
+	sort.SliceStable(containers, func(i, j int) bool {
+		return containerNumber(containers[i]) < containerNumber(containers[j])
+	})
+
+	return containers, nil
+}
+
+// containerNumber returns the replica number of a service container,
+// as set by compose in the container labels
+func containerNumber(c dockertypes.Container) int {
+	n, err := strconv.Atoi(c.Labels[api.ContainerNumberLabel])
+	if err != nil {
+		return 0
 	}
-	container.SetLogger(d.logger)
 
+	return n
+}
+
+// dockerProvider returns a Docker provider using the Docker client of the stack
+func (d *dockerCompose) dockerProvider() (*testcontainers.DockerProvider, error) {
 	dockerProvider, err := testcontainers.NewDockerProvider(testcontainers.WithLogger(d.logger))
 	if err != nil {
 		return nil, err
@@ -321,26 +752,34 @@ func (d *dockerCompose) lookupContainer(ctx context.Context, svcName string) (*t
 
 	dockerProvider.SetClient(d.dockerClient)
 
-	container.SetProvider(dockerProvider)
-
-	d.containers[svcName] = container
-
-	return container, nil
+	return dockerProvider, nil
 }
 
 func (d *dockerCompose) compileProject() (*types.Project, error) {
-	const nameAndDefaultConfigPath = 2
-	projectOptions := make([]cli.ProjectOptionsFn, len(d.projectOptions), len(d.projectOptions)+nameAndDefaultConfigPath)
+	const nameDefaultConfigPathEnvFilesAndProfiles = 5
+	projectOptions := make([]cli.ProjectOptionsFn, len(d.projectOptions), len(d.projectOptions)+nameDefaultConfigPathEnvFilesAndProfiles)
 
 	copy(projectOptions, d.projectOptions)
-	projectOptions = append(projectOptions, cli.WithName(d.name), cli.WithDefaultConfigPath)
+	projectOptions = append(projectOptions, cli.WithName(d.name))
+	if len(d.contents) == 0 {
+		// look for the default stack files only if there are no in-memory contents
+		projectOptions = append(projectOptions, cli.WithDefaultConfigPath)
+	}
+	// env files are loaded last so that explicitly set variables take precedence, like the docker compose CLI does
+	projectOptions = append(projectOptions, cli.WithEnvFiles(d.envFiles...), cli.WithDotEnv)
+	projectOptions = append(projectOptions, cli.WithProfiles(d.profiles))
 
 	compiledOptions, err := cli.NewProjectOptions(d.configs, projectOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	proj, err := cli.ProjectFromOptions(compiledOptions)
+	var proj *types.Project
+	if len(d.contents) == 0 {
+		proj, err = cli.ProjectFromOptions(compiledOptions)
+	} else {
+		proj, err = d.projectFromContents(compiledOptions)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -358,13 +797,134 @@ func (d *dockerCompose) compileProject() (*types.Project, error) {
 			// add a label for each env file, indexed by its position
 			s.CustomLabels[fmt.Sprintf("%s.%d", api.EnvironmentFileLabel, i)] = envFile
 		}
+		// the session labels allow the reaper to remove the containers of the stack
+		for k, v := range testcontainers.GenericLabels() {
+			s.CustomLabels[k] = v
+		}
 
 		proj.Services[i] = s
 	}
 
+	// networks and volumes created by the stack are labelled too, so that the reaper removes them as well.
+	// External resources are not managed by the stack, so they are left untouched.
+	for n, network := range proj.Networks {
+		if network.External {
+			continue
+		}
+		network.Labels = addGenericLabels(network.Labels)
+		proj.Networks[n] = network
+	}
+
+	for v, volume := range proj.Volumes {
+		if volume.External {
+			continue
+		}
+		volume.Labels = addGenericLabels(volume.Labels)
+		proj.Volumes[v] = volume
+	}
+
+	return proj, nil
+}
+
+// addGenericLabels returns the given labels with the testcontainers session labels added.
+func addGenericLabels(labels types.Labels) types.Labels {
+	if labels == nil {
+		labels = types.Labels{}
+	}
+
+	for k, v := range testcontainers.GenericLabels() {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+// projectFromContents loads the compose project from the stack files and the in-memory contents,
+// which are merged in order after the stack files.
+func (d *dockerCompose) projectFromContents(options *cli.ProjectOptions) (*types.Project, error) {
+	workingDir, err := options.GetWorkingDir()
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make([]types.ConfigFile, 0, len(options.ConfigPaths)+len(d.contents))
+	for _, p := range options.ConfigPaths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := os.ReadFile(abs)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, types.ConfigFile{Filename: abs, Content: content})
+	}
+
+	for i, content := range d.contents {
+		configs = append(configs, types.ConfigFile{
+			// a virtual file name, relative to the working dir, used to identify the content in errors
+			Filename: filepath.Join(workingDir, fmt.Sprintf("%s-stack-%d.yml", d.name, i)),
+			Content:  content,
+		})
+	}
+
+	proj, err := loader.Load(types.ConfigDetails{
+		ConfigFiles: configs,
+		WorkingDir:  workingDir,
+		Environment: options.Environment,
+	}, func(o *loader.Options) {
+		o.SetProjectName(d.name, true)
+	}, loader.WithProfiles(d.profiles))
+	if err != nil {
+		return nil, err
+	}
+
+	proj.ComposeFiles = options.ConfigPaths
+
 	return proj, nil
 }
 
+// selectServices returns a copy of the project containing only the given services
+// and the services they depend on, transitively.
+func selectServices(project *types.Project, services []string) (*types.Project, error) {
+	selected, err := project.WithSelectedServices(services, types.IncludeDependencies)
+	if err != nil {
+		return nil, fmt.Errorf("select services: %w", err)
+	}
+
+	return selected, nil
+}
+
+// forceBuild sets the pull policy of the services declaring a build section to 'build',
+// so that their images are always built, even if they already exist.
+func forceBuild(project *types.Project) {
+	for name, s := range project.Services {
+		if s.Build == nil {
+			continue
+		}
+
+		s.PullPolicy = types.PullPolicyBuild
+		project.Services[name] = s
+	}
+}
+
+// scaleServices sets the number of replicas of the given services
+func scaleServices(project *types.Project, scale map[string]int) error {
+	for name, replicas := range scale {
+		s, ok := project.Services[name]
+		if !ok {
+			return fmt.Errorf("no service %s found to scale", name)
+		}
+
+		s.SetScale(replicas)
+		project.Services[name] = s
+	}
+
+	return nil
+}
+
 func withEnv(env map[string]string) func(*cli.ProjectOptions) error {
 	return func(options *cli.ProjectOptions) error {
 		for k, v := range env {
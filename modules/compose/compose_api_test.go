@@ -1,13 +1,20 @@
 package compose
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"hash/fnv"
+	"io"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/google/uuid"
@@ -118,6 +125,60 @@ func TestDockerComposeAPIWithRunServices(t *testing.T) {
 	assert.Contains(t, serviceNames, "nginx")
 }
 
+func TestDockerComposeAPIWithServicesAndDependencies(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-depends-on.yml")
+	compose, err := NewDockerCompose(path)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	require.NoError(t, compose.Up(ctx, Wait(true), Services("app")), "compose.Up()")
+
+	serviceNames := compose.Services()
+	assert.ElementsMatch(t, []string{"app", "cache", "db"}, serviceNames)
+
+	for _, svc := range serviceNames {
+		_, err := compose.ServiceContainer(ctx, svc)
+		require.NoError(t, err, "compose.ServiceContainer()")
+	}
+}
+
+func TestSelectServices(t *testing.T) {
+	compose := &dockerCompose{
+		name:    "select-services",
+		configs: []string{filepath.Join(testdataPackage, "docker-compose-depends-on.yml")},
+	}
+
+	project, err := compose.compileProject()
+	require.NoError(t, err, "compose.compileProject()")
+
+	t.Run("with-dependencies", func(t *testing.T) {
+		selected, err := selectServices(project, []string{"app"})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"app", "cache", "db"}, selected.ServiceNames())
+	})
+
+	t.Run("without-dependencies", func(t *testing.T) {
+		selected, err := selectServices(project, []string{"cache", "db"})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"cache", "db"}, selected.ServiceNames())
+		// the original project is left untouched
+		assert.Len(t, project.Services, 3)
+	})
+
+	t.Run("unknown-service", func(t *testing.T) {
+		_, err := selectServices(project, []string{"unknown"})
+		require.Error(t, err)
+	})
+}
+
 func TestDockerComposeAPIWithStopServices(t *testing.T) {
 	path := filepath.Join(testdataPackage, complexCompose)
 	compose, err := NewDockerComposeWith(
@@ -155,6 +216,144 @@ func TestDockerComposeAPIWithStopServices(t *testing.T) {
 	assert.Equal(t, "exited", state.Status)
 }
 
+func TestDockerComposeAPIServiceContainer(t *testing.T) {
+	path := filepath.Join(testdataPackage, simpleCompose)
+	compose, err := NewDockerCompose(path)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	require.NoError(t, compose.Up(ctx, Wait(true)), "compose.Up()")
+
+	var nginx testcontainers.Container
+	nginx, err = compose.ServiceContainer(ctx, "nginx")
+	require.NoError(t, err, "compose.ServiceContainer()")
+
+	assert.True(t, nginx.IsRunning())
+	assert.NotEmpty(t, nginx.SessionID())
+
+	port, err := nginx.MappedPort(ctx, "80/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, "9080", port.Port())
+
+	code, _, err := nginx.Exec(ctx, []string{"ls", "/usr/share/nginx/html/index.html"})
+	require.NoError(t, err)
+	assert.Zero(t, code)
+
+	logs, err := nginx.Logs(ctx)
+	require.NoError(t, err)
+	require.NoError(t, logs.Close())
+}
+
+func TestDockerComposeAPIWithLogConsumers(t *testing.T) {
+	path := filepath.Join(testdataPackage, complexCompose)
+	compose, err := NewDockerCompose(path)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	nginxLogs := &testLogConsumer{}
+	allLogs := &testLogConsumer{}
+
+	err = compose.
+		WithLogConsumer("nginx", nginxLogs).
+		WithLogConsumerForAll(allLogs).
+		WaitForService("mysql", wait.NewLogStrategy("started").WithStartupTimeout(10*time.Second).WithOccurrence(1)).
+		Up(ctx, Wait(true))
+	require.NoError(t, err, "compose.Up()")
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(nginxLogs.String(), "start worker process")
+	}, 10*time.Second, 100*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		all := allLogs.String()
+		return strings.Contains(all, "start worker process") && strings.Contains(all, "started")
+	}, 10*time.Second, 100*time.Millisecond)
+
+	assert.NotContains(t, nginxLogs.String(), "mysqld")
+}
+
+func TestLogConsumerWriter(t *testing.T) {
+	consumers := []testcontainers.LogConsumer{&testLogConsumer{}, &testLogConsumer{}}
+
+	w := &logConsumerWriter{logType: testcontainers.StderrLog, consumers: consumers}
+
+	content := []byte("hello compose")
+	n, err := w.Write(content)
+	require.NoError(t, err)
+	assert.Equal(t, len(content), n)
+
+	// the consumers must not be affected by the reuse of the buffer
+	copy(content, "HELLO")
+
+	for _, c := range consumers {
+		lc := c.(*testLogConsumer)
+		require.Len(t, lc.logs, 1)
+		assert.Equal(t, testcontainers.StderrLog, lc.logs[0].LogType)
+		assert.Equal(t, "hello compose", string(lc.logs[0].Content))
+	}
+}
+
+func TestDockerComposeAPIWithScale(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-scale.yml")
+	compose, err := NewDockerCompose(path)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	require.NoError(t, compose.Up(ctx, Wait(true), WithScale("nginx", 3)), "compose.Up()")
+
+	replicas, err := compose.ServiceContainers(ctx, "nginx")
+	require.NoError(t, err, "compose.ServiceContainers()")
+	require.Len(t, replicas, 3)
+
+	ports := map[string]bool{}
+	for _, replica := range replicas {
+		port, err := replica.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		ports[port.Port()] = true
+	}
+	assert.Len(t, ports, 3, "each replica must be exposed on its own port")
+
+	first, err := compose.ServiceContainer(ctx, "nginx")
+	require.NoError(t, err, "compose.ServiceContainer()")
+	assert.Equal(t, replicas[0].GetContainerID(), first.GetContainerID())
+}
+
+func TestScaleServices(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-scale.yml")
+
+	compose := &dockerCompose{
+		name:    "scale",
+		configs: []string{path},
+	}
+
+	project, err := compose.compileProject()
+	require.NoError(t, err, "compose.compileProject()")
+
+	require.NoError(t, scaleServices(project, map[string]int{"nginx": 3}))
+	nginx := project.Services["nginx"]
+	assert.Equal(t, 3, nginx.GetScale())
+
+	require.Error(t, scaleServices(project, map[string]int{"mysql": 2}))
+}
+
 func TestDockerComposeAPIWithWaitForService(t *testing.T) {
 	path := filepath.Join(testdataPackage, simpleCompose)
 	compose, err := NewDockerCompose(path)
@@ -260,6 +459,83 @@ func TestDockerComposeAPIWithWaitStrategy_NoExposedPorts(t *testing.T) {
 	assert.Contains(t, serviceNames, "nginx")
 }
 
+func TestDockerComposeAPIWaitForAllHealthy(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-healthcheck.yml")
+	compose, err := NewDockerCompose(path)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	require.NoError(t, compose.Up(ctx, WaitForAllHealthy(30*time.Second)), "compose.Up()")
+
+	nginx, err := compose.ServiceContainer(ctx, "nginx")
+	require.NoError(t, err, "compose.ServiceContainer()")
+
+	state, err := nginx.State(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, state.Health)
+	assert.Equal(t, "healthy", state.Health.Status)
+}
+
+func TestDockerComposeAPIWithDependsOn(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-depends-on.yml")
+	compose, err := NewDockerCompose(path)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	require.NoError(t, compose.Up(ctx, Wait(true), MaxConcurrency(2)), "compose.Up()")
+
+	for _, svc := range []string{"db", "cache", "app"} {
+		c, err := compose.ServiceContainer(ctx, svc)
+		require.NoError(t, err, "compose.ServiceContainer()")
+
+		state, err := c.State(ctx)
+		require.NoError(t, err)
+		assert.True(t, state.Running, "service %s is not running", svc)
+	}
+}
+
+func TestHealthStrategy(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-healthcheck.yml")
+
+	compose := &dockerCompose{
+		name:    "health-strategy",
+		configs: []string{path},
+	}
+
+	project, err := compose.compileProject()
+	require.NoError(t, err, "compose.compileProject()")
+
+	t.Run("healthcheck-and-ports", func(t *testing.T) {
+		strategy := healthStrategy(project.Services["nginx"], 10*time.Second)
+		require.NotNil(t, strategy)
+
+		multi, ok := strategy.(*wait.MultiStrategy)
+		require.True(t, ok)
+		require.Len(t, multi.Strategies, 2)
+		assert.IsType(t, &wait.HealthStrategy{}, multi.Strategies[0])
+
+		hp, ok := multi.Strategies[1].(*wait.HostPortStrategy)
+		require.True(t, ok)
+		assert.Equal(t, "80/tcp", string(hp.Port))
+	})
+
+	t.Run("nothing-to-wait-for", func(t *testing.T) {
+		assert.Nil(t, healthStrategy(project.Services["alpine"], 10*time.Second))
+	})
+}
+
 func TestDockerComposeAPIWithMultipleWaitStrategies(t *testing.T) {
 	path := filepath.Join(testdataPackage, complexCompose)
 	compose, err := NewDockerCompose(path)
@@ -370,6 +646,174 @@ func TestDockerComposeAPIWithEnvironment(t *testing.T) {
 	assertContainerEnvironmentVariables(t, identifier.String(), "nginx", present, absent)
 }
 
+func TestDockerComposeAPIWithEnvFile(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-env-file.yml")
+	envFile := filepath.Join(testdataPackage, "docker-compose-env-file.env")
+
+	t.Run("env-file-and-defaults", func(t *testing.T) {
+		compose := &dockerCompose{
+			name:     "env-file",
+			configs:  []string{path},
+			envFiles: []string{envFile},
+		}
+
+		project, err := compose.compileProject()
+		require.NoError(t, err, "compose.compileProject()")
+
+		env := project.Services["nginx"].Environment
+		require.NotNil(t, env["bar"])
+		assert.Equal(t, "BAR_FROM_FILE", *env["bar"])
+		require.NotNil(t, env["foo"])
+		assert.Equal(t, "FOO_DEFAULT", *env["foo"])
+	})
+
+	t.Run("explicit-env-takes-precedence", func(t *testing.T) {
+		compose := &dockerCompose{
+			name:     "env-file-override",
+			configs:  []string{path},
+			envFiles: []string{envFile},
+		}
+		compose.projectOptions = append(compose.projectOptions, withEnv(map[string]string{
+			"bar": "BAR",
+			"foo": "FOO",
+		}))
+
+		project, err := compose.compileProject()
+		require.NoError(t, err, "compose.compileProject()")
+
+		env := project.Services["nginx"].Environment
+		require.NotNil(t, env["bar"])
+		assert.Equal(t, "BAR", *env["bar"])
+		require.NotNil(t, env["foo"])
+		assert.Equal(t, "FOO", *env["foo"])
+	})
+}
+
+func TestDockerComposeAPIWithProfiles(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-profiles.yml")
+
+	testCases := []struct {
+		name     string
+		profiles []string
+		expected []string
+	}{
+		{
+			name:     "no-profiles",
+			expected: []string{"nginx"},
+		},
+		{
+			name:     "single-profile",
+			profiles: []string{"integration"},
+			expected: []string{"nginx", "postgres"},
+		},
+		{
+			name:     "multiple-profiles",
+			profiles: []string{"integration", "observability"},
+			expected: []string{"grafana", "nginx", "postgres"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			compose := &dockerCompose{
+				name:     tc.name,
+				configs:  []string{path},
+				profiles: tc.profiles,
+			}
+
+			project, err := compose.compileProject()
+			require.NoError(t, err, "compose.compileProject()")
+
+			serviceNames := project.ServiceNames()
+			sort.Strings(serviceNames)
+			assert.Equal(t, tc.expected, serviceNames)
+		})
+	}
+}
+
+func TestDockerComposeAPIWithStackStrings(t *testing.T) {
+	override := `
+services:
+  nginx:
+    environment:
+      foo: FOO_FROM_OVERRIDE
+`
+
+	t.Run("in-memory-only", func(t *testing.T) {
+		simple, err := os.ReadFile(filepath.Join(testdataPackage, simpleCompose))
+		require.NoError(t, err)
+
+		compose := &dockerCompose{
+			name:     "stack-strings",
+			contents: [][]byte{simple, []byte(override)},
+		}
+		compose.projectOptions = append(compose.projectOptions, withEnv(map[string]string{
+			"bar": "BAR",
+		}))
+
+		project, err := compose.compileProject()
+		require.NoError(t, err, "compose.compileProject()")
+
+		assert.Equal(t, []string{"nginx"}, project.ServiceNames())
+
+		env := project.Services["nginx"].Environment
+		require.NotNil(t, env["bar"])
+		assert.Equal(t, "BAR", *env["bar"])
+		require.NotNil(t, env["foo"])
+		assert.Equal(t, "FOO_FROM_OVERRIDE", *env["foo"])
+	})
+
+	t.Run("files-and-in-memory", func(t *testing.T) {
+		compose := &dockerCompose{
+			name:     "stack-files-and-strings",
+			configs:  []string{filepath.Join(testdataPackage, simpleCompose), filepath.Join(testdataPackage, "docker-compose-postgres.yml")},
+			contents: [][]byte{[]byte(override)},
+		}
+
+		project, err := compose.compileProject()
+		require.NoError(t, err, "compose.compileProject()")
+
+		serviceNames := project.ServiceNames()
+		sort.Strings(serviceNames)
+		assert.Equal(t, []string{"nginx", "postgres"}, serviceNames)
+
+		env := project.Services["nginx"].Environment
+		require.NotNil(t, env["foo"])
+		assert.Equal(t, "FOO_FROM_OVERRIDE", *env["foo"])
+	})
+}
+
+func TestDockerComposeAPIWithStackReaders(t *testing.T) {
+	identifier := testNameHash(t.Name())
+
+	simple, err := os.ReadFile(filepath.Join(testdataPackage, simpleCompose))
+	require.NoError(t, err)
+
+	compose, err := NewDockerComposeWith(WithStackReaders(bytes.NewReader(simple)), identifier)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	err = compose.
+		WithEnv(map[string]string{
+			"bar": "BAR",
+		}).
+		Up(ctx, Wait(true))
+	require.NoError(t, err, "compose.Up()")
+
+	present := map[string]string{
+		"bar": "BAR",
+	}
+	absent := map[string]string{}
+	assertContainerEnvironmentVariables(t, identifier.String(), "nginx", present, absent)
+}
+
 func TestDockerComposeAPIWithMultipleComposeFiles(t *testing.T) {
 	composeFiles := ComposeStackFiles{
 		filepath.Join(testdataPackage, simpleCompose),
@@ -453,6 +897,29 @@ func TestDockerComposeAPIVolumesDeletedOnDown(t *testing.T) {
 	assert.Empty(t, volumeList.Volumes, "Volumes are not cleaned up")
 }
 
+func TestDockerComposeAPIDownOptions(t *testing.T) {
+	options := stackDownOptions{}
+
+	opts := []StackDownOption{
+		RemoveOrphans(true),
+		RemoveVolumes(true),
+		RemoveImagesLocal,
+		DownTimeout(5 * time.Second),
+	}
+	for _, opt := range opts {
+		opt.applyToStackDown(&options)
+	}
+
+	assert.True(t, options.RemoveOrphans)
+	assert.True(t, options.Volumes)
+	assert.Equal(t, "local", options.Images)
+	require.NotNil(t, options.Timeout)
+	assert.Equal(t, 5*time.Second, *options.Timeout)
+
+	RemoveImagesAll.applyToStackDown(&options)
+	assert.Equal(t, "all", options.Images)
+}
+
 func TestDockerComposeAPIWithBuild(t *testing.T) {
 	t.Skip("Skipping test because of the opentelemetry dependencies issue. See https://github.com/open-telemetry/opentelemetry-go/issues/4476#issuecomment-1840547010")
 
@@ -474,6 +941,57 @@ func TestDockerComposeAPIWithBuild(t *testing.T) {
 	require.NoError(t, err, "compose.Up()")
 }
 
+func TestDockerComposeAPIWithBuildArgsAndTarget(t *testing.T) {
+	path := filepath.Join(testdataPackage, "docker-compose-build-args.yml")
+
+	compose := &dockerCompose{
+		name:    "build-args",
+		configs: []string{path},
+	}
+	compose.projectOptions = append(compose.projectOptions, withEnv(map[string]string{
+		"greeting": "Hello, Compose!",
+	}))
+
+	project, err := compose.compileProject()
+	require.NoError(t, err, "compose.compileProject()")
+
+	build := project.Services["echo"].Build
+	require.NotNil(t, build)
+	assert.Equal(t, "release", build.Target)
+	require.NotNil(t, build.Args["GREETING"])
+	assert.Equal(t, "Hello, Compose!", *build.Args["GREETING"])
+
+	forceBuild(project)
+	assert.Equal(t, types.PullPolicyBuild, project.Services["echo"].PullPolicy)
+}
+
+func TestDockerComposeAPIWithForcedBuild(t *testing.T) {
+	t.Skip("Skipping test because of the opentelemetry dependencies issue. See https://github.com/open-telemetry/opentelemetry-go/issues/4476#issuecomment-1840547010")
+
+	path := filepath.Join(testdataPackage, "docker-compose-build-args.yml")
+	compose, err := NewDockerCompose(path)
+	require.NoError(t, err, "NewDockerCompose()")
+
+	t.Cleanup(func() {
+		require.NoError(t, compose.Down(context.Background(), RemoveOrphans(true), RemoveImagesLocal), "compose.Down()")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	err = compose.
+		WithEnv(map[string]string{
+			"greeting": "Hello, Compose!",
+		}).
+		WaitForService("echo", wait.ForHTTP("/env").WithPort("8080/tcp").WithResponseMatcher(func(body io.Reader) bool {
+			bs, err := io.ReadAll(body)
+			return err == nil && string(bs) == "Hello, Compose!"
+		})).
+		Up(ctx, Wait(true), Build(true))
+
+	require.NoError(t, err, "compose.Up()")
+}
+
 func TestDockerComposeApiWithWaitForShortLifespanService(t *testing.T) {
 	path := filepath.Join(testdataPackage, "docker-compose-short-lifespan.yml")
 	compose, err := NewDockerCompose(path)
@@ -501,6 +1019,53 @@ func TestDockerComposeApiWithWaitForShortLifespanService(t *testing.T) {
 	assert.Contains(t, services, "tzatziki")
 }
 
+func TestDockerComposeAPISessionLabels(t *testing.T) {
+	compose := &dockerCompose{
+		name:    "session-labels",
+		configs: []string{filepath.Join(testdataPackage, composeWithVolume)},
+	}
+	compose.projectOptions = append(compose.projectOptions, withEnv(map[string]string{
+		"bar": "BAR",
+	}))
+
+	project, err := compose.compileProject()
+	require.NoError(t, err, "compose.compileProject()")
+	require.NotEmpty(t, project.Networks)
+
+	for k, v := range testcontainers.GenericLabels() {
+		assert.Equal(t, v, project.Services["nginx"].CustomLabels[k], "service label %s", k)
+		assert.Equal(t, v, project.Volumes["mydata"].Labels[k], "volume label %s", k)
+
+		for name, network := range project.Networks {
+			assert.Equal(t, v, network.Labels[k], "network %s label %s", name, k)
+		}
+	}
+}
+
+type testLogConsumer struct {
+	mx   sync.Mutex
+	logs []testcontainers.Log
+}
+
+func (lc *testLogConsumer) Accept(l testcontainers.Log) {
+	lc.mx.Lock()
+	defer lc.mx.Unlock()
+
+	lc.logs = append(lc.logs, l)
+}
+
+func (lc *testLogConsumer) String() string {
+	lc.mx.Lock()
+	defer lc.mx.Unlock()
+
+	sb := strings.Builder{}
+	for _, l := range lc.logs {
+		sb.Write(l.Content)
+	}
+
+	return sb.String()
+}
+
 func testNameHash(name string) StackIdentifier {
 	return StackIdentifier(fmt.Sprintf("%x", fnv.New32a().Sum([]byte(name))))
 }
@@ -3,8 +3,11 @@ package consul
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -38,6 +41,23 @@ func (c *ConsulContainer) ApiEndpoint(ctx context.Context) (string, error) {
 	return uri, nil
 }
 
+// DNSEndpoint returns host:port for the DNS interface, which answers the queries over UDP,
+// e.g. for the "<service>.service.consul" names of the registered services.
+func (c *ConsulContainer) DNSEndpoint(ctx context.Context) (string, error) {
+	mappedPort, err := c.MappedPort(ctx, defaultBrokerPort+"/udp")
+	if err != nil {
+		return "", err
+	}
+
+	hostIP, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	uri := fmt.Sprintf("%s:%s", hostIP, mappedPort.Port())
+	return uri, nil
+}
+
 // WithConfigString takes in a JSON string of keys and values to define a configuration to be used by the instance.
 func WithConfigString(config string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
@@ -57,11 +77,50 @@ func WithConfigFile(configPath string) testcontainers.CustomizeRequestOption {
 	}
 }
 
-// RunContainer creates an instance of the Consul container type
+// WithInitCommand runs the given consul commands, in order, once the instance is ready, to bootstrap its state,
+// e.g. WithInitCommand("kv put config/app/debug true", "services register -name=web -port=80").
+// The commands are run by the shell of the container with the consul CLI, so they are given without the "consul" prefix,
+// and their arguments can be quoted.
+func WithInitCommand(commands ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					for _, command := range commands {
+						if err := runCommand(ctx, c, command); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			},
+		})
+	}
+}
+
+// runCommand runs the given consul command in the container.
+func runCommand(ctx context.Context, c testcontainers.Container, command string) error {
+	code, reader, err := c.Exec(ctx, []string{"sh", "-c", "consul " + command}, exec.Multiplexed())
+	if err != nil {
+		return fmt.Errorf("consul %s: %w", command, err)
+	}
+
+	if code != 0 {
+		output, _ := io.ReadAll(reader)
+		return fmt.Errorf("consul %s: exit code %d: %s", command, code, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// RunContainer creates an instance of the Consul container type.
+// The agent runs in dev mode, as a single in-memory server that elects itself as the leader,
+// with its HTTP and DNS interfaces bound to all the interfaces of the container.
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*ConsulContainer, error) {
 	containerReq := testcontainers.GenericContainerRequest{
 		ContainerRequest: testcontainers.ContainerRequest{
 			Image: DefaultBaseImage,
+			Cmd:   []string{"agent", "-dev", "-client", "0.0.0.0"},
 			ExposedPorts: []string{
 				defaultHttpApiPort + "/tcp",
 				defaultBrokerPort + "/tcp",
@@ -2,6 +2,7 @@ package consul_test
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"path/filepath"
 	"testing"
@@ -71,3 +72,68 @@ func TestConsul(t *testing.T) {
 		})
 	}
 }
+
+func TestConsul_withInitCommand(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := consul.RunContainer(ctx,
+		// withInitCommand {
+		consul.WithInitCommand(
+			"kv put config/app/debug true",
+			`kv put config/app/greeting "hello world"`,
+		),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx), "failed to terminate container") })
+
+	host, err := container.ApiEndpoint(ctx)
+	require.NoError(t, err)
+
+	cfg := capi.DefaultConfig()
+	cfg.Address = host
+
+	client, err := capi.NewClient(cfg)
+	require.NoError(t, err)
+
+	pair, _, err := client.KV().Get("config/app/greeting", nil)
+	require.NoError(t, err)
+	require.NotNil(t, pair)
+	assert.Equal(t, "hello world", string(pair.Value))
+}
+
+func TestConsul_withInitCommandError(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := consul.RunContainer(ctx, consul.WithInitCommand("kv get config/missing"))
+	if container != nil {
+		t.Cleanup(func() { require.NoError(t, container.Terminate(ctx), "failed to terminate container") })
+	}
+	require.Error(t, err)
+}
+
+func TestConsul_DNSEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := consul.RunContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx), "failed to terminate container") })
+
+	// dnsEndpoint {
+	endpoint, err := container.DNSEndpoint(ctx)
+	// }
+	require.NoError(t, err)
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			dialer := net.Dialer{}
+			return dialer.DialContext(ctx, "udp", endpoint)
+		},
+	}
+
+	// the consul service is registered by the server itself
+	addrs, err := resolver.LookupHost(ctx, "consul.service.consul")
+	require.NoError(t, err)
+	assert.NotEmpty(t, addrs)
+}
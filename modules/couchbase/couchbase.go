@@ -104,7 +104,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 			config.password = credentialsCustomizer.password
 
 			if len(credentialsCustomizer.password) < 6 {
-				return nil, errors.New("admin password must be at most 6 characters long")
+				return nil, errors.New("admin password must be at least 6 characters long")
 			}
 		}
 	}
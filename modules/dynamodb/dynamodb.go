@@ -0,0 +1,82 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "amazon/dynamodb-local:2.2.1"
+	defaultPort  = "8000/tcp"
+	// DynamoDB Local accepts any credentials, so these are only needed by the SDKs, which require some.
+	defaultAccessKeyID     = "dummy"
+	defaultSecretAccessKey = "dummy"
+	defaultRegion          = "us-east-1"
+)
+
+// DynamoDBContainer represents the DynamoDB container type used in the module
+type DynamoDBContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the DynamoDB container type, running DynamoDB Local in memory,
+// with a single database shared by all the credentials and regions.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*DynamoDBContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Cmd:          []string{"-jar", "DynamoDBLocal.jar", "-inMemory", "-sharedDb"},
+		// the API answers the requests without a target with a 400 status once it's up
+		WaitingFor: wait.ForHTTP("/").WithPort(defaultPort).WithStatusCodeMatcher(func(status int) bool {
+			return status == 400
+		}),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DynamoDBContainer{Container: container}, nil
+}
+
+// WithDisableTelemetry disables the telemetry DynamoDB Local sends to AWS.
+func WithDisableTelemetry() testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Cmd = append(req.Cmd, "-disableTelemetry")
+	}
+}
+
+// EndpointURL returns the URL of the DynamoDB API, in the http://<host>:<port> format,
+// to be used as the base endpoint of the DynamoDB clients of the AWS SDKs.
+func (c *DynamoDBContainer) EndpointURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, port.Port()), nil
+}
+
+// Credentials returns the static credentials, an access key ID and a secret access key, and the region
+// to configure the DynamoDB clients of the AWS SDKs with, as they require some. DynamoDB Local accepts any.
+func (c *DynamoDBContainer) Credentials() (accessKeyID string, secretAccessKey string, region string) {
+	return defaultAccessKeyID, defaultSecretAccessKey, defaultRegion
+}
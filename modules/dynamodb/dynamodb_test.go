@@ -0,0 +1,74 @@
+package dynamodb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/dynamodb"
+)
+
+func TestDynamoDB(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := dynamodb.RunContainer(ctx,
+		testcontainers.WithImage("amazon/dynamodb-local:2.2.1"),
+		dynamodb.WithDisableTelemetry(),
+	)
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+	})
+
+	// dynamoDBClient {
+	endpoint, err := container.EndpointURL(ctx)
+	require.NoError(t, err)
+
+	accessKeyID, secretAccessKey, region := container.Credentials()
+
+	client := awsdynamodb.New(awsdynamodb.Options{
+		BaseEndpoint: aws.String(endpoint),
+		Credentials:  credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, ""),
+		Region:       region,
+	})
+	// }
+
+	_, err = client.CreateTable(ctx, &awsdynamodb.CreateTableInput{
+		TableName: aws.String("orders"),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+		},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	require.NoError(t, err)
+
+	_, err = client.PutItem(ctx, &awsdynamodb.PutItemInput{
+		TableName: aws.String("orders"),
+		Item: map[string]types.AttributeValue{
+			"id":   &types.AttributeValueMemberS{Value: "42"},
+			"item": &types.AttributeValueMemberS{Value: "book"},
+		},
+	})
+	require.NoError(t, err)
+
+	out, err := client.GetItem(ctx, &awsdynamodb.GetItemInput{
+		TableName: aws.String("orders"),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: "42"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "book"}, out.Item["item"])
+}
@@ -0,0 +1,38 @@
+package dynamodb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/dynamodb"
+)
+
+func ExampleRunContainer() {
+	// runDynamoDBContainer {
+	ctx := context.Background()
+
+	dynamodbContainer, err := dynamodb.RunContainer(ctx, testcontainers.WithImage("amazon/dynamodb-local:2.2.1"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := dynamodbContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := dynamodbContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
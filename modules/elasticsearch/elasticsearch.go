@@ -2,10 +2,16 @@ package elasticsearch
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -48,6 +54,20 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 			//   matches 7.x JSON logging with whitespace between message field and content
 			//   matches 6.x text logging with node name in brackets and just a 'started' message till the end of the line
 			WaitingFor: wait.ForLog(`.*("message":\s?"started(\s|")?.*|]\sstarted\n)`).AsRegexp(),
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.Ulimits = []*units.Ulimit{
+					{
+						Name: "memlock",
+						Soft: -1, // Set memlock to unlimited (no soft or hard limit)
+						Hard: -1,
+					},
+					{
+						Name: "nofile",
+						Soft: 65536, // Maximum number of open files for the elasticsearch user - set to at least 65536
+						Hard: 65536,
+					},
+				}
+			},
 			LifecycleHooks: []testcontainers.ContainerLifecycleHooks{
 				{
 					// the container needs a post create hook to set the default JVM options in a file
@@ -84,6 +104,9 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		req.LifecycleHooks[0].PostCreates = append(req.LifecycleHooks[0].PostCreates, configureJvmOpts)
 	}
 
+	// the HTTP API is ready once the certificate, if any, is copied from the container
+	req.LifecycleHooks[0].PostReadies = append(req.LifecycleHooks[0].PostReadies, waitForHTTP(settings))
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
@@ -122,6 +145,31 @@ func configureAddress(ctx context.Context, c *ElasticsearchContainer) (string, e
 	return fmt.Sprintf("%s://%s:%s", proto, host, containerPort.Port()), nil
 }
 
+// waitForHTTP returns a post ready hook waiting for the HTTP API to answer the requests authenticated with
+// the credentials of the container, over TLS if the certificate is set, which is the case since version 8.
+func waitForHTTP(settings *Options) testcontainers.ContainerHook {
+	return func(ctx context.Context, c testcontainers.Container) error {
+		strategy := wait.ForHTTP("/").
+			WithPort(defaultHTTPPort + "/tcp").
+			WithStatusCodeMatcher(func(status int) bool {
+				return status == http.StatusOK
+			})
+
+		if settings.Password != "" {
+			strategy = strategy.WithBasicAuth(settings.Username, settings.Password)
+		}
+
+		if settings.CACert != nil {
+			caCertPool := x509.NewCertPool()
+			caCertPool.AppendCertsFromPEM(settings.CACert)
+
+			strategy = strategy.WithTLS(true, &tls.Config{RootCAs: caCertPool})
+		}
+
+		return strategy.WaitUntilReady(ctx, c)
+	}
+}
+
 // configureCertificate transfers the certificate settings to the container request.
 // For that, it defines a post start hook that copies the certificate from the container to the host.
 // The certificate is only available since version 8, and will be located in a well-known location.
@@ -204,6 +204,46 @@ func TestElasticsearch8WithoutCredentials(t *testing.T) {
 	}
 }
 
+func TestElasticsearchWithGeneratedPassword(t *testing.T) {
+	ctx := context.Background()
+
+	// usingGeneratedPassword {
+	container, err := elasticsearch.RunContainer(ctx, testcontainers.WithImage(baseImage8), elasticsearch.WithGeneratedPassword())
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if container.Settings.Password == "" || container.Settings.Password == "changeme" {
+		t.Fatal("expected a generated password but got", container.Settings.Password)
+	}
+
+	httpClient := configureHTTPClient(container)
+
+	req, err := http.NewRequest("GET", container.Settings.Address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.SetBasicAuth(container.Settings.Username, container.Settings.Password)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatal(err, "Should be able to access / URI with client using the generated password over HTTPS.")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal("expected 200 status code but got", resp.StatusCode)
+	}
+}
+
 func TestElasticsearchOSSCannotuseWithPassword(t *testing.T) {
 	ctx := context.Background()
 
@@ -1,6 +1,9 @@
 package elasticsearch
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+
 	"github.com/testcontainers/testcontainers-go"
 )
 
@@ -38,3 +41,22 @@ func WithPassword(password string) Option {
 		o.Password = password
 	}
 }
+
+// WithGeneratedPassword enables the security of the Elasticsearch container with a random password,
+// which is available in the settings of the container, so that the tests do not depend on a well-known password.
+func WithGeneratedPassword() Option {
+	return func(o *Options) {
+		o.Password = generatePassword()
+	}
+}
+
+// generatePassword returns a random password made of 32 hexadecimal characters.
+func generatePassword() string {
+	bs := make([]byte, 16)
+	if _, err := rand.Read(bs); err != nil {
+		// the random generator of the OS never fails in practice
+		panic(err)
+	}
+
+	return hex.EncodeToString(bs)
+}
@@ -0,0 +1,137 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage        = "gcr.io/etcd-development/etcd:v3.5.12"
+	defaultClusterToken = "testcontainers"
+	clientPort          = "2379/tcp"
+	peerPort            = "2380/tcp"
+	memberName          = "etcd"
+	rootUser            = "root"
+)
+
+// EtcdContainer represents the Etcd container type used in the module
+type EtcdContainer struct {
+	testcontainers.Container
+	// Username is the name of the root user when the authentication is enabled with WithRootPassword, else it's empty.
+	Username string
+	// Password is the password of the root user when the authentication is enabled with WithRootPassword, else it's empty.
+	Password string
+}
+
+// RunContainer creates an instance of the Etcd container type, running a single-member cluster
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*EtcdContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{clientPort, peerPort},
+		WaitingFor:   wait.ForHTTP("/health").WithPort(clientPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if len(genericContainerReq.Cmd) == 0 {
+		genericContainerReq.Cmd = command(settings)
+	}
+
+	if settings.rootPassword != "" {
+		genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return enableAuth(ctx, c, settings.rootPassword)
+				},
+			},
+		})
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &EtcdContainer{Container: container}
+	if settings.rootPassword != "" {
+		c.Username = rootUser
+		c.Password = settings.rootPassword
+	}
+
+	return c, nil
+}
+
+// ClientURL returns the URL of the client API of etcd, in the http://<host>:<port> format,
+// to be used as an endpoint of the etcd clients.
+func (c *EtcdContainer) ClientURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, clientPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, port.Port()), nil
+}
+
+// command returns the command starting an etcd member that bootstraps a cluster on its own,
+// listening on all the interfaces of the container.
+func command(settings options) []string {
+	return []string{
+		"etcd",
+		"--name=" + memberName,
+		"--data-dir=/etcd-data",
+		"--listen-client-urls=http://0.0.0.0:2379",
+		"--advertise-client-urls=http://localhost:2379",
+		"--listen-peer-urls=http://0.0.0.0:2380",
+		"--initial-advertise-peer-urls=http://localhost:2380",
+		"--initial-cluster=" + memberName + "=http://localhost:2380",
+		"--initial-cluster-token=" + settings.clusterToken,
+		"--initial-cluster-state=new",
+	}
+}
+
+// enableAuth creates the root user with the given password and the root role, and enables the authentication.
+func enableAuth(ctx context.Context, c testcontainers.Container, password string) error {
+	cmds := [][]string{
+		{"etcdctl", "role", "add", rootUser},
+		{"etcdctl", "user", "add", rootUser, "--new-user-password=" + password},
+		{"etcdctl", "user", "grant-role", rootUser, rootUser},
+		{"etcdctl", "auth", "enable"},
+	}
+
+	for _, cmd := range cmds {
+		code, reader, err := c.Exec(ctx, cmd, exec.Multiplexed())
+		if err != nil {
+			return fmt.Errorf("enable authentication: %w", err)
+		}
+
+		if code != 0 {
+			// the arguments are left out of the error, as they can include the password
+			output, _ := io.ReadAll(reader)
+			return fmt.Errorf("enable authentication: %s: exit code %d: %s", strings.Join(cmd[:3], " "), code, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
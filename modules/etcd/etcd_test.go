@@ -0,0 +1,97 @@
+package etcd_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/etcd"
+)
+
+func TestEtcd(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := etcd.RunContainer(ctx,
+		testcontainers.WithImage("gcr.io/etcd-development/etcd:v3.5.12"),
+		// withClusterToken {
+		etcd.WithClusterToken("my-cluster"),
+		// }
+	)
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+	})
+
+	// clientURL {
+	endpoint, err := container.ClientURL(ctx)
+	// }
+	require.NoError(t, err)
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{endpoint},
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	_, err = client.Put(ctx, "greeting", "hello")
+	require.NoError(t, err)
+
+	resp, err := client.Get(ctx, "greeting")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, "hello", string(resp.Kvs[0].Value))
+}
+
+func TestEtcd_withRootPassword(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := etcd.RunContainer(ctx,
+		// withRootPassword {
+		etcd.WithRootPassword("s3cr3t"),
+		// }
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+	})
+
+	endpoint, err := container.ClientURL(ctx)
+	require.NoError(t, err)
+
+	t.Run("anonymous", func(t *testing.T) {
+		client, err := clientv3.New(clientv3.Config{
+			Endpoints:   []string{endpoint},
+			DialTimeout: 5 * time.Second,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+		_, err = client.Put(ctx, "greeting", "hello")
+		require.Error(t, err)
+	})
+
+	t.Run("root", func(t *testing.T) {
+		// authenticatedClient {
+		client, err := clientv3.New(clientv3.Config{
+			Endpoints:   []string{endpoint},
+			Username:    container.Username,
+			Password:    container.Password,
+			DialTimeout: 5 * time.Second,
+		})
+		// }
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+		_, err = client.Put(ctx, "greeting", "hello")
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,38 @@
+package etcd_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/etcd"
+)
+
+func ExampleRunContainer() {
+	// runEtcdContainer {
+	ctx := context.Background()
+
+	etcdContainer, err := etcd.RunContainer(ctx, testcontainers.WithImage("gcr.io/etcd-development/etcd:v3.5.12"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := etcdContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := etcdContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,42 @@
+package etcd
+
+import "github.com/testcontainers/testcontainers-go"
+
+type options struct {
+	clusterToken string
+	rootPassword string
+}
+
+func defaultOptions() options {
+	return options{
+		clusterToken: defaultClusterToken,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Etcd container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithClusterToken sets the initial cluster token of the etcd member, which identifies the cluster it bootstraps.
+func WithClusterToken(token string) Option {
+	return func(o *options) {
+		o.clusterToken = token
+	}
+}
+
+// WithRootPassword enables the authentication of etcd once it's ready, creating the "root" user
+// with the given password and the root role. The clients must then authenticate as the root user,
+// whose credentials are available in the Username and Password fields of the container.
+// See https://etcd.io/docs/v3.5/op-guide/authentication/rbac/ for more information.
+func WithRootPassword(password string) Option {
+	return func(o *options) {
+		o.rootPassword = password
+	}
+}
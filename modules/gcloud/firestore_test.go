@@ -6,24 +6,11 @@ import (
 	"log"
 
 	"cloud.google.com/go/firestore"
-	"google.golang.org/api/option"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/gcloud"
 )
 
-type emulatorCreds struct{}
-
-func (ec emulatorCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
-	return map[string]string{"authorization": "Bearer owner"}, nil
-}
-
-func (ec emulatorCreds) RequireTransportSecurity() bool {
-	return false
-}
-
 func ExampleRunFirestoreContainer() {
 	// runFirestoreContainer {
 	ctx := context.Background()
@@ -48,15 +35,9 @@ func ExampleRunFirestoreContainer() {
 	// firestoreClient {
 	projectID := firestoreContainer.Settings.ProjectID
 
-	conn, err := grpc.Dial(firestoreContainer.URI, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithPerRPCCredentials(emulatorCreds{}))
-	if err != nil {
-		log.Fatalf("failed to dial: %v", err) // nolint:gocritic
-	}
-
-	options := []option.ClientOption{option.WithGRPCConn(conn)}
-	client, err := firestore.NewClient(ctx, projectID, options...)
+	client, err := firestore.NewClient(ctx, projectID, firestoreContainer.ClientOptions()...)
 	if err != nil {
-		log.Fatalf("failed to create client: %v", err)
+		log.Fatalf("failed to create client: %v", err) // nolint:gocritic
 	}
 	defer client.Close()
 	// }
@@ -5,6 +5,10 @@ import (
 	"fmt"
 
 	"github.com/docker/go-connections/nat"
+	"google.golang.org/api/option"
+	"google.golang.org/api/option/internaloption"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/testcontainers/testcontainers-go"
 )
@@ -40,6 +44,31 @@ func newGCloudContainer(ctx context.Context, port int, c testcontainers.Containe
 	return gCloudContainer, nil
 }
 
+// ClientOptions returns the options to create the clients of the Google Cloud SDKs connected to the emulator,
+// e.g. pubsub.NewClient(ctx, c.Settings.ProjectID, c.ClientOptions()...), without authentication and TLS.
+// The requests are sent with the credentials of the owner of the project, which bypass the security rules
+// of the emulators supporting them, like Firestore.
+func (c *GCloudContainer) ClientOptions() []option.ClientOption {
+	return []option.ClientOption{
+		option.WithEndpoint(c.URI),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		option.WithGRPCDialOption(grpc.WithPerRPCCredentials(emulatorCredentials{})),
+		internaloption.SkipDialSettingsValidation(),
+	}
+}
+
+// emulatorCredentials are the credentials of the owner of the project, as the emulators expect them.
+type emulatorCredentials struct{}
+
+func (emulatorCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer owner"}, nil
+}
+
+func (emulatorCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
 type options struct {
 	ProjectID string
 }
@@ -0,0 +1,163 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage    = "gitea/gitea:1.21.7"
+	defaultHTTPPort = "3000/tcp"
+	defaultSSHPort  = "22/tcp"
+
+	defaultUsername = "testcontainers"
+	defaultPassword = "testcontainers"
+
+	// tokenName is the name of the access token generated for the admin user
+	tokenName = "testcontainers"
+)
+
+// GiteaContainer represents the Gitea container type used in the module
+type GiteaContainer struct {
+	testcontainers.Container
+	username string
+	password string
+	token    string
+}
+
+// RunContainer creates an instance of the Gitea container type, storing the data in SQLite,
+// with an admin user and an access token of the admin user with all the scopes, provisioned at startup.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*GiteaContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultHTTPPort, defaultSSHPort},
+		Env: map[string]string{
+			// skips the installation page, using the default SQLite database
+			"GITEA__security__INSTALL_LOCK": "true",
+			"GITEA__database__DB_TYPE":      "sqlite3",
+		},
+		WaitingFor: wait.ForHTTP("/api/healthz").WithPort(defaultHTTPPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &GiteaContainer{Container: container, username: settings.Username, password: settings.Password}
+
+	_, err = c.gitea(ctx, "admin", "user", "create", "--admin",
+		"--username", c.username,
+		"--password", c.password,
+		"--email", c.username+"@example.com",
+		"--must-change-password=false",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create admin user: %w", err)
+	}
+
+	token, err := c.gitea(ctx, "admin", "user", "generate-access-token",
+		"--username", c.username,
+		"--token-name", tokenName,
+		"--scopes", "all",
+		"--raw",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("generate access token: %w", err)
+	}
+	c.token = token
+
+	return c, nil
+}
+
+// gitea runs the given command of the gitea CLI as the git user, which owns the data of the server,
+// returning its trimmed standard output, without the warnings the CLI may write to the standard error.
+func (c *GiteaContainer) gitea(ctx context.Context, args ...string) (string, error) {
+	var stdout bytes.Buffer
+
+	code, output, err := c.Exec(ctx, append([]string{"gitea"}, args...), exec.WithUser("git"), exec.WithDemux(&stdout, nil))
+	if err != nil {
+		return "", err
+	}
+
+	if code != 0 {
+		bs, err := io.ReadAll(output)
+		if err != nil {
+			return "", fmt.Errorf("exit code %d: %w", code, err)
+		}
+
+		return "", fmt.Errorf("exit code %d: %s", code, strings.TrimSpace(string(bs)))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// URL returns the URL of the web interface and the HTTP git server, in the http://<host>:<port> format.
+func (c *GiteaContainer) URL(ctx context.Context) (string, error) {
+	address, err := c.address(ctx, defaultHTTPPort)
+	if err != nil {
+		return "", err
+	}
+
+	return "http://" + address, nil
+}
+
+// SSHAddress returns the address of the SSH git server, in the <host>:<port> format.
+func (c *GiteaContainer) SSHAddress(ctx context.Context) (string, error) {
+	return c.address(ctx, defaultSSHPort)
+}
+
+// AdminUsername returns the username of the admin user.
+func (c *GiteaContainer) AdminUsername() string {
+	return c.username
+}
+
+// AdminPassword returns the password of the admin user.
+func (c *GiteaContainer) AdminPassword() string {
+	return c.password
+}
+
+// Token returns the access token of the admin user, with all the scopes, to authenticate the requests
+// to the API, with the "token <token>" Authorization header, or the git operations over HTTP,
+// as the password of the admin user.
+func (c *GiteaContainer) Token() string {
+	return c.token
+}
+
+// address returns the address of the given port, in the <host>:<port> format.
+func (c *GiteaContainer) address(ctx context.Context, port nat.Port) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, mappedPort.Port()), nil
+}
@@ -0,0 +1,108 @@
+package gitea_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/gitea"
+)
+
+func TestGitea(t *testing.T) {
+	ctx := context.Background()
+
+	// withAdminCredentials {
+	container, err := gitea.RunContainer(ctx,
+		testcontainers.WithImage("gitea/gitea:1.21.7"),
+		gitea.WithAdminCredentials("alice", "alice-password"),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// createRepository {
+	cloneURL, err := container.CreateRepository(ctx, "fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = container.PushFiles(ctx, "fixtures", "Add the fixtures", map[string][]byte{
+		"config/app.yaml": []byte("name: orders\n"),
+		"VERSION":         []byte("1.0.0\n"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	url, err := container.URL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("raw file", func(t *testing.T) {
+		content := get(t, url+"/alice/fixtures/raw/branch/main/config/app.yaml", container.AdminUsername(), container.AdminPassword())
+
+		if content != "name: orders\n" {
+			t.Fatalf("expected content %q, got %q", "name: orders\n", content)
+		}
+	})
+
+	t.Run("git over HTTP with the token", func(t *testing.T) {
+		// the smart HTTP protocol advertises the refs of the repository
+		refs := get(t, cloneURL+"/info/refs?service=git-upload-pack", container.AdminUsername(), container.Token())
+
+		if !strings.Contains(refs, "refs/heads/main") {
+			t.Fatalf("expected the main branch to be advertised, got %q", refs)
+		}
+	})
+
+	t.Run("ssh address", func(t *testing.T) {
+		address, err := container.SSHAddress(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if address == "" {
+			t.Fatal("expected an address")
+		}
+	})
+}
+
+func get(t *testing.T, url string, username string, password string) string {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(username, password)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.StatusCode, body)
+	}
+
+	return string(body)
+}
@@ -0,0 +1,35 @@
+package gitea
+
+import "github.com/testcontainers/testcontainers-go"
+
+type options struct {
+	Username string
+	Password string
+}
+
+func defaultOptions() options {
+	return options{
+		Username: defaultUsername,
+		Password: defaultPassword,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Gitea container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithAdminCredentials sets the username and password of the admin user created at startup.
+// Gitea reserves some usernames, like "admin", which can't be used.
+func WithAdminCredentials(username string, password string) Option {
+	return func(o *options) {
+		o.Username = username
+		o.Password = password
+	}
+}
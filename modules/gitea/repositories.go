@@ -0,0 +1,105 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// defaultBranch is the branch created with the repositories
+const defaultBranch = "main"
+
+// CreateRepository creates a repository with the given name, owned by the admin user,
+// with an initial commit on the "main" branch, and returns its HTTP clone URL,
+// in the http://<host>:<port>/<owner>/<name>.git format. The git operations over HTTP
+// can authenticate with the credentials of the admin user or its access token.
+func (c *GiteaContainer) CreateRepository(ctx context.Context, name string) (string, error) {
+	body := map[string]any{
+		"name":           name,
+		"auto_init":      true,
+		"default_branch": defaultBranch,
+	}
+
+	if err := c.api(ctx, http.MethodPost, "/user/repos", body); err != nil {
+		return "", fmt.Errorf("create repository %s: %w", name, err)
+	}
+
+	url, err := c.URL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return url + "/" + c.username + "/" + name + ".git", nil
+}
+
+// PushFiles commits the given files, indexed by their path, to the "main" branch of the given repository
+// of the admin user, in a single commit with the given message. The files must not exist in the branch yet.
+// It allows to push fixtures without a git client.
+func (c *GiteaContainer) PushFiles(ctx context.Context, repository string, message string, files map[string][]byte) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	changes := make([]map[string]string, 0, len(paths))
+	for _, path := range paths {
+		changes = append(changes, map[string]string{
+			"operation": "create",
+			"path":      path,
+			"content":   base64.StdEncoding.EncodeToString(files[path]),
+		})
+	}
+
+	body := map[string]any{
+		"branch":  defaultBranch,
+		"message": message,
+		"files":   changes,
+	}
+
+	if err := c.api(ctx, http.MethodPost, "/repos/"+c.username+"/"+repository+"/contents", body); err != nil {
+		return fmt.Errorf("push files to %s: %w", repository, err)
+	}
+
+	return nil
+}
+
+// api sends a request with the given JSON body to the given path of the API,
+// authenticated with the access token of the admin user.
+func (c *GiteaContainer) api(ctx context.Context, method string, path string, body any) error {
+	url, err := c.URL(ctx)
+	if err != nil {
+		return err
+	}
+
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url+"/api/v1"+path, bytes.NewReader(bs))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		bs, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(bs)))
+	}
+
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/inbucket/inbucket/pkg/rest/client"
+
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -47,6 +49,17 @@ func (c *InbucketContainer) WebInterface(ctx context.Context) (string, error) {
 	return fmt.Sprintf("http://%s", net.JoinHostPort(host, containerPort.Port())), nil
 }
 
+// Client returns a client of the REST API of the Inbucket container, to retrieve the messages
+// received by the mailboxes, using the web interface returned by WebInterface.
+func (c *InbucketContainer) Client(ctx context.Context) (*client.Client, error) {
+	webInterface, err := c.WebInterface(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(webInterface)
+}
+
 // RunContainer creates an instance of the Inbucket container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*InbucketContainer, error) {
 	req := testcontainers.ContainerRequest{
@@ -3,10 +3,9 @@ package inbucket
 import (
 	"context"
 	"net/smtp"
+	"strings"
 	"testing"
 
-	"github.com/inbucket/inbucket/pkg/rest/client"
-
 	"github.com/testcontainers/testcontainers-go"
 )
 
@@ -39,7 +38,13 @@ func TestInbucket(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	restClient, err := client.New(webInterfaceUrl)
+	if !strings.HasPrefix(webInterfaceUrl, "http://") {
+		t.Fatalf("expected an http URL, got %s", webInterfaceUrl)
+	}
+
+	// restClient {
+	restClient, err := container.Client(ctx)
+	// }
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -68,5 +73,13 @@ func TestInbucket(t *testing.T) {
 	if len(headers) != 1 {
 		t.Fatalf("expected 1 message, got %d", len(headers))
 	}
-	// perform assertions
+
+	message, err := restClient.GetMessage("to@example.org", headers[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Subject != "Testcontainers test!" {
+		t.Fatalf("expected subject %q, got %q", "Testcontainers test!", message.Subject)
+	}
 }
@@ -0,0 +1,38 @@
+package influxdb_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/influxdb"
+)
+
+func ExampleRunContainer() {
+	// runInfluxDBContainer {
+	ctx := context.Background()
+
+	influxdbContainer, err := influxdb.RunContainer(ctx, testcontainers.WithImage("influxdb:2.7.5-alpine"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := influxdbContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := influxdbContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
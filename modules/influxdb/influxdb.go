@@ -0,0 +1,158 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage        = "influxdb:2.7.5-alpine"
+	defaultPort         = "8086/tcp"
+	defaultUsername     = "admin"
+	defaultPassword     = "password"
+	defaultOrganization = "testcontainers"
+	defaultBucket       = "testcontainers"
+	defaultAdminToken   = "testcontainers-token"
+)
+
+// InfluxDBContainer represents the InfluxDB container type used in the module
+type InfluxDBContainer struct {
+	testcontainers.Container
+	username     string
+	password     string
+	organization string
+	bucket       string
+	token        string
+}
+
+// RunContainer creates an instance of the InfluxDB container type, running InfluxDB v2 set up with
+// an initial user, organization and bucket, and an operator token.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*InfluxDBContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		Env: map[string]string{
+			"DOCKER_INFLUXDB_INIT_MODE":        "setup",
+			"DOCKER_INFLUXDB_INIT_USERNAME":    defaultUsername,
+			"DOCKER_INFLUXDB_INIT_PASSWORD":    defaultPassword,
+			"DOCKER_INFLUXDB_INIT_ORG":         defaultOrganization,
+			"DOCKER_INFLUXDB_INIT_BUCKET":      defaultBucket,
+			"DOCKER_INFLUXDB_INIT_ADMIN_TOKEN": defaultAdminToken,
+		},
+		// the setup runs against a temporary server listening on another port,
+		// so the server is only available on the default port once it's set up.
+		WaitingFor: wait.ForHTTP("/health").WithPort(defaultPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	env := genericContainerReq.Env
+
+	return &InfluxDBContainer{
+		Container:    container,
+		username:     env["DOCKER_INFLUXDB_INIT_USERNAME"],
+		password:     env["DOCKER_INFLUXDB_INIT_PASSWORD"],
+		organization: env["DOCKER_INFLUXDB_INIT_ORG"],
+		bucket:       env["DOCKER_INFLUXDB_INIT_BUCKET"],
+		token:        env["DOCKER_INFLUXDB_INIT_ADMIN_TOKEN"],
+	}, nil
+}
+
+// WithUsername sets the name of the initial user. The default username is "admin".
+func WithUsername(username string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["DOCKER_INFLUXDB_INIT_USERNAME"] = username
+	}
+}
+
+// WithPassword sets the password of the initial user, which must be at least 8 characters long.
+// The default password is "password".
+func WithPassword(password string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["DOCKER_INFLUXDB_INIT_PASSWORD"] = password
+	}
+}
+
+// WithOrganization sets the name of the initial organization. The default organization is "testcontainers".
+func WithOrganization(organization string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["DOCKER_INFLUXDB_INIT_ORG"] = organization
+	}
+}
+
+// WithBucket sets the name of the initial bucket of the initial organization. The default bucket is "testcontainers".
+func WithBucket(bucket string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["DOCKER_INFLUXDB_INIT_BUCKET"] = bucket
+	}
+}
+
+// WithRetention sets the retention of the initial bucket, e.g. "1w". By default, the data is kept forever.
+func WithRetention(retention string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["DOCKER_INFLUXDB_INIT_RETENTION"] = retention
+	}
+}
+
+// WithAdminToken sets the operator token, which has all the permissions on all the organizations.
+// The default token is "testcontainers-token".
+func WithAdminToken(token string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["DOCKER_INFLUXDB_INIT_ADMIN_TOKEN"] = token
+	}
+}
+
+// URL returns the URL of the InfluxDB API, in the http://<host>:<port> format.
+func (c *InfluxDBContainer) URL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, port.Port()), nil
+}
+
+// Username returns the name of the initial user.
+func (c *InfluxDBContainer) Username() string {
+	return c.username
+}
+
+// Password returns the password of the initial user.
+func (c *InfluxDBContainer) Password() string {
+	return c.password
+}
+
+// Organization returns the name of the initial organization.
+func (c *InfluxDBContainer) Organization() string {
+	return c.organization
+}
+
+// Bucket returns the name of the initial bucket.
+func (c *InfluxDBContainer) Bucket() string {
+	return c.bucket
+}
+
+// Token returns the operator token, to authenticate the clients.
+func (c *InfluxDBContainer) Token() string {
+	return c.token
+}
@@ -0,0 +1,62 @@
+package influxdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/influxdb"
+)
+
+func TestInfluxDB(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := influxdb.RunContainer(ctx,
+		testcontainers.WithImage("influxdb:2.7.5-alpine"),
+		// withSetup {
+		influxdb.WithUsername("tester"),
+		influxdb.WithPassword("tester-password"),
+		influxdb.WithOrganization("acme"),
+		influxdb.WithBucket("metrics"),
+		influxdb.WithRetention("1w"),
+		influxdb.WithAdminToken("my-token"),
+		// }
+	)
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+	})
+
+	assert.Equal(t, "tester", container.Username())
+	assert.Equal(t, "tester-password", container.Password())
+
+	// connectInfluxDB {
+	url, err := container.URL(ctx)
+	require.NoError(t, err)
+
+	client := influxdb2.NewClient(url, container.Token())
+	defer client.Close()
+	// }
+
+	ok, err := client.Ping(ctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	writeAPI := client.WriteAPIBlocking(container.Organization(), container.Bucket())
+	point := influxdb2.NewPoint("cpu", map[string]string{"host": "server01"}, map[string]any{"usage": 42.5}, time.Now())
+	require.NoError(t, writeAPI.WritePoint(ctx, point))
+
+	queryAPI := client.QueryAPI(container.Organization())
+	result, err := queryAPI.Query(ctx, `from(bucket: "metrics") |> range(start: -1h) |> filter(fn: (r) => r._measurement == "cpu")`)
+	require.NoError(t, err)
+
+	require.True(t, result.Next(), "expected a record: %v", result.Err())
+	assert.Equal(t, 42.5, result.Record().Value())
+}
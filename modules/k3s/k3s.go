@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/mount"
@@ -13,6 +15,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -75,7 +78,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Env: map[string]string{
 			"K3S_KUBECONFIG_MODE": "644",
 		},
-		WaitingFor: wait.ForLog(".*Node controller sync successful.*").AsRegexp(),
+		WaitingFor: defaultWaitStrategy(),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -95,6 +98,17 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	return &K3sContainer{Container: container}, nil
 }
 
+// defaultWaitStrategy waits for the node to be registered, then for the API server to be ready,
+// and for the default service account to be created, as the pods can't be created in the default
+// namespace without it.
+func defaultWaitStrategy() wait.Strategy {
+	return wait.ForAll(
+		wait.ForLog(".*Node controller sync successful.*").AsRegexp(),
+		wait.ForExec([]string{"kubectl", "get", "--raw", "/readyz"}),
+		wait.ForExec([]string{"kubectl", "get", "serviceaccount", "default", "--namespace", "default"}),
+	).WithStartupTimeoutDefault(2 * time.Minute)
+}
+
 func getContainerHost(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (string, error) {
 	// Use a dummy request to get the provider from options.
 	var req testcontainers.GenericContainerRequest
@@ -182,7 +196,8 @@ func unmarshal(bytes []byte) (*KubeConfigValue, error) {
 	return &kubeConfig, nil
 }
 
-// LoadImages loads images into the k3s container.
+// LoadImages loads images into the k3s container, so that the pods can use them without pulling them,
+// e.g. with the Never or IfNotPresent image pull policy. The images must be present in the Docker host.
 func (c *K3sContainer) LoadImages(ctx context.Context, images ...string) error {
 	provider, err := testcontainers.ProviderDocker.GetProvider()
 	if err != nil {
@@ -198,21 +213,36 @@ func (c *K3sContainer) LoadImages(ctx context.Context, images ...string) error {
 		_ = os.Remove(imagesTar.Name())
 	}()
 
-	err = provider.SaveImages(context.Background(), imagesTar.Name(), images...)
+	err = provider.SaveImages(ctx, imagesTar.Name(), images...)
 	if err != nil {
 		return fmt.Errorf("saving images %w", err)
 	}
 
 	containerPath := fmt.Sprintf("/tmp/%s", filepath.Base(imagesTar.Name()))
-	err = c.Container.CopyFileToContainer(ctx, imagesTar.Name(), containerPath, 0x644)
+	err = c.Container.CopyFileToContainer(ctx, imagesTar.Name(), containerPath, 0o644)
 	if err != nil {
 		return fmt.Errorf("copying image to container %w", err)
 	}
 
-	_, _, err = c.Container.Exec(ctx, []string{"ctr", "-n=k8s.io", "images", "import", containerPath})
+	code, output, err := c.Container.Exec(ctx, []string{"ctr", "-n=k8s.io", "images", "import", containerPath}, exec.Multiplexed())
+	if err != nil {
+		return fmt.Errorf("importing image %w", err)
+	}
+
+	out, err := io.ReadAll(output)
 	if err != nil {
 		return fmt.Errorf("importing image %w", err)
 	}
 
+	if code != 0 {
+		return fmt.Errorf("importing image: exit code %d: %s", code, strings.TrimSpace(string(out)))
+	}
+
+	// the archive is no longer needed once its images are imported
+	_, _, err = c.Container.Exec(ctx, []string{"rm", containerPath})
+	if err != nil {
+		return fmt.Errorf("removing images archive %w", err)
+	}
+
 	return nil
 }
@@ -139,6 +139,13 @@ func Test_APIServerReady(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// the container is started once the default service account is created,
+	// so the pods can be created right away.
+	_, err = k8s.CoreV1().ServiceAccounts("default").Get(ctx, "default", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the default service account %v", err)
+	}
+
 	pod := &corev1.Pod{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Pod",
@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"strings"
 
@@ -10,10 +11,15 @@ import (
 	"golang.org/x/mod/semver"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const publicPort = nat.Port("9093/tcp")
+
+// brokerPort is the port of the listener used by the clients running in the same Docker network as the Kafka container.
+const brokerPort = 9092
+
 const (
 	starterScript = "/usr/sbin/testcontainers_start.sh"
 
@@ -38,6 +44,9 @@ type KafkaContainer struct {
 
 // RunContainer creates an instance of the Kafka container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*KafkaContainer, error) {
+	// the host advertised to the clients in the same Docker network, which depends on the network aliases set by the options
+	internalHost := ""
+
 	req := testcontainers.ContainerRequest{
 		Image:        "confluentinc/confluent-local:7.5.0",
 		ExposedPorts: []string{string(publicPort)},
@@ -77,7 +86,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 							return err
 						}
 
-						scriptContent := fmt.Sprintf(starterScriptContent, host, port.Int(), host)
+						scriptContent := fmt.Sprintf(starterScriptContent, host, port.Int(), internalHost)
 
 						return c.CopyToContainer(ctx, []byte(scriptContent), starterScript, 0o755)
 					},
@@ -107,6 +116,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	clusterID := genericContainerReq.Env["CLUSTER_ID"]
 
 	configureControllerQuorumVoters(&genericContainerReq)
+	internalHost = internalBrokerHost(&genericContainerReq)
 
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
@@ -122,6 +132,52 @@ func WithClusterID(clusterID string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithTopics creates the given topics, with one partition each, once the Kafka server is ready,
+// so that the tests can produce and consume messages without creating them first.
+// The topics that already exist are left untouched.
+func WithTopics(topics ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostStarts: []testcontainers.ContainerHook{
+				// the hooks of the module wait for the Kafka server to be ready before this one runs
+				func(ctx context.Context, c testcontainers.Container) error {
+					for _, topic := range topics {
+						if err := createTopic(ctx, c, topic); err != nil {
+							return err
+						}
+					}
+
+					return nil
+				},
+			},
+		})
+	}
+}
+
+// createTopic creates a topic with one partition using the kafka-topics tool of the container.
+func createTopic(ctx context.Context, c testcontainers.Container, topic string) error {
+	cmd := []string{
+		"kafka-topics",
+		"--bootstrap-server", fmt.Sprintf("localhost:%d", brokerPort),
+		"--create", "--if-not-exists",
+		"--topic", topic,
+		"--partitions", "1",
+		"--replication-factor", "1",
+	}
+
+	exitCode, reader, err := c.Exec(ctx, cmd, exec.Multiplexed())
+	if err != nil {
+		return fmt.Errorf("create topic %s: %w", topic, err)
+	}
+
+	if exitCode != 0 {
+		output, _ := io.ReadAll(reader)
+		return fmt.Errorf("create topic %s: exit code %d: %s", topic, exitCode, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
 // Brokers retrieves the broker connection strings from Kafka with only one entry,
 // defined by the exposed public port.
 func (kc *KafkaContainer) Brokers(ctx context.Context) ([]string, error) {
@@ -160,6 +216,20 @@ func configureControllerQuorumVoters(req *testcontainers.GenericContainerRequest
 	// }
 }
 
+// internalBrokerHost returns the host advertised by the listener used by the clients in the same Docker network
+// as the Kafka container, and by the broker itself. It's the first alias in the first network, if any.
+// Else, it's the hostname of the container, which is resolved by the shell running the starter script.
+func internalBrokerHost(req *testcontainers.GenericContainerRequest) string {
+	if len(req.Networks) > 0 {
+		nw := req.Networks[0]
+		if len(req.NetworkAliases[nw]) > 0 {
+			return req.NetworkAliases[nw][0]
+		}
+	}
+
+	return "$(hostname)"
+}
+
 // validateKRaftVersion validates if the image version is compatible with KRaft mode,
 // which is available since version 7.0.0.
 func validateKRaftVersion(fqName string) error {
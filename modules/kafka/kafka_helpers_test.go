@@ -62,6 +62,55 @@ func TestConfigureQuorumVoters(t *testing.T) {
 	}
 }
 
+func TestInternalBrokerHost(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *testcontainers.GenericContainerRequest
+		expectedHost string
+	}{
+		{
+			name:         "hostname without networks",
+			req:          &testcontainers.GenericContainerRequest{},
+			expectedHost: "$(hostname)",
+		},
+		{
+			name: "first network alias of the first network",
+			req: &testcontainers.GenericContainerRequest{
+				ContainerRequest: testcontainers.ContainerRequest{
+					Networks: []string{"foo", "bar"},
+					NetworkAliases: map[string][]string{
+						"foo": {"foo0", "foo1"},
+						"bar": {"bar0", "bar1"},
+					},
+				},
+			},
+			expectedHost: "foo0",
+		},
+		{
+			name: "hostname if no alias in the first network",
+			req: &testcontainers.GenericContainerRequest{
+				ContainerRequest: testcontainers.ContainerRequest{
+					Networks: []string{"foo", "bar"},
+					NetworkAliases: map[string][]string{
+						"bar": {"bar0", "bar1"},
+					},
+				},
+			},
+			expectedHost: "$(hostname)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			host := internalBrokerHost(test.req)
+
+			if host != test.expectedHost {
+				t.Fatalf("expected internal broker host to be %s, got %s", test.expectedHost, host)
+			}
+		})
+	}
+}
+
 func TestValidateKRaftVersion(t *testing.T) {
 	tests := []struct {
 		name    string
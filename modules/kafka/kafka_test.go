@@ -9,6 +9,8 @@ import (
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/kafka"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 func TestKafka(t *testing.T) {
@@ -85,6 +87,106 @@ func TestKafka(t *testing.T) {
 	}
 }
 
+func TestKafka_withTopics(t *testing.T) {
+	ctx := context.Background()
+
+	// withTopics {
+	kafkaContainer, err := kafka.RunContainer(ctx, kafka.WithClusterID("kraftCluster"), kafka.WithTopics("orders", "payments"))
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := kafkaContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	brokers, err := kafkaContainer.Brokers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := sarama.NewClusterAdmin(brokers, sarama.NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer admin.Close()
+
+	topics, err := admin.ListTopics()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, topic := range []string{"orders", "payments"} {
+		if _, ok := topics[topic]; !ok {
+			t.Fatalf("expected topic %s to be created", topic)
+		}
+	}
+}
+
+func TestKafka_networkClients(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := nw.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	// networkClients {
+	kafkaContainer, err := kafka.RunContainer(ctx,
+		kafka.WithClusterID("kraftCluster"),
+		kafka.WithTopics("orders"),
+		network.WithNetwork([]string{"kafka"}, nw),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := kafkaContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// a client in the same network connects to the broker listener, using the network alias
+	client, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "confluentinc/confluent-local:7.5.0",
+			Entrypoint: []string{"kafka-topics"},
+			Cmd:        []string{"--bootstrap-server", "kafka:9092", "--describe", "--topic", "orders"},
+			Networks:   []string{nw.Name},
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := client.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	state, err := client.State(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if state.ExitCode != 0 {
+		t.Fatalf("expected the network client to describe the topic, got exit code %d", state.ExitCode)
+	}
+}
+
 func TestKafka_invalidVersion(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,38 @@
+package keycloak_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/keycloak"
+)
+
+func ExampleRunContainer() {
+	// runKeycloakContainer {
+	ctx := context.Background()
+
+	keycloakContainer, err := keycloak.RunContainer(ctx, testcontainers.WithImage("quay.io/keycloak/keycloak:25.0"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := keycloakContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := keycloakContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,124 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage          = "quay.io/keycloak/keycloak:25.0"
+	defaultPort           = "8080/tcp"
+	defaultManagementPort = "9000/tcp"
+	defaultAdminUsername  = "admin"
+	defaultAdminPassword  = "admin"
+	realmImportDir        = "/opt/keycloak/data/import/"
+)
+
+// KeycloakContainer represents the Keycloak container type used in the module
+type KeycloakContainer struct {
+	testcontainers.Container
+	adminUsername string
+	adminPassword string
+}
+
+// RunContainer creates an instance of the Keycloak container type, running Keycloak in development mode,
+// which imports the realms of the files copied with WithRealmImportFile at startup.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*KeycloakContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort, defaultManagementPort},
+		Env: map[string]string{
+			"KEYCLOAK_ADMIN":          defaultAdminUsername,
+			"KEYCLOAK_ADMIN_PASSWORD": defaultAdminPassword,
+			"KC_HEALTH_ENABLED":       "true",
+		},
+		Cmd: []string{"start-dev", "--import-realm"},
+		// the health endpoints are served on the management port, and the server is only ready
+		// once the realms are imported.
+		WaitingFor: wait.ForHTTP("/health/ready").WithPort(defaultManagementPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeycloakContainer{
+		Container:     container,
+		adminUsername: genericContainerReq.Env["KEYCLOAK_ADMIN"],
+		adminPassword: genericContainerReq.Env["KEYCLOAK_ADMIN_PASSWORD"],
+	}, nil
+}
+
+// WithAdminCredentials sets the username and the password of the administrator of the master realm.
+// The default username and password are "admin".
+func WithAdminCredentials(username string, password string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Env["KEYCLOAK_ADMIN"] = username
+		req.Env["KEYCLOAK_ADMIN_PASSWORD"] = password
+	}
+}
+
+// WithRealmImportFile copies the given realm export, a JSON file, to the container, so that the realm is imported
+// when Keycloak starts. The name of the file must end with ".json". The option can be used multiple times,
+// to import several realms.
+func WithRealmImportFile(realmFile string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      realmFile,
+			ContainerFilePath: realmImportDir + filepath.Base(realmFile),
+			FileMode:          0o644,
+		})
+	}
+}
+
+// URL returns the base URL of Keycloak, in the http://<host>:<port> format.
+func (c *KeycloakContainer) URL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, port.Port()), nil
+}
+
+// IssuerURL returns the URL of the OpenID Connect issuer of the given realm, which is the base URL
+// of its discovery document, to be configured in the services validating the tokens of the realm.
+// As Keycloak runs in development mode, the issuer of the tokens is derived from the URL the clients
+// request them with, so they must use this URL too.
+func (c *KeycloakContainer) IssuerURL(ctx context.Context, realm string) (string, error) {
+	url, err := c.URL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return url + "/realms/" + realm, nil
+}
+
+// AdminUsername returns the username of the administrator of the master realm.
+func (c *KeycloakContainer) AdminUsername() string {
+	return c.adminUsername
+}
+
+// AdminPassword returns the password of the administrator of the master realm.
+func (c *KeycloakContainer) AdminPassword() string {
+	return c.adminPassword
+}
@@ -0,0 +1,125 @@
+package keycloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/keycloak"
+)
+
+func TestKeycloak(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := keycloak.RunContainer(ctx,
+		testcontainers.WithImage("quay.io/keycloak/keycloak:25.0"),
+		// withRealm {
+		keycloak.WithRealmImportFile(filepath.Join("testdata", "realm.json")),
+		keycloak.WithAdminCredentials("root", "root-password"),
+		// }
+	)
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+	})
+
+	t.Run("discovers the imported realm", func(t *testing.T) {
+		// issuerURL {
+		issuer, err := container.IssuerURL(ctx, "testcontainers")
+		// }
+		require.NoError(t, err)
+
+		resp, err := http.Get(issuer + "/.well-known/openid-configuration")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var discovery struct {
+			Issuer        string `json:"issuer"`
+			TokenEndpoint string `json:"token_endpoint"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&discovery))
+		assert.Equal(t, issuer, discovery.Issuer)
+		assert.Equal(t, issuer+"/protocol/openid-connect/token", discovery.TokenEndpoint)
+	})
+
+	t.Run("issues tokens to the imported users", func(t *testing.T) {
+		issuer, err := container.IssuerURL(ctx, "testcontainers")
+		require.NoError(t, err)
+
+		token := requestToken(t, issuer, url.Values{
+			"grant_type":    {"password"},
+			"client_id":     {"orders-service"},
+			"client_secret": {"orders-secret"},
+			"username":      {"alice"},
+			"password":      {"alice-password"},
+		})
+		assert.NotEmpty(t, token)
+	})
+
+	t.Run("authenticates the administrator", func(t *testing.T) {
+		assert.Equal(t, "root", container.AdminUsername())
+		assert.Equal(t, "root-password", container.AdminPassword())
+
+		// adminToken {
+		issuer, err := container.IssuerURL(ctx, "master")
+		require.NoError(t, err)
+
+		token := requestToken(t, issuer, url.Values{
+			"grant_type": {"password"},
+			"client_id":  {"admin-cli"},
+			"username":   {container.AdminUsername()},
+			"password":   {container.AdminPassword()},
+		})
+		// }
+
+		baseURL, err := container.URL(ctx)
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/admin/realms/testcontainers/users?username=alice", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var users []struct {
+			Email string `json:"email"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&users))
+		require.Len(t, users, 1)
+		assert.Equal(t, "alice@example.com", users[0].Email)
+	})
+}
+
+// requestToken requests an access token to the token endpoint of the realm of the given issuer.
+func requestToken(t *testing.T, issuer string, form url.Values) string {
+	t.Helper()
+
+	resp, err := http.Post(issuer+"/protocol/openid-connect/token", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&token))
+
+	return token.AccessToken
+}
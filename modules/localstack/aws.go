@@ -0,0 +1,68 @@
+package localstack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/docker/go-connections/nat"
+)
+
+const (
+	// accessKeyID and secretAccessKey are the credentials accepted by LocalStack, which does not validate them.
+	accessKeyID     = "test"
+	secretAccessKey = "test"
+)
+
+// Region returns the AWS region of the LocalStack container, set with WithRegion.
+func (c *LocalStackContainer) Region() string {
+	return c.region
+}
+
+// EndpointURL returns the URL of the edge port of the LocalStack container, which serves all the AWS services,
+// e.g. "http://localhost:32768".
+func (c *LocalStackContainer) EndpointURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, nat.Port(fmt.Sprintf("%d/tcp", defaultPort)))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%d", host, port.Int()), nil
+}
+
+// Credentials returns static AWS credentials for the LocalStack container, which does not validate them.
+func (c *LocalStackContainer) Credentials() aws.CredentialsProvider {
+	return credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, "")
+}
+
+// AWSConfig returns an AWS SDK v2 config pre-wired to the LocalStack container: its base endpoint is the endpoint URL
+// of the container, its region is the region of the container, and its credentials are the ones returned by Credentials.
+// The optional functions are applied to the options loading the default config, before the ones of the container,
+// e.g. to set the retry mode. Please note that the S3 clients must use path-style addressing.
+func (c *LocalStackContainer) AWSConfig(ctx context.Context, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
+	endpoint, err := c.EndpointURL(ctx)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	optFns = append(optFns,
+		config.WithRegion(c.region),
+		config.WithCredentialsProvider(c.Credentials()),
+	)
+
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("load AWS config: %w", err)
+	}
+
+	cfg.BaseEndpoint = aws.String(endpoint)
+
+	return cfg, nil
+}
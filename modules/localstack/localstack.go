@@ -89,10 +89,16 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		},
 	}
 
+	settings := defaultOptions()
 	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
 		opt.Customize(&localStackReq.GenericContainerRequest)
 	}
 
+	applyOptions(settings, &localStackReq.GenericContainerRequest)
+
 	if isLegacyMode(localStackReq.Image) {
 		return nil, fmt.Errorf("version=%s. Testcontainers for Go does not support running LocalStack in legacy mode. Please use a version >= 0.11.0", localStackReq.Image)
 	}
@@ -115,6 +121,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 
 	c := &LocalStackContainer{
 		Container: container,
+		region:    settings.Region,
 	}
 	return c, nil
 }
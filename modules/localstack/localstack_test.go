@@ -84,6 +84,43 @@ func TestConfigureDockerHost(t *testing.T) {
 	}
 }
 
+func TestApplyOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		req := generateContainerRequest()
+
+		applyOptions(defaultOptions(), &req.GenericContainerRequest)
+
+		assert.Equal(t, "us-east-1", req.Env["DEFAULT_REGION"])
+		assert.NotContains(t, req.Env, "SERVICES")
+	})
+
+	t.Run("region and services", func(t *testing.T) {
+		req := generateContainerRequest()
+
+		settings := defaultOptions()
+		WithRegion("eu-west-1")(&settings)
+		WithServices("s3", "sqs")(&settings)
+		applyOptions(settings, &req.GenericContainerRequest)
+
+		assert.Equal(t, "eu-west-1", req.Env["DEFAULT_REGION"])
+		assert.Equal(t, "s3,sqs", req.Env["SERVICES"])
+	})
+
+	t.Run("environment variables take precedence", func(t *testing.T) {
+		req := generateContainerRequest()
+		req.Env["DEFAULT_REGION"] = "ap-south-1"
+		req.Env["SERVICES"] = "dynamodb"
+
+		settings := defaultOptions()
+		WithRegion("eu-west-1")(&settings)
+		WithServices("s3")(&settings)
+		applyOptions(settings, &req.GenericContainerRequest)
+
+		assert.Equal(t, "ap-south-1", req.Env["DEFAULT_REGION"])
+		assert.Equal(t, "dynamodb", req.Env["SERVICES"])
+	})
+}
+
 func TestIsLegacyMode(t *testing.T) {
 	tests := []struct {
 		version string
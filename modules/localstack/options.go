@@ -0,0 +1,60 @@
+package localstack
+
+import (
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// defaultRegion is the AWS region of the LocalStack container, unless WithRegion is used.
+const defaultRegion = "us-east-1"
+
+type options struct {
+	Region   string
+	Services []string
+}
+
+func defaultOptions() options {
+	return options{
+		Region: defaultRegion,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the LocalStack container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithRegion sets the AWS region of the LocalStack container, which is used by the AWS config
+// returned by the AWSConfig method. It's also the default region of LocalStack for versions prior to 2.0.
+func WithRegion(region string) Option {
+	return func(o *options) {
+		o.Region = region
+	}
+}
+
+// WithServices sets the AWS services to be enabled in the LocalStack container, e.g. "s3" or "sqs",
+// so that the other ones are not loaded. By default, all the services are enabled on demand.
+func WithServices(services ...string) Option {
+	return func(o *options) {
+		o.Services = append(o.Services, services...)
+	}
+}
+
+// applyOptions transfers the settings to the environment variables of the container request,
+// unless they are explicitly set as environment variables.
+func applyOptions(settings options, req *testcontainers.GenericContainerRequest) {
+	if _, ok := req.Env["DEFAULT_REGION"]; !ok {
+		req.Env["DEFAULT_REGION"] = settings.Region
+	}
+
+	if _, ok := req.Env["SERVICES"]; !ok && len(settings.Services) > 0 {
+		req.Env["SERVICES"] = strings.Join(settings.Services, ",")
+	}
+}
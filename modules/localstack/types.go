@@ -7,6 +7,7 @@ import (
 // LocalStackContainer represents the LocalStack container type used in the module
 type LocalStackContainer struct {
 	testcontainers.Container
+	region string
 }
 
 // LocalStackContainerRequest represents the LocalStack container request type used in the module
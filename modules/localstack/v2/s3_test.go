@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -128,3 +129,39 @@ func TestS3(t *testing.T) {
 		})
 	})
 }
+
+func TestS3WithAWSConfig(t *testing.T) {
+	ctx := context.Background()
+
+	// awsConfig {
+	container, err := localstack.RunContainer(ctx, localstack.WithServices("s3"), localstack.WithRegion("eu-west-1"))
+	require.NoError(t, err)
+
+	awsCfg, err := container.AWSConfig(ctx)
+	require.NoError(t, err)
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+	// }
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	assert.Equal(t, "eu-west-1", awsCfg.Region)
+
+	bucketName := "localstack-config-bucket"
+	_, err = client.CreateBucket(ctx, &s3.CreateBucketInput{
+		Bucket: aws.String(bucketName),
+		CreateBucketConfiguration: &types.CreateBucketConfiguration{
+			LocationConstraint: types.BucketLocationConstraint(container.Region()),
+		},
+	})
+	require.NoError(t, err)
+
+	output, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	require.NoError(t, err)
+	require.Len(t, output.Buckets, 1)
+	assert.Equal(t, bucketName, *output.Buckets[0].Name)
+}
@@ -110,6 +110,16 @@ func WithScripts(scripts ...string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// defaultWaitStrategy waits for the server listening on the 3306 port. On the first start, the entrypoint of the image
+// starts a temporary server, which doesn't listen on any TCP port ("port: 0"), to run the init scripts,
+// so waiting for the "ready for connections" message alone could return before the init scripts are run.
+func defaultWaitStrategy() wait.Strategy {
+	return wait.ForAll(
+		wait.ForLog("port: 3306  mariadb.org binary distribution"),
+		wait.ForListeningPort("3306/tcp"),
+	)
+}
+
 // RunContainer creates an instance of the MariaDB container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MariaDBContainer, error) {
 	req := testcontainers.ContainerRequest{
@@ -120,7 +130,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 			"MARIADB_PASSWORD": defaultPassword,
 			"MARIADB_DATABASE": defaultDatabaseName,
 		},
-		WaitingFor: wait.ForLog("port: 3306  mariadb.org binary distribution"),
+		WaitingFor: defaultWaitStrategy(),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
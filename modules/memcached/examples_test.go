@@ -0,0 +1,38 @@
+package memcached_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/memcached"
+)
+
+func ExampleRunContainer() {
+	// runMemcachedContainer {
+	ctx := context.Background()
+
+	memcachedContainer, err := memcached.RunContainer(ctx, testcontainers.WithImage("memcached:1.6.24-alpine"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := memcachedContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := memcachedContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,74 @@
+package memcached
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "memcached:1.6.24-alpine"
+	defaultPort  = "11211/tcp"
+)
+
+// MemcachedContainer represents the Memcached container type used in the module
+type MemcachedContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the Memcached container type
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MemcachedContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		WaitingFor:   wait.ForListeningPort(defaultPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	return testcontainers.RunContainer(ctx, genericContainerReq, func(container testcontainers.Container) *MemcachedContainer {
+		return &MemcachedContainer{Container: container}
+	})
+}
+
+// WithMemoryLimit sets the amount of memory, in megabytes, used by Memcached to store the items.
+// Memcached evicts the least recently used items once the limit is reached. The default limit is 64 megabytes.
+func WithMemoryLimit(megabytes int) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Cmd = append(req.Cmd, "--memory-limit", strconv.Itoa(megabytes))
+	}
+}
+
+// WithMaxItemSize sets the maximum size of the items stored by Memcached, e.g. "2m".
+// The default size is 1 megabyte.
+func WithMaxItemSize(size string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Cmd = append(req.Cmd, "--max-item-size", size)
+	}
+}
+
+// Address returns the address of the Memcached server, in the <host>:<port> format,
+// to be used as the server address of the Memcached clients.
+func (c *MemcachedContainer) Address(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
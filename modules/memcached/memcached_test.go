@@ -0,0 +1,104 @@
+package memcached_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bradfitz/gomemcache/memcache"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/memcached"
+)
+
+func TestMemcached(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := memcached.RunContainer(ctx, testcontainers.WithImage("memcached:1.6.24-alpine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// memcachedClient {
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := memcache.New(address)
+	// }
+
+	err = client.Set(&memcache.Item{Key: "greeting", Value: []byte("Hello, Memcached!")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := client.Get("greeting")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(item.Value, []byte("Hello, Memcached!")) {
+		t.Fatalf("expected value %q, got %q", "Hello, Memcached!", item.Value)
+	}
+
+	if err := client.Delete("greeting"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Get("greeting")
+	if !errors.Is(err, memcache.ErrCacheMiss) {
+		t.Fatalf("expected a cache miss, got %v", err)
+	}
+}
+
+func TestMemcached_withMaxItemSize(t *testing.T) {
+	ctx := context.Background()
+
+	// withMaxItemSize {
+	container, err := memcached.RunContainer(ctx,
+		memcached.WithMemoryLimit(128),
+		memcached.WithMaxItemSize("2m"),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := memcache.New(address)
+
+	// the item is larger than the default maximum size of 1 megabyte
+	value := bytes.Repeat([]byte("a"), 1536*1024)
+
+	if err := client.Set(&memcache.Item{Key: "large", Value: value}); err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := client.Get("large")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(item.Value) != len(value) {
+		t.Fatalf("expected a value of %d bytes, got %d", len(value), len(item.Value))
+	}
+}
@@ -3,8 +3,11 @@ package minio
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -12,6 +15,8 @@ const (
 	defaultUser     = "minioadmin"
 	defaultPassword = "minioadmin"
 	defaultImage    = "docker.io/minio/minio:RELEASE.2024-01-16T16-07-38Z"
+	s3Port          = "9000/tcp"
+	consolePort     = "9001/tcp"
 )
 
 // MinioContainer represents the Minio container type used in the module
@@ -39,6 +44,51 @@ func WithPassword(password string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithBuckets creates the given buckets once the Minio container is ready, using the mc client of the container
+// with the root credentials, so that the tests can store objects without creating them first.
+// The buckets that already exist are left untouched.
+func WithBuckets(buckets ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return createBuckets(ctx, c, buckets)
+				},
+			},
+		})
+	}
+}
+
+// createBuckets creates the buckets with the mc client of the container. The root credentials are read
+// from the environment of the container, and the buckets are passed as arguments of the shell, so that
+// they are not interpreted by it.
+func createBuckets(ctx context.Context, c testcontainers.Container, buckets []string) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	cmd := []string{
+		"sh", "-c",
+		`mc alias set testcontainers http://localhost:9000 "$MINIO_ROOT_USER" "$MINIO_ROOT_PASSWORD" >/dev/null && mc mb --ignore-existing "$@"`,
+		"sh",
+	}
+	for _, bucket := range buckets {
+		cmd = append(cmd, "testcontainers/"+bucket)
+	}
+
+	exitCode, reader, err := c.Exec(ctx, cmd, exec.Multiplexed())
+	if err != nil {
+		return fmt.Errorf("create buckets: %w", err)
+	}
+
+	if exitCode != 0 {
+		output, _ := io.ReadAll(reader)
+		return fmt.Errorf("create buckets: exit code %d: %s", exitCode, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
 // ConnectionString returns the connection string for the minio container, using the default 9000 port, and
 // obtaining the host and exposed port from the container.
 func (c *MinioContainer) ConnectionString(ctx context.Context) (string, error) {
@@ -46,24 +96,31 @@ func (c *MinioContainer) ConnectionString(ctx context.Context) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	port, err := c.MappedPort(ctx, "9000/tcp")
+	port, err := c.MappedPort(ctx, s3Port)
 	if err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("%s:%s", host, port.Port()), nil
 }
 
+// ConsoleURL returns the URL of the web console of the minio container, using the 9001 port,
+// where it's possible to log in with the root credentials.
+func (c *MinioContainer) ConsoleURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, consolePort, "http")
+}
+
 // RunContainer creates an instance of the Minio container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MinioContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImage,
-		ExposedPorts: []string{"9000/tcp"},
-		WaitingFor:   wait.ForHTTP("/minio/health/live").WithPort("9000"),
+		ExposedPorts: []string{s3Port, consolePort},
+		// the readiness probe checks that the server can serve requests, unlike the liveness one
+		WaitingFor: wait.ForHTTP("/minio/health/ready").WithPort(s3Port),
 		Env: map[string]string{
 			"MINIO_ROOT_USER":     defaultUser,
 			"MINIO_ROOT_PASSWORD": defaultPassword,
 		},
-		Cmd: []string{"server", "/data"},
+		Cmd: []string{"server", "/data", "--console-address", ":9001"},
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -3,6 +3,7 @@ package minio_test
 import (
 	"context"
 	"io"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -81,3 +82,61 @@ func TestMinio(t *testing.T) {
 		t.Fatalf("expected %d; got %d", contentLength, n)
 	}
 }
+
+func TestMinio_withBuckets(t *testing.T) {
+	ctx := context.Background()
+
+	// withBuckets {
+	container, err := tcminio.RunContainer(ctx, tcminio.WithBuckets("images", "documents"))
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	url, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	minioClient, err := minio.New(url, &minio.Options{
+		Creds:  credentials.NewStaticV4(container.Username, container.Password, ""),
+		Secure: false,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bucket := range []string{"images", "documents"} {
+		exists, err := minioClient.BucketExists(ctx, bucket)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !exists {
+			t.Fatalf("expected bucket %s to be created", bucket)
+		}
+	}
+
+	// consoleURL {
+	consoleURL, err := container.ConsoleURL(ctx)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get(consoleURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the console to answer with 200, got %d", resp.StatusCode)
+	}
+}
@@ -1,21 +1,29 @@
 package mongodb
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 // defaultImage is the default MongoDB container image
 const defaultImage = "mongo:6"
 
+// replicaSetName is the name of the single-node replica set created by WithReplicaSet
+const replicaSetName = "rs0"
+
 // MongoDBContainer represents the MongoDB container type used in the module
 type MongoDBContainer struct {
 	testcontainers.Container
-	username string
-	password string
+	username   string
+	password   string
+	replicaSet bool
 }
 
 // RunContainer creates an instance of the MongoDB container type
@@ -44,6 +52,12 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		return nil, fmt.Errorf("if you specify username or password, you must provide both of them")
 	}
 
+	replicaSet := isReplicaSet(genericContainerReq.Cmd)
+	if replicaSet && username != "" {
+		// the members of a replica set with authentication must share a key file, which the module does not provide
+		return nil, fmt.Errorf("the replica set cannot be used with a username and a password")
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -52,7 +66,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	if username != "" && password != "" {
 		return &MongoDBContainer{Container: container, username: username, password: password}, nil
 	}
-	return &MongoDBContainer{Container: container}, nil
+	return &MongoDBContainer{Container: container, replicaSet: replicaSet}, nil
 }
 
 // WithUsername sets the initial username to be created when the container starts
@@ -73,8 +87,72 @@ func WithPassword(password string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithReplicaSet starts MongoDB as a single-node replica set named "rs0", initiating it once the server accepts
+// connections and waiting for the node to become the primary, so that transactions and change streams can be used.
+// The member of the replica set is configured as "localhost:27017", so the connection string of the container
+// connects directly to it, instead of discovering the members of the replica set.
+// It cannot be used with a username and a password.
+func WithReplicaSet() testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Cmd = append(req.Cmd, "--replSet", replicaSetName)
+
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				initiateReplicaSet,
+			},
+		})
+	}
+}
+
+// initiateReplicaSet initiates the single-node replica set, and waits for the node to become the primary.
+func initiateReplicaSet(ctx context.Context, c testcontainers.Container) error {
+	config := fmt.Sprintf("rs.initiate({_id: '%s', members: [{_id: 0, host: 'localhost:27017'}]})", replicaSetName)
+
+	exitCode, reader, err := c.Exec(ctx, eval(config), exec.Multiplexed())
+	if err != nil {
+		return fmt.Errorf("initiate replica set: %w", err)
+	}
+
+	if exitCode != 0 {
+		output, _ := io.ReadAll(reader)
+		return fmt.Errorf("initiate replica set: exit code %d: %s", exitCode, strings.TrimSpace(string(output)))
+	}
+
+	return wait.ForExec(eval("db.hello().isWritablePrimary")).
+		WithResponseMatcher(func(body io.Reader) bool {
+			bs, err := io.ReadAll(body)
+			if err != nil {
+				return false
+			}
+
+			return bytes.Contains(bs, []byte("true"))
+		}).
+		WaitUntilReady(ctx, c)
+}
+
+// eval returns the command evaluating the given JavaScript expression with the MongoDB shell,
+// which is mongosh since MongoDB 6, and mongo in the previous versions.
+func eval(expression string) []string {
+	return []string{
+		"sh", "-c",
+		fmt.Sprintf("if command -v mongosh >/dev/null 2>&1; then mongosh --quiet --eval %[1]q; else mongo --quiet --eval %[1]q; fi", expression),
+	}
+}
+
+// isReplicaSet returns true if the MongoDB server is started as a member of a replica set.
+func isReplicaSet(cmd []string) bool {
+	for _, arg := range cmd {
+		if arg == "--replSet" || strings.HasPrefix(arg, "--replSet=") {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ConnectionString returns the connection string for the MongoDB container.
 // If you provide a username and a password, the connection string will also include them.
+// If the container was started with WithReplicaSet, the connection string connects directly to the member of the replica set.
 func (c *MongoDBContainer) ConnectionString(ctx context.Context) (string, error) {
 	host, err := c.Host(ctx)
 	if err != nil {
@@ -87,5 +165,8 @@ func (c *MongoDBContainer) ConnectionString(ctx context.Context) (string, error)
 	if c.username != "" && c.password != "" {
 		return fmt.Sprintf("mongodb://%s:%s@%s:%s", c.username, c.password, host, port.Port()), nil
 	}
+	if c.replicaSet {
+		return fmt.Sprintf("mongodb://%s:%s/?directConnection=true", host, port.Port()), nil
+	}
 	return c.Endpoint(ctx, "mongodb")
 }
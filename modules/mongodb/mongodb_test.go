@@ -5,6 +5,7 @@ import (
 	"log"
 	"testing"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -70,3 +71,67 @@ func TestMongoDB(t *testing.T) {
 		})
 	}
 }
+
+func TestMongoDBReplicaSet(t *testing.T) {
+	ctx := context.Background()
+
+	// withReplicaSet {
+	mongodbContainer, err := mongodb.RunContainer(ctx, mongodb.WithReplicaSet())
+	// }
+	if err != nil {
+		t.Fatalf("failed to start container: %s", err)
+	}
+
+	defer func() {
+		if err := mongodbContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+
+	endpoint, err := mongodbContainer.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("failed to get connection string: %s", err)
+	}
+
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(endpoint))
+	if err != nil {
+		t.Fatalf("failed to connect to MongoDB: %s", err)
+	}
+	defer mongoClient.Disconnect(ctx) // nolint:errcheck
+
+	collection := mongoClient.Database("test").Collection("orders")
+
+	// change streams require a replica set
+	stream, err := collection.Watch(ctx, mongo.Pipeline{})
+	if err != nil {
+		t.Fatalf("failed to watch the collection: %s", err)
+	}
+	defer stream.Close(ctx)
+
+	// transactions require a replica set
+	session, err := mongoClient.StartSession()
+	if err != nil {
+		t.Fatalf("failed to start session: %s", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return collection.InsertOne(sc, bson.M{"order": 1})
+	})
+	if err != nil {
+		t.Fatalf("failed to run the transaction: %s", err)
+	}
+
+	if !stream.Next(ctx) {
+		t.Fatalf("expected a change event: %s", stream.Err())
+	}
+}
+
+func TestMongoDBReplicaSet_withCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := mongodb.RunContainer(ctx, mongodb.WithReplicaSet(), mongodb.WithUsername("root"), mongodb.WithPassword("password"))
+	if err == nil {
+		t.Fatal("expected an error when using the replica set with credentials")
+	}
+}
@@ -3,6 +3,7 @@ module github.com/testcontainers/testcontainers-go/modules/mssql
 go 1.21
 
 require (
+	github.com/docker/go-connections v0.5.0
 	github.com/microsoft/go-mssqldb v1.7.0
 	github.com/testcontainers/testcontainers-go v0.29.1
 )
@@ -18,7 +19,6 @@ require (
 	github.com/cpuguy83/dockercfg v0.3.1 // indirect
 	github.com/distribution/reference v0.5.0 // indirect
 	github.com/docker/docker v25.0.3+incompatible // indirect
-	github.com/docker/go-connections v0.5.0 // indirect
 	github.com/docker/go-units v0.5.0 // indirect
 	github.com/felixge/httpsnoop v1.0.3 // indirect
 	github.com/go-logr/logr v1.2.4 // indirect
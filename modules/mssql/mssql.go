@@ -2,10 +2,21 @@ package mssql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"path/filepath"
 	"strings"
+	"time"
+	"unicode"
+
+	"github.com/docker/go-connections/nat"
+	_ "github.com/microsoft/go-mssqldb"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -23,12 +34,16 @@ type MSSQLServerContainer struct {
 	username string
 }
 
+// WithAcceptEULA accepts the End-User License Agreement of MS SQL Server, which is required for the server to start.
 func WithAcceptEULA() testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		req.Env["ACCEPT_EULA"] = "Y"
 	}
 }
 
+// WithPassword sets the password of the system administrator, which must follow the password policy of MS SQL Server:
+// at least 8 characters, from at least three of the uppercase letters, lowercase letters, digits and symbols categories.
+// Otherwise, RunContainer returns an error before starting the container.
 func WithPassword(password string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		if password == "" {
@@ -38,7 +53,90 @@ func WithPassword(password string) testcontainers.CustomizeRequestOption {
 	}
 }
 
-// RunContainer creates an instance of the MSSQLServer container type
+// WithInitScripts runs the given SQL scripts, in order, with the sqlcmd client of the container once the server is ready,
+// logged in as the system administrator. The container fails to start if any of the scripts fails.
+func WithInitScripts(scripts ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		files := make([]string, 0, len(scripts))
+		for _, script := range scripts {
+			cf := testcontainers.ContainerFile{
+				HostFilePath:      script,
+				ContainerFilePath: "/tmp/" + filepath.Base(script),
+				FileMode:          0o644,
+			}
+			req.Files = append(req.Files, cf)
+			files = append(files, cf.ContainerFilePath)
+		}
+
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					for _, file := range files {
+						if err := runScript(ctx, c, file); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			},
+		})
+	}
+}
+
+// runScript runs the given SQL script with sqlcmd, which is installed in /opt/mssql-tools18 by the recent images,
+// and in /opt/mssql-tools by the older ones. The password is read from the environment of the container.
+func runScript(ctx context.Context, c testcontainers.Container, file string) error {
+	cmd := []string{
+		"sh", "-c",
+		`sqlcmd=$(command -v sqlcmd || ls /opt/mssql-tools18/bin/sqlcmd /opt/mssql-tools/bin/sqlcmd 2>/dev/null | head -n 1) && ` +
+			`"$sqlcmd" -C -b -S localhost -U ` + defaultUsername + ` -P "$MSSQL_SA_PASSWORD" -i "$1"`,
+		"sh", file,
+	}
+
+	code, reader, err := c.Exec(ctx, cmd, exec.Multiplexed())
+	if err != nil {
+		return fmt.Errorf("run init script %s: %w", file, err)
+	}
+
+	if code != 0 {
+		output, _ := io.ReadAll(reader)
+		return fmt.Errorf("run init script %s: exit code %d: %s", file, code, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// validatePassword checks that the password follows the password policy of MS SQL Server, which the server
+// enforces at startup for the system administrator.
+// See https://learn.microsoft.com/en-us/sql/relational-databases/security/password-policy
+func validatePassword(password string) error {
+	if len(password) < 8 {
+		return errors.New("the password must be at least 8 characters long")
+	}
+
+	var upper, lower, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+
+	if upper+lower+digit+symbol < 3 {
+		return errors.New("the password must contain characters from at least three of these categories: uppercase letters, lowercase letters, digits and symbols")
+	}
+
+	return nil
+}
+
+// RunContainer creates an instance of the MSSQLServer container type.
+// The container is ready once the system administrator can log in, and run a query.
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MSSQLServerContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImage,
@@ -46,7 +144,6 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Env: map[string]string{
 			"MSSQL_SA_PASSWORD": defaultPassword,
 		},
-		WaitingFor: wait.ForLog("Recovery is complete."),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -54,10 +151,18 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Started:          true,
 	}
 
+	genericContainerReq.WaitingFor = wait.ForSQL(defaultPort, "sqlserver", func(host string, port nat.Port) string {
+		return connString(defaultUsername, genericContainerReq.Env["MSSQL_SA_PASSWORD"], host, port)
+	}).WithStartupTimeout(2 * time.Minute).WithPollInterval(time.Second)
+
 	for _, opt := range opts {
 		opt.Customize(&genericContainerReq)
 	}
 
+	if err := validatePassword(genericContainerReq.Env["MSSQL_SA_PASSWORD"]); err != nil {
+		return nil, fmt.Errorf("invalid password: %w", err)
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -86,3 +191,14 @@ func (c *MSSQLServerContainer) ConnectionString(ctx context.Context, args ...str
 
 	return connStr, nil
 }
+
+// connString returns the connection string used by the wait strategy.
+func connString(username string, password string, host string, port nat.Port) string {
+	u := url.URL{
+		Scheme: "sqlserver",
+		User:   url.UserPassword(username, password),
+		Host:   net.JoinHostPort(host, port.Port()),
+	}
+
+	return u.String()
+}
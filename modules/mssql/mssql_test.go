@@ -3,6 +3,8 @@ package mssql_test
 import (
 	"context"
 	"database/sql"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	_ "github.com/microsoft/go-mssqldb"
@@ -152,21 +154,39 @@ func TestMSSQLServerWithCustomStrongPassword(t *testing.T) {
 	}
 }
 
-// tests that a weak password is not accepted by the container due to Microsoft's password strength policy
+// tests that a weak password is rejected before starting the container, due to Microsoft's password strength policy
 func TestMSSQLServerWithInvalidPassword(t *testing.T) {
 	ctx := context.Background()
 
 	container, err := mssql.RunContainer(ctx,
-		testcontainers.WithWaitStrategy(
-			wait.ForLog("Password validation failed")),
 		mssql.WithAcceptEULA(),
 		mssql.WithPassword("weakPassword"),
 	)
-
 	if err == nil {
-		t.Log("Success: Received invalid password validation docker log.")
-	} else {
-		t.Fatalf("Expected a password validation log but got error: %s", err)
+		t.Cleanup(func() {
+			if err := container.Terminate(ctx); err != nil {
+				t.Fatalf("failed to terminate container: %s", err)
+			}
+		})
+		t.Fatal("Expected a password validation error")
+	}
+
+	if !strings.Contains(err.Error(), "invalid password") {
+		t.Fatalf("Expected a password validation error but got: %s", err)
+	}
+}
+
+func TestMSSQLServerWithInitScripts(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := mssql.RunContainer(ctx,
+		mssql.WithAcceptEULA(),
+		// withInitScripts {
+		mssql.WithInitScripts(filepath.Join("testdata", "seed.sql")),
+		// }
+	)
+	if err != nil {
+		t.Fatal(err)
 	}
 
 	// Clean up the container after the test is complete
@@ -175,6 +195,26 @@ func TestMSSQLServerWithInvalidPassword(t *testing.T) {
 			t.Fatalf("failed to terminate container: %s", err)
 		}
 	})
+
+	connectionString, err := container.ConnectionString(ctx, "database=inventory")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlserver", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM products WHERE id = 1").Scan(&name); err != nil {
+		t.Fatalf("error querying the seeded table: %+v", err)
+	}
+
+	if name != "keyboard" {
+		t.Fatalf("expected the seeded product, got %q", name)
+	}
 }
 
 func TestMSSQLServerWithAlternativeImage(t *testing.T) {
@@ -0,0 +1,30 @@
+package mssql
+
+import "testing"
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		password string
+		valid    bool
+	}{
+		{password: defaultPassword, valid: true},
+		{password: "Passw0rd", valid: true},
+		{password: "passw0rd!", valid: true},
+		{password: "Pa0!", valid: false},
+		{password: "weakPassword", valid: false},
+		{password: "12345678", valid: false},
+		{password: "", valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.password, func(t *testing.T) {
+			err := validatePassword(test.password)
+			if test.valid && err != nil {
+				t.Fatalf("expected password %q to be valid, got %s", test.password, err)
+			}
+			if !test.valid && err == nil {
+				t.Fatalf("expected password %q to be invalid", test.password)
+			}
+		})
+	}
+}
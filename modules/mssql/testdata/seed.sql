@@ -0,0 +1,14 @@
+CREATE DATABASE inventory;
+GO
+
+USE inventory;
+GO
+
+CREATE TABLE products (
+    id INT PRIMARY KEY,
+    name NVARCHAR(128) NOT NULL
+);
+GO
+
+INSERT INTO products (id, name) VALUES (1, 'keyboard');
+GO
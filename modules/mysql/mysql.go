@@ -46,6 +46,16 @@ func WithDefaultCredentials() testcontainers.CustomizeRequestOption {
 	}
 }
 
+// defaultWaitStrategy waits for the server listening on the 3306 port. On the first start, the entrypoint of the image
+// starts a temporary server, which doesn't listen on any TCP port ("port: 0"), to run the init scripts,
+// so waiting for the "ready for connections" message alone could return before the init scripts are run.
+func defaultWaitStrategy() wait.Strategy {
+	return wait.ForAll(
+		wait.ForLog("port: 3306  MySQL Community Server"),
+		wait.ForListeningPort("3306/tcp"),
+	)
+}
+
 // RunContainer creates an instance of the MySQL container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MySQLContainer, error) {
 	req := testcontainers.ContainerRequest{
@@ -56,7 +66,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 			"MYSQL_PASSWORD": defaultPassword,
 			"MYSQL_DATABASE": defaultDatabaseName,
 		},
-		WaitingFor: wait.ForLog("port: 3306  MySQL Community Server"),
+		WaitingFor: defaultWaitStrategy(),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
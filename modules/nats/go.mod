@@ -4,6 +4,7 @@ go 1.21
 
 require (
 	github.com/nats-io/nats.go v1.33.1
+	github.com/nats-io/nkeys v0.4.7
 	github.com/testcontainers/testcontainers-go v0.29.1
 )
 
@@ -35,7 +36,6 @@ require (
 	github.com/moby/sys/user v0.1.0 // indirect
 	github.com/moby/term v0.5.0 // indirect
 	github.com/morikuni/aec v1.0.0 // indirect
-	github.com/nats-io/nkeys v0.4.7 // indirect
 	github.com/nats-io/nuid v1.0.1 // indirect
 	github.com/opencontainers/go-digest v1.0.0 // indirect
 	github.com/opencontainers/image-spec v1.1.0 // indirect
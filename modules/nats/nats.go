@@ -2,7 +2,12 @@ package nats
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -14,6 +19,13 @@ const (
 	defaultMonitoringPort = "8222/tcp"
 )
 
+const (
+	// configFilePath is the path of the configuration file generated for the options of the module.
+	configFilePath = "/etc/nats/testcontainers.conf"
+	// customConfigFilePath is the path of the configuration file set with WithConfigFile, included by the generated one.
+	customConfigFilePath = "/etc/nats/custom.conf"
+)
+
 // NATSContainer represents the NATS container type used in the module
 type NATSContainer struct {
 	testcontainers.Container
@@ -26,7 +38,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	req := testcontainers.ContainerRequest{
 		Image:        "nats:2.9",
 		ExposedPorts: []string{defaultClientPort, defaultRoutingPort, defaultMonitoringPort},
-		Cmd:          []string{"-DV", "-js"},
+		Cmd:          []string{"-DV"},
 		WaitingFor:   wait.ForLog("Listening for client connections on 0.0.0.0:4222"),
 	}
 
@@ -44,12 +56,37 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		opt.Customize(&genericContainerReq)
 	}
 
+	if len(settings.NKeys) > 0 && (settings.CmdArgs["user"] != "" || settings.CmdArgs["pass"] != "") {
+		return nil, errors.New("nkeys can't be combined with a username and a password")
+	}
+
+	if settings.JetStream {
+		genericContainerReq.Cmd = append(genericContainerReq.Cmd, "-js")
+	}
+
 	// Include the command line arguments
 	for k, v := range settings.CmdArgs {
 		// always prepend the dash because it was removed in the options
 		genericContainerReq.Cmd = append(genericContainerReq.Cmd, []string{"--" + k, v}...)
 	}
 
+	if settings.ConfigFile != "" || len(settings.NKeys) > 0 {
+		if settings.ConfigFile != "" {
+			genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+				HostFilePath:      settings.ConfigFile,
+				ContainerFilePath: customConfigFilePath,
+				FileMode:          0o644,
+			})
+		}
+
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(renderConfig(settings)),
+			ContainerFilePath: configFilePath,
+			FileMode:          0o644,
+		})
+		genericContainerReq.Cmd = append(genericContainerReq.Cmd, "--config", configFilePath)
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -64,7 +101,8 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 	return &natsContainer, nil
 }
 
-// ConnectionString returns a connection string for the NATS container
+// ConnectionString returns a connection string for the NATS container, which can be passed to nats.Connect.
+// It includes the username and the password, when they are set.
 func (c *NATSContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
 	mappedPort, err := c.MappedPort(ctx, defaultClientPort)
 	if err != nil {
@@ -76,6 +114,33 @@ func (c *NATSContainer) ConnectionString(ctx context.Context, args ...string) (s
 		return "", err
 	}
 
-	uri := fmt.Sprintf("nats://%s:%s", hostIP, mappedPort.Port())
-	return uri, nil
+	u := url.URL{
+		Scheme: "nats",
+		Host:   net.JoinHostPort(hostIP, mappedPort.Port()),
+	}
+	if c.User != "" || c.Password != "" {
+		u.User = url.UserPassword(c.User, c.Password)
+	}
+
+	return u.String(), nil
+}
+
+// renderConfig generates the configuration file of the server, including the one set with WithConfigFile,
+// and declaring the users authenticating with the nkeys set with WithNKeys.
+func renderConfig(settings options) string {
+	var config strings.Builder
+
+	if settings.ConfigFile != "" {
+		fmt.Fprintf(&config, "include ./%s\n", path.Base(customConfigFilePath))
+	}
+
+	if len(settings.NKeys) > 0 {
+		config.WriteString("authorization {\n  users = [\n")
+		for _, nkey := range settings.NKeys {
+			fmt.Fprintf(&config, "    { nkey: %q }\n", nkey)
+		}
+		config.WriteString("  ]\n}\n")
+	}
+
+	return config.String()
 }
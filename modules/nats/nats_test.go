@@ -2,9 +2,11 @@ package nats_test
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nkeys"
 
 	tcnats "github.com/testcontainers/testcontainers-go/modules/nats"
 )
@@ -74,3 +76,118 @@ func TestNATS(t *testing.T) {
 		t.Fatalf("expected message to be 'hello', got '%s'", msg.Data)
 	}
 }
+
+func TestNATS_withCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := tcnats.RunContainer(ctx, tcnats.WithUsername("foo"), tcnats.WithPassword("bar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("failed to get connection string: %s", err)
+	}
+
+	// the connection string includes the credentials
+	nc, err := nats.Connect(uri)
+	if err != nil {
+		t.Fatalf("failed to connect to nats: %s", err)
+	}
+	nc.Close()
+}
+
+func TestNATS_withNKeys(t *testing.T) {
+	ctx := context.Background()
+
+	// withNKeys {
+	user, err := nkeys.CreateUser()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := user.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	container, err := tcnats.RunContainer(ctx, tcnats.WithNKeys(publicKey))
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("failed to get connection string: %s", err)
+	}
+
+	if _, err := nats.Connect(uri); err == nil {
+		t.Fatal("expected the anonymous connection to be rejected")
+	}
+
+	// connectWithNKey {
+	nc, err := nats.Connect(uri, nats.Nkey(publicKey, user.Sign))
+	// }
+	if err != nil {
+		t.Fatalf("failed to connect to nats: %s", err)
+	}
+	nc.Close()
+}
+
+func TestNATS_withConfigFile(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := tcnats.RunContainer(ctx,
+		// withConfigFile {
+		tcnats.WithConfigFile(filepath.Join("testdata", "nats.conf")),
+		// }
+		tcnats.WithJetStream(false),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("failed to get connection string: %s", err)
+	}
+
+	nc, err := nats.Connect(uri)
+	if err != nil {
+		t.Fatalf("failed to connect to nats: %s", err)
+	}
+	defer nc.Close()
+
+	if nc.MaxPayload() != 1024 {
+		t.Fatalf("expected the max payload of the config file, got %d", nc.MaxPayload())
+	}
+
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatalf("failed to create jetstream context: %s", err)
+	}
+
+	if _, err := js.AccountInfo(); err == nil {
+		t.Fatal("expected JetStream to be disabled")
+	}
+}
@@ -7,12 +7,16 @@ import (
 )
 
 type options struct {
-	CmdArgs map[string]string
+	CmdArgs    map[string]string
+	JetStream  bool
+	NKeys      []string
+	ConfigFile string
 }
 
 func defaultOptions() options {
 	return options{
-		CmdArgs: make(map[string]string, 0),
+		CmdArgs:   make(map[string]string, 0),
+		JetStream: true,
 	}
 }
 
@@ -27,12 +31,14 @@ func (o CmdOption) Customize(req *testcontainers.GenericContainerRequest) {
 	// NOOP to satisfy interface.
 }
 
+// WithUsername sets the username the clients must authenticate with, along with the password set with WithPassword.
 func WithUsername(username string) CmdOption {
 	return func(o *options) {
 		o.CmdArgs["user"] = username
 	}
 }
 
+// WithPassword sets the password the clients must authenticate with, along with the username set with WithUsername.
 func WithPassword(password string) CmdOption {
 	return func(o *options) {
 		o.CmdArgs["pass"] = password
@@ -48,3 +54,28 @@ func WithArgument(flag string, value string) CmdOption {
 		o.CmdArgs[flag] = value
 	}
 }
+
+// WithJetStream enables or disables JetStream, the persistence layer of NATS, which is enabled by default.
+// The streams are stored in the filesystem of the container.
+func WithJetStream(enabled bool) CmdOption {
+	return func(o *options) {
+		o.JetStream = enabled
+	}
+}
+
+// WithNKeys sets the public user nkeys the clients can authenticate with, signing the challenge of the server
+// with the matching seeds, e.g. with the nats.Nkey option of nats.go. It can't be combined with WithUsername and WithPassword.
+// See https://docs.nats.io/running-a-nats-service/configuration/securing_nats/auth_intro/nkey_auth for more information.
+func WithNKeys(publicKeys ...string) CmdOption {
+	return func(o *options) {
+		o.NKeys = append(o.NKeys, publicKeys...)
+	}
+}
+
+// WithConfigFile sets the configuration file of the NATS server, e.g. to define accounts, or to tune the limits of JetStream.
+// The options of the module take precedence over the configuration file, as they are command line arguments of the server.
+func WithConfigFile(configFile string) CmdOption {
+	return func(o *options) {
+		o.ConfigFile = configFile
+	}
+}
@@ -0,0 +1,35 @@
+package nats
+
+import "testing"
+
+func TestRenderConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings options
+		expected string
+	}{
+		{
+			name:     "config file",
+			settings: options{ConfigFile: "testdata/nats.conf"},
+			expected: "include ./custom.conf\n",
+		},
+		{
+			name:     "nkeys",
+			settings: options{NKeys: []string{"UA", "UB"}},
+			expected: "authorization {\n  users = [\n    { nkey: \"UA\" }\n    { nkey: \"UB\" }\n  ]\n}\n",
+		},
+		{
+			name:     "config file and nkeys",
+			settings: options{ConfigFile: "testdata/nats.conf", NKeys: []string{"UA"}},
+			expected: "include ./custom.conf\nauthorization {\n  users = [\n    { nkey: \"UA\" }\n  ]\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if config := renderConfig(test.settings); config != test.expected {
+				t.Fatalf("expected config %q, got %q", test.expected, config)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/docker/go-connections/nat"
 
@@ -48,9 +49,29 @@ func (c Neo4jContainer) BoltUrl(ctx context.Context) (string, error) {
 	return fmt.Sprintf("neo4j://%s:%d", host, mappedPort.Int()), nil
 }
 
-// RunContainer creates an instance of the Neo4j container type
+// HttpUrl returns the url of the HTTP API of the Neo4j container, using the http port, in the format of http://host:port
+func (c Neo4jContainer) HttpUrl(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+	containerPort, err := nat.NewPort("tcp", defaultHttpPort)
+	if err != nil {
+		return "", err
+	}
+	mappedPort, err := c.MappedPort(ctx, containerPort)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("http://%s:%d", host, mappedPort.Int()), nil
+}
+
+// RunContainer creates an instance of the Neo4j container type.
+// The container is ready once the bolt port is listening and the HTTP API serves its discovery document,
+// which both the community and the enterprise editions do once they are started.
 func RunContainer(ctx context.Context, options ...testcontainers.ContainerCustomizer) (*Neo4jContainer, error) {
 	httpPort, _ := nat.NewPort("tcp", defaultHttpPort)
+	boltPort, _ := nat.NewPort("tcp", defaultBoltPort)
 	request := testcontainers.ContainerRequest{
 		Image: fmt.Sprintf("docker.io/%s:%s", defaultImageName, defaultTag),
 		Env: map[string]string{
@@ -61,15 +82,10 @@ func RunContainer(ctx context.Context, options ...testcontainers.ContainerCustom
 			fmt.Sprintf("%s/tcp", defaultHttpPort),
 			fmt.Sprintf("%s/tcp", defaultHttpsPort),
 		},
-		WaitingFor: &wait.MultiStrategy{
-			Strategies: []wait.Strategy{
-				wait.NewLogStrategy("Bolt enabled on"),
-				&wait.HTTPStrategy{
-					Port:              httpPort,
-					StatusCodeMatcher: isHttpOk(),
-				},
-			},
-		},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(boltPort),
+			wait.ForHTTP("/").WithPort(httpPort).WithStatusCodeMatcher(isHttpOk()),
+		).WithStartupTimeoutDefault(2 * time.Minute),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -51,6 +52,29 @@ func TestNeo4j(outer *testing.T) {
 		}
 	})
 
+	outer.Run("serves the HTTP API", func(t *testing.T) {
+		// httpURL {
+		httpUrl, err := container.HttpUrl(ctx)
+		// }
+		if err != nil {
+			t.Fatalf("failed to get the HTTP URL: %s", err)
+		}
+
+		resp, err := http.Get(httpUrl)
+		if err != nil {
+			t.Fatalf("failed to get the discovery document: %s", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read the discovery document: %s", err)
+		}
+		if !strings.Contains(string(body), "bolt_direct") {
+			t.Fatalf("expected the discovery document to include the bolt URL but got: %s", body)
+		}
+	})
+
 	outer.Run("is configured with custom Neo4j settings", func(t *testing.T) {
 		env := getContainerEnv(t, ctx, container)
 
@@ -66,8 +90,10 @@ func TestNeo4jWithEnterpriseLicense(t *testing.T) {
 	ctx := context.Background()
 
 	images := map[string]string{
-		"StandardEdition":   "docker.io/neo4j:4.4",
-		"EnterpriseEdition": "docker.io/neo4j:4.4-enterprise",
+		"StandardEdition":    "docker.io/neo4j:4.4",
+		"EnterpriseEdition":  "docker.io/neo4j:4.4-enterprise",
+		"StandardEdition5":   "docker.io/neo4j:5.17",
+		"EnterpriseEdition5": "docker.io/neo4j:5.17-enterprise",
 	}
 
 	for edition, image := range images {
@@ -3,6 +3,8 @@ package ollama
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -10,6 +12,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/internal/core"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -37,6 +40,38 @@ func (c *OllamaContainer) ConnectionString(ctx context.Context) (string, error)
 	return fmt.Sprintf("http://%s:%d", host, port.Int()), nil
 }
 
+// OpenAIURL returns the URL of the OpenAI-compatible API of Ollama, in the http://<host>:<port>/v1 format,
+// to be used as the base URL of the OpenAI clients, e.g. to run the chat completions of a pulled model.
+func (c *OllamaContainer) OpenAIURL(ctx context.Context) (string, error) {
+	url, err := c.ConnectionString(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return url + "/v1", nil
+}
+
+// PullModel pulls the model with the given name, e.g. "llama2" or "all-minilm:l6-v2", from the Ollama library.
+// The models are large, so the container can be committed to an image with Commit afterwards,
+// for the following runs to start from that image instead of pulling the model again.
+func (c *OllamaContainer) PullModel(ctx context.Context, name string) error {
+	code, output, err := c.Exec(ctx, []string{"ollama", "pull", name}, exec.Multiplexed())
+	if err != nil {
+		return fmt.Errorf("pull model %s: %w", name, err)
+	}
+
+	if code != 0 {
+		bs, err := io.ReadAll(output)
+		if err != nil {
+			return fmt.Errorf("pull model %s: exit code %d: %w", name, code, err)
+		}
+
+		return fmt.Errorf("pull model %s: exit code %d: %s", name, code, strings.TrimSpace(string(bs)))
+	}
+
+	return nil
+}
+
 // Commit it commits the current file system changes in the container into a new target image.
 // The target image name should be unique, as this method will commit the current state
 // of the container into a new image with the given name, so it doesn't override existing images.
@@ -2,6 +2,7 @@ package ollama_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -54,9 +55,11 @@ func TestOllama(t *testing.T) {
 	t.Run("Pull and Run Model", func(t *testing.T) {
 		model := "all-minilm"
 
-		_, _, err = container.Exec(context.Background(), []string{"ollama", "pull", model})
+		// pullModel {
+		err = container.PullModel(ctx, model)
+		// }
 		if err != nil {
-			log.Fatalf("failed to pull model %s: %s", model, err)
+			t.Fatalf("failed to pull model %s: %s", model, err)
 		}
 
 		_, _, err = container.Exec(context.Background(), []string{"ollama", "run", model})
@@ -67,6 +70,36 @@ func TestOllama(t *testing.T) {
 		assertLoadedModel(t, container)
 	})
 
+	t.Run("OpenAIURL", func(t *testing.T) {
+		// openAIURL {
+		openAIURL, err := container.OpenAIURL(ctx)
+		// }
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body := `{"model": "non-existent", "messages": [{"role": "user", "content": "Hello"}]}`
+		resp, err := http.Post(openAIURL+"/chat/completions", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		// the errors of the OpenAI-compatible API are in the OpenAI format
+		var openAIError struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&openAIError); err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode != http.StatusNotFound || !strings.Contains(openAIError.Error.Message, "non-existent") {
+			t.Fatalf("expected a not found error for the model, got %d: %s", resp.StatusCode, openAIError.Error.Message)
+		}
+	})
+
 	t.Run("Commit to image including model", func(t *testing.T) {
 		// commitOllamaContainer {
 
@@ -139,11 +172,17 @@ func TestRunContainer_withModel_error(t *testing.T) {
 		t.Fatalf("expected error to be nil, got %s", err)
 	}
 
+	t.Cleanup(func() {
+		if err := ollamaContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
 	model := "non-existent"
 
-	_, _, err = ollamaContainer.Exec(ctx, []string{"ollama", "pull", model})
-	if err != nil {
-		log.Fatalf("expected nil error, got %s", err)
+	err = ollamaContainer.PullModel(ctx, model)
+	if err == nil || !strings.Contains(err.Error(), "file does not exist") {
+		t.Fatalf("expected the pull to fail as the model does not exist, got %v", err)
 	}
 
 	// we need to parse the response here to check if the error message is correct
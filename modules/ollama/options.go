@@ -28,12 +28,19 @@ func withGpu() testcontainers.CustomizeRequestOption {
 		return noopCustomizeRequestOption
 	}
 
-	return testcontainers.WithHostConfigModifier(func(hostConfig *container.HostConfig) {
-		hostConfig.DeviceRequests = []container.DeviceRequest{
-			{
+	return func(req *testcontainers.GenericContainerRequest) {
+		// keep the host config modifier of the other options, as this option is added last
+		modifier := req.HostConfigModifier
+
+		req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+			if modifier != nil {
+				modifier(hostConfig)
+			}
+
+			hostConfig.DeviceRequests = append(hostConfig.DeviceRequests, container.DeviceRequest{
 				Count:        -1,
 				Capabilities: [][]string{{"gpu"}},
-			},
+			})
 		}
-	})
+	}
 }
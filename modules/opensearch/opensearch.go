@@ -18,6 +18,8 @@ const (
 	defaultPassword = "admin"
 	defaultUsername = "admin"
 	defaultHTTPPort = "9200/tcp"
+	// caCertPath is the path of the certificate of the demo CA, used when the security plugin is enabled
+	caCertPath = "/usr/share/opensearch/config/root-ca.pem"
 )
 
 // OpenSearchContainer represents the OpenSearch container type used in the module
@@ -25,6 +27,9 @@ type OpenSearchContainer struct {
 	testcontainers.Container
 	User     string
 	Password string
+	// CACert is the PEM encoded certificate of the CA signing the certificate of the HTTP API,
+	// when the security plugin is enabled with WithSecurity.
+	CACert []byte
 }
 
 // RunContainer creates an instance of the OpenSearch container type
@@ -38,6 +43,7 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 			"DISABLE_SECURITY_PLUGIN":     "true",
 			"OPENSEARCH_USERNAME":         defaultUsername,
 			"OPENSEARCH_PASSWORD":         defaultPassword,
+			"OPENSEARCH_JAVA_OPTS":        "-Xms512m -Xmx512m",
 		},
 		HostConfigModifier: func(hc *container.HostConfig) {
 			hc.Ulimits = []*units.Ulimit{
@@ -77,14 +83,21 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		genericContainerReq.Env["OPENSEARCH_PASSWORD"] = settings.Password
 	}
 
+	var caCert []byte
+	if settings.Security {
+		if err := configureSecurity(settings, &genericContainerReq, &caCert); err != nil {
+			return nil, err
+		}
+	}
+
 	username := genericContainerReq.Env["OPENSEARCH_USERNAME"]
 	password := genericContainerReq.Env["OPENSEARCH_PASSWORD"]
 
-	// the wat strategy does not support TLS at the moment,
-	// so we need to disable it in the strategy for now.
+	// the HTTP API is served over TLS when the security plugin is enabled,
+	// using a certificate signed by the demo CA, which the strategy does not verify
 	genericContainerReq.WaitingFor = wait.ForHTTP("/").
 		WithPort("9200").
-		WithTLS(false).
+		WithTLS(settings.Security).
 		WithStartupTimeout(120*time.Second).
 		WithStatusCodeMatcher(func(status int) bool {
 			return status == 200
@@ -114,11 +127,47 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		return nil, err
 	}
 
-	return &OpenSearchContainer{Container: container, User: username, Password: password}, nil
+	return &OpenSearchContainer{Container: container, User: username, Password: password, CACert: caCert}, nil
+}
+
+// configureSecurity enables the security plugin with its demo configuration, setting the initial password of the admin
+// user, and copies the certificate of the demo CA from the container once it's ready.
+func configureSecurity(settings *Options, req *testcontainers.GenericContainerRequest, caCert *[]byte) error {
+	if settings.Username != defaultUsername {
+		return fmt.Errorf("the security plugin only supports the %s user, got %s", defaultUsername, settings.Username)
+	}
+
+	// the default password is too weak for the password validation of OpenSearch
+	if settings.Password == defaultPassword {
+		settings.Password = generatePassword()
+	}
+
+	req.Env["DISABLE_INSTALL_DEMO_CONFIG"] = "false"
+	req.Env["DISABLE_SECURITY_PLUGIN"] = "false"
+	req.Env["OPENSEARCH_INITIAL_ADMIN_PASSWORD"] = settings.Password
+	req.Env["OPENSEARCH_USERNAME"] = settings.Username
+	req.Env["OPENSEARCH_PASSWORD"] = settings.Password
+
+	req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, c testcontainers.Container) error {
+				reader, err := c.CopyFileFromContainer(ctx, caCertPath)
+				if err != nil {
+					return fmt.Errorf("copy the CA certificate: %w", err)
+				}
+				defer reader.Close()
+
+				*caCert, err = io.ReadAll(reader)
+				return err
+			},
+		},
+	})
+
+	return nil
 }
 
 // Address retrieves the address of the OpenSearch container.
-// It will use http as protocol, as TLS is not supported at the moment.
+// It will use https as protocol when the security plugin is enabled with WithSecurity, and http otherwise.
 func (c *OpenSearchContainer) Address(ctx context.Context) (string, error) {
 	containerPort, err := c.MappedPort(ctx, defaultHTTPPort)
 	if err != nil {
@@ -130,5 +179,10 @@ func (c *OpenSearchContainer) Address(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	return fmt.Sprintf("http://%s:%s", host, containerPort.Port()), nil
+	proto := "http"
+	if c.CACert != nil {
+		proto = "https"
+	}
+
+	return fmt.Sprintf("%s://%s:%s", proto, host, containerPort.Port()), nil
 }
@@ -2,7 +2,10 @@ package opensearch_test
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/testcontainers/testcontainers-go"
@@ -44,3 +47,75 @@ func TestOpenSearch(t *testing.T) {
 		defer resp.Body.Close()
 	})
 }
+
+func TestOpenSearchWithSecurity(t *testing.T) {
+	ctx := context.Background()
+
+	// withSecurity {
+	container, err := opensearch.RunContainer(ctx, testcontainers.WithImage("opensearchproject/opensearch:2.12.0"), opensearch.WithSecurity())
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if container.Password == "admin" {
+		t.Fatal("expected a generated password")
+	}
+
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(address, "https://") {
+		t.Fatalf("expected an HTTPS address, got %s", address)
+	}
+
+	// the certificate of the demo CA is copied from the container
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(container.CACert) {
+		t.Fatal("expected a PEM encoded CA certificate")
+	}
+
+	// httpsClient {
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				// the certificates of the demo configuration are only meant for testing
+				InsecureSkipVerify: true, // nolint:gosec
+			},
+		},
+	}
+	// }
+
+	req, err := http.NewRequest("GET", address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(container.User, container.Password)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("failed to perform GET request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 status code, got %d", resp.StatusCode)
+	}
+}
+
+func TestOpenSearchWithSecurity_customUser(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := opensearch.RunContainer(ctx, opensearch.WithSecurity(), opensearch.WithUsername("new-username"))
+	if err == nil {
+		t.Fatal("expected an error when using the security plugin with a custom user")
+	}
+}
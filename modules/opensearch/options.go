@@ -1,11 +1,17 @@
 package opensearch
 
-import "github.com/testcontainers/testcontainers-go"
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/testcontainers/testcontainers-go"
+)
 
 // Options is a struct for specifying options for the OpenSearch container.
 type Options struct {
 	Password string
 	Username string
+	Security bool
 }
 
 func defaultOptions() *Options {
@@ -39,3 +45,25 @@ func WithUsername(username string) Option {
 		o.Username = username
 	}
 }
+
+// WithSecurity enables the security plugin of the OpenSearch container, with its demo configuration:
+// the HTTP API is served over TLS, using certificates signed by a demo CA, and requires the credentials of the admin user.
+// The password of the admin user is the one set with WithPassword, or a generated one.
+// It requires OpenSearch 2.12 or later, where the initial password of the admin user can be set.
+func WithSecurity() Option {
+	return func(o *Options) {
+		o.Security = true
+	}
+}
+
+// generatePassword returns a random password, which is strong enough for the password validation of OpenSearch:
+// it contains uppercase and lowercase letters, digits, and special characters.
+func generatePassword() string {
+	bs := make([]byte, 16)
+	if _, err := rand.Read(bs); err != nil {
+		// the random generator of the OS never fails in practice
+		panic(err)
+	}
+
+	return "Tc-" + hex.EncodeToString(bs) + "-Os1"
+}
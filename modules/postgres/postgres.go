@@ -6,15 +6,18 @@ import (
 	"net"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const (
-	defaultUser          = "postgres"
-	defaultPassword      = "postgres"
-	defaultPostgresImage = "docker.io/postgres:11-alpine"
-	defaultSnapshotName  = "migrated_template"
+	defaultUser           = "postgres"
+	defaultPassword       = "postgres"
+	defaultPostgresImage  = "docker.io/postgres:11-alpine"
+	defaultTimescaleImage = "docker.io/timescale/timescaledb:2.14.2-pg16"
+	defaultSnapshotName   = "migrated_template"
 )
 
 // PostgresContainer represents the postgres container type used in the module
@@ -110,7 +113,63 @@ func WithUsername(user string) testcontainers.CustomizeRequestOption {
 	}
 }
 
-// RunContainer creates an instance of the postgres container type
+// defaultWaitStrategy waits for the server started after the initialization of the database,
+// as the entrypoint of the image starts a temporary server, only listening on the unix socket, to run the init scripts.
+// For this reason, the "ready to accept connections" message is logged twice on the first start.
+func defaultWaitStrategy() wait.Strategy {
+	return wait.ForAll(
+		wait.ForLog("database system is ready to accept connections").
+			WithOccurrence(2).
+			WithStartupTimeout(60*time.Second),
+		wait.ForListeningPort("5432/tcp").
+			WithStartupTimeout(60*time.Second),
+	)
+}
+
+// Run creates an instance of the postgres container type from the given image, e.g. "docker.io/postgres:16-alpine",
+// or a variant of it like PGVector, Timescale or Postgis, and starts it, waiting for the database to accept connections.
+// The default wait strategy can be replaced with testcontainers.WithWaitStrategy.
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*PostgresContainer, error) {
+	defaults := []testcontainers.ContainerCustomizer{
+		testcontainers.WithImage(img),
+		testcontainers.WithWaitStrategy(defaultWaitStrategy()),
+	}
+
+	return RunContainer(ctx, append(defaults, opts...)...)
+}
+
+// RunTimescaleDB creates an instance of the postgres container type running TimescaleDB,
+// using the "docker.io/timescale/timescaledb:2.14.2-pg16" image, which can be replaced with testcontainers.WithImage,
+// and starts it, waiting for the database to accept connections. The timescaledb extension is created
+// in the initial database before the init scripts run, so they can already create hypertables.
+func RunTimescaleDB(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*PostgresContainer, error) {
+	defaults := []testcontainers.ContainerCustomizer{
+		withExtension("timescaledb"),
+	}
+
+	return Run(ctx, defaultTimescaleImage, append(defaults, opts...)...)
+}
+
+// WithPGVector creates the vector extension of pgvector in the initial database before the init scripts run,
+// so they can already create tables with vector columns. The image must ship pgvector,
+// e.g. "docker.io/pgvector/pgvector:pg16".
+func WithPGVector() testcontainers.CustomizeRequestOption {
+	return withExtension("vector")
+}
+
+// withExtension creates the given extension in the initial database, with an init script
+// sorted before the ones added with WithInitScripts.
+func withExtension(name string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader("CREATE EXTENSION IF NOT EXISTS " + name + ";\n"),
+			ContainerFilePath: "/docker-entrypoint-initdb.d/000_testcontainers_" + name + ".sql",
+			FileMode:          0o644,
+		})
+	}
+}
+
+// RunContainer creates an instance of the postgres container type, using the default image
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*PostgresContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image: defaultPostgresImage,
@@ -219,6 +219,122 @@ func TestWithInitScript(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	// runWithImage {
+	container, err := postgres.Run(ctx, "docker.io/postgres:16-alpine",
+		postgres.WithInitScripts(filepath.Join("testdata", "init-user-db.sh")),
+		postgres.WithDatabase(dbname),
+		postgres.WithUsername(user),
+		postgres.WithPassword(password),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// explicitly set sslmode=disable because the container is not configured to use TLS
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	db, err := sql.Open("postgres", connStr)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// the default wait strategy waits for the init scripts to run, so the database is ready to be queried
+	result, err := db.Exec("SELECT * FROM testdb;")
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestRunTimescaleDB(t *testing.T) {
+	ctx := context.Background()
+
+	// runTimescaleDB {
+	container, err := postgres.RunTimescaleDB(ctx,
+		postgres.WithDatabase(dbname),
+		postgres.WithUsername(user),
+		postgres.WithPassword(password),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// explicitly set sslmode=disable because the container is not configured to use TLS
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	db, err := sql.Open("postgres", connStr)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var extension string
+	err = db.QueryRow("SELECT extname FROM pg_extension WHERE extname = 'timescaledb'").Scan(&extension)
+	require.NoError(t, err)
+	assert.Equal(t, "timescaledb", extension)
+
+	_, err = db.Exec("CREATE TABLE metrics (time TIMESTAMPTZ NOT NULL, value DOUBLE PRECISION)")
+	require.NoError(t, err)
+
+	_, err = db.Exec("SELECT create_hypertable('metrics', 'time')")
+	require.NoError(t, err)
+}
+
+func TestWithPGVector(t *testing.T) {
+	ctx := context.Background()
+
+	// withPGVector {
+	container, err := postgres.Run(ctx, "docker.io/pgvector/pgvector:pg16",
+		postgres.WithDatabase(dbname),
+		postgres.WithUsername(user),
+		postgres.WithPassword(password),
+		postgres.WithPGVector(),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// explicitly set sslmode=disable because the container is not configured to use TLS
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	db, err := sql.Open("postgres", connStr)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE items (id SERIAL PRIMARY KEY, embedding vector(3))")
+	require.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO items (embedding) VALUES ('[1,2,3]'), ('[4,5,6]'), ('[1,1,1]')")
+	require.NoError(t, err)
+
+	var id int
+	err = db.QueryRow("SELECT id FROM items ORDER BY embedding <-> '[4,5,5]' LIMIT 1").Scan(&id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+}
+
 func TestSnapshot(t *testing.T) {
 	// snapshotAndReset {
 	ctx := context.Background()
@@ -34,12 +34,9 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		opt.Customize(&genericContainerReq)
 	}
 
-	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
-	if err != nil {
-		return nil, err
-	}
-
-	return &QdrantContainer{Container: container}, nil
+	return testcontainers.RunContainer(ctx, genericContainerReq, func(container testcontainers.Container) *QdrantContainer {
+		return &QdrantContainer{Container: container}
+	})
 }
 
 // RESTEndpoint returns the REST endpoint of the Qdrant container
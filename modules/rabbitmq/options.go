@@ -13,6 +13,7 @@ type options struct {
 	AdminUsername string
 	AdminPassword string
 	SSLSettings   *SSLSettings
+	Topology      topology
 }
 
 func defaultOptions() options {
@@ -68,3 +69,55 @@ func WithSSL(settings SSLSettings) Option {
 		o.SSLSettings = &settings
 	}
 }
+
+// WithPlugins enables the given plugins once the RabbitMQ container is ready, before declaring the rest of the topology.
+func WithPlugins(plugins ...string) Option {
+	return func(o *options) {
+		o.Topology.Plugins = append(o.Topology.Plugins, plugins...)
+	}
+}
+
+// WithVirtualHosts declares the given virtual hosts once the RabbitMQ container is ready.
+func WithVirtualHosts(vhosts ...string) Option {
+	return func(o *options) {
+		o.Topology.VirtualHosts = append(o.Topology.VirtualHosts, vhosts...)
+	}
+}
+
+// WithUsers creates the given users once the RabbitMQ container is ready.
+// Use WithPermissions to grant them access to the virtual hosts.
+func WithUsers(users ...User) Option {
+	return func(o *options) {
+		o.Topology.Users = append(o.Topology.Users, users...)
+	}
+}
+
+// WithPermissions grants the given permissions once the RabbitMQ container is ready,
+// after the virtual hosts and the users are declared.
+func WithPermissions(permissions ...Permission) Option {
+	return func(o *options) {
+		o.Topology.Permissions = append(o.Topology.Permissions, permissions...)
+	}
+}
+
+// WithExchanges declares the given exchanges once the RabbitMQ container is ready.
+func WithExchanges(exchanges ...Exchange) Option {
+	return func(o *options) {
+		o.Topology.Exchanges = append(o.Topology.Exchanges, exchanges...)
+	}
+}
+
+// WithQueues declares the given queues once the RabbitMQ container is ready.
+func WithQueues(queues ...Queue) Option {
+	return func(o *options) {
+		o.Topology.Queues = append(o.Topology.Queues, queues...)
+	}
+}
+
+// WithBindings declares the given bindings once the RabbitMQ container is ready,
+// after the exchanges and the queues are declared.
+func WithBindings(bindings ...Binding) Option {
+	return func(o *options) {
+		o.Topology.Bindings = append(o.Topology.Bindings, bindings...)
+	}
+}
@@ -46,9 +46,9 @@ func (c *RabbitMQContainer) AmqpURL(ctx context.Context) (string, error) {
 	return fmt.Sprintf("amqp://%s:%s@%s", c.AdminUsername, c.AdminPassword, endpoint), nil
 }
 
-// AmqpURL returns the URL for AMQPS clients.
+// AmqpsURL returns the URL for AMQPS clients.
 func (c *RabbitMQContainer) AmqpsURL(ctx context.Context) (string, error) {
-	endpoint, err := c.PortEndpoint(ctx, nat.Port(DefaultAMQPPort), "")
+	endpoint, err := c.PortEndpoint(ctx, nat.Port(DefaultAMQPSPort), "")
 	if err != nil {
 		return "", err
 	}
@@ -120,6 +120,21 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 
 	withConfig(tmpConfigFile)(&genericContainerReq)
 
+	if !settings.Topology.empty() {
+		cmds, err := topologyCommands(settings)
+		if err != nil {
+			return nil, err
+		}
+
+		genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return declareTopology(ctx, c, cmds)
+				},
+			},
+		})
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
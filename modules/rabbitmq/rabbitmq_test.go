@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
@@ -191,6 +192,75 @@ func TestRunContainer_withAllSettings(t *testing.T) {
 	}
 }
 
+func TestRunContainer_withTopology(t *testing.T) {
+	ctx := context.Background()
+
+	// withTopology {
+	rabbitmqContainer, err := rabbitmq.RunContainer(ctx,
+		rabbitmq.WithPlugins("rabbitmq_shovel"),
+		rabbitmq.WithVirtualHosts("orders"),
+		rabbitmq.WithUsers(rabbitmq.User{Name: "app", Password: "app-password", Tags: []string{"management"}}),
+		rabbitmq.WithPermissions(rabbitmq.Permission{VHost: "orders", User: "app", Configure: ".*", Write: ".*", Read: ".*"}),
+		rabbitmq.WithExchanges(rabbitmq.Exchange{VHost: "orders", Name: "events", Type: "topic", Durable: true}),
+		rabbitmq.WithQueues(rabbitmq.Queue{VHost: "orders", Name: "order-created", Durable: true}),
+		rabbitmq.WithBindings(rabbitmq.Binding{VHost: "orders", Source: "events", Destination: "order-created", RoutingKey: "order.created"}),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := rabbitmqContainer.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	if !assertPluginIsEnabled(t, rabbitmqContainer, "rabbitmq_shovel") {
+		t.Fatal("plugin rabbitmq_shovel is not enabled")
+	}
+
+	endpoint, err := rabbitmqContainer.PortEndpoint(ctx, rabbitmq.DefaultAMQPPort, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the app user is allowed to use the orders virtual host
+	amqpConnection, err := amqp.Dial(fmt.Sprintf("amqp://app:app-password@%s/orders", endpoint))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer amqpConnection.Close()
+
+	channel, err := amqpConnection.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = channel.PublishWithContext(ctx, "events", "order.created", false, false, amqp.Publishing{Body: []byte("order-1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the message is routed to the queue by the binding
+	var msg amqp.Delivery
+	for i := 0; i < 50; i++ {
+		var ok bool
+		msg, ok, err = channel.Get("order-created", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if string(msg.Body) != "order-1" {
+		t.Fatalf("expected the message to be routed to the queue, got %q", string(msg.Body))
+	}
+}
+
 func assertEntity(t *testing.T, container testcontainers.Container, listCommand string, entities ...string) bool {
 	t.Helper()
 
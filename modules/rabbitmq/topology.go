@@ -0,0 +1,231 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/exec"
+)
+
+// Exchange is an exchange declared when the RabbitMQ container is ready.
+type Exchange struct {
+	// VHost is the virtual host of the exchange. It defaults to "/".
+	VHost string
+	Name  string
+	// Type is the type of the exchange, e.g. "direct", "fanout", "topic" or "headers". It defaults to "direct".
+	Type       string
+	Durable    bool
+	AutoDelete bool
+	Internal   bool
+	// Args are the optional arguments of the exchange, e.g. "alternate-exchange".
+	Args map[string]interface{}
+}
+
+// Queue is a queue declared when the RabbitMQ container is ready.
+type Queue struct {
+	// VHost is the virtual host of the queue. It defaults to "/".
+	VHost      string
+	Name       string
+	Durable    bool
+	AutoDelete bool
+	// Args are the optional arguments of the queue, e.g. "x-message-ttl" or "x-queue-type".
+	Args map[string]interface{}
+}
+
+// Binding is a binding declared when the RabbitMQ container is ready.
+type Binding struct {
+	// VHost is the virtual host of the binding. It defaults to "/".
+	VHost string
+	// Source is the name of the exchange the messages are routed from.
+	Source string
+	// Destination is the name of the queue or exchange the messages are routed to.
+	Destination string
+	// DestinationType is either "queue" or "exchange". It defaults to "queue".
+	DestinationType string
+	RoutingKey      string
+	// Args are the optional arguments of the binding, e.g. the headers matched by a headers exchange.
+	Args map[string]interface{}
+}
+
+// User is a user created when the RabbitMQ container is ready.
+type User struct {
+	Name     string
+	Password string
+	// Tags are the tags of the user, e.g. "administrator" or "management".
+	Tags []string
+}
+
+// Permission grants a user access to a virtual host when the RabbitMQ container is ready.
+// Configure, Write and Read are regular expressions matching the names of the resources.
+type Permission struct {
+	// VHost is the virtual host of the permission. It defaults to "/".
+	VHost     string
+	User      string
+	Configure string
+	Write     string
+	Read      string
+}
+
+// topology is the topology of the RabbitMQ server, declared when the container is ready.
+type topology struct {
+	Plugins      []string
+	VirtualHosts []string
+	Users        []User
+	Permissions  []Permission
+	Exchanges    []Exchange
+	Queues       []Queue
+	Bindings     []Binding
+}
+
+func (t topology) empty() bool {
+	return len(t.Plugins) == 0 && len(t.VirtualHosts) == 0 && len(t.Users) == 0 && len(t.Permissions) == 0 &&
+		len(t.Exchanges) == 0 && len(t.Queues) == 0 && len(t.Bindings) == 0
+}
+
+// topologyCommands returns the commands declaring the topology, in dependency order: the plugins first, as they
+// can provide exchange types, then the virtual hosts, the users and their permissions, and finally the exchanges,
+// the queues and the bindings between them. The management API is called with the credentials of the admin user.
+func topologyCommands(opts options) ([][]string, error) {
+	t := opts.Topology
+	cmds := [][]string{}
+
+	if len(t.Plugins) > 0 {
+		cmds = append(cmds, append([]string{"rabbitmq-plugins", "enable"}, t.Plugins...))
+	}
+
+	admin := func(vhost string, args ...string) []string {
+		cmd := []string{"rabbitmqadmin", "--username=" + opts.AdminUsername, "--password=" + opts.AdminPassword}
+		if vhost != "" {
+			cmd = append(cmd, "--vhost="+vhost)
+		}
+
+		return append(cmd, args...)
+	}
+
+	for _, vhost := range t.VirtualHosts {
+		cmds = append(cmds, admin("", "declare", "vhost", "name="+vhost))
+	}
+
+	for _, u := range t.Users {
+		cmds = append(cmds, admin("", "declare", "user", "name="+u.Name, "password="+u.Password, "tags="+strings.Join(uniqueSorted(u.Tags), ",")))
+	}
+
+	for _, p := range t.Permissions {
+		cmds = append(cmds, admin("", "declare", "permission",
+			"vhost="+defaultVHost(p.VHost), "user="+p.User, "configure="+p.Configure, "write="+p.Write, "read="+p.Read))
+	}
+
+	for _, e := range t.Exchanges {
+		exchangeType := e.Type
+		if exchangeType == "" {
+			exchangeType = "direct"
+		}
+
+		args := []string{"declare", "exchange", "name=" + e.Name, "type=" + exchangeType,
+			fmt.Sprintf("durable=%t", e.Durable), fmt.Sprintf("auto_delete=%t", e.AutoDelete), fmt.Sprintf("internal=%t", e.Internal)}
+		args, err := withArguments(args, e.Args)
+		if err != nil {
+			return nil, fmt.Errorf("exchange %s: %w", e.Name, err)
+		}
+
+		cmds = append(cmds, admin(defaultVHost(e.VHost), args...))
+	}
+
+	for _, q := range t.Queues {
+		args := []string{"declare", "queue", "name=" + q.Name, fmt.Sprintf("durable=%t", q.Durable), fmt.Sprintf("auto_delete=%t", q.AutoDelete)}
+		args, err := withArguments(args, q.Args)
+		if err != nil {
+			return nil, fmt.Errorf("queue %s: %w", q.Name, err)
+		}
+
+		cmds = append(cmds, admin(defaultVHost(q.VHost), args...))
+	}
+
+	for _, b := range t.Bindings {
+		destinationType := b.DestinationType
+		if destinationType == "" {
+			destinationType = "queue"
+		}
+
+		args := []string{"declare", "binding", "source=" + b.Source, "destination=" + b.Destination,
+			"destination_type=" + destinationType, "routing_key=" + b.RoutingKey}
+		args, err := withArguments(args, b.Args)
+		if err != nil {
+			return nil, fmt.Errorf("binding %s -> %s: %w", b.Source, b.Destination, err)
+		}
+
+		cmds = append(cmds, admin(defaultVHost(b.VHost), args...))
+	}
+
+	return cmds, nil
+}
+
+// declareTopology runs the commands declaring the topology in the container, failing on the first error.
+func declareTopology(ctx context.Context, c testcontainers.Container, cmds [][]string) error {
+	for _, cmd := range cmds {
+		exitCode, reader, err := c.Exec(ctx, cmd, exec.Multiplexed())
+		if err != nil {
+			return fmt.Errorf("declare topology: %w", err)
+		}
+
+		if exitCode != 0 {
+			output, _ := io.ReadAll(reader)
+			return fmt.Errorf("declare topology: %s %s: exit code %d: %s", cmd[0], strings.Join(redact(cmd[1:]), " "), exitCode, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
+
+// redact hides the passwords in the arguments of a command, so that they are not part of the errors.
+func redact(args []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "password=") || strings.HasPrefix(arg, "--password=") {
+			arg = arg[:strings.Index(arg, "=")+1] + "***"
+		}
+		redacted[i] = arg
+	}
+
+	return redacted
+}
+
+func withArguments(args []string, arguments map[string]interface{}) ([]string, error) {
+	if len(arguments) == 0 {
+		return args, nil
+	}
+
+	bs, err := json.Marshal(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(args, "arguments="+string(bs)), nil
+}
+
+func defaultVHost(vhost string) string {
+	if vhost == "" {
+		return "/"
+	}
+
+	return vhost
+}
+
+func uniqueSorted(values []string) []string {
+	set := map[string]bool{}
+	unique := []string{}
+	for _, v := range values {
+		if !set[v] {
+			set[v] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.Strings(unique)
+
+	return unique
+}
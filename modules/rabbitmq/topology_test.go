@@ -0,0 +1,64 @@
+package rabbitmq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTopologyCommands(t *testing.T) {
+	opts := defaultOptions()
+	opts.AdminUsername = "admin"
+	opts.AdminPassword = "secret"
+
+	WithBindings(Binding{Source: "events", Destination: "orders", RoutingKey: "order.*"})(&opts)
+	WithQueues(Queue{VHost: "shop", Name: "orders", Durable: true, Args: map[string]interface{}{"x-queue-type": "quorum"}})(&opts)
+	WithExchanges(Exchange{Name: "events", Type: "topic"})(&opts)
+	WithPermissions(Permission{User: "app", Configure: ".*", Write: ".*", Read: ".*"})(&opts)
+	WithUsers(User{Name: "app", Password: "app-secret", Tags: []string{"management", "administrator", "management"}})(&opts)
+	WithVirtualHosts("shop")(&opts)
+	WithPlugins("rabbitmq_shovel", "rabbitmq_random_exchange")(&opts)
+
+	cmds, err := topologyCommands(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin := []string{"rabbitmqadmin", "--username=admin", "--password=secret"}
+	expected := [][]string{
+		{"rabbitmq-plugins", "enable", "rabbitmq_shovel", "rabbitmq_random_exchange"},
+		append(admin[:3:3], "declare", "vhost", "name=shop"),
+		append(admin[:3:3], "declare", "user", "name=app", "password=app-secret", "tags=administrator,management"),
+		append(admin[:3:3], "declare", "permission", "vhost=/", "user=app", "configure=.*", "write=.*", "read=.*"),
+		append(admin[:3:3], "--vhost=/", "declare", "exchange", "name=events", "type=topic", "durable=false", "auto_delete=false", "internal=false"),
+		append(admin[:3:3], "--vhost=shop", "declare", "queue", "name=orders", "durable=true", "auto_delete=false", `arguments={"x-queue-type":"quorum"}`),
+		append(admin[:3:3], "--vhost=/", "declare", "binding", "source=events", "destination=orders", "destination_type=queue", "routing_key=order.*"),
+	}
+	if !reflect.DeepEqual(expected, cmds) {
+		t.Fatalf("expected commands %v, got %v", expected, cmds)
+	}
+}
+
+func TestTopologyCommands_empty(t *testing.T) {
+	opts := defaultOptions()
+	if !opts.Topology.empty() {
+		t.Fatal("expected the default topology to be empty")
+	}
+
+	cmds, err := topologyCommands(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cmds) != 0 {
+		t.Fatalf("expected no commands, got %v", cmds)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	redacted := redact([]string{"--username=admin", "--password=secret", "declare", "user", "name=app", "password=app-secret"})
+
+	expected := []string{"--username=admin", "--password=***", "declare", "user", "name=app", "password=***"}
+	if !reflect.DeepEqual(expected, redacted) {
+		t.Fatalf("expected redacted arguments %v, got %v", expected, redacted)
+	}
+}
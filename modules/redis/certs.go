@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+)
+
+// tlsCerts are the PEM encoded certificates the Redis server is started with when TLS is enabled.
+type tlsCerts struct {
+	CACert     *x509.Certificate
+	CACertPEM  []byte
+	ServerCert []byte
+	ServerKey  []byte
+}
+
+// newTLSCerts generates a CA, and a certificate for the Redis server, valid for "localhost", signed by that CA.
+func newTLSCerts() (*tlsCerts, error) {
+	caCert, caKey, err := generateCA()
+	if err != nil {
+		return nil, err
+	}
+
+	serverCert, serverKey, err := generateServer(caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tlsCerts{
+		CACert: caCert,
+		CACertPEM: pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: caCert.Raw,
+		}),
+		ServerCert: serverCert,
+		ServerKey:  serverKey,
+	}, nil
+}
+
+func generateCA() (*x509.Certificate, *rsa.PrivateKey, error) {
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(2019),
+		Subject: pkix.Name{
+			CommonName: "Redis Test CA",
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	caPrivKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, caPrivKey.Public(), caPrivKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caCert, err := x509.ParseCertificate(caBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return caCert, caPrivKey, nil
+}
+
+func generateServer(caCert *x509.Certificate, caKey *rsa.PrivateKey) ([]byte, []byte, error) {
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(2019),
+		Subject: pkix.Name{
+			CommonName: "redis",
+		},
+		DNSNames: []string{"localhost"},
+		IPAddresses: []net.IP{
+			net.IPv4(127, 0, 0, 1),
+			net.IPv6loopback,
+		},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(time.Hour),
+		KeyUsage:  x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+		},
+		BasicConstraintsValid: true,
+	}
+
+	certPrivKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, &template, caCert, certPrivKey.Public(), caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certBytes,
+	})
+	certKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(certPrivKey),
+	})
+
+	return cert, certKey, nil
+}
@@ -0,0 +1,38 @@
+package redis
+
+import "github.com/testcontainers/testcontainers-go"
+
+type options struct {
+	Password string
+	TLS      bool
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Redis container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithPassword sets the password of the default user of the Redis server, using the requirepass directive.
+// The connection string of the container includes the password.
+// See https://redis.io/docs/management/security/#authentication for more information.
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.Password = password
+	}
+}
+
+// WithTLS enables TLS on the Redis server, using a certificate for "localhost" signed by a CA generated for the container.
+// The plain-text port is disabled, so the clients must connect with the TLS config returned by TLSConfig.
+// Clients are not required to present a certificate. TLS is supported from Redis 6.
+// See https://redis.io/docs/management/security/encryption/ for more information.
+func WithTLS() Option {
+	return func(o *options) {
+		o.TLS = true
+	}
+}
@@ -1,6 +1,8 @@
 package redis
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -136,3 +138,79 @@ func TestWithSnapshotting(t *testing.T) {
 		})
 	}
 }
+
+func TestWithACLFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		cmds         []string
+		expectedCmds []string
+	}{
+		{
+			name:         "no existing command",
+			cmds:         []string{},
+			expectedCmds: []string{redisServerProcess, "--aclfile", "/usr/local/etc/redis/users.acl"},
+		},
+		{
+			name:         "existing redis-server command as first argument",
+			cmds:         []string{redisServerProcess, "a", "b", "c"},
+			expectedCmds: []string{redisServerProcess, "a", "b", "c", "--aclfile", "/usr/local/etc/redis/users.acl"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &testcontainers.GenericContainerRequest{
+				ContainerRequest: testcontainers.ContainerRequest{
+					Cmd: tt.cmds,
+				},
+			}
+
+			WithACLFile("users.acl")(req)
+
+			require.Equal(t, tt.expectedCmds, req.Cmd)
+			require.Len(t, req.Files, 1)
+			require.Equal(t, "users.acl", req.Files[0].HostFilePath)
+			require.Equal(t, "/usr/local/etc/redis/users.acl", req.Files[0].ContainerFilePath)
+		})
+	}
+}
+
+func TestWithTLSCerts(t *testing.T) {
+	certs, err := newTLSCerts()
+	require.NoError(t, err)
+
+	req := &testcontainers.GenericContainerRequest{}
+
+	withTLSCerts(req, certs)
+
+	require.Equal(t, []string{
+		redisServerProcess,
+		"--port", "0",
+		"--tls-port", "6379",
+		"--tls-cert-file", "/tls/redis.crt",
+		"--tls-key-file", "/tls/redis.key",
+		"--tls-ca-cert-file", "/tls/ca.crt",
+		"--tls-auth-clients", "no",
+	}, req.Cmd)
+	require.Len(t, req.Files, 3)
+
+	// the server certificate is trusted by the TLS config of the container
+	c := &RedisContainer{tlsCerts: certs}
+	tlsConfig, err := c.TLSConfig()
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(certs.ServerCert)
+	require.NotNil(t, block)
+	serverCert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	_, err = serverCert.Verify(x509.VerifyOptions{Roots: tlsConfig.RootCAs, DNSName: tlsConfig.ServerName})
+	require.NoError(t, err)
+}
+
+func TestTLSConfigNotEnabled(t *testing.T) {
+	c := &RedisContainer{}
+
+	_, err := c.TLSConfig()
+	require.ErrorIs(t, err, ErrTLSNotEnabled)
+}
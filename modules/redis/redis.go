@@ -1,8 +1,15 @@
 package redis
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/url"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -14,6 +21,12 @@ const defaultImage = "docker.io/redis:7"
 // redisServerProcess is the name of the redis server process
 const redisServerProcess = "redis-server"
 
+// tlsDir is the directory of the redis container where the TLS certificates are copied
+const tlsDir = "/tls"
+
+// ErrTLSNotEnabled is returned by TLSConfig when the container was not started with WithTLS.
+var ErrTLSNotEnabled = errors.New("tls not enabled")
+
 type LogLevel string
 
 const (
@@ -29,8 +42,12 @@ const (
 
 type RedisContainer struct {
 	testcontainers.Container
+	password string
+	tlsCerts *tlsCerts
 }
 
+// ConnectionString returns the connection string of the Redis server, including the password set with WithPassword,
+// e.g. "redis://:password@localhost:32768". It uses the "rediss" scheme when the container was started with WithTLS.
 func (c *RedisContainer) ConnectionString(ctx context.Context) (string, error) {
 	mappedPort, err := c.MappedPort(ctx, "6379/tcp")
 	if err != nil {
@@ -42,16 +59,49 @@ func (c *RedisContainer) ConnectionString(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	uri := fmt.Sprintf("redis://%s:%s", hostIP, mappedPort.Port())
-	return uri, nil
+	uri := url.URL{
+		Scheme: "redis",
+		Host:   net.JoinHostPort(hostIP, mappedPort.Port()),
+	}
+	if c.tlsCerts != nil {
+		uri.Scheme = "rediss"
+	}
+	if c.password != "" {
+		uri.User = url.UserPassword("", c.password)
+	}
+
+	return uri.String(), nil
+}
+
+// TLSConfig returns the TLS config to connect to the Redis server when the container was started with WithTLS,
+// trusting the CA generated for the container. It returns ErrTLSNotEnabled otherwise.
+func (c *RedisContainer) TLSConfig() (*tls.Config, error) {
+	if c.tlsCerts == nil {
+		return nil, ErrTLSNotEnabled
+	}
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(c.tlsCerts.CACert)
+
+	return &tls.Config{
+		RootCAs:    certPool,
+		ServerName: "localhost",
+		MinVersion: tls.VersionTLS12,
+	}, nil
 }
 
 // RunContainer creates an instance of the Redis container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*RedisContainer, error) {
+	settings := options{}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+	}
+
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImage,
 		ExposedPorts: []string{"6379/tcp"},
-		WaitingFor:   wait.ForLog("* Ready to accept connections"),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -59,6 +109,24 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Started:          true,
 	}
 
+	var certs *tlsCerts
+	if settings.TLS {
+		var err error
+		certs, err = newTLSCerts()
+		if err != nil {
+			return nil, fmt.Errorf("generate TLS certificates: %w", err)
+		}
+
+		withTLSCerts(&genericContainerReq, certs)
+	}
+
+	if settings.Password != "" {
+		processRedisServerArgs(&genericContainerReq, []string{"--requirepass", settings.Password})
+	}
+
+	genericContainerReq.WaitingFor = defaultWaitStrategy(settings)
+
+	// the options are applied after the settings, so that the users can override the default wait strategy
 	for _, opt := range opts {
 		opt.Customize(&genericContainerReq)
 	}
@@ -68,7 +136,80 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		return nil, err
 	}
 
-	return &RedisContainer{Container: container}, nil
+	return &RedisContainer{Container: container, password: settings.Password, tlsCerts: certs}, nil
+}
+
+// defaultWaitStrategy waits for the Redis server to answer a PING sent with redis-cli from inside the container,
+// which also covers the server loading its dataset. A NOAUTH error means the server is ready, but requires
+// authentication, e.g. because a password is set in the config file.
+func defaultWaitStrategy(settings options) wait.Strategy {
+	cmd := []string{"redis-cli"}
+	if settings.TLS {
+		cmd = append(cmd, "--tls", "--cacert", tlsDir+"/ca.crt")
+	}
+	if settings.Password != "" {
+		cmd = append(cmd, "-a", settings.Password, "--no-auth-warning")
+	}
+	cmd = append(cmd, "ping")
+
+	return wait.ForExec(cmd).
+		WithExitCodeMatcher(func(int) bool {
+			// redis-cli may exit with 0 on errors, so the response is checked instead
+			return true
+		}).
+		WithResponseMatcher(func(body io.Reader) bool {
+			bs, err := io.ReadAll(body)
+			if err != nil {
+				return false
+			}
+
+			return bytes.Contains(bs, []byte("PONG")) || bytes.Contains(bs, []byte("NOAUTH"))
+		})
+}
+
+// withTLSCerts copies the TLS certificates to the container, and configures the redis server process
+// to accept only TLS connections on the 6379 port.
+func withTLSCerts(req *testcontainers.GenericContainerRequest, certs *tlsCerts) {
+	files := map[string][]byte{
+		"ca.crt":    certs.CACertPEM,
+		"redis.crt": certs.ServerCert,
+		"redis.key": certs.ServerKey,
+	}
+	for name, content := range files {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(content),
+			ContainerFilePath: tlsDir + "/" + name,
+			// the redis server process does not run as root
+			FileMode: 0o644,
+		})
+	}
+
+	processRedisServerArgs(req, []string{
+		"--port", "0",
+		"--tls-port", "6379",
+		"--tls-cert-file", tlsDir + "/redis.crt",
+		"--tls-key-file", tlsDir + "/redis.key",
+		"--tls-ca-cert-file", tlsDir + "/ca.crt",
+		"--tls-auth-clients", "no",
+	})
+}
+
+// WithACLFile sets the ACL file to be used for the redis container, defining the users of the Redis server
+// and their permissions. The connection string of the container does not include any ACL user, so it must be
+// set in the client options.
+// See https://redis.io/docs/management/security/acl/#use-an-external-acl-file for more information.
+func WithACLFile(aclFile string) testcontainers.CustomizeRequestOption {
+	const defaultACLFile = "/usr/local/etc/redis/users.acl"
+
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      aclFile,
+			ContainerFilePath: defaultACLFile,
+			FileMode:          0o644,
+		})
+
+		processRedisServerArgs(req, []string{"--aclfile", defaultACLFile})
+	}
 }
 
 // WithConfigFile sets the config file to be used for the redis container, and sets the command to run the redis server
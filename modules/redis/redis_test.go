@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -115,6 +116,80 @@ func TestRedisWithSnapshotting(t *testing.T) {
 	assertSetsGets(t, ctx, redisContainer, 10)
 }
 
+func TestRedisWithPassword(t *testing.T) {
+	ctx := context.Background()
+
+	// withPassword {
+	redisContainer, err := tcredis.RunContainer(ctx, tcredis.WithPassword("my-secret"))
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+	require.Contains(t, uri, ":my-secret@")
+
+	assertSetsGets(t, ctx, redisContainer, 1)
+}
+
+func TestRedisWithTLS(t *testing.T) {
+	ctx := context.Background()
+
+	// withTLS {
+	redisContainer, err := tcredis.RunContainer(ctx, tcredis.WithTLS(), tcredis.WithPassword("my-secret"))
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(uri, "rediss://"))
+
+	assertSetsGets(t, ctx, redisContainer, 1)
+}
+
+func TestRedisWithACLFile(t *testing.T) {
+	ctx := context.Background()
+
+	// withACLFile {
+	redisContainer, err := tcredis.RunContainer(ctx, tcredis.WithACLFile(filepath.Join("testdata", "users.acl")))
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	uri, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	options, err := redis.ParseURL(uri)
+	require.NoError(t, err)
+
+	// the default user is disabled in the ACL file
+	client := redis.NewClient(options)
+	defer client.Close()
+	require.Error(t, client.Ping(ctx).Err())
+
+	options.Username = "app"
+	options.Password = "app-password"
+	appClient := redis.NewClient(options)
+	defer appClient.Close()
+
+	require.NoError(t, appClient.Set(ctx, "app:key", "value", time.Minute).Err())
+	// the app user can only access the keys with the app: prefix
+	require.Error(t, appClient.Set(ctx, "other:key", "value", time.Minute).Err())
+}
+
 func assertSetsGets(t *testing.T, ctx context.Context, redisContainer *tcredis.RedisContainer, keyCount int) {
 	// connectionString {
 	uri, err := redisContainer.ConnectionString(ctx)
@@ -127,6 +202,12 @@ func assertSetsGets(t *testing.T, ctx context.Context, redisContainer *tcredis.R
 	options, err := redis.ParseURL(uri)
 	require.NoError(t, err)
 
+	if tlsConfig, err := redisContainer.TLSConfig(); err == nil {
+		// tlsConfig {
+		options.TLSConfig = tlsConfig
+		// }
+	}
+
 	client := redis.NewClient(options)
 	defer func(t *testing.T, ctx context.Context, client *redis.Client) {
 		require.NoError(t, flushRedis(ctx, *client))
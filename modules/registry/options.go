@@ -0,0 +1,40 @@
+package registry
+
+import "github.com/testcontainers/testcontainers-go"
+
+type options struct {
+	Username    string
+	Password    string
+	Certificate []byte
+	Key         []byte
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Registry container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithBasicAuth enables the htpasswd authentication of the registry, with a single user
+// with the given username and password.
+func WithBasicAuth(username string, password string) Option {
+	return func(o *options) {
+		o.Username = username
+		o.Password = password
+	}
+}
+
+// WithTLS enables TLS on the registry, serving the given PEM-encoded certificate and private key.
+// The Docker daemon doesn't verify the certificates of the registries on the loopback addresses,
+// so a self-signed certificate is enough to push images to the registry.
+func WithTLS(certificate []byte, key []byte) Option {
+	return func(o *options) {
+		o.Certificate = certificate
+		o.Key = key
+	}
+}
@@ -0,0 +1,185 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "registry:2.8.3"
+	defaultPort  = "5000/tcp"
+
+	htpasswdPath    = "/auth/htpasswd"
+	certificatePath = "/certs/registry.crt"
+	keyPath         = "/certs/registry.key"
+)
+
+// RegistryContainer represents the Registry container type used in the module
+type RegistryContainer struct {
+	testcontainers.Container
+	username string
+	password string
+}
+
+// RunContainer creates an instance of the Registry container type, running a Docker registry
+// storing the images in the container.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*RegistryContainer, error) {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        defaultImage,
+			ExposedPorts: []string{defaultPort},
+			Env:          map[string]string{},
+		},
+		Started: true,
+	}
+
+	settings := options{}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&req)
+	}
+
+	// the root of the API answers once the registry is up, even if authentication is enabled
+	waitStrategy := wait.ForHTTP("/").WithPort(defaultPort)
+
+	if settings.Username != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(settings.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("hash the password: %w", err)
+		}
+
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            bytes.NewReader([]byte(settings.Username + ":" + string(hash) + "\n")),
+			ContainerFilePath: htpasswdPath,
+			FileMode:          0o644,
+		})
+		req.Env["REGISTRY_AUTH"] = "htpasswd"
+		req.Env["REGISTRY_AUTH_HTPASSWD_REALM"] = "Registry"
+		req.Env["REGISTRY_AUTH_HTPASSWD_PATH"] = htpasswdPath
+	}
+
+	if settings.Certificate != nil {
+		req.Files = append(req.Files,
+			testcontainers.ContainerFile{
+				Reader:            bytes.NewReader(settings.Certificate),
+				ContainerFilePath: certificatePath,
+				FileMode:          0o644,
+			},
+			testcontainers.ContainerFile{
+				Reader:            bytes.NewReader(settings.Key),
+				ContainerFilePath: keyPath,
+				FileMode:          0o600,
+			},
+		)
+		req.Env["REGISTRY_HTTP_TLS_CERTIFICATE"] = certificatePath
+		req.Env["REGISTRY_HTTP_TLS_KEY"] = keyPath
+
+		waitStrategy = waitStrategy.WithTLS(true).WithAllowInsecure(true)
+	}
+
+	if req.WaitingFor == nil {
+		req.WaitingFor = waitStrategy
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegistryContainer{Container: container, username: settings.Username, password: settings.Password}, nil
+}
+
+// Address returns the address of the registry, in the <host>:<port> format, which prefixes
+// the references of the images stored in the registry, e.g. "localhost:32768/library/alpine:3.19".
+func (c *RegistryContainer) Address(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
+
+// PushImage pushes the given local image to the registry, returning the reference of the pushed image,
+// which keeps the path and the tag of the given one, e.g. "alpine:3.19" is pushed as
+// "<address>/library/alpine:3.19". The image must be present in the Docker host.
+func (c *RegistryContainer) PushImage(ctx context.Context, image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("parse image %s: %w", image, err)
+	}
+
+	address, err := c.Address(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// the images without tag are pushed with the latest tag
+	named = reference.TagNameOnly(named)
+
+	target := address + "/" + reference.Path(named)
+	if tagged, ok := named.(reference.Tagged); ok {
+		target += ":" + tagged.Tag()
+	}
+
+	cli, err := testcontainers.NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer cli.Close()
+
+	if err := cli.ImageTag(ctx, image, target); err != nil {
+		return "", fmt.Errorf("tag image %s: %w", image, err)
+	}
+
+	auth, err := registry.EncodeAuthConfig(registry.AuthConfig{
+		Username:      c.username,
+		Password:      c.password,
+		ServerAddress: address,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	rc, err := cli.ImagePush(ctx, target, types.ImagePushOptions{RegistryAuth: auth})
+	if err != nil {
+		return "", fmt.Errorf("push image %s: %w", target, err)
+	}
+	defer rc.Close()
+
+	// the errors of the push are only reported in its progress messages
+	if err := jsonmessage.DisplayJSONMessagesStream(rc, io.Discard, 0, false, nil); err != nil {
+		return "", fmt.Errorf("push image %s: %w", target, err)
+	}
+
+	return target, nil
+}
+
+// Username returns the name of the user of the registry, or an empty string if authentication is disabled.
+func (c *RegistryContainer) Username() string {
+	return c.username
+}
+
+// Password returns the password of the user of the registry, or an empty string if authentication is disabled.
+func (c *RegistryContainer) Password() string {
+	return c.password
+}
@@ -0,0 +1,165 @@
+package registry_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/registry"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	certificate, key := selfSignedCertificate(t)
+
+	tests := []struct {
+		name   string
+		opts   []testcontainers.ContainerCustomizer
+		scheme string
+	}{
+		{
+			name:   "without authentication",
+			scheme: "http",
+		},
+		{
+			name: "with basic authentication",
+			opts: []testcontainers.ContainerCustomizer{
+				// withBasicAuth {
+				registry.WithBasicAuth("testuser", "testpassword"),
+				// }
+			},
+			scheme: "http",
+		},
+		{
+			name: "with TLS",
+			opts: []testcontainers.ContainerCustomizer{
+				registry.WithBasicAuth("testuser", "testpassword"),
+				// withTLS {
+				registry.WithTLS(certificate, key),
+				// }
+			},
+			scheme: "https",
+		},
+	}
+
+	provider, err := testcontainers.ProviderDocker.GetProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	require.NoError(t, provider.PullImage(ctx, "docker.io/alpine:3.19"))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]testcontainers.ContainerCustomizer{testcontainers.WithImage("registry:2.8.3")}, tt.opts...)
+
+			container, err := registry.RunContainer(ctx, opts...)
+			require.NoError(t, err)
+
+			// Clean up the container after the test is complete
+			t.Cleanup(func() {
+				require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+			})
+
+			// pushImage {
+			image, err := container.PushImage(ctx, "docker.io/alpine:3.19")
+			// }
+			require.NoError(t, err)
+
+			address, err := container.Address(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, address+"/library/alpine:3.19", image)
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, tt.scheme+"://"+address+"/v2/library/alpine/tags/list", nil)
+			require.NoError(t, err)
+			if container.Username() != "" {
+				req.SetBasicAuth(container.Username(), container.Password())
+			}
+
+			client := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+				},
+			}
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var tags struct {
+				Tags []string `json:"tags"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&tags))
+			assert.Equal(t, []string{"3.19"}, tags.Tags)
+		})
+	}
+}
+
+func TestRegistry_requiresAuthentication(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := registry.RunContainer(ctx,
+		testcontainers.WithImage("registry:2.8.3"),
+		registry.WithBasicAuth("testuser", "testpassword"),
+	)
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+	})
+
+	address, err := container.Address(ctx)
+	require.NoError(t, err)
+
+	resp, err := http.Get("http://" + address + "/v2/")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+// selfSignedCertificate returns a PEM-encoded self-signed certificate for localhost, and its private key.
+func selfSignedCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
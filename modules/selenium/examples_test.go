@@ -0,0 +1,38 @@
+package selenium_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/selenium"
+)
+
+func ExampleRunContainer() {
+	// runSeleniumContainer {
+	ctx := context.Background()
+
+	seleniumContainer, err := selenium.RunContainer(ctx, testcontainers.WithImage("selenium/standalone-chrome:4.18.1"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := seleniumContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := seleniumContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,100 @@
+package selenium
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	videoImage = "selenium/video:ffmpeg-6.1-20240402"
+	videoPath  = "/videos/video.mp4"
+	// videoStopTimeout is the time given to ffmpeg to finish writing the video when the recording is stopped.
+	videoStopTimeout = 10 * time.Second
+)
+
+// Recording is an ffmpeg sidecar recording the screen of the browser as an MP4 video.
+type Recording struct {
+	sidecar  testcontainers.Container
+	stopOnce sync.Once
+	stopErr  error
+}
+
+// StartRecording starts recording the screen of the browser, with an ffmpeg sidecar sharing the network namespace
+// of the container. The video can be saved once, with SaveTo, e.g. at the end of each test, to keep a video per test.
+// The recordings which are not saved are stopped before the container is terminated.
+func (c *SeleniumContainer) StartRecording(ctx context.Context) (*Recording, error) {
+	sidecar, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: videoImage,
+			Env: map[string]string{
+				// the display of the browser is reachable on localhost, as the network namespace is shared
+				"DISPLAY_CONTAINER_NAME": "localhost",
+			},
+			HostConfigModifier: func(hostConfig *container.HostConfig) {
+				hostConfig.NetworkMode = container.NetworkMode("container:" + c.GetContainerID())
+			},
+			// ffmpeg logs the description of the video once it has started writing it
+			WaitingFor: wait.ForLog("Output #0, mp4"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start recording: %w", err)
+	}
+
+	recording := &Recording{sidecar: sidecar}
+
+	c.recordingsMutex.Lock()
+	c.recordings = append(c.recordings, recording)
+	c.recordingsMutex.Unlock()
+
+	return recording, nil
+}
+
+// SaveTo stops the recording and writes the MP4 video to the given path of the host,
+// e.g. "videos/" + t.Name() + ".mp4". The recording can't be saved again afterwards.
+func (r *Recording) SaveTo(ctx context.Context, path string) error {
+	// ffmpeg only finishes writing the video, making it playable, when it's stopped gracefully
+	timeout := videoStopTimeout
+	if err := r.sidecar.Stop(ctx, &timeout); err != nil {
+		return fmt.Errorf("stop recording: %w", err)
+	}
+	defer r.Stop(ctx) //nolint:errcheck
+
+	rc, err := r.sidecar.CopyFileFromContainer(ctx, videoPath)
+	if err != nil {
+		return fmt.Errorf("copy video: %w", err)
+	}
+	defer rc.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, rc); err != nil {
+		return fmt.Errorf("write video %s: %w", path, err)
+	}
+
+	return f.Close()
+}
+
+// Stop stops the recording, terminating the sidecar container, and discards the video.
+// It's called automatically before the container is terminated.
+func (r *Recording) Stop(ctx context.Context) error {
+	r.stopOnce.Do(func() {
+		r.stopErr = r.sidecar.Terminate(ctx)
+	})
+
+	return r.stopErr
+}
@@ -0,0 +1,129 @@
+package selenium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage     = "selenium/standalone-chrome:4.18.1"
+	defaultPort      = "4444/tcp"
+	defaultNoVNCPort = "7900/tcp"
+	// defaultVNCPassword is the password of the VNC server of the Selenium images.
+	defaultVNCPassword = "secret"
+	// the browsers need a bigger shared memory than the default 64MB of Docker, or they crash.
+	defaultShmSize = 2 * 1024 * 1024 * 1024
+)
+
+// SeleniumContainer represents the Selenium container type used in the module
+type SeleniumContainer struct {
+	testcontainers.Container
+
+	recordingsMutex sync.Mutex
+	recordings      []*Recording
+}
+
+// RunContainer creates an instance of the Selenium container type, running a standalone Selenium server
+// with a browser, Chrome by default. The browser can be replaced with testcontainers.WithImage, using another
+// standalone image of Selenium, e.g. "selenium/standalone-firefox:4.18.1".
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*SeleniumContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort, defaultNoVNCPort},
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.ShmSize = defaultShmSize
+		},
+		// the server listens before the browser is registered, so it's only ready
+		// once its status says that it can create sessions.
+		WaitingFor: wait.ForHTTP("/status").WithPort(defaultPort).WithResponseMatcher(isReady),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeleniumContainer{Container: container}, nil
+}
+
+// isReady returns true if the status of the Selenium server says that it can create sessions.
+func isReady(body io.Reader) bool {
+	var status struct {
+		Value struct {
+			Ready bool `json:"ready"`
+		} `json:"value"`
+	}
+	if err := json.NewDecoder(body).Decode(&status); err != nil {
+		return false
+	}
+
+	return status.Value.Ready
+}
+
+// WebDriverURL returns the URL of the WebDriver endpoint of the Selenium server, in the http://<host>:<port> format,
+// to be used as the remote URL of the WebDriver clients.
+func (c *SeleniumContainer) WebDriverURL(ctx context.Context) (string, error) {
+	return c.url(ctx, defaultPort)
+}
+
+// NoVNCURL returns the URL of the web VNC client showing the screen of the browser, to watch the tests
+// as they run, or to debug them.
+func (c *SeleniumContainer) NoVNCURL(ctx context.Context) (string, error) {
+	url, err := c.url(ctx, defaultNoVNCPort)
+	if err != nil {
+		return "", err
+	}
+
+	return url + "/?autoconnect=1&resize=scale&password=" + defaultVNCPassword, nil
+}
+
+// url returns the URL of the given port of the container, in the http://<host>:<port> format.
+func (c *SeleniumContainer) url(ctx context.Context, port nat.Port) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
+
+// Terminate stops the recordings of the screen of the browser which are still running, and terminates the container.
+func (c *SeleniumContainer) Terminate(ctx context.Context) error {
+	c.recordingsMutex.Lock()
+	recordings := c.recordings
+	c.recordings = nil
+	c.recordingsMutex.Unlock()
+
+	var errs []error
+	for _, recording := range recordings {
+		if err := recording.Stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(append(errs, c.Container.Terminate(ctx))...)
+}
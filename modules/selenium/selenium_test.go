@@ -0,0 +1,163 @@
+package selenium_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/selenium"
+)
+
+func TestSelenium(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		image   string
+		browser string
+	}{
+		{
+			name:    "Chrome",
+			image:   "selenium/standalone-chrome:4.18.1",
+			browser: "chrome",
+		},
+		{
+			name: "Firefox",
+			// firefox {
+			image: "selenium/standalone-firefox:4.18.1",
+			// }
+			browser: "firefox",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container, err := selenium.RunContainer(ctx, testcontainers.WithImage(tt.image))
+			require.NoError(t, err)
+
+			// Clean up the container after the test is complete
+			t.Cleanup(func() {
+				require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+			})
+
+			// webDriverURL {
+			webDriverURL, err := container.WebDriverURL(ctx)
+			// }
+			require.NoError(t, err)
+
+			title := browse(t, webDriverURL, tt.browser, "data:text/html,<title>testcontainers</title>")
+			assert.Equal(t, "testcontainers", title)
+
+			noVNCURL, err := container.NoVNCURL(ctx)
+			require.NoError(t, err)
+
+			resp, err := http.Get(noVNCURL)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestSelenium_recording(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := selenium.RunContainer(ctx, testcontainers.WithImage("selenium/standalone-chrome:4.18.1"))
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+	})
+
+	webDriverURL, err := container.WebDriverURL(ctx)
+	require.NoError(t, err)
+
+	t.Run("saves a video of the test", func(t *testing.T) {
+		video := filepath.Join(t.TempDir(), "video.mp4")
+
+		// recordTest {
+		recording, err := container.StartRecording(ctx)
+		require.NoError(t, err)
+		// }
+
+		browse(t, webDriverURL, "chrome", "data:text/html,<h1>recorded</h1>")
+
+		require.NoError(t, recording.SaveTo(ctx, video))
+
+		bs, err := os.ReadFile(video)
+		require.NoError(t, err)
+		require.Greater(t, len(bs), 8)
+		// the second box of an MP4 file is its ftyp box
+		assert.Equal(t, "ftyp", string(bs[4:8]))
+	})
+
+	t.Run("stops the recordings on termination", func(t *testing.T) {
+		_, err := container.StartRecording(ctx)
+		require.NoError(t, err)
+	})
+}
+
+// browse opens a WebDriver session with the given browser, navigates to the given URL,
+// and returns the title of the page, closing the session.
+func browse(t *testing.T, webDriverURL string, browser string, url string) string {
+	t.Helper()
+
+	var session struct {
+		SessionID string `json:"sessionId"`
+	}
+	webDriver(t, http.MethodPost, webDriverURL+"/session", map[string]any{
+		"capabilities": map[string]any{
+			"alwaysMatch": map[string]any{"browserName": browser},
+		},
+	}, &session)
+	require.NotEmpty(t, session.SessionID)
+
+	sessionURL := fmt.Sprintf("%s/session/%s", webDriverURL, session.SessionID)
+	defer webDriver(t, http.MethodDelete, sessionURL, nil, nil)
+
+	webDriver(t, http.MethodPost, sessionURL+"/url", map[string]any{"url": url}, nil)
+
+	var title string
+	webDriver(t, http.MethodGet, sessionURL+"/title", nil, &title)
+
+	return title
+}
+
+// webDriver sends a command of the WebDriver protocol, decoding its value into out, unless it's nil.
+func webDriver(t *testing.T, method string, url string, body any, out any) {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&payload).Encode(body))
+	}
+
+	req, err := http.NewRequest(method, url, &payload)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result struct {
+		Value json.RawMessage `json:"value"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Equal(t, http.StatusOK, resp.StatusCode, strings.TrimSpace(string(result.Value)))
+
+	if out != nil {
+		require.NoError(t, json.Unmarshal(result.Value, out))
+	}
+}
@@ -0,0 +1,33 @@
+package sftp
+
+import "github.com/testcontainers/testcontainers-go"
+
+type user struct {
+	name        string
+	password    string
+	directories []string
+}
+
+type options struct {
+	users []user
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the SFTP container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithUser adds a user with the given name and password, which can be used more than once to add several users.
+// The users are chrooted to their home directory, which they can't write to, so the given directories
+// are created in the home directory, owned by the user, to upload the files to.
+func WithUser(name string, password string, directories ...string) Option {
+	return func(o *options) {
+		o.users = append(o.users, user{name: name, password: password, directories: directories})
+	}
+}
@@ -0,0 +1,83 @@
+package sftp
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "atmoz/sftp:alpine"
+	defaultPort  = "22/tcp"
+
+	// DefaultUsername is the name of the user created when no user is added with WithUser.
+	DefaultUsername = "test"
+	// DefaultPassword is the password of the user created when no user is added with WithUser.
+	DefaultPassword = "test"
+	// DefaultDirectory is the directory created in the home directory of the default user.
+	DefaultDirectory = "upload"
+)
+
+// SFTPContainer represents the SFTP container type used in the module
+type SFTPContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the SFTP container type, running an OpenSSH server
+// only serving SFTP. If no user is added with WithUser, a user named "test", with the "test" password,
+// is created with an "upload" directory.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*SFTPContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		WaitingFor:   wait.ForLog("Server listening on 0.0.0.0 port 22"),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := options{}
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	if len(settings.users) == 0 {
+		settings.users = []user{{name: DefaultUsername, password: DefaultPassword, directories: []string{DefaultDirectory}}}
+	}
+
+	// the users are passed as arguments, in the user:password:uid:gid:directories format,
+	// letting the server choose the ids
+	for _, u := range settings.users {
+		genericContainerReq.Cmd = append(genericContainerReq.Cmd, u.name+":"+u.password+":::"+strings.Join(u.directories, ","))
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SFTPContainer{Container: container}, nil
+}
+
+// Address returns the address of the SFTP server, in the <host>:<port> format.
+func (c *SFTPContainer) Address(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
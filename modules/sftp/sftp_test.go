@@ -0,0 +1,146 @@
+package sftp_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcsftp "github.com/testcontainers/testcontainers-go/modules/sftp"
+)
+
+func TestSFTP(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := tcsftp.RunContainer(ctx, testcontainers.WithImage("atmoz/sftp:alpine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	client := newClient(t, ctx, container, tcsftp.DefaultUsername, tcsftp.DefaultPassword)
+
+	writeAndRead(t, client, "/"+tcsftp.DefaultDirectory+"/greeting.txt")
+}
+
+func TestSFTP_withUser(t *testing.T) {
+	ctx := context.Background()
+
+	// withUser {
+	container, err := tcsftp.RunContainer(ctx,
+		tcsftp.WithUser("alice", "alice-password", "inbox", "outbox"),
+		tcsftp.WithUser("bob", "bob-password", "inbox"),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	alice := newClient(t, ctx, container, "alice", "alice-password")
+	writeAndRead(t, alice, "/inbox/greeting.txt")
+	writeAndRead(t, alice, "/outbox/greeting.txt")
+
+	bob := newClient(t, ctx, container, "bob", "bob-password")
+	writeAndRead(t, bob, "/inbox/greeting.txt")
+
+	// the users are chrooted to their home directory
+	if _, err := bob.Stat("/outbox"); !os.IsNotExist(err) {
+		t.Fatalf("expected the outbox directory of bob to not exist, got %v", err)
+	}
+
+	// the default user is not created
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            tcsftp.DefaultUsername,
+		Auth:            []ssh.AuthMethod{ssh.Password(tcsftp.DefaultPassword)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if err == nil {
+		t.Fatal("expected the default user to be rejected")
+	}
+}
+
+func newClient(t *testing.T, ctx context.Context, container *tcsftp.SFTPContainer, username string, password string) *sftp.Client {
+	t.Helper()
+
+	// sftpClient {
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User: username,
+		Auth: []ssh.AuthMethod{ssh.Password(password)},
+		// the host key is generated when the container starts
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	t.Cleanup(func() {
+		client.Close()
+		conn.Close()
+	})
+
+	return client
+}
+
+func writeAndRead(t *testing.T, client *sftp.Client, path string) {
+	t.Helper()
+
+	f, err := client.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.Write([]byte("Hello, SFTP!")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err = client.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "Hello, SFTP!" {
+		t.Fatalf("expected content %q, got %q", "Hello, SFTP!", content)
+	}
+}
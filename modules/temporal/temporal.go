@@ -0,0 +1,96 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage    = "temporalio/temporal:1.1.2"
+	defaultGRPCPort = "7233/tcp"
+	defaultUIPort   = "8233/tcp"
+
+	// DefaultNamespace is the namespace always created by the development server.
+	DefaultNamespace = "default"
+)
+
+// TemporalContainer represents the Temporal container type used in the module
+type TemporalContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the Temporal container type, running the development server
+// of the Temporal CLI, which stores the workflows in memory and serves the frontend service and the web UI.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*TemporalContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultGRPCPort, defaultUIPort},
+		Cmd:          []string{"server", "start-dev", "--ip", "0.0.0.0"},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(defaultGRPCPort),
+			wait.ForHTTP("/").WithPort(defaultUIPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemporalContainer{Container: container}, nil
+}
+
+// WithNamespaces creates the given namespaces when the server starts, besides the default one.
+func WithNamespaces(namespaces ...string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		for _, namespace := range namespaces {
+			req.Cmd = append(req.Cmd, "--namespace", namespace)
+		}
+	}
+}
+
+// FrontendAddress returns the address of the frontend service, in the <host>:<port> format,
+// to be used as the HostPort of the options of the Temporal clients.
+func (c *TemporalContainer) FrontendAddress(ctx context.Context) (string, error) {
+	return c.address(ctx, defaultGRPCPort)
+}
+
+// UIURL returns the URL of the web UI, in the http://<host>:<port> format.
+func (c *TemporalContainer) UIURL(ctx context.Context) (string, error) {
+	address, err := c.address(ctx, defaultUIPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s", address), nil
+}
+
+// address returns the address of the given port, in the <host>:<port> format.
+func (c *TemporalContainer) address(ctx context.Context, port nat.Port) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, mappedPort.Port()), nil
+}
@@ -0,0 +1,86 @@
+package temporal_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/temporal"
+)
+
+func TestTemporal(t *testing.T) {
+	ctx := context.Background()
+
+	// withNamespaces {
+	container, err := temporal.RunContainer(ctx,
+		testcontainers.WithImage("temporalio/temporal:1.1.2"),
+		temporal.WithNamespaces("orders"),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// temporalClient {
+	address, err := container.FrontendAddress(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := client.Dial(client.Options{
+		HostPort:  address,
+		Namespace: "orders",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	// }
+
+	w := worker.New(c, "greetings", worker.Options{})
+	w.RegisterWorkflow(greet)
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	run, err := c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{TaskQueue: "greetings"}, greet, "Temporal")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var greeting string
+	if err := run.Get(ctx, &greeting); err != nil {
+		t.Fatal(err)
+	}
+
+	if greeting != "Hello, Temporal!" {
+		t.Fatalf("expected greeting %q, got %q", "Hello, Temporal!", greeting)
+	}
+
+	uiURL, err := container.UIURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(uiURL, "http://") {
+		t.Fatalf("expected an http URL, got %s", uiURL)
+	}
+}
+
+func greet(_ workflow.Context, name string) (string, error) {
+	return "Hello, " + name + "!", nil
+}
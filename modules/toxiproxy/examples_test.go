@@ -0,0 +1,63 @@
+package toxiproxy_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/toxiproxy"
+)
+
+func ExampleRunContainer() {
+	// runToxiproxyContainer {
+	ctx := context.Background()
+
+	toxiproxyContainer, err := toxiproxy.RunContainer(ctx,
+		testcontainers.WithImage("ghcr.io/shopify/toxiproxy:2.9.0"),
+		toxiproxy.WithProxy("redis", "redis:6379"),
+	)
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := toxiproxyContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := toxiproxyContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// addToxic {
+	proxy, err := toxiproxyContainer.Proxy("redis")
+	if err != nil {
+		log.Fatalf("failed to get proxy: %s", err) // nolint:gocritic
+	}
+
+	// connections to the proxy endpoint are forwarded to redis:6379 with one extra second of latency
+	err = proxy.AddToxic(ctx, toxiproxy.Latency(time.Second, 100*time.Millisecond))
+	if err != nil {
+		log.Fatalf("failed to add toxic: %s", err) // nolint:gocritic
+	}
+	// }
+
+	toxics, err := proxy.Toxics(ctx)
+	if err != nil {
+		log.Fatalf("failed to list toxics: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(toxics[0].Name)
+
+	// Output:
+	// true
+	// latency_downstream
+}
@@ -0,0 +1,38 @@
+package toxiproxy
+
+import "github.com/testcontainers/testcontainers-go"
+
+type options struct {
+	proxies []proxyConfig
+}
+
+// proxyConfig is the configuration of a proxy to be created once the container is ready.
+type proxyConfig struct {
+	name     string
+	upstream string
+}
+
+func defaultOptions() options {
+	return options{}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (*Option)(nil)
+
+// Option is an option for the Toxiproxy container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// WithProxy registers a proxy with the given name, forwarding the connections to the upstream address,
+// e.g. "redis:6379". The upstream address is resolved from within the Toxiproxy container, so it's usually
+// the network alias of another container in the same network.
+// Each proxy listens on its own port of the container, which is exposed to the host.
+func WithProxy(name string, upstream string) Option {
+	return func(o *options) {
+		o.proxies = append(o.proxies, proxyConfig{name: name, upstream: upstream})
+	}
+}
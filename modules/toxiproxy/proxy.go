@@ -0,0 +1,238 @@
+package toxiproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Stream is the direction of the traffic a toxic is applied to.
+type Stream string
+
+const (
+	// Upstream is the traffic from the client to the upstream service.
+	Upstream Stream = "upstream"
+	// Downstream is the traffic from the upstream service to the client.
+	Downstream Stream = "downstream"
+)
+
+// Toxic is a fault injected into the traffic of a proxy.
+// Use one of the Latency, Bandwidth, ResetPeer or Timeout functions to create it.
+type Toxic struct {
+	// Name of the toxic, unique within the proxy. Toxiproxy defaults it to "<type>_<stream>", e.g. "latency_downstream".
+	Name string `json:"name,omitempty"`
+	// Type of the toxic, e.g. "latency".
+	Type string `json:"type"`
+	// Stream the toxic is applied to. Defaults to Downstream.
+	Stream Stream `json:"stream,omitempty"`
+	// Toxicity is the probability, between 0 and 1, of the toxic being applied to a connection.
+	Toxicity float32 `json:"toxicity"`
+	// Attributes of the toxic, which depend on its type.
+	Attributes map[string]any `json:"attributes"`
+}
+
+// Latency returns a toxic that delays the data by the given latency, plus or minus a random jitter.
+func Latency(latency time.Duration, jitter time.Duration) Toxic {
+	return newToxic("latency", map[string]any{
+		"latency": latency.Milliseconds(),
+		"jitter":  jitter.Milliseconds(),
+	})
+}
+
+// Bandwidth returns a toxic that limits the data to the given rate, in KB/s.
+func Bandwidth(rate int64) Toxic {
+	return newToxic("bandwidth", map[string]any{
+		"rate": rate,
+	})
+}
+
+// ResetPeer returns a toxic that resets the connections after the given timeout,
+// closing them with a TCP RST. A zero timeout resets the connections immediately.
+func ResetPeer(timeout time.Duration) Toxic {
+	return newToxic("reset_peer", map[string]any{
+		"timeout": timeout.Milliseconds(),
+	})
+}
+
+// Timeout returns a toxic that stops all the data from getting through, and closes the connections
+// after the given timeout. A zero timeout keeps the connections open until the toxic is removed.
+func Timeout(timeout time.Duration) Toxic {
+	return newToxic("timeout", map[string]any{
+		"timeout": timeout.Milliseconds(),
+	})
+}
+
+func newToxic(toxicType string, attributes map[string]any) Toxic {
+	return Toxic{
+		Type:       toxicType,
+		Stream:     Downstream,
+		Toxicity:   1,
+		Attributes: attributes,
+	}
+}
+
+// WithName returns a copy of the toxic with the given name.
+func (t Toxic) WithName(name string) Toxic {
+	t.Name = name
+	return t
+}
+
+// WithStream returns a copy of the toxic applied to the given stream.
+func (t Toxic) WithStream(stream Stream) Toxic {
+	t.Stream = stream
+	return t
+}
+
+// WithToxicity returns a copy of the toxic applied with the given probability, between 0 and 1.
+func (t Toxic) WithToxicity(toxicity float32) Toxic {
+	t.Toxicity = toxicity
+	return t
+}
+
+// Proxy is a proxy of the Toxiproxy container, forwarding the connections it accepts
+// to an upstream service, and applying the toxics added to it.
+type Proxy struct {
+	// Name of the proxy.
+	Name string
+	// Upstream is the address of the service the connections are forwarded to.
+	Upstream string
+	// Port is the port of the container the proxy listens on.
+	Port nat.Port
+
+	container testcontainers.Container
+	client    *client
+}
+
+// Endpoint returns the host:port address to connect to the upstream service through the proxy.
+func (p *Proxy) Endpoint(ctx context.Context) (string, error) {
+	host, err := p.container.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := p.container.MappedPort(ctx, p.Port)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, mappedPort.Port()), nil
+}
+
+// AddToxic adds the toxic to the proxy, which is applied to the new and the existing connections.
+func (p *Proxy) AddToxic(ctx context.Context, toxic Toxic) error {
+	if err := p.client.do(ctx, http.MethodPost, p.path("toxics"), toxic, nil); err != nil {
+		return fmt.Errorf("add %s toxic to proxy %s: %w", toxic.Type, p.Name, err)
+	}
+
+	return nil
+}
+
+// RemoveToxic removes the toxic with the given name from the proxy.
+func (p *Proxy) RemoveToxic(ctx context.Context, name string) error {
+	if err := p.client.do(ctx, http.MethodDelete, p.path("toxics", name), nil, nil); err != nil {
+		return fmt.Errorf("remove toxic %s from proxy %s: %w", name, p.Name, err)
+	}
+
+	return nil
+}
+
+// Toxics returns the toxics of the proxy.
+func (p *Proxy) Toxics(ctx context.Context) ([]Toxic, error) {
+	var toxics []Toxic
+	if err := p.client.do(ctx, http.MethodGet, p.path("toxics"), nil, &toxics); err != nil {
+		return nil, fmt.Errorf("list toxics of proxy %s: %w", p.Name, err)
+	}
+
+	return toxics, nil
+}
+
+// Enable makes the proxy accept connections again, after it has been disabled.
+func (p *Proxy) Enable(ctx context.Context) error {
+	return p.setEnabled(ctx, true)
+}
+
+// Disable closes all the connections of the proxy, and stops accepting new ones,
+// which is the way to simulate the upstream service being down.
+func (p *Proxy) Disable(ctx context.Context) error {
+	return p.setEnabled(ctx, false)
+}
+
+func (p *Proxy) setEnabled(ctx context.Context, enabled bool) error {
+	if err := p.client.do(ctx, http.MethodPost, p.path(), map[string]bool{"enabled": enabled}, nil); err != nil {
+		return fmt.Errorf("update proxy %s: %w", p.Name, err)
+	}
+
+	return nil
+}
+
+// path returns the path of the given resource of the proxy in the Toxiproxy API.
+func (p *Proxy) path(elem ...string) string {
+	path := "/proxies/" + url.PathEscape(p.Name)
+	for _, e := range elem {
+		path += "/" + url.PathEscape(e)
+	}
+	return path
+}
+
+// client is a minimal client for the HTTP API of Toxiproxy.
+type client struct {
+	uri        string
+	httpClient *http.Client
+}
+
+// apiError is the body of the responses of the Toxiproxy API for failed requests.
+type apiError struct {
+	Message string `json:"error"`
+	Status  int    `json:"status"`
+}
+
+// do sends a request with the JSON encoded body, if any, to the given path of the API,
+// and decodes the JSON response into out, if not nil.
+func (c *client) do(ctx context.Context, method string, path string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.uri+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var apiErr apiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Message == "" {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return fmt.Errorf("%s (status code %d)", apiErr.Message, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
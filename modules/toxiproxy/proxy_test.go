@@ -0,0 +1,174 @@
+package toxiproxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// request is a request received by the fake Toxiproxy API.
+type request struct {
+	method string
+	path   string
+	body   map[string]any
+}
+
+// newFakeProxy returns a proxy whose client sends the requests to a fake Toxiproxy API,
+// which records them and responds with the given status code and body.
+func newFakeProxy(t *testing.T, status int, response string) (*Proxy, *[]request) {
+	t.Helper()
+
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{method: r.Method, path: r.URL.EscapedPath()}
+
+		bs, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %s", err)
+		}
+		if len(bs) > 0 {
+			if err := json.Unmarshal(bs, &req.body); err != nil {
+				t.Errorf("failed to decode request body: %s", err)
+			}
+		}
+		requests = append(requests, req)
+
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+
+	return &Proxy{
+		Name:   "redis",
+		client: &client{uri: server.URL, httpClient: server.Client()},
+	}, &requests
+}
+
+func TestProxy_AddToxic(t *testing.T) {
+	tests := []struct {
+		name     string
+		toxic    Toxic
+		expected map[string]any
+	}{
+		{
+			name:  "latency",
+			toxic: Latency(time.Second, 100*time.Millisecond),
+			expected: map[string]any{
+				"type":       "latency",
+				"stream":     "downstream",
+				"toxicity":   float64(1),
+				"attributes": map[string]any{"latency": float64(1000), "jitter": float64(100)},
+			},
+		},
+		{
+			name:  "bandwidth upstream",
+			toxic: Bandwidth(64).WithStream(Upstream),
+			expected: map[string]any{
+				"type":       "bandwidth",
+				"stream":     "upstream",
+				"toxicity":   float64(1),
+				"attributes": map[string]any{"rate": float64(64)},
+			},
+		},
+		{
+			name:  "reset peer with name and toxicity",
+			toxic: ResetPeer(0).WithName("flaky").WithToxicity(0.5),
+			expected: map[string]any{
+				"name":       "flaky",
+				"type":       "reset_peer",
+				"stream":     "downstream",
+				"toxicity":   0.5,
+				"attributes": map[string]any{"timeout": float64(0)},
+			},
+		},
+		{
+			name:  "timeout",
+			toxic: Timeout(2 * time.Second),
+			expected: map[string]any{
+				"type":       "timeout",
+				"stream":     "downstream",
+				"toxicity":   float64(1),
+				"attributes": map[string]any{"timeout": float64(2000)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy, requests := newFakeProxy(t, http.StatusOK, "{}")
+
+			if err := proxy.AddToxic(context.Background(), tt.toxic); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(*requests) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(*requests))
+			}
+
+			req := (*requests)[0]
+			if req.method != http.MethodPost || req.path != "/proxies/redis/toxics" {
+				t.Fatalf("unexpected request: %s %s", req.method, req.path)
+			}
+
+			expected, _ := json.Marshal(tt.expected)
+			actual, _ := json.Marshal(req.body)
+			if string(expected) != string(actual) {
+				t.Fatalf("expected body %s, got %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestProxy_RemoveToxic(t *testing.T) {
+	proxy, requests := newFakeProxy(t, http.StatusNoContent, "")
+
+	if err := proxy.RemoveToxic(context.Background(), "latency_downstream"); err != nil {
+		t.Fatal(err)
+	}
+
+	req := (*requests)[0]
+	if req.method != http.MethodDelete || req.path != "/proxies/redis/toxics/latency_downstream" {
+		t.Fatalf("unexpected request: %s %s", req.method, req.path)
+	}
+}
+
+func TestProxy_EnableDisable(t *testing.T) {
+	proxy, requests := newFakeProxy(t, http.StatusOK, "{}")
+
+	if err := proxy.Disable(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := proxy.Enable(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, enabled := range []bool{false, true} {
+		req := (*requests)[i]
+		if req.method != http.MethodPost || req.path != "/proxies/redis" {
+			t.Fatalf("unexpected request: %s %s", req.method, req.path)
+		}
+
+		if req.body["enabled"] != enabled {
+			t.Fatalf("expected enabled to be %t, got %v", enabled, req.body["enabled"])
+		}
+	}
+}
+
+func TestProxy_apiError(t *testing.T) {
+	proxy, _ := newFakeProxy(t, http.StatusConflict, `{"error":"toxic already exists","status":409}`)
+
+	err := proxy.AddToxic(context.Background(), Latency(time.Second, 0))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !strings.Contains(err.Error(), "toxic already exists") {
+		t.Fatalf("expected the error of the API, got %s", err)
+	}
+}
@@ -0,0 +1,135 @@
+package toxiproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// ControlPort is the port of the HTTP API used to manage the proxies and their toxics.
+	ControlPort = "8474/tcp"
+
+	// firstProxyPort is the port of the container the first proxy listens on,
+	// the following proxies listen on the consecutive ports.
+	firstProxyPort = 8666
+)
+
+// ToxiproxyContainer represents the Toxiproxy container type used in the module
+type ToxiproxyContainer struct {
+	testcontainers.Container
+	client  *client
+	proxies map[string]*Proxy
+}
+
+// RunContainer creates an instance of the Toxiproxy container type
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*ToxiproxyContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        "ghcr.io/shopify/toxiproxy:2.9.0",
+		ExposedPorts: []string{ControlPort},
+		WaitingFor:   wait.ForHTTP("/version").WithPort(ControlPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+		opt.Customize(&genericContainerReq)
+	}
+
+	// each proxy listens on its own port, which must be exposed before the container is started
+	for i := range settings.proxies {
+		genericContainerReq.ExposedPorts = append(genericContainerReq.ExposedPorts, string(proxyPort(i)))
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ToxiproxyContainer{
+		Container: container,
+		proxies:   make(map[string]*Proxy, len(settings.proxies)),
+	}
+
+	uri, err := c.URI(ctx)
+	if err != nil {
+		return nil, errors.Join(err, container.Terminate(ctx))
+	}
+	c.client = &client{uri: uri, httpClient: &http.Client{Timeout: 10 * time.Second}}
+
+	for i, cfg := range settings.proxies {
+		proxy, err := c.createProxy(ctx, cfg, proxyPort(i))
+		if err != nil {
+			return nil, errors.Join(err, container.Terminate(ctx))
+		}
+		c.proxies[cfg.name] = proxy
+	}
+
+	return c, nil
+}
+
+// proxyPort returns the port of the container the i-th proxy listens on.
+func proxyPort(i int) nat.Port {
+	return nat.Port(fmt.Sprintf("%d/tcp", firstProxyPort+i))
+}
+
+// createProxy creates the proxy in Toxiproxy, listening on the given port of the container.
+func (c *ToxiproxyContainer) createProxy(ctx context.Context, cfg proxyConfig, port nat.Port) (*Proxy, error) {
+	body := map[string]any{
+		"name":     cfg.name,
+		"listen":   fmt.Sprintf("0.0.0.0:%s", port.Port()),
+		"upstream": cfg.upstream,
+		"enabled":  true,
+	}
+
+	if err := c.client.do(ctx, http.MethodPost, "/proxies", body, nil); err != nil {
+		return nil, fmt.Errorf("create proxy %s: %w", cfg.name, err)
+	}
+
+	return &Proxy{
+		Name:      cfg.name,
+		Upstream:  cfg.upstream,
+		Port:      port,
+		container: c.Container,
+		client:    c.client,
+	}, nil
+}
+
+// URI returns the URI of the HTTP API of Toxiproxy, e.g. "http://localhost:32768".
+func (c *ToxiproxyContainer) URI(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, ControlPort, "http")
+}
+
+// Proxy returns the proxy with the given name, registered with the WithProxy option.
+func (c *ToxiproxyContainer) Proxy(name string) (*Proxy, error) {
+	proxy, ok := c.proxies[name]
+	if !ok {
+		return nil, fmt.Errorf("proxy %s not found", name)
+	}
+
+	return proxy, nil
+}
+
+// Reset enables all the proxies and removes all their toxics, so that the traffic
+// flows normally again, e.g. between tests sharing the same container.
+func (c *ToxiproxyContainer) Reset(ctx context.Context) error {
+	if err := c.client.do(ctx, http.MethodPost, "/reset", nil, nil); err != nil {
+		return fmt.Errorf("reset proxies: %w", err)
+	}
+
+	return nil
+}
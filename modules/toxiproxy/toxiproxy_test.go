@@ -0,0 +1,160 @@
+package toxiproxy_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/toxiproxy"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestToxiproxy(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := nw.Remove(context.Background()); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "nginx:alpine",
+			WaitingFor: wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := nginx.Terminate(context.Background()); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if err := nginx.(*testcontainers.DockerContainer).ConnectToNetwork(ctx, nw.Name, "nginx"); err != nil {
+		t.Fatal(err)
+	}
+
+	container, err := toxiproxy.RunContainer(ctx,
+		toxiproxy.WithProxy("nginx", "nginx:80"),
+		network.WithNetwork([]string{"toxiproxy"}, nw),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	proxy, err := container.Proxy("nginx")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	endpoint, err := proxy.Endpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+
+	get := func() (time.Duration, error) {
+		start := time.Now()
+		resp, err := httpClient.Get("http://" + endpoint)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+		return time.Since(start), nil
+	}
+
+	if _, err := get(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("latency", func(t *testing.T) {
+		if err := proxy.AddToxic(ctx, toxiproxy.Latency(time.Second, 0)); err != nil {
+			t.Fatal(err)
+		}
+
+		elapsed, err := get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if elapsed < time.Second {
+			t.Fatalf("expected the request to take at least 1s, took %s", elapsed)
+		}
+
+		if err := proxy.RemoveToxic(ctx, "latency_downstream"); err != nil {
+			t.Fatal(err)
+		}
+
+		toxics, err := proxy.Toxics(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(toxics) != 0 {
+			t.Fatalf("expected no toxics, got %d", len(toxics))
+		}
+	})
+
+	t.Run("reset peer", func(t *testing.T) {
+		if err := proxy.AddToxic(ctx, toxiproxy.ResetPeer(0)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := get(); err == nil {
+			t.Fatal("expected the connection to be reset")
+		}
+
+		if err := container.Reset(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := get(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		if err := proxy.Disable(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := get(); err == nil {
+			t.Fatal("expected the proxy to be down")
+		}
+
+		if err := proxy.Enable(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := get(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("unknown proxy", func(t *testing.T) {
+		if _, err := container.Proxy("unknown"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
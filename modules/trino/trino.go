@@ -0,0 +1,114 @@
+package trino
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "trinodb/trino:440"
+	defaultPort  = "8080/tcp"
+
+	// defaultUser is the user of the connection string. Trino accepts any user when authentication is disabled.
+	defaultUser = "testcontainers"
+
+	catalogDir = "/etc/trino/catalog"
+)
+
+// TrinoContainer represents the Trino container type used in the module
+type TrinoContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the Trino container type, running a single node acting as
+// coordinator and worker, with the catalogs of the image, e.g. "memory" and "tpch".
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*TrinoContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		WaitingFor:   wait.ForLog("======== SERVER STARTED ========"),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrinoContainer{Container: container}, nil
+}
+
+// WithCatalog adds a catalog with the given name, configured with the given properties,
+// which must include the "connector.name" property, e.g. "memory" or "postgresql".
+// It replaces the catalog of the image with the same name, if any.
+func WithCatalog(name string, properties map[string]string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		keys := make([]string, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var content strings.Builder
+		for _, key := range keys {
+			content.WriteString(key + "=" + properties[key] + "\n")
+		}
+
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(content.String()),
+			ContainerFilePath: catalogDir + "/" + name + ".properties",
+			FileMode:          0o644,
+		})
+	}
+}
+
+// WithCatalogFile adds a catalog from the given properties file, named after the file without
+// its ".properties" extension, e.g. "orders.properties" adds the "orders" catalog.
+func WithCatalogFile(path string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		name := strings.TrimSuffix(filepath.Base(path), ".properties")
+
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      path,
+			ContainerFilePath: catalogDir + "/" + name + ".properties",
+			FileMode:          0o644,
+		})
+	}
+}
+
+// ConnectionString returns the DSN of the Trino server for the trino-go-client driver, in the
+// http://testcontainers@<host>:<port> format. The given args are added as query parameters,
+// e.g. "catalog=memory" and "schema=default".
+func (c *TrinoContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	dsn := fmt.Sprintf("http://%s@%s", defaultUser, net.JoinHostPort(host, port.Port()))
+	if len(args) > 0 {
+		dsn += "?" + strings.Join(args, "&")
+	}
+
+	return dsn, nil
+}
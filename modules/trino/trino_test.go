@@ -0,0 +1,77 @@
+package trino_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/trinodb/trino-go-client/trino"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/trino"
+)
+
+func TestTrino(t *testing.T) {
+	ctx := context.Background()
+
+	// withCatalog {
+	container, err := trino.RunContainer(ctx,
+		testcontainers.WithImage("trinodb/trino:440"),
+		trino.WithCatalog("inventory", map[string]string{"connector.name": "memory"}),
+		trino.WithCatalogFile(filepath.Join("testdata", "orders.properties")),
+	)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	dsn, err := container.ConnectionString(ctx, "catalog=inventory", "schema=default")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("trino", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// }
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE items (id BIGINT, name VARCHAR)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.ExecContext(ctx, "INSERT INTO items VALUES (1, 'apple'), (2, 'pear')"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE orders.default.lines (item_id BIGINT, quantity BIGINT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.ExecContext(ctx, "INSERT INTO orders.default.lines VALUES (1, 3), (2, 5), (1, 4)"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the query federates the tables of both catalogs
+	var quantity int64
+	err = db.QueryRowContext(ctx,
+		"SELECT sum(l.quantity) FROM orders.default.lines l JOIN items i ON i.id = l.item_id WHERE i.name = 'apple'",
+	).Scan(&quantity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if quantity != 7 {
+		t.Fatalf("expected a quantity of 7, got %d", quantity)
+	}
+}
@@ -0,0 +1,109 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// mount is the secret engine a path belongs to, as returned by the sys/internal/ui/mounts endpoint.
+type mount struct {
+	Path    string            `json:"path"`
+	Type    string            `json:"type"`
+	Options map[string]string `json:"options"`
+}
+
+// enableSecretEngine enables a secret engine of the given type at the given path.
+func enableSecretEngine(ctx context.Context, c testcontainers.Container, token string, path string, engineType string, options map[string]string) error {
+	body := map[string]any{
+		"type":    engineType,
+		"options": options,
+	}
+
+	if _, err := request(ctx, c, token, http.MethodPost, "sys/mounts/"+strings.Trim(path, "/"), body); err != nil {
+		return fmt.Errorf("enable secret engine %s at %s: %w", engineType, path, err)
+	}
+
+	return nil
+}
+
+// writeSecrets writes the given data at the given path, wrapping it as the version 2 KV secret engines expect.
+func writeSecrets(ctx context.Context, c testcontainers.Container, token string, path string, data map[string]any) error {
+	path = strings.Trim(path, "/")
+
+	resp, err := request(ctx, c, token, http.MethodGet, "sys/internal/ui/mounts/"+path, nil)
+	if err != nil {
+		return fmt.Errorf("find the secret engine of %s: %w", path, err)
+	}
+
+	var info struct {
+		Data mount `json:"data"`
+	}
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return fmt.Errorf("find the secret engine of %s: %w", path, err)
+	}
+
+	apiPath, body := secretsRequest(info.Data, path, data)
+	if _, err := request(ctx, c, token, http.MethodPost, apiPath, body); err != nil {
+		return fmt.Errorf("write secrets at %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// secretsRequest returns the API path and the body of the request writing the given data at the given path
+// of the given secret engine.
+func secretsRequest(m mount, path string, data map[string]any) (string, any) {
+	if m.Type != "kv" || m.Options["version"] != "2" {
+		return path, data
+	}
+
+	return m.Path + "data/" + strings.TrimPrefix(path, m.Path), map[string]any{"data": data}
+}
+
+// request sends a request with the given token to the given path of the Vault API, returning the body of the response.
+func request(ctx context.Context, c testcontainers.Container, token string, method string, path string, body any) ([]byte, error) {
+	address, err := httpHostAddress(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, address+"/v1/"+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(bs)))
+	}
+
+	return bs, nil
+}
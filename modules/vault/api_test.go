@@ -0,0 +1,29 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretsRequest(t *testing.T) {
+	data := map[string]any{"password": "s3cr3t"}
+
+	t.Run("kv version 2", func(t *testing.T) {
+		path, body := secretsRequest(mount{Path: "secret/", Type: "kv", Options: map[string]string{"version": "2"}}, "secret/app/db", data)
+		assert.Equal(t, "secret/data/app/db", path)
+		assert.Equal(t, map[string]any{"data": data}, body)
+	})
+
+	t.Run("kv version 1", func(t *testing.T) {
+		path, body := secretsRequest(mount{Path: "kv-v1/", Type: "kv", Options: map[string]string{"version": "1"}}, "kv-v1/app", data)
+		assert.Equal(t, "kv-v1/app", path)
+		assert.Equal(t, data, body)
+	})
+
+	t.Run("other secret engine", func(t *testing.T) {
+		path, body := secretsRequest(mount{Path: "transit/", Type: "transit"}, "transit/keys/my-key", data)
+		assert.Equal(t, "transit/keys/my-key", path)
+		assert.Equal(t, data, body)
+	})
+}
@@ -14,11 +14,13 @@ import (
 const (
 	defaultPort      = "8200"
 	defaultImageName = "hashicorp/vault:1.13.0"
+	defaultToken     = "root"
 )
 
 // VaultContainer represents the vault container type used in the module
 type VaultContainer struct {
 	testcontainers.Container
+	token string
 }
 
 // RunContainer creates an instance of the vault container type
@@ -31,7 +33,9 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		},
 		WaitingFor: wait.ForHTTP("/v1/sys/health").WithPort(defaultPort),
 		Env: map[string]string{
-			"VAULT_ADDR": "http://0.0.0.0:" + defaultPort,
+			"VAULT_ADDR":              "http://0.0.0.0:" + defaultPort,
+			"VAULT_DEV_ROOT_TOKEN_ID": defaultToken,
+			"VAULT_TOKEN":             defaultToken,
 		},
 	}
 
@@ -49,10 +53,11 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		return nil, err
 	}
 
-	return &VaultContainer{container}, nil
+	return &VaultContainer{Container: container, token: genericContainerReq.Env["VAULT_DEV_ROOT_TOKEN_ID"]}, nil
 }
 
-// WithToken is a container option function that sets the root token for the Vault
+// WithToken is a container option function that sets the root token for the Vault.
+// The default root token is "root".
 func WithToken(token string) testcontainers.CustomizeRequestOption {
 	return func(req *testcontainers.GenericContainerRequest) {
 		req.Env["VAULT_DEV_ROOT_TOKEN_ID"] = token
@@ -73,15 +78,58 @@ func WithInitCommand(commands ...string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// WithSecretEngine is an option function that enables a secret engine of the given type at the given path
+// once Vault is ready, e.g. WithSecretEngine("kv-v1", "kv", map[string]string{"version": "1"}).
+// The options are specific to the type of the secret engine, and can be nil.
+// The secret engines and the secrets are created in the order of the options, so the secret engines
+// must be enabled before the secrets stored in them are written.
+// See https://developer.hashicorp.com/vault/api-docs/system/mounts#enable-secrets-engine for more information.
+func WithSecretEngine(path string, engineType string, options map[string]string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return enableSecretEngine(ctx, c, req.Env["VAULT_DEV_ROOT_TOKEN_ID"], path, engineType, options)
+				},
+			},
+		})
+	}
+}
+
+// WithSecrets is an option function that writes the given data at the given path using the Vault API
+// once Vault is ready, e.g. WithSecrets("secret/app", map[string]any{"password": "s3cr3t"}).
+// As with the "vault kv put" command, the path of the secrets stored in a version 2 KV secret engine,
+// such as the "secret" one of the dev mode, doesn't include the "data" segment of the API.
+func WithSecrets(path string, data map[string]any) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return writeSecrets(ctx, c, req.Env["VAULT_DEV_ROOT_TOKEN_ID"], path, data)
+				},
+			},
+		})
+	}
+}
+
+// Token returns the root token of Vault.
+func (v *VaultContainer) Token() string {
+	return v.token
+}
+
 // HttpHostAddress returns the http host address of Vault.
 // It returns a string with the format http://<host>:<port>
 func (v *VaultContainer) HttpHostAddress(ctx context.Context) (string, error) {
-	host, err := v.Host(ctx)
+	return httpHostAddress(ctx, v)
+}
+
+func httpHostAddress(ctx context.Context, c testcontainers.Container) (string, error) {
+	host, err := c.Host(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	port, err := v.MappedPort(ctx, defaultPort)
+	port, err := c.MappedPort(ctx, defaultPort)
 	if err != nil {
 		return "", err
 	}
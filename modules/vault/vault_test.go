@@ -122,6 +122,10 @@ func TestVault(t *testing.T) {
 		})
 	})
 
+	t.Run("Root token", func(t *testing.T) {
+		assert.Equal(t, token, vaultContainer.Token())
+	})
+
 	t.Cleanup(func() {
 		// Clean up the vault after the test is complete
 		if err := vaultContainer.Terminate(ctx); err != nil {
@@ -129,3 +133,36 @@ func TestVault(t *testing.T) {
 		}
 	})
 }
+
+func TestVault_withSecretEngineAndSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	vaultContainer, err := testcontainervault.RunContainer(ctx,
+		// withSecrets {
+		testcontainervault.WithSecretEngine("kv-v1", "kv", map[string]string{"version": "1"}),
+		testcontainervault.WithSecrets("kv-v1/app", map[string]any{"username": "admin"}),
+		testcontainervault.WithSecrets("secret/app", map[string]any{"password": "s3cr3t"}),
+		// }
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, vaultContainer.Terminate(ctx))
+	})
+
+	hostAddress, err := vaultContainer.HttpHostAddress(ctx)
+	require.NoError(t, err)
+
+	client, err := vaultClient.New(vaultClient.WithAddress(hostAddress))
+	require.NoError(t, err)
+
+	// the default root token is "root"
+	require.NoError(t, client.SetToken(vaultContainer.Token()))
+
+	v1, err := client.Secrets.KvV1Read(ctx, "app", vaultClient.WithMountPath("kv-v1"))
+	require.NoError(t, err)
+	assert.Equal(t, "admin", v1.Data["username"])
+
+	v2, err := client.Secrets.KvV2Read(ctx, "app", vaultClient.WithMountPath("secret"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", v2.Data.Data["password"])
+}
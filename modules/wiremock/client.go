@@ -0,0 +1,211 @@
+package wiremock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Matcher matches a value of a request, e.g. a header or the body, as defined by WireMock.
+// See https://wiremock.org/docs/request-matching/
+type Matcher map[string]any
+
+// EqualTo matches the values equal to the given one.
+func EqualTo(value string) Matcher {
+	return Matcher{"equalTo": value}
+}
+
+// Contains matches the values containing the given one.
+func Contains(value string) Matcher {
+	return Matcher{"contains": value}
+}
+
+// Matches matches the values matching the given regular expression.
+func Matches(regex string) Matcher {
+	return Matcher{"matches": regex}
+}
+
+// EqualToJSON matches the JSON values semantically equal to the given one.
+func EqualToJSON(json string) Matcher {
+	return Matcher{"equalToJson": json}
+}
+
+// RequestPattern matches the requests received by WireMock. The URL is matched by one of URL, URLPath,
+// URLPattern or URLPathPattern, the first two being exact matches, the last two regular expressions.
+// The path only matchers ignore the query parameters of the URL.
+type RequestPattern struct {
+	Method          string             `json:"method,omitempty"`
+	URL             string             `json:"url,omitempty"`
+	URLPath         string             `json:"urlPath,omitempty"`
+	URLPattern      string             `json:"urlPattern,omitempty"`
+	URLPathPattern  string             `json:"urlPathPattern,omitempty"`
+	Headers         map[string]Matcher `json:"headers,omitempty"`
+	QueryParameters map[string]Matcher `json:"queryParameters,omitempty"`
+	BodyPatterns    []Matcher          `json:"bodyPatterns,omitempty"`
+}
+
+// Response is the response returned by a stub. The body is defined by one of Body, JSONBody or BodyFileName,
+// the name of a file copied with WithFile.
+type Response struct {
+	Status                 int               `json:"status"`
+	Headers                map[string]string `json:"headers,omitempty"`
+	Body                   string            `json:"body,omitempty"`
+	JSONBody               any               `json:"jsonBody,omitempty"`
+	BodyFileName           string            `json:"bodyFileName,omitempty"`
+	FixedDelayMilliseconds int               `json:"fixedDelayMilliseconds,omitempty"`
+}
+
+// Stub returns the response to the requests matching its request pattern. When several stubs match a request,
+// the one with the highest priority, i.e. the lowest value, wins, then the most recently registered one.
+type Stub struct {
+	ID       string         `json:"id,omitempty"`
+	Request  RequestPattern `json:"request"`
+	Response Response       `json:"response"`
+	Priority int            `json:"priority,omitempty"`
+}
+
+// LoggedRequest is a request received by WireMock. The value of a header is a string,
+// or a slice of strings if the header was sent several times.
+type LoggedRequest struct {
+	Method  string         `json:"method"`
+	URL     string         `json:"url"`
+	Headers map[string]any `json:"headers"`
+	Body    string         `json:"body"`
+}
+
+// Client is a thin client of the admin API of WireMock.
+// See https://wiremock.org/docs/standalone/admin-api-reference/
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the admin API of the WireMock server with the given base URL.
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{},
+	}
+}
+
+// Register registers the given stub, returning its ID, which WireMock generates if the stub has none.
+func (c *Client) Register(ctx context.Context, stub Stub) (string, error) {
+	var registered Stub
+	if err := c.do(ctx, http.MethodPost, "/mappings", stub, &registered); err != nil {
+		return "", fmt.Errorf("register stub: %w", err)
+	}
+
+	return registered.ID, nil
+}
+
+// Remove removes the stub with the given ID.
+func (c *Client) Remove(ctx context.Context, id string) error {
+	if err := c.do(ctx, http.MethodDelete, "/mappings/"+id, nil, nil); err != nil {
+		return fmt.Errorf("remove stub %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// Reset removes the stubs registered with the client, restoring the stubs of the mapping files,
+// and forgets the received requests.
+func (c *Client) Reset(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodPost, "/reset", nil, nil); err != nil {
+		return fmt.Errorf("reset: %w", err)
+	}
+
+	return nil
+}
+
+// Requests returns the received requests matching the given pattern, the most recent first.
+func (c *Client) Requests(ctx context.Context, pattern RequestPattern) ([]LoggedRequest, error) {
+	var found struct {
+		Requests []LoggedRequest `json:"requests"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/requests/find", pattern, &found); err != nil {
+		return nil, fmt.Errorf("find requests: %w", err)
+	}
+
+	return found.Requests, nil
+}
+
+// Count returns the number of received requests matching the given pattern.
+func (c *Client) Count(ctx context.Context, pattern RequestPattern) (int, error) {
+	var count struct {
+		Count int `json:"count"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/requests/count", pattern, &count); err != nil {
+		return 0, fmt.Errorf("count requests: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// Verify returns an error if the number of received requests matching the given pattern is not the expected one.
+func (c *Client) Verify(ctx context.Context, pattern RequestPattern, expected int) error {
+	count, err := c.Count(ctx, pattern)
+	if err != nil {
+		return err
+	}
+
+	if count != expected {
+		return fmt.Errorf("expected %d requests matching %s %s, received %d", expected, pattern.Method, pattern.url(), count)
+	}
+
+	return nil
+}
+
+// url returns the URL matcher of the pattern, to describe it.
+func (p RequestPattern) url() string {
+	for _, url := range []string{p.URL, p.URLPath, p.URLPattern, p.URLPathPattern} {
+		if url != "" {
+			return url
+		}
+	}
+
+	return "any URL"
+}
+
+// do sends a request with the given body, encoded as JSON, to the given path of the admin API,
+// decoding the body of the response into out, unless it's nil.
+func (c *Client) do(ctx context.Context, method string, path string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+"/__admin"+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(bs)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(bs, out)
+}
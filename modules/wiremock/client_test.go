@@ -0,0 +1,82 @@
+package wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient(t *testing.T) {
+	var method, path string
+	var body map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+
+		bs, _ := io.ReadAll(r.Body)
+		body = nil
+		_ = json.Unmarshal(bs, &body)
+
+		switch r.URL.Path {
+		case "/__admin/mappings":
+			_, _ = w.Write([]byte(`{"id": "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7"}`))
+		case "/__admin/requests/count":
+			_, _ = w.Write([]byte(`{"count": 2}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL + "/")
+
+	t.Run("register", func(t *testing.T) {
+		id, err := client.Register(ctx, Stub{
+			Request:  RequestPattern{Method: http.MethodGet, URLPath: "/users", Headers: map[string]Matcher{"Accept": EqualTo("application/json")}},
+			Response: Response{Status: http.StatusOK, Body: "[]"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if id != "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7" {
+			t.Fatalf("unexpected id: %s", id)
+		}
+
+		if method != http.MethodPost || path != "/__admin/mappings" {
+			t.Fatalf("unexpected request: %s %s", method, path)
+		}
+
+		request := body["request"].(map[string]any)
+		if request["urlPath"] != "/users" || request["url"] != nil {
+			t.Fatalf("unexpected request pattern: %v", request)
+		}
+
+		headers := request["headers"].(map[string]any)
+		if headers["Accept"].(map[string]any)["equalTo"] != "application/json" {
+			t.Fatalf("unexpected headers: %v", headers)
+		}
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		if err := client.Verify(ctx, RequestPattern{Method: http.MethodGet, URLPath: "/users"}, 2); err != nil {
+			t.Fatal(err)
+		}
+
+		err := client.Verify(ctx, RequestPattern{Method: http.MethodGet, URLPathPattern: "/users/.*"}, 1)
+		if err == nil || err.Error() != "expected 1 requests matching GET /users/.*, received 2" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := client.Remove(ctx, "unknown")
+		if err == nil || err.Error() != "remove stub unknown: DELETE /mappings/unknown: status 404: not found" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
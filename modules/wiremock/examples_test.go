@@ -0,0 +1,38 @@
+package wiremock_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/wiremock"
+)
+
+func ExampleRunContainer() {
+	// runWireMockContainer {
+	ctx := context.Background()
+
+	wiremockContainer, err := wiremock.RunContainer(ctx, testcontainers.WithImage("wiremock/wiremock:3.5.2"))
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := wiremockContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+	// }
+
+	state, err := wiremockContainer.State(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container state: %s", err) // nolint:gocritic
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
@@ -0,0 +1,99 @@
+package wiremock
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	defaultImage = "wiremock/wiremock:3.5.2"
+	defaultPort  = "8080/tcp"
+	mappingsDir  = "/home/wiremock/mappings/"
+	filesDir     = "/home/wiremock/__files/"
+)
+
+// WireMockContainer represents the WireMock container type used in the module
+type WireMockContainer struct {
+	testcontainers.Container
+}
+
+// RunContainer creates an instance of the WireMock container type, serving the stubs of the mapping files
+// copied with WithMappingFile, and the stubs registered with the client returned by the Client method.
+func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*WireMockContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{defaultPort},
+		// the mapping files are loaded before the admin API is served.
+		WaitingFor: wait.ForHTTP("/__admin/mappings").WithPort(defaultPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WireMockContainer{Container: container}, nil
+}
+
+// WithMappingFile copies the given mapping file, a JSON file defining one or more stubs, to the container,
+// so that its stubs are registered when WireMock starts.
+func WithMappingFile(mappingFile string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      mappingFile,
+			ContainerFilePath: mappingsDir + filepath.Base(mappingFile),
+			FileMode:          0o644,
+		})
+	}
+}
+
+// WithFile copies the given file to the container, so that it can be used as the body of the responses
+// of the stubs, referenced by its name in their "bodyFileName" field.
+func WithFile(file string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      file,
+			ContainerFilePath: filesDir + filepath.Base(file),
+			FileMode:          0o644,
+		})
+	}
+}
+
+// URL returns the base URL of WireMock, in the http://<host>:<port> format, which the services under test
+// must send their requests to.
+func (c *WireMockContainer) URL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, defaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, port.Port()), nil
+}
+
+// Client returns a client of the admin API of WireMock, to register stubs and verify the received requests.
+func (c *WireMockContainer) Client(ctx context.Context) (*Client, error) {
+	url, err := c.URL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(url), nil
+}
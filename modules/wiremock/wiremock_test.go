@@ -0,0 +1,117 @@
+package wiremock_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/wiremock"
+)
+
+func TestWireMock(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := wiremock.RunContainer(ctx,
+		testcontainers.WithImage("wiremock/wiremock:3.5.2"),
+		// withMappings {
+		wiremock.WithMappingFile(filepath.Join("testdata", "greetings.json")),
+		wiremock.WithFile(filepath.Join("testdata", "greeting.txt")),
+		// }
+	)
+	require.NoError(t, err)
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx), "failed to terminate container")
+	})
+
+	url, err := container.URL(ctx)
+	require.NoError(t, err)
+
+	client, err := container.Client(ctx)
+	require.NoError(t, err)
+
+	t.Run("serves the stubs of the mapping files", func(t *testing.T) {
+		resp, err := http.Get(url + "/greetings")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "Hello from WireMock\n", string(body))
+	})
+
+	t.Run("serves the registered stubs and verifies the requests", func(t *testing.T) {
+		t.Cleanup(func() {
+			require.NoError(t, client.Reset(ctx))
+		})
+
+		// registerStub {
+		_, err := client.Register(ctx, wiremock.Stub{
+			Request: wiremock.RequestPattern{
+				Method:       http.MethodPost,
+				URLPath:      "/api/orders",
+				BodyPatterns: []wiremock.Matcher{wiremock.EqualToJSON(`{"item": "book"}`)},
+			},
+			Response: wiremock.Response{
+				Status:   http.StatusCreated,
+				JSONBody: map[string]any{"id": 42},
+			},
+		})
+		// }
+		require.NoError(t, err)
+
+		resp, err := http.Post(url+"/api/orders", "application/json", strings.NewReader(`{"item": "book"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.JSONEq(t, `{"id": 42}`, string(body))
+
+		// verifyRequests {
+		err = client.Verify(ctx, wiremock.RequestPattern{Method: http.MethodPost, URLPath: "/api/orders"}, 1)
+		// }
+		require.NoError(t, err)
+
+		requests, err := client.Requests(ctx, wiremock.RequestPattern{URLPath: "/api/orders"})
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.JSONEq(t, `{"item": "book"}`, requests[0].Body)
+
+		err = client.Verify(ctx, wiremock.RequestPattern{Method: http.MethodGet, URLPath: "/api/orders"}, 1)
+		require.EqualError(t, err, "expected 1 requests matching GET /api/orders, received 0")
+	})
+
+	t.Run("resets to the stubs of the mapping files", func(t *testing.T) {
+		id, err := client.Register(ctx, wiremock.Stub{
+			Request:  wiremock.RequestPattern{Method: http.MethodGet, URLPath: "/greetings"},
+			Response: wiremock.Response{Status: http.StatusTeapot},
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, id)
+
+		require.NoError(t, client.Reset(ctx))
+
+		resp, err := http.Get(url + "/greetings")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		count, err := client.Count(ctx, wiremock.RequestPattern{URLPath: "/greetings"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
@@ -0,0 +1,85 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// AliasDialer dials the network aliases of containers from the host, e.g. "postgres:5432",
+// connecting to the host endpoint the container port is mapped to instead. In this way,
+// client code configured with the service names, like in production, can run unmodified from the test process.
+// The addresses that are not aliases of the containers are dialed as usual.
+type AliasDialer struct {
+	containers map[string]testcontainers.Container
+	dialer     net.Dialer
+}
+
+// NewAliasDialer returns a dialer for the aliases the containers have in the given network.
+// The aliases are read once, so the containers must be attached to the network before calling it.
+func NewAliasDialer(ctx context.Context, nw *testcontainers.DockerNetwork, containers ...testcontainers.Container) (*AliasDialer, error) {
+	d := &AliasDialer{
+		containers: make(map[string]testcontainers.Container),
+	}
+
+	for _, c := range containers {
+		aliases, err := c.NetworkAliases(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, alias := range aliases[nw.Name] {
+			d.containers[alias] = c
+		}
+	}
+
+	return d, nil
+}
+
+// Resolve returns the host endpoint, in host:port form, the address is mapped to,
+// or the address itself if its host is not an alias of the containers.
+// The network must be "tcp" or "udp", or one of their variants, e.g. "tcp4".
+func (d *AliasDialer) Resolve(ctx context.Context, network string, address string) (string, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", err
+	}
+
+	c, ok := d.containers[host]
+	if !ok {
+		return address, nil
+	}
+
+	proto := strings.TrimRight(network, "46")
+	if proto != "tcp" && proto != "udp" {
+		return "", fmt.Errorf("unsupported network %s for alias %s", network, host)
+	}
+
+	mappedPort, err := c.MappedPort(ctx, nat.Port(port+"/"+proto))
+	if err != nil {
+		return "", fmt.Errorf("resolve alias %s: %w", address, err)
+	}
+
+	mappedHost, err := c.Host(ctx)
+	if err != nil {
+		return "", fmt.Errorf("resolve alias %s: %w", address, err)
+	}
+
+	return net.JoinHostPort(mappedHost, mappedPort.Port()), nil
+}
+
+// DialContext connects to the address, resolving it first if its host is an alias of the containers.
+// It has the signature of the DialContext field of the http.Transport, and of most of the database drivers' dialers.
+func (d *AliasDialer) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	resolved, err := d.Resolve(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.dialer.DialContext(ctx, network, resolved)
+}
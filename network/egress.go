@@ -0,0 +1,53 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const probeImage = "alpine:3.17"
+
+// CanReach reports whether a container attached to the given network can open a TCP connection
+// to the address, in host:port form, e.g. "1.1.1.1:443". It's meant to assert that the containers
+// attached to an internal network can't reach the internet.
+// The connection is attempted from a short-lived probe container, which is terminated afterwards.
+func CanReach(ctx context.Context, nw *testcontainers.DockerNetwork, address string) (bool, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return false, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	probe, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      probeImage,
+			Cmd:        []string{"nc", "-z", "-w", "5", host, port},
+			Networks:   []string{nw.Name},
+			WaitingFor: wait.ForExit().WithExitTimeout(30 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		if probe != nil {
+			err = errors.Join(err, probe.Terminate(context.WithoutCancel(ctx)))
+		}
+
+		return false, fmt.Errorf("start probe container: %w", err)
+	}
+
+	state, err := probe.State(ctx)
+	if err != nil {
+		return false, errors.Join(err, probe.Terminate(ctx))
+	}
+
+	if err := probe.Terminate(ctx); err != nil {
+		return false, err
+	}
+
+	return state.ExitCode == 0, nil
+}
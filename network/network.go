@@ -37,6 +37,7 @@ func New(ctx context.Context, opts ...NetworkCustomizer) (*testcontainers.Docker
 		Labels:         nc.Labels,
 		Attachable:     nc.Attachable,
 		IPAM:           nc.IPAM,
+		Options:        nc.Options,
 	}
 
 	//nolint:staticcheck
@@ -52,6 +53,45 @@ func New(ctx context.Context, opts ...NetworkCustomizer) (*testcontainers.Docker
 	return n.(*testcontainers.DockerNetwork), nil
 }
 
+// NewInternal creates a new network without egress, i.e. the containers attached to it can reach
+// each other, but they can't reach the host nor the internet. It's useful to test the offline behavior
+// of a service, e.g. its fallbacks or its data-exfiltration protections.
+// Please note that Docker does not publish the ports of a container attached only to internal networks,
+// so it must also be attached to a regular network to be reachable from the host.
+func NewInternal(ctx context.Context, opts ...NetworkCustomizer) (*testcontainers.DockerNetwork, error) {
+	return New(ctx, append(opts, WithInternal())...)
+}
+
+// TestingT is the subset of testing.TB used by NewForTest, so that this package does not depend on the testing package.
+type TestingT interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// NewForTest creates a new network with a random UUID name, failing the test if the network can't be created.
+// It receives the same options as the New function, and the network is removed when the test and all its
+// subtests complete, so parallel tests don't need to coordinate the names of their networks.
+// As any other network created by the library, it's also removed by Ryuk if the test process is interrupted.
+// The returned network can be used directly in a container request, with its Name field or with the WithNetwork option.
+func NewForTest(ctx context.Context, t TestingT, opts ...NetworkCustomizer) *testcontainers.DockerNetwork {
+	t.Helper()
+
+	nw, err := New(ctx, opts...)
+	if err != nil {
+		t.Fatalf("failed to create network: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if err := nw.Remove(context.Background()); err != nil {
+			t.Errorf("failed to remove network %s: %s", nw.Name, err)
+		}
+	})
+
+	return nw
+}
+
 // NetworkCustomizer is an interface that can be used to configure the network create request.
 type NetworkCustomizer interface {
 	Customize(req *types.NetworkCreate)
@@ -88,6 +128,20 @@ func WithDriver(driver string) CustomizeNetworkOption {
 	}
 }
 
+// WithDriverOptions allows to set driver specific options, adding them to the existing ones,
+// e.g. "com.docker.network.driver.mtu" to set the MTU of a bridge network.
+func WithDriverOptions(options map[string]string) CustomizeNetworkOption {
+	return func(original *types.NetworkCreate) {
+		if original.Options == nil {
+			original.Options = make(map[string]string)
+		}
+
+		for k, v := range options {
+			original.Options[k] = v
+		}
+	}
+}
+
 // WithEnableIPv6 allows to set the network as IPv6 enabled.
 // Please use this option if and only if IPv6 is enabled on the Docker daemon.
 func WithEnableIPv6() CustomizeNetworkOption {
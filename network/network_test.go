@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -229,6 +231,61 @@ func TestContainerIPs(t *testing.T) {
 	if len(ips) != 2 {
 		t.Errorf("Expected two IP addresses, got %v", len(ips))
 	}
+
+	// containerIPInNetwork {
+	// ContainerIPInNetwork is not part of the Container interface
+	ip, err := nginx.(*testcontainers.DockerContainer).ContainerIPInNetwork(ctx, networkName)
+	// }
+	require.NoError(t, err)
+	assert.Contains(t, ips, ip)
+
+	_, err = nginx.(*testcontainers.DockerContainer).ContainerIPInNetwork(ctx, "non-existent-network")
+	require.Error(t, err)
+}
+
+func TestContainerIPv6s(t *testing.T) {
+	ctx := context.Background()
+
+	// newIPv6Network {
+	newNetwork, err := network.New(ctx,
+		network.WithEnableIPv6(),
+		network.WithIPAM(&dockernetwork.IPAM{
+			Config: []dockernetwork.IPAMConfig{
+				{Subnet: "fd00:dead:beef::/64"},
+			},
+		}),
+	)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, newNetwork.Remove(ctx))
+	})
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: nginxAlpineImage,
+			ExposedPorts: []string{
+				nginxDefaultPort,
+			},
+			Networks: []string{
+				newNetwork.Name,
+			},
+			WaitingFor: wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, nginx.Terminate(ctx))
+	}()
+
+	// containerIPv6s {
+	// ContainerIPv6s is not part of the Container interface
+	ips, err := nginx.(*testcontainers.DockerContainer).ContainerIPv6s(ctx)
+	// }
+	require.NoError(t, err)
+	require.Len(t, ips, 1)
+	assert.True(t, strings.HasPrefix(ips[0], "fd00:dead:beef:"), "unexpected IPv6 address %s", ips[0])
 }
 
 func TestContainerWithReaperNetwork(t *testing.T) {
@@ -406,6 +463,171 @@ func TestNew_withOptions(t *testing.T) {
 	assert.Equal(t, ipamConfig, foundNetwork.IPAM)
 }
 
+func TestNew_withDriverOptions(t *testing.T) {
+	// newNetworkWithDriverOptions {
+	ctx := context.Background()
+
+	net, err := network.New(ctx,
+		network.WithDriver("bridge"),
+		network.WithDriverOptions(map[string]string{
+			"com.docker.network.driver.mtu": "1400",
+		}),
+		network.WithIPAM(&dockernetwork.IPAM{
+			Config: []dockernetwork.IPAMConfig{
+				{
+					Subnet:  "10.1.2.0/24",
+					Gateway: "10.1.2.254",
+				},
+			},
+		}),
+		network.WithInternal(),
+		network.WithAttachable(),
+	)
+	// }
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, net.Remove(ctx))
+	}()
+
+	// inspectNetwork {
+	resource, err := net.Inspect(ctx)
+	// }
+	require.NoError(t, err)
+
+	assert.Equal(t, net.ID, resource.ID)
+	assert.Equal(t, "bridge", resource.Driver)
+	assert.Equal(t, "1400", resource.Options["com.docker.network.driver.mtu"])
+	assert.True(t, resource.Internal)
+	assert.True(t, resource.Attachable)
+	require.Len(t, resource.IPAM.Config, 1)
+	assert.Equal(t, "10.1.2.0/24", resource.IPAM.Config[0].Subnet)
+	assert.Equal(t, "10.1.2.254", resource.IPAM.Config[0].Gateway)
+}
+
+func TestNewInternal(t *testing.T) {
+	ctx := context.Background()
+
+	// newInternalNetwork {
+	internalNetwork, err := network.NewInternal(ctx)
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, internalNetwork.Remove(ctx))
+	})
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      nginxAlpineImage,
+			WaitingFor: wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, nginx.Terminate(ctx))
+	}()
+
+	require.NoError(t, nginx.(*testcontainers.DockerContainer).ConnectToNetwork(ctx, internalNetwork.Name, "nginx"))
+
+	// containers attached to the internal network can reach each other
+	reachable, err := network.CanReach(ctx, internalNetwork, "nginx:80")
+	require.NoError(t, err)
+	assert.True(t, reachable)
+
+	// canReach {
+	reachable, err = network.CanReach(ctx, internalNetwork, "1.1.1.1:443")
+	// }
+	require.NoError(t, err)
+	assert.False(t, reachable)
+}
+
+func TestNewForTest(t *testing.T) {
+	ctx := context.Background()
+
+	var networkName string
+
+	t.Run("create", func(t *testing.T) {
+		// newNetworkForTest {
+		nw := network.NewForTest(ctx, t)
+
+		nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:    nginxAlpineImage,
+				Networks: []string{nw.Name},
+			},
+			Started: true,
+		})
+		// }
+		require.NoError(t, err)
+		// the container must be terminated before the network is removed
+		t.Cleanup(func() {
+			require.NoError(t, nginx.Terminate(ctx))
+		})
+
+		networkName = nw.Name
+	})
+
+	// the network has been removed on the cleanup of the subtest
+	cli, err := testcontainers.NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	_, err = cli.NetworkInspect(ctx, networkName, types.NetworkInspectOptions{})
+	require.Error(t, err)
+}
+
+func TestAliasDialer(t *testing.T) {
+	ctx := context.Background()
+
+	nw := network.NewForTest(ctx, t)
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nginx.Terminate(ctx))
+	})
+
+	require.NoError(t, nginx.(*testcontainers.DockerContainer).ConnectToNetwork(ctx, nw.Name, "web"))
+
+	// aliasDialer {
+	dialer, err := network.NewAliasDialer(ctx, nw, nginx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+
+	resp, err := httpClient.Get("http://web/")
+	// }
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	host, err := nginx.Host(ctx)
+	require.NoError(t, err)
+
+	port, err := nginx.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	resolved, err := dialer.Resolve(ctx, "tcp", "web:80")
+	require.NoError(t, err)
+	assert.Equal(t, host+":"+port.Port(), resolved)
+
+	// the addresses that are not aliases are not modified
+	resolved, err = dialer.Resolve(ctx, "tcp", "example.com:80")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com:80", resolved)
+}
+
 func TestWithNetwork(t *testing.T) {
 	// first create the network to be reused
 	nw, err := network.New(context.Background(), network.WithCheckDuplicate(), network.WithLabels(map[string]string{"network-type": "unique"}))
@@ -455,6 +677,113 @@ func TestWithNetwork(t *testing.T) {
 	assert.Equal(t, expectedLabels, newNetwork.Labels)
 }
 
+func TestContainerConnectToNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	frontend, err := network.New(ctx)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, frontend.Remove(ctx))
+	}()
+
+	backend, err := network.New(ctx)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, backend.Remove(ctx))
+	}()
+
+	storage, err := network.New(ctx)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, storage.Remove(ctx))
+	}()
+
+	// multipleNetworksWithAliases {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	}
+
+	network.WithNetwork([]string{"web", "www"}, frontend)(&req)
+	network.WithNetwork([]string{"api"}, backend)(&req)
+
+	nginx, err := testcontainers.GenericContainer(ctx, req)
+	// }
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, nginx.Terminate(ctx))
+	}()
+
+	// connectToNetwork {
+	// ConnectToNetwork is not part of the Container interface
+	err = nginx.(*testcontainers.DockerContainer).ConnectToNetwork(ctx, storage.Name, "cache", "files")
+	// }
+	require.NoError(t, err)
+
+	aliases, err := nginx.NetworkAliases(ctx)
+	require.NoError(t, err)
+
+	assert.Subset(t, aliases[frontend.Name], []string{"web", "www"})
+	assert.Subset(t, aliases[backend.Name], []string{"api"})
+	assert.Subset(t, aliases[storage.Name], []string{"cache", "files"})
+
+	err = nginx.(*testcontainers.DockerContainer).ConnectToNetwork(ctx, "non-existent-network")
+	require.Error(t, err)
+}
+
+func TestContainerNetworkPartition(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, nw.Remove(ctx))
+	}()
+
+	newNode := func(alias string) testcontainers.Container {
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "alpine:3.17",
+				Cmd:   []string{"top"},
+			},
+			Started: true,
+		}
+		network.WithNetwork([]string{alias}, nw)(&req)
+
+		c, err := testcontainers.GenericContainer(ctx, req)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, c.Terminate(ctx))
+		})
+
+		return c
+	}
+
+	node1 := newNode("node1")
+	node2 := newNode("node2")
+
+	ping := func(from testcontainers.Container, to string) int {
+		code, _, err := from.Exec(ctx, []string{"ping", "-c", "1", "-W", "1", to})
+		require.NoError(t, err)
+		return code
+	}
+
+	require.Equal(t, 0, ping(node1, "node2"))
+
+	// networkPartition {
+	// DisconnectFromNetwork is not part of the Container interface
+	err = node2.(*testcontainers.DockerContainer).DisconnectFromNetwork(ctx, nw.Name)
+	// }
+	require.NoError(t, err)
+	require.NotEqual(t, 0, ping(node1, "node2"))
+
+	err = node2.(*testcontainers.DockerContainer).ConnectToNetwork(ctx, nw.Name, "node2")
+	require.NoError(t, err)
+	require.Equal(t, 0, ping(node1, "node2"))
+}
+
 func TestWithSyntheticNetwork(t *testing.T) {
 	nw := &testcontainers.DockerNetwork{
 		Name: "synthetic-network",
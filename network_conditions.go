@@ -0,0 +1,100 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// NetworkConditions are the conditions of the network traffic sent by a container, emulated with tc/netem.
+// The zero value stands for an unconstrained network.
+type NetworkConditions struct {
+	// Latency is the delay added to each packet.
+	Latency time.Duration
+	// Jitter is the random variation of the latency, plus or minus.
+	Jitter time.Duration
+	// Loss is the percentage, between 0 and 100, of packets that are dropped.
+	Loss float64
+	// Rate is the maximum bandwidth, in bits per second, or zero for no limit.
+	Rate uint64
+}
+
+// netemArgs returns the arguments of the netem queueing discipline for the conditions,
+// or nil if the network is unconstrained.
+func (nc NetworkConditions) netemArgs() []string {
+	var args []string
+
+	if nc.Latency > 0 || nc.Jitter > 0 {
+		args = append(args, "delay", fmt.Sprintf("%dus", nc.Latency.Microseconds()))
+		if nc.Jitter > 0 {
+			args = append(args, fmt.Sprintf("%dus", nc.Jitter.Microseconds()))
+		}
+	}
+
+	if nc.Loss > 0 {
+		args = append(args, "loss", strconv.FormatFloat(nc.Loss, 'f', -1, 64)+"%")
+	}
+
+	if nc.Rate > 0 {
+		args = append(args, "rate", fmt.Sprintf("%dbit", nc.Rate))
+	}
+
+	return args
+}
+
+// SetNetworkConditions applies the network conditions to the traffic sent by the container, on all its interfaces
+// but the loopback, replacing the previous ones. Passing the zero value removes the conditions.
+// The traffic control rules are applied from a short-lived sidecar sharing the network namespace of the container,
+// with the NET_ADMIN capability, so the container itself does not need it.
+func (c *DockerContainer) SetNetworkConditions(ctx context.Context, conditions NetworkConditions) error {
+	var rule string
+	if args := conditions.netemArgs(); len(args) > 0 {
+		rule = `tc qdisc replace dev "$dev" root netem ` + strings.Join(args, " ") + ` || exit 1`
+	} else {
+		// the interface may have no rule to delete
+		rule = `tc qdisc del dev "$dev" root 2>/dev/null || true`
+	}
+
+	script := `for dev in $(ls /sys/class/net); do [ "$dev" = "lo" ] && continue; ` + rule + `; done`
+
+	sidecar, err := c.provider.RunContainer(ctx, ContainerRequest{
+		Image:      netshootImage,
+		Entrypoint: []string{"sh", "-c"},
+		Cmd:        []string{script},
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.NetworkMode = container.NetworkMode("container:" + c.ID)
+			hostConfig.CapAdd = []string{"NET_ADMIN"}
+		},
+		WaitingFor: wait.ForExit().WithExitTimeout(time.Minute),
+	})
+	if err != nil {
+		return fmt.Errorf("start traffic control sidecar: %w", err)
+	}
+
+	state, err := sidecar.State(ctx)
+	if err != nil {
+		return errors.Join(err, sidecar.Terminate(ctx))
+	}
+
+	if state.ExitCode != 0 {
+		var output string
+		if logs, err := sidecar.Logs(ctx); err == nil {
+			bs, _ := io.ReadAll(logs)
+			output = strings.TrimSpace(string(bs))
+			logs.Close()
+		}
+
+		err := fmt.Errorf("set network conditions of container %s: exit code %d: %s", c.ID, state.ExitCode, output)
+		return errors.Join(err, sidecar.Terminate(ctx))
+	}
+
+	return sidecar.Terminate(ctx)
+}
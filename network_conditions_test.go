@@ -0,0 +1,108 @@
+package testcontainers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestNetworkConditions_netemArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions NetworkConditions
+		expected   []string
+	}{
+		{
+			name:       "unconstrained",
+			conditions: NetworkConditions{},
+			expected:   nil,
+		},
+		{
+			name:       "latency",
+			conditions: NetworkConditions{Latency: 100 * time.Millisecond},
+			expected:   []string{"delay", "100000us"},
+		},
+		{
+			name:       "latency and jitter",
+			conditions: NetworkConditions{Latency: 100 * time.Millisecond, Jitter: 10 * time.Millisecond},
+			expected:   []string{"delay", "100000us", "10000us"},
+		},
+		{
+			name:       "loss",
+			conditions: NetworkConditions{Loss: 2.5},
+			expected:   []string{"loss", "2.5%"},
+		},
+		{
+			name:       "rate",
+			conditions: NetworkConditions{Rate: 1000000},
+			expected:   []string{"rate", "1000000bit"},
+		},
+		{
+			name: "all",
+			conditions: NetworkConditions{
+				Latency: time.Second,
+				Jitter:  time.Millisecond,
+				Loss:    10,
+				Rate:    8000,
+			},
+			expected: []string{"delay", "1000000us", "1000us", "loss", "10%", "rate", "8000bit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.conditions.netemArgs())
+		})
+	}
+}
+
+func TestSetNetworkConditions(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	endpoint, err := nginx.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+
+	get := func() time.Duration {
+		start := time.Now()
+		resp, err := http.Get(endpoint)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return time.Since(start)
+	}
+
+	// setNetworkConditions {
+	// SetNetworkConditions is not part of the Container interface
+	err = nginx.(*DockerContainer).SetNetworkConditions(ctx, NetworkConditions{
+		Latency: 500 * time.Millisecond,
+		Jitter:  50 * time.Millisecond,
+	})
+	// }
+	require.NoError(t, err)
+
+	// the latency is added to the packets sent by nginx, at least once per request
+	assert.GreaterOrEqual(t, get(), 450*time.Millisecond)
+
+	// resetNetworkConditions {
+	err = nginx.(*DockerContainer).SetNetworkConditions(ctx, NetworkConditions{})
+	// }
+	require.NoError(t, err)
+
+	assert.Less(t, get(), 450*time.Millisecond)
+}
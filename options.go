@@ -28,6 +28,33 @@ func (opt CustomizeRequestOption) Customize(req *GenericContainerRequest) {
 	opt(req)
 }
 
+// ModuleOption is an option of a module, transferring state to its settings, of type T, instead of customizing
+// the container request, e.g. the credentials to provision once the container is ready. Returning an error
+// rejects the value of the option. A module declares its option type as an alias,
+// e.g. `type Option = testcontainers.ModuleOption[options]`, and applies them with ApplyModuleOptions.
+type ModuleOption[T any] func(settings *T) error
+
+// Customize is a NOOP. It's defined to satisfy the ContainerCustomizer interface.
+func (o ModuleOption[T]) Customize(*GenericContainerRequest) {
+	// NOOP to satisfy interface.
+}
+
+// ApplyModuleOptions applies the given options in order, customizing the request with all of them,
+// and transferring the state of the ModuleOption ones to the given settings. It returns the first error
+// of the ModuleOption ones, so the entrypoint of the module fails before creating the container.
+func ApplyModuleOptions[T any](req *GenericContainerRequest, settings *T, opts ...ContainerCustomizer) error {
+	for _, opt := range opts {
+		if apply, ok := opt.(ModuleOption[T]); ok {
+			if err := apply(settings); err != nil {
+				return err
+			}
+		}
+		opt.Customize(req)
+	}
+
+	return nil
+}
+
 // CustomizeRequest returns a function that can be used to merge the passed container request with the one that is used by the container.
 // Slices and Maps will be appended.
 func CustomizeRequest(src GenericContainerRequest) CustomizeRequestOption {
@@ -74,6 +101,15 @@ func WithHostConfigModifier(modifier func(hostConfig *container.HostConfig)) Cus
 	}
 }
 
+// WithExposedHostPorts makes the given ports of the host reachable from the container at HostInternal:<port>,
+// e.g. to call an HTTP mock or the application running in the test process.
+// It works with remote and rootless Docker daemons too, as the ports are tunneled through an SSH server running in a container.
+func WithExposedHostPorts(ports ...int) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.ExposedHostPorts = append(req.ExposedHostPorts, ports...)
+	}
+}
+
 // WithImage sets the image for a container
 func WithImage(image string) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) {
@@ -81,6 +117,137 @@ func WithImage(image string) CustomizeRequestOption {
 	}
 }
 
+// WithExposedPorts appends the given ports, e.g. "8080/tcp", to the ports exposed by the container.
+func WithExposedPorts(ports ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.ExposedPorts = append(req.ExposedPorts, ports...)
+	}
+}
+
+// WithCmd replaces the command of the container.
+func WithCmd(cmd ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Cmd = cmd
+	}
+}
+
+// WithCmdArgs appends the given arguments to the command of the container.
+func WithCmdArgs(args ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Cmd = append(req.Cmd, args...)
+	}
+}
+
+// WithEntrypoint replaces the entrypoint of the container.
+func WithEntrypoint(entrypoint ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Entrypoint = entrypoint
+	}
+}
+
+// WithFiles appends the given files to the ones copied to the container before it starts.
+func WithFiles(files ...ContainerFile) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Files = append(req.Files, files...)
+	}
+}
+
+// WithChownToRuntimeUser makes the files copied to the container, and the writable directories mounted in it, owned by
+// the user the container runs as, i.e. its User, or else the USER of its image, instead of root, e.g. to avoid the
+// permission-denied errors of the containers running as a non-root user on their fixtures. The Docker daemon resolves
+// the user in the container, and maps it to the user namespace of the container, so it works with userns-remap and
+// rootless daemons. Only the mounted directories themselves are chowned, not their contents. Linux containers only.
+func WithChownToRuntimeUser() CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.ChownToRuntimeUser = true
+	}
+}
+
+// WithLabels adds the given labels to the container, overriding the existing ones with the same key.
+func WithLabels(labels map[string]string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		if req.Labels == nil {
+			req.Labels = map[string]string{}
+		}
+
+		for key, val := range labels {
+			req.Labels[key] = val
+		}
+	}
+}
+
+// WithMounts appends the given mounts to the ones of the container.
+func WithMounts(mounts ...ContainerMount) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Mounts = append(req.Mounts, mounts...)
+	}
+}
+
+// WithTmpfs adds the given tmpfs mounts to the container, indexed by their path in the container,
+// with their mount options as values, e.g. "rw,size=64m".
+func WithTmpfs(tmpfs map[string]string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		if req.Tmpfs == nil {
+			req.Tmpfs = map[string]string{}
+		}
+
+		for path, options := range tmpfs {
+			req.Tmpfs[path] = options
+		}
+	}
+}
+
+// WithName sets the name of the container.
+func WithName(name string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Name = name
+	}
+}
+
+// WithLifecycleHooks appends the given hooks to the ones executed during the lifecycle of the container.
+func WithLifecycleHooks(hooks ...ContainerLifecycleHooks) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.LifecycleHooks = append(req.LifecycleHooks, hooks...)
+	}
+}
+
+// WithStartupDeadline sets the time by which the container must be created and started,
+// including the image pull and the wait strategy, keeping the earliest deadline if it's already set.
+func WithStartupDeadline(deadline time.Time) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		if req.Deadline.IsZero() || deadline.Before(req.Deadline) {
+			req.Deadline = deadline
+		}
+	}
+}
+
+// WithPortConflictRetries sets the number of times the container is created again when one of its fixed host ports
+// is already in use, binding it to the next free host port, which is logged and returned by MappedPort,
+// instead of failing with a PortConflictError. Reused containers are not retried.
+func WithPortConflictRetries(retries int) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.PortConflictRetries = retries
+	}
+}
+
+// WithPrivilegedPortRemapping makes the container be created again when the rootless container runtime can't publish
+// one of its privileged host ports, e.g. 80:80/tcp, binding it to a random host port, which is logged and returned
+// by MappedPort, instead of failing with a PrivilegedPortError. Reused containers are not created again.
+func WithPrivilegedPortRemapping() CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.RemapPrivilegedPorts = true
+	}
+}
+
+// WithStartupAttempts sets the number of attempts to create and start the container, with an exponential backoff
+// between attempts, terminating the container of the failed attempts, so that transient failures, e.g. a registry
+// blip or a port race, don't fail the test. Reused containers are not retried.
+func WithStartupAttempts(attempts int) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.StartupAttempts = attempts
+	}
+}
+
 // imageSubstitutor {
 
 // ImageSubstitutor represents a way to substitute container image names
@@ -143,6 +310,14 @@ func WithImageSubstitutors(fn ...ImageSubstitutor) CustomizeRequestOption {
 	}
 }
 
+// WithImagePolicy appends the given policies to the ones deciding whether the container can be started from its image,
+// once it's pulled or built, after the policies registered with RegisterImagePolicy.
+func WithImagePolicy(policies ...ImagePolicy) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.ImagePolicies = append(req.ImagePolicies, policies...)
+	}
+}
+
 // WithLogConsumers sets the log consumers for a container
 func WithLogConsumers(consumer ...LogConsumer) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) {
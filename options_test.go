@@ -2,8 +2,10 @@ package testcontainers_test
 
 import (
 	"context"
+	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -210,3 +212,111 @@ func TestWithEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyModuleOptions(t *testing.T) {
+	// moduleOptions {
+	type settings struct {
+		username string
+		password string
+	}
+
+	type Option = testcontainers.ModuleOption[settings]
+
+	withCredentials := func(username string, password string) Option {
+		return func(s *settings) error {
+			if len(password) < 6 {
+				return errors.New("the password must be at least 6 characters long")
+			}
+
+			s.username = username
+			s.password = password
+			return nil
+		}
+	}
+	// }
+
+	t.Run("valid", func(t *testing.T) {
+		req := testcontainers.GenericContainerRequest{}
+		s := settings{username: "default"}
+
+		err := testcontainers.ApplyModuleOptions(&req, &s,
+			testcontainers.WithImage("alpine:3.19"),
+			withCredentials("alice", "alice-password"),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, settings{username: "alice", password: "alice-password"}, s)
+		assert.Equal(t, "alpine:3.19", req.Image)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		req := testcontainers.GenericContainerRequest{}
+		s := settings{}
+
+		err := testcontainers.ApplyModuleOptions(&req, &s,
+			withCredentials("alice", "12345"),
+			testcontainers.WithImage("alpine:3.19"),
+		)
+		require.EqualError(t, err, "the password must be at least 6 characters long")
+
+		// the options after the invalid one are not applied
+		assert.Empty(t, req.Image)
+	})
+}
+
+func TestRequestOptions(t *testing.T) {
+	hook := testcontainers.ContainerLifecycleHooks{}
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			ExposedPorts: []string{"80/tcp"},
+			Cmd:          []string{"nginx"},
+			Labels:       map[string]string{"a": "1"},
+		},
+	}
+
+	opts := []testcontainers.ContainerCustomizer{
+		testcontainers.WithExposedPorts("443/tcp"),
+		testcontainers.WithCmdArgs("-g", "daemon off;"),
+		testcontainers.WithEntrypoint("/docker-entrypoint.sh"),
+		testcontainers.WithFiles(testcontainers.ContainerFile{HostFilePath: "nginx.conf", ContainerFilePath: "/etc/nginx/nginx.conf"}),
+		testcontainers.WithLabels(map[string]string{"a": "2", "b": "3"}),
+		testcontainers.WithMounts(testcontainers.VolumeMount("data", "/data")),
+		testcontainers.WithTmpfs(map[string]string{"/tmp": "rw"}),
+		testcontainers.WithName("nginx"),
+		testcontainers.WithLifecycleHooks(hook),
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&req)
+	}
+
+	assert.Equal(t, []string{"80/tcp", "443/tcp"}, req.ExposedPorts)
+	assert.Equal(t, []string{"nginx", "-g", "daemon off;"}, req.Cmd)
+	assert.Equal(t, []string{"/docker-entrypoint.sh"}, req.Entrypoint)
+	assert.Len(t, req.Files, 1)
+	assert.Equal(t, map[string]string{"a": "2", "b": "3"}, req.Labels)
+	assert.Len(t, req.Mounts, 1)
+	assert.Equal(t, map[string]string{"/tmp": "rw"}, req.Tmpfs)
+	assert.Equal(t, "nginx", req.Name)
+	assert.Len(t, req.LifecycleHooks, 1)
+
+	testcontainers.WithCmd("nginx-debug").Customize(&req)
+	assert.Equal(t, []string{"nginx-debug"}, req.Cmd)
+}
+
+func TestWithStartupDeadline(t *testing.T) {
+	req := testcontainers.GenericContainerRequest{}
+
+	deadline := time.Now().Add(time.Minute)
+	testcontainers.WithStartupDeadline(deadline).Customize(&req)
+	assert.Equal(t, deadline, req.Deadline)
+
+	// the earliest deadline is kept
+	testcontainers.WithStartupDeadline(deadline.Add(time.Minute)).Customize(&req)
+	assert.Equal(t, deadline, req.Deadline)
+
+	earlier := deadline.Add(-30 * time.Second)
+	testcontainers.WithStartupDeadline(earlier).Customize(&req)
+	assert.Equal(t, earlier, req.Deadline)
+}
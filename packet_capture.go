@@ -0,0 +1,105 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	netshootImage     = "nicolaka/netshoot:v0.12"
+	packetCapturePath = "/tmp/capture.pcap"
+)
+
+// PacketCapture is a tcpdump sidecar capturing the network traffic of a container,
+// which can be retrieved as a pcap file to debug protocol-level failures, e.g. with Wireshark.
+type PacketCapture struct {
+	sidecar  Container
+	stopOnce sync.Once
+	stopErr  error
+}
+
+// CapturePackets starts capturing the network traffic of the container, on all its interfaces, with a tcpdump sidecar
+// sharing its network namespace. The filter is a pcap-filter expression, e.g. "tcp port 5432", or empty to capture all the traffic.
+// The capture is stopped before the container is terminated, so the pcap file must be retrieved before that.
+func (c *DockerContainer) CapturePackets(ctx context.Context, filter string) (*PacketCapture, error) {
+	cmd := []string{"-i", "any", "-U", "-w", packetCapturePath}
+	if filter != "" {
+		cmd = append(cmd, filter)
+	}
+
+	sidecar, err := c.provider.RunContainer(ctx, ContainerRequest{
+		Image:      netshootImage,
+		Entrypoint: []string{"tcpdump"},
+		Cmd:        cmd,
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.NetworkMode = container.NetworkMode("container:" + c.ID)
+			hostConfig.CapAdd = []string{"NET_ADMIN", "NET_RAW"}
+		},
+		// tcpdump logs to stderr once it's ready to capture
+		WaitingFor: wait.ForLog("listening on"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start packet capture: %w", err)
+	}
+
+	capture := &PacketCapture{sidecar: sidecar}
+
+	c.lifecycleHooks = append(c.lifecycleHooks, ContainerLifecycleHooks{
+		PreTerminates: []ContainerHook{
+			func(ctx context.Context, _ Container) error {
+				return capture.Stop(ctx)
+			},
+		},
+	})
+
+	return capture, nil
+}
+
+// Pcap returns a reader for the pcap file with the packets captured so far.
+func (p *PacketCapture) Pcap(ctx context.Context) (io.ReadCloser, error) {
+	r, err := p.sidecar.CopyFileFromContainer(ctx, packetCapturePath)
+	if err != nil {
+		return nil, fmt.Errorf("copy pcap file: %w", err)
+	}
+
+	return r, nil
+}
+
+// SaveTo writes the pcap file with the packets captured so far to the given path of the host,
+// e.g. to keep it as an artifact of a failed test.
+func (p *PacketCapture) SaveTo(ctx context.Context, path string) error {
+	r, err := p.Pcap(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("write pcap file %s: %w", path, err)
+	}
+
+	return f.Close()
+}
+
+// Stop stops capturing the traffic, terminating the sidecar container. The pcap file is no longer available afterwards.
+// It's called automatically before the captured container is terminated.
+func (p *PacketCapture) Stop(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		p.stopErr = p.sidecar.Terminate(ctx)
+	})
+
+	return p.stopErr
+}
@@ -0,0 +1,64 @@
+package testcontainers_test
+
+import (
+	"context"
+	"encoding/binary"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestCapturePackets(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "nginx:alpine",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nginx.Terminate(context.Background()))
+	})
+
+	// capturePackets {
+	// CapturePackets is not part of the Container interface
+	capture, err := nginx.(*testcontainers.DockerContainer).CapturePackets(ctx, "tcp port 80")
+	// }
+	require.NoError(t, err)
+
+	endpoint, err := nginx.PortEndpoint(ctx, "80/tcp", "http")
+	require.NoError(t, err)
+
+	resp, err := http.Get(endpoint)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// savePcap {
+	pcapPath := filepath.Join(t.TempDir(), "nginx.pcap")
+	err = capture.SaveTo(ctx, pcapPath)
+	// }
+	require.NoError(t, err)
+
+	bs, err := os.ReadFile(pcapPath)
+	require.NoError(t, err)
+
+	// the pcap global header is 24 bytes long, followed by the captured packets
+	require.Greater(t, len(bs), 24)
+	magic := binary.LittleEndian.Uint32(bs[:4])
+	assert.Contains(t, []uint32{0xa1b2c3d4, 0xd4c3b2a1}, magic)
+
+	require.NoError(t, capture.Stop(ctx))
+	// stopping the capture again is a no-op
+	require.NoError(t, capture.Stop(ctx))
+}
@@ -19,6 +19,7 @@ type ParallelContainersOptions struct {
 
 // ParallelContainersRequestError represents error from parallel request
 type ParallelContainersRequestError struct {
+	Index   int // index of the request in the requests passed to GenericParallelContainers or ParallelContainers
 	Request GenericContainerRequest
 	Error   error
 }
@@ -31,91 +32,84 @@ func (gpe ParallelContainersError) Error() string {
 	return fmt.Sprintf("%v", gpe.Errors)
 }
 
-func parallelContainersRunner(
-	ctx context.Context,
-	requests <-chan GenericContainerRequest,
-	errors chan<- ParallelContainersRequestError,
-	containers chan<- Container,
-	wg *sync.WaitGroup,
-) {
-	for req := range requests {
-		c, err := GenericContainer(ctx, req)
-		if err != nil {
-			errors <- ParallelContainersRequestError{
-				Request: req,
-				Error:   err,
-			}
-			continue
-		}
-		containers <- c
-	}
-	wg.Done()
-}
-
-// ParallelContainers creates a generic containers with parameters and run it in parallel mode
-func ParallelContainers(ctx context.Context, reqs ParallelContainerRequest, opt ParallelContainersOptions) ([]Container, error) {
-	if opt.WorkersCount == 0 {
-		opt.WorkersCount = defaultWorkersCount
+// GenericParallelContainers creates and starts the given requests concurrently, processing at most
+// WorkersCount requests at the same time, and returns the containers in the order of the requests.
+// If any request fails, the error is a ParallelContainersError with an entry per failed request,
+// and the container of a failed request is the one returned by GenericContainer, if any, so that
+// it can be terminated by the caller.
+func GenericParallelContainers(ctx context.Context, reqs []GenericContainerRequest, opt ParallelContainersOptions) ([]Container, error) {
+	workersCount := opt.WorkersCount
+	if workersCount <= 0 {
+		workersCount = defaultWorkersCount
 	}
 
-	tasksChanSize := opt.WorkersCount
-	if tasksChanSize > len(reqs) {
-		tasksChanSize = len(reqs)
+	if workersCount > len(reqs) {
+		workersCount = len(reqs)
 	}
 
-	tasksChan := make(chan GenericContainerRequest, tasksChanSize)
-	errsChan := make(chan ParallelContainersRequestError)
-	resChan := make(chan Container)
-	waitRes := make(chan struct{})
+	containers := make([]Container, len(reqs))
+	errs := make([]error, len(reqs))
 
-	containers := make([]Container, 0)
-	errors := make([]ParallelContainersRequestError, 0)
+	// each worker writes to the index of the request it processes, so the results don't need to be synchronized
+	indexes := make(chan int)
 
 	wg := sync.WaitGroup{}
-	wg.Add(tasksChanSize)
-
-	// run workers
-	for i := 0; i < tasksChanSize; i++ {
-		go parallelContainersRunner(ctx, tasksChan, errsChan, resChan, &wg)
-	}
+	wg.Add(workersCount)
 
-	go func() {
-		for {
-			select {
-			case c, ok := <-resChan:
-				if !ok {
-					resChan = nil
-				} else {
-					containers = append(containers, c)
-				}
-			case e, ok := <-errsChan:
-				if !ok {
-					errsChan = nil
-				} else {
-					errors = append(errors, e)
-				}
-			}
+	for i := 0; i < workersCount; i++ {
+		go func() {
+			defer wg.Done()
 
-			if resChan == nil && errsChan == nil {
-				waitRes <- struct{}{}
-				break
+			for idx := range indexes {
+				containers[idx], errs[idx] = GenericContainer(ctx, reqs[idx])
 			}
-		}
-	}()
+		}()
+	}
 
-	for _, req := range reqs {
-		tasksChan <- req
+	for idx := range reqs {
+		indexes <- idx
 	}
-	close(tasksChan)
+	close(indexes)
 	wg.Wait()
-	close(resChan)
-	close(errsChan)
 
-	<-waitRes
+	var requestErrors []ParallelContainersRequestError
+	for idx, err := range errs {
+		if err != nil {
+			requestErrors = append(requestErrors, ParallelContainersRequestError{
+				Index:   idx,
+				Request: reqs[idx],
+				Error:   err,
+			})
+		}
+	}
 
-	if len(errors) != 0 {
-		return containers, ParallelContainersError{Errors: errors}
+	if len(requestErrors) != 0 {
+		return containers, ParallelContainersError{Errors: requestErrors}
 	}
 
 	return containers, nil
 }
+
+// ParallelContainers creates a generic containers with parameters and run it in parallel mode.
+// Only the containers of the successful requests are returned: use GenericParallelContainers
+// to match the containers with their requests.
+func ParallelContainers(ctx context.Context, reqs ParallelContainerRequest, opt ParallelContainersOptions) ([]Container, error) {
+	res, err := GenericParallelContainers(ctx, reqs, opt)
+	if err == nil {
+		return res, nil
+	}
+
+	failed := map[int]bool{}
+	for _, e := range err.(ParallelContainersError).Errors {
+		failed[e.Index] = true
+	}
+
+	containers := make([]Container, 0, len(res))
+	for idx, c := range res {
+		if !failed[idx] {
+			containers = append(containers, c)
+		}
+	}
+
+	return containers, err
+}
@@ -165,3 +165,50 @@ func TestParallelContainersWithReuse(t *testing.T) {
 	// Container is reused, only terminate first container
 	terminateContainerOnEnd(t, ctx, res[0])
 }
+
+func TestGenericParallelContainers(t *testing.T) {
+	ctx := context.Background()
+
+	// genericParallelContainers {
+	reqs := []GenericContainerRequest{
+		{
+			ContainerRequest: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{nginxDefaultPort},
+			},
+			Started: true,
+		},
+		{
+			ContainerRequest: ContainerRequest{
+				Image: "bad bad bad",
+			},
+			Started: true,
+		},
+		{
+			ContainerRequest: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{nginxDefaultPort},
+			},
+			Started: true,
+		},
+	}
+
+	containers, err := GenericParallelContainers(ctx, reqs, ParallelContainersOptions{WorkersCount: 2})
+	for _, c := range containers {
+		// the containers are returned in the order of the requests,
+		// and the ones of the failed requests may be nil
+		terminateContainerOnEnd(t, ctx, c)
+	}
+	// }
+	require.Len(t, containers, len(reqs))
+
+	var e ParallelContainersError
+	require.ErrorAs(t, err, &e)
+	require.Len(t, e.Errors, 1)
+	require.Equal(t, 1, e.Errors[0].Index)
+	require.Equal(t, "bad bad bad", e.Errors[0].Request.Image)
+
+	require.NotNil(t, containers[0])
+	require.NotNil(t, containers[2])
+	require.NotEqual(t, containers[0].GetContainerID(), containers[2].GetContainerID())
+}
@@ -0,0 +1,242 @@
+package testcontainers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ContainerPlan represents the configuration a container would be created with, resolved from a request
+// without calling the Docker daemon, to debug the settings of a container or to review them.
+type ContainerPlan struct {
+	Name             string
+	Platform         string
+	Config           *container.Config
+	HostConfig       *container.HostConfig
+	NetworkingConfig *network.NetworkingConfig
+	// Networks are the networks of the request: the container is created in the first one,
+	// and connected to the rest of them, with their aliases, once it's created.
+	Networks       []string
+	NetworkAliases map[string][]string
+}
+
+// PlanContainer resolves the given request into the configuration the container would be created with,
+// applying the options, the image substitutors and the modifiers of the request, without calling the Docker daemon.
+// As the daemon is not called, the plan differs from the created container in that:
+//
+//   - the ports exposed by the image are not exposed if the request does not expose any port.
+//   - the default network of the provider is not added to the networks, when the bridge network is not available.
+//   - the lifecycle hooks, the files to copy and the host ports to expose are not considered.
+//   - the image to build from a Dockerfile is named after its repo and tag, which are random if not set.
+func PlanContainer(req GenericContainerRequest) (*ContainerPlan, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	logging := req.Logger
+	if logging == nil {
+		logging = Logger
+	}
+
+	imageName := req.Image
+	if req.ShouldBuildImage() {
+		imageName = req.GetRepo() + ":" + req.GetTag()
+	} else {
+		// always append the hub substitutor after the user-defined ones, without modifying the request
+		substitutors := make([]ImageSubstitutor, 0, len(req.ImageSubstitutors)+1)
+		substitutors = append(substitutors, req.ImageSubstitutors...)
+		substitutors = append(substitutors, newPrependHubRegistry(config.Read().HubImageNamePrefix))
+
+		var err error
+		imageName, err = substituteImage(logging, substitutors, imageName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	labels := map[string]string{}
+	for k, v := range req.Labels {
+		labels[k] = v
+	}
+	for k, v := range core.DefaultLabels(core.SessionID()) {
+		labels[k] = v
+	}
+	req.Labels = labels
+
+	dockerInput, hostConfig := newContainerConfigs(req.ContainerRequest, imageName)
+	networkingConfig := &network.NetworkingConfig{}
+
+	// the IDs of the networks and the ports exposed by the image are only known by the daemon
+	networkID := func(string) (string, bool) { return "", true }
+	imageExposedPorts := func(string) ([]string, error) { return nil, nil }
+
+	if err := resolveContainerConfigs(req.ContainerRequest, dockerInput, hostConfig, networkingConfig, networkID, imageExposedPorts); err != nil {
+		return nil, err
+	}
+
+	return &ContainerPlan{
+		Name:             req.Name,
+		Platform:         req.ImagePlatform,
+		Config:           dockerInput,
+		HostConfig:       hostConfig,
+		NetworkingConfig: networkingConfig,
+		Networks:         req.Networks,
+		NetworkAliases:   req.NetworkAliases,
+	}, nil
+}
+
+// DockerRunArgs returns the arguments of the docker run command equivalent to the plan, in a deterministic order.
+// The container is run in the first network of the plan only, as the rest of them are connected once it's created.
+func (p *ContainerPlan) DockerRunArgs() []string {
+	args := []string{"run", "--detach"}
+
+	flag := func(name string, values ...string) {
+		for _, v := range values {
+			if v != "" {
+				args = append(args, name, v)
+			}
+		}
+	}
+
+	flag("--name", p.Name)
+	flag("--platform", p.Platform)
+	flag("--hostname", p.Config.Hostname)
+	flag("--user", p.Config.User)
+	flag("--workdir", p.Config.WorkingDir)
+	flag("--env", p.Config.Env...)
+	for _, key := range sortedKeys(p.Config.Labels) {
+		flag("--label", key+"="+p.Config.Labels[key])
+	}
+
+	if len(p.Config.Entrypoint) > 0 {
+		flag("--entrypoint", p.Config.Entrypoint[0])
+	}
+
+	if p.HostConfig.NetworkMode != "" {
+		flag("--network", string(p.HostConfig.NetworkMode))
+	} else if len(p.Networks) > 0 {
+		flag("--network", p.Networks[0])
+		flag("--network-alias", p.NetworkAliases[p.Networks[0]]...)
+	}
+
+	flag("--publish", publishSpecs(p.HostConfig.PortBindings)...)
+	for _, port := range sortedPorts(p.Config.ExposedPorts) {
+		if _, published := p.HostConfig.PortBindings[port]; !published {
+			flag("--expose", string(port))
+		}
+	}
+
+	flag("--add-host", p.HostConfig.ExtraHosts...)
+	flag("--volume", p.HostConfig.Binds...)
+	for _, m := range p.HostConfig.Mounts {
+		spec := fmt.Sprintf("type=%s,target=%s", m.Type, m.Target)
+		if m.Source != "" {
+			spec = fmt.Sprintf("type=%s,source=%s,target=%s", m.Type, m.Source, m.Target)
+		}
+		if m.ReadOnly {
+			spec += ",readonly"
+		}
+		flag("--mount", spec)
+	}
+	for _, path := range sortedKeys(p.HostConfig.Tmpfs) {
+		if options := p.HostConfig.Tmpfs[path]; options != "" {
+			path += ":" + options
+		}
+		flag("--tmpfs", path)
+	}
+
+	if p.HostConfig.Privileged {
+		args = append(args, "--privileged")
+	}
+	if p.HostConfig.AutoRemove {
+		args = append(args, "--rm")
+	}
+	flag("--cap-add", p.HostConfig.CapAdd...)
+	flag("--cap-drop", p.HostConfig.CapDrop...)
+	if p.HostConfig.ShmSize > 0 {
+		flag("--shm-size", strconv.FormatInt(p.HostConfig.ShmSize, 10))
+	}
+	if p.HostConfig.Memory > 0 {
+		flag("--memory", strconv.FormatInt(p.HostConfig.Memory, 10))
+	}
+	if p.HostConfig.NanoCPUs > 0 {
+		flag("--cpus", strconv.FormatFloat(float64(p.HostConfig.NanoCPUs)/1e9, 'f', -1, 64))
+	}
+
+	args = append(args, p.Config.Image)
+
+	// the docker run command only accepts the first element of the entrypoint
+	if len(p.Config.Entrypoint) > 1 {
+		args = append(args, p.Config.Entrypoint[1:]...)
+	}
+
+	return append(args, p.Config.Cmd...)
+}
+
+// DockerRunCommand returns the docker run command equivalent to the plan, quoting the arguments for a POSIX shell.
+func (p *ContainerPlan) DockerRunCommand() string {
+	args := p.DockerRunArgs()
+
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, "docker")
+	for _, arg := range args {
+		quoted = append(quoted, ShellQuote(arg))
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// sortedKeys returns the keys of the map, sorted.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sortedPorts returns the ports of the set, sorted.
+func sortedPorts(ports nat.PortSet) []nat.Port {
+	sorted := make([]nat.Port, 0, len(ports))
+	for port := range ports {
+		sorted = append(sorted, port)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return sorted
+}
+
+// publishSpecs returns the specs of the --publish flag for the port bindings, e.g. "127.0.0.1:8080:80/tcp",
+// sorted by container port. A port without host port is published to a random port of the host.
+func publishSpecs(bindings nat.PortMap) []string {
+	ports := make(nat.PortSet, len(bindings))
+	for port := range bindings {
+		ports[port] = struct{}{}
+	}
+
+	var specs []string
+	for _, port := range sortedPorts(ports) {
+		for _, binding := range bindings[port] {
+			spec := string(port)
+			switch {
+			case binding.HostIP != "":
+				spec = binding.HostIP + ":" + binding.HostPort + ":" + spec
+			case binding.HostPort != "":
+				spec = binding.HostPort + ":" + spec
+			}
+			specs = append(specs, spec)
+		}
+	}
+
+	return specs
+}
@@ -0,0 +1,91 @@
+package testcontainers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func TestPlanContainer(t *testing.T) {
+	// planContainer {
+	plan, err := PlanContainer(GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:          "nginx:alpine",
+			Name:           "web",
+			Env:            map[string]string{"B": "2", "A": "1 2"},
+			Labels:         map[string]string{"team": "checkout"},
+			ExposedPorts:   []string{"80/tcp", "127.0.0.1:8443:443/tcp"},
+			Networks:       []string{"backend", "frontend"},
+			NetworkAliases: map[string][]string{"backend": {"web"}},
+			Mounts:         ContainerMounts{VolumeMount("data", "/data")},
+			Tmpfs:          map[string]string{"/tmp": "rw,size=64m"},
+			Entrypoint:     []string{"/docker-entrypoint.sh", "--verbose"},
+			Cmd:            []string{"nginx", "-g", "daemon off;"},
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.CapAdd = []string{"NET_ADMIN"}
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	command := plan.DockerRunCommand()
+	// }
+
+	defaultLabels := core.DefaultLabels(core.SessionID())
+	var labels []string
+	for _, key := range sortedKeys(defaultLabels) {
+		labels = append(labels, "--label "+key+"="+defaultLabels[key])
+	}
+
+	expected := "docker run --detach --name web --env 'A=1 2' --env B=2 " + strings.Join(labels, " ") + " --label team=checkout" +
+		" --entrypoint /docker-entrypoint.sh --network backend --network-alias web" +
+		" --publish 127.0.0.1:8443:443/tcp --publish 80/tcp --mount type=volume,source=data,target=/data --tmpfs /tmp:rw,size=64m" +
+		" --cap-add NET_ADMIN nginx:alpine --verbose nginx -g 'daemon off;'"
+	require.Equal(t, expected, command)
+
+	require.Equal(t, "nginx:alpine", plan.Config.Image)
+	require.Equal(t, []string{"NET_ADMIN"}, []string(plan.HostConfig.CapAdd))
+	require.Equal(t, []string{"web"}, plan.NetworkingConfig.EndpointsConfig["backend"].Aliases)
+	require.Equal(t, []string{"backend", "frontend"}, plan.Networks)
+
+	t.Run("host network mode", func(t *testing.T) {
+		plan, err := PlanContainer(GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "nginx:alpine",
+				HostConfigModifier: func(hc *container.HostConfig) {
+					hc.NetworkMode = "host"
+					hc.Privileged = true
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		args := plan.DockerRunArgs()
+		require.Contains(t, strings.Join(args, " "), "--network host --privileged nginx:alpine")
+	})
+
+	t.Run("image substitutors", func(t *testing.T) {
+		plan, err := PlanContainer(GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:             "nginx:alpine",
+				ImageSubstitutors: []ImageSubstitutor{newPrependHubRegistry("registry.mycompany.com/mirror")},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "registry.mycompany.com/mirror/nginx:alpine", plan.Config.Image)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err := PlanContainer(GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        "nginx:alpine",
+				ExposedPorts: []string{"80/foo"},
+			},
+		})
+		require.ErrorIs(t, err, ErrInvalidExposedPort)
+	})
+}
@@ -0,0 +1,354 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+var (
+	// ErrClosed is returned when acquiring a container from a closed pool.
+	ErrClosed = errors.New("pool is closed")
+
+	// ErrNotLent is returned when releasing a container that is not lent by the pool.
+	ErrNotLent = errors.New("container is not lent by the pool")
+)
+
+// ResetFunc restores the state of a container released to the pool, e.g. flushing a database,
+// so that the next test acquiring it starts from a clean state. If it returns an error,
+// the container is replaced with a new one.
+type ResetFunc func(ctx context.Context, c testcontainers.Container) error
+
+// Option is a type that can be used to configure the pool.
+type Option func(p *Pool)
+
+// WithReset sets the function that resets the containers when they are released to the pool.
+func WithReset(reset ResetFunc) Option {
+	return func(p *Pool) {
+		p.reset = reset
+	}
+}
+
+// WithWorkersCount sets the number of containers that are created and started at the same time
+// while warming up the pool. It defaults to the default of testcontainers.GenericParallelContainers.
+func WithWorkersCount(count int) Option {
+	return func(p *Pool) {
+		p.workersCount = count
+	}
+}
+
+// Pool maintains a fixed number of started containers, created from the same request, which are lent to the tests
+// with Acquire and returned with Release, so the cost of starting the containers is paid once, while warming up the pool.
+// It's safe for concurrent use.
+type Pool struct {
+	req          testcontainers.GenericContainerRequest
+	reset        ResetFunc
+	workersCount int
+	logger       testcontainers.Logging
+
+	// create creates and starts a container from the request of the pool, replacing the ones that can't be reset
+	create func(ctx context.Context, req testcontainers.GenericContainerRequest) (testcontainers.Container, error)
+
+	idle   chan testcontainers.Container
+	missed chan struct{} // notifies the waiting Acquire calls that a container could not be replaced
+	done   chan struct{}
+
+	mu         sync.Mutex
+	closed     bool
+	containers map[string]testcontainers.Container // the idle and the lent containers, by ID
+	lent       map[string]bool
+	missing    int // the containers that could not be replaced, which are created again by Acquire
+}
+
+// New creates a pool of the given size, creating and starting its containers from the given request concurrently.
+// The request can't set the name of the container, nor reuse it, as all the containers of the pool are created
+// from it. If any container fails to start, the started ones are terminated and the error is returned.
+// As any other container created by the library, the containers of the pool are removed by Ryuk when the test process exits,
+// or when the Close method of the pool is called, e.g. at the end of TestMain.
+func New(ctx context.Context, req testcontainers.GenericContainerRequest, size int, opts ...Option) (*Pool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid pool size %d: it must be greater than zero", size)
+	}
+
+	if req.Name != "" || req.Reuse {
+		return nil, errors.New("the containers of a pool can't be named nor reused")
+	}
+
+	req.Started = true
+	p := newPool(req, size, opts...)
+
+	reqs := make([]testcontainers.GenericContainerRequest, size)
+	for i := range reqs {
+		reqs[i] = req
+	}
+
+	containers, err := testcontainers.GenericParallelContainers(ctx, reqs, testcontainers.ParallelContainersOptions{
+		WorkersCount: p.workersCount,
+	})
+	if err != nil {
+		// the containers must be terminated even if the context is done
+		ctx = context.WithoutCancel(ctx)
+
+		errs := []error{err}
+		for _, c := range containers {
+			if c == nil {
+				continue
+			}
+
+			if err := c.Terminate(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("terminate container %s: %w", c.GetContainerID(), err))
+			}
+		}
+
+		return nil, fmt.Errorf("warm up pool: %w", errors.Join(errs...))
+	}
+
+	for _, c := range containers {
+		// the pool is not closed yet, so the containers are not terminated
+		_ = p.add(ctx, c)
+	}
+
+	return p, nil
+}
+
+// newPool returns an empty pool of the given size for the request.
+func newPool(req testcontainers.GenericContainerRequest, size int, opts ...Option) *Pool {
+	logger := req.Logger
+	if logger == nil {
+		logger = testcontainers.Logger
+	}
+
+	p := &Pool{
+		req:        req,
+		logger:     logger,
+		create:     testcontainers.GenericContainer,
+		idle:       make(chan testcontainers.Container, size),
+		missed:     make(chan struct{}, size),
+		done:       make(chan struct{}),
+		containers: make(map[string]testcontainers.Container, size),
+		lent:       make(map[string]bool, size),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// add adds an idle container to the pool, terminating it if the pool is closed.
+func (p *Pool) add(ctx context.Context, c testcontainers.Container) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return c.Terminate(ctx)
+	}
+	p.containers[c.GetContainerID()] = c
+	p.mu.Unlock()
+
+	// the channel has room for all the containers of the pool, so it never blocks
+	p.idle <- c
+
+	return nil
+}
+
+// Acquire lends an idle container of the pool, waiting for one to be released if all of them are lent,
+// until the context is done. If a container of the pool could not be replaced, and no container is idle,
+// a new one is created and started in its place instead. The container must be returned to the pool with Release.
+func (p *Pool) Acquire(ctx context.Context) (testcontainers.Container, error) {
+	for {
+		select {
+		case c := <-p.idle:
+			return p.lend(c)
+		default:
+		}
+
+		if c, err := p.refill(ctx); c != nil || err != nil {
+			return c, err
+		}
+
+		select {
+		case c := <-p.idle:
+			return p.lend(c)
+		case <-p.missed:
+			// another Acquire call may have refilled the pool already
+		case <-p.done:
+			return nil, ErrClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// lend marks an idle container of the pool as lent.
+func (p *Pool) lend(c testcontainers.Container) (testcontainers.Container, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrClosed
+	}
+
+	p.lent[c.GetContainerID()] = true
+
+	return c, nil
+}
+
+// refill creates and starts a new lent container in place of one that could not be replaced, if any.
+// It returns a nil container and a nil error if the pool has all its containers.
+func (p *Pool) refill(ctx context.Context) (testcontainers.Container, error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrClosed
+	}
+	if p.missing == 0 {
+		p.mu.Unlock()
+		return nil, nil
+	}
+	p.missing--
+	p.mu.Unlock()
+
+	c, err := p.create(ctx, p.req)
+	if err != nil {
+		if c != nil {
+			err = errors.Join(err, c.Terminate(context.WithoutCancel(ctx)))
+		}
+
+		p.mu.Lock()
+		p.missing++
+		p.mu.Unlock()
+
+		return nil, fmt.Errorf("refill pool: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, errors.Join(ErrClosed, c.Terminate(ctx))
+	}
+
+	p.containers[c.GetContainerID()] = c
+	p.lent[c.GetContainerID()] = true
+
+	return c, nil
+}
+
+// Release returns a lent container to the pool, resetting it with the reset function of the pool, if any.
+// If the container can't be reset, it's terminated and replaced with a new one, which is started before returning.
+// Releasing a container to a closed pool is a no-op, as the container is already terminated.
+func (p *Pool) Release(ctx context.Context, c testcontainers.Container) error {
+	id := c.GetContainerID()
+
+	p.mu.Lock()
+	if !p.lent[id] {
+		p.mu.Unlock()
+		return fmt.Errorf("release container %s: %w", id, ErrNotLent)
+	}
+	delete(p.lent, id)
+	closed := p.closed
+	p.mu.Unlock()
+
+	if closed {
+		return nil
+	}
+
+	if p.reset != nil {
+		if err := p.reset(ctx, c); err != nil {
+			p.logger.Printf("🔄 Replacing container %s of the pool, as it could not be reset: %v", id, err)
+			return p.replace(ctx, c)
+		}
+	}
+
+	// the channel has room for all the containers of the pool, so it never blocks
+	p.idle <- c
+
+	return nil
+}
+
+// replace terminates the given container, and creates and starts a new one from the request of the pool in its place.
+// If the new container fails to start, the next Acquire finding no idle container creates it again.
+func (p *Pool) replace(ctx context.Context, old testcontainers.Container) error {
+	p.mu.Lock()
+	delete(p.containers, old.GetContainerID())
+	p.mu.Unlock()
+
+	if err := old.Terminate(ctx); err != nil {
+		p.logger.Printf("🔥 Failed to terminate container %s of the pool: %v", old.GetContainerID(), err)
+	}
+
+	c, err := p.create(ctx, p.req)
+	if err != nil {
+		if c != nil {
+			err = errors.Join(err, c.Terminate(context.WithoutCancel(ctx)))
+		}
+
+		p.mu.Lock()
+		p.missing++
+		p.mu.Unlock()
+
+		select {
+		case p.missed <- struct{}{}:
+		default:
+		}
+
+		return fmt.Errorf("replace container %s: %w", old.GetContainerID(), err)
+	}
+
+	return p.add(ctx, c)
+}
+
+// Close terminates all the containers of the pool, including the lent ones. Acquiring a container from
+// a closed pool returns ErrClosed, and closing it again is a no-op.
+func (p *Pool) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+	containers := p.containers
+	p.containers = map[string]testcontainers.Container{}
+	p.mu.Unlock()
+
+	var errs []error
+	for id, c := range containers {
+		if err := c.Terminate(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("terminate container %s: %w", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// TestingT is the subset of testing.TB used by AcquireForTest, so that this package does not depend on the testing package.
+type TestingT interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// AcquireForTest lends an idle container of the pool to the test, failing the test if the container can't be acquired.
+// The container is released to the pool when the test and all its subtests complete.
+func (p *Pool) AcquireForTest(ctx context.Context, t TestingT) testcontainers.Container {
+	t.Helper()
+
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("failed to acquire container: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if err := p.Release(context.Background(), c); err != nil {
+			t.Errorf("failed to release container %s: %s", c.GetContainerID(), err)
+		}
+	})
+
+	return c
+}
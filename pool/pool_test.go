@@ -0,0 +1,260 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// fakeContainer is a container that only knows its ID and whether it's terminated.
+type fakeContainer struct {
+	testcontainers.Container
+	id         string
+	terminated bool
+}
+
+func (c *fakeContainer) GetContainerID() string {
+	return c.id
+}
+
+func (c *fakeContainer) Terminate(context.Context) error {
+	c.terminated = true
+	return nil
+}
+
+// newFakePool returns a pool of the given size, filled with fake containers.
+func newFakePool(t *testing.T, size int, opts ...Option) (*Pool, []*fakeContainer) {
+	t.Helper()
+
+	p := newPool(testcontainers.GenericContainerRequest{}, size, opts...)
+
+	containers := make([]*fakeContainer, size)
+	for i := range containers {
+		containers[i] = &fakeContainer{id: fmt.Sprintf("container-%d", i)}
+		require.NoError(t, p.add(context.Background(), containers[i]))
+	}
+
+	return p, containers
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid size", func(t *testing.T) {
+		_, err := New(ctx, testcontainers.GenericContainerRequest{}, 0)
+		require.ErrorContains(t, err, "invalid pool size 0")
+	})
+
+	t.Run("named request", func(t *testing.T) {
+		_, err := New(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{Name: "redis"},
+		}, 2)
+		require.ErrorContains(t, err, "can't be named nor reused")
+	})
+}
+
+func TestPool_AcquireRelease(t *testing.T) {
+	ctx := context.Background()
+
+	var resets []string
+	p, containers := newFakePool(t, 2, WithReset(func(_ context.Context, c testcontainers.Container) error {
+		resets = append(resets, c.GetContainerID())
+		return nil
+	}))
+
+	first, err := p.Acquire(ctx)
+	require.NoError(t, err)
+	second, err := p.Acquire(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"container-0", "container-1"}, []string{first.GetContainerID(), second.GetContainerID()})
+
+	// all the containers are lent, so acquiring waits until the context is done
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = p.Acquire(timeoutCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, p.Release(ctx, first))
+	require.Equal(t, []string{first.GetContainerID()}, resets)
+	require.ErrorIs(t, p.Release(ctx, first), ErrNotLent)
+
+	again, err := p.Acquire(ctx)
+	require.NoError(t, err)
+	require.Equal(t, first.GetContainerID(), again.GetContainerID())
+
+	require.NoError(t, p.Close(ctx))
+	for _, c := range containers {
+		require.True(t, c.terminated)
+	}
+
+	_, err = p.Acquire(ctx)
+	require.ErrorIs(t, err, ErrClosed)
+
+	// releasing to a closed pool is a no-op
+	require.NoError(t, p.Release(ctx, second))
+	require.NoError(t, p.Close(ctx))
+}
+
+func TestPool_AcquireForTest(t *testing.T) {
+	p, _ := newFakePool(t, 1)
+
+	t.Run("lent", func(t *testing.T) {
+		c := p.AcquireForTest(context.Background(), t)
+		require.Equal(t, "container-0", c.GetContainerID())
+	})
+
+	// the container is released at the end of the subtest
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c, err := p.Acquire(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "container-0", c.GetContainerID())
+}
+
+func TestPool_ResetError(t *testing.T) {
+	p, containers := newFakePool(t, 1, WithReset(func(context.Context, testcontainers.Container) error {
+		return errors.New("dirty")
+	}))
+
+	ctx := context.Background()
+
+	c, err := p.Acquire(ctx)
+	require.NoError(t, err)
+
+	// the container is replaced with a new one from the request of the pool, which is empty
+	err = p.Release(ctx, c)
+	require.ErrorContains(t, err, "replace container container-0")
+	require.True(t, containers[0].terminated)
+}
+
+func TestPool_ReplaceError(t *testing.T) {
+	ctx := context.Background()
+
+	p, containers := newFakePool(t, 1, WithReset(func(_ context.Context, c testcontainers.Container) error {
+		if c.GetContainerID() == "container-0" {
+			return errors.New("dirty")
+		}
+		return nil
+	}))
+
+	// the first two containers fail to start
+	var created int
+	p.create = func(context.Context, testcontainers.GenericContainerRequest) (testcontainers.Container, error) {
+		created++
+		if created <= 2 {
+			return nil, errors.New("port is already allocated")
+		}
+		return &fakeContainer{id: fmt.Sprintf("container-%d", created)}, nil
+	}
+
+	c, err := p.Acquire(ctx)
+	require.NoError(t, err)
+
+	err = p.Release(ctx, c)
+	require.ErrorContains(t, err, "replace container container-0: port is already allocated")
+	require.True(t, containers[0].terminated)
+
+	// the missing container is created again when acquiring, instead of waiting forever
+	_, err = p.Acquire(ctx)
+	require.ErrorContains(t, err, "refill pool: port is already allocated")
+
+	c, err = p.Acquire(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "container-3", c.GetContainerID())
+
+	require.NoError(t, p.Release(ctx, c))
+
+	again, err := p.Acquire(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "container-3", again.GetContainerID())
+	require.Equal(t, 3, created)
+}
+
+func TestPool_ReplaceErrorWhileAcquiring(t *testing.T) {
+	ctx := context.Background()
+
+	p, _ := newFakePool(t, 1, WithReset(func(context.Context, testcontainers.Container) error {
+		return errors.New("dirty")
+	}))
+
+	created := make(chan struct{}, 2)
+	p.create = func(context.Context, testcontainers.GenericContainerRequest) (testcontainers.Container, error) {
+		created <- struct{}{}
+		if len(created) == 1 {
+			return nil, errors.New("port is already allocated")
+		}
+		return &fakeContainer{id: "container-new"}, nil
+	}
+
+	c, err := p.Acquire(ctx)
+	require.NoError(t, err)
+
+	// the container is acquired while all the containers are lent
+	acquired := make(chan testcontainers.Container)
+	go func() {
+		c, err := p.Acquire(ctx)
+		if err != nil {
+			t.Errorf("acquire: %s", err)
+		}
+		acquired <- c
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	require.ErrorContains(t, p.Release(ctx, c), "replace container container-0")
+
+	select {
+	case c := <-acquired:
+		require.Equal(t, "container-new", c.GetContainerID())
+	case <-time.After(time.Second):
+		t.Fatal("the waiting Acquire call did not refill the pool")
+	}
+}
+
+func TestPool(t *testing.T) {
+	ctx := context.Background()
+
+	// createPool {
+	p, err := New(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7-alpine",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForListeningPort("6379/tcp"),
+		},
+	}, 2, WithReset(func(ctx context.Context, c testcontainers.Container) error {
+		_, _, err := c.Exec(ctx, []string{"redis-cli", "FLUSHALL"})
+		return err
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(ctx))
+	})
+	// }
+
+	for i := 0; i < 4; i++ {
+		t.Run(fmt.Sprintf("test-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			// acquireContainer {
+			c := p.AcquireForTest(ctx, t)
+			// }
+
+			// the container is reset, so the key set by a previous test is not there
+			_, reader, err := c.Exec(ctx, []string{"redis-cli", "SET", "key", "value", "NX"}, tcexec.Multiplexed())
+			require.NoError(t, err)
+
+			output, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.Equal(t, "OK", strings.TrimSpace(string(output)))
+		})
+	}
+}
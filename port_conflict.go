@@ -0,0 +1,326 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// ErrPortConflict is the error of a container that can't be started because one of its host ports is already in use.
+var ErrPortConflict = errors.New("host port already in use")
+
+// maxFreePortLookups is the number of ports after a host port in use to look for a free one.
+const maxFreePortLookups = 100
+
+// portConflictRegexes match the errors of the container runtimes when a host port is already in use,
+// capturing its IP and its number.
+var portConflictRegexes = []*regexp.Regexp{
+	// Bind for 0.0.0.0:16379 failed: port is already allocated
+	regexp.MustCompile(`Bind for \[?([0-9a-fA-F.:]*?)\]?:(\d+) failed: port is already allocated`),
+	// listen tcp4 0.0.0.0:16379: bind: address already in use, e.g. by Docker Desktop and rootless Podman
+	regexp.MustCompile(`listen (?:tcp|udp)[46]? \[?([0-9a-fA-F.:]*?)\]?:(\d+): bind: address already in use`),
+}
+
+// PortConflictError is the error of a container that can't be started because one of its host ports
+// is already in use, with what's listening on it, if it's found. It matches ErrPortConflict with errors.Is.
+type PortConflictError struct {
+	// HostIP is the IP the port is bound to, e.g. 0.0.0.0.
+	HostIP string
+	// HostPort is the host port already in use.
+	HostPort string
+	// Holder describes what's listening on the host port, e.g. another container or a local process, if it's found.
+	Holder string
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *PortConflictError) Error() string {
+	msg := fmt.Sprintf("host port %s is already in use", net.JoinHostPort(e.HostIP, e.HostPort))
+	if e.Holder != "" {
+		msg += " by " + e.Holder
+	}
+
+	return fmt.Sprintf("%s: %s", msg, e.Err)
+}
+
+// Unwrap returns the error of the container runtime.
+func (e *PortConflictError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes the error match ErrPortConflict.
+func (e *PortConflictError) Is(target error) bool {
+	return target == ErrPortConflict
+}
+
+// parsePortConflict returns the IP and the number of the host port already in use, if the error is a port conflict.
+func parsePortConflict(err error) (string, string, bool) {
+	for _, re := range portConflictRegexes {
+		if m := re.FindStringSubmatch(err.Error()); m != nil {
+			return m[1], m[2], true
+		}
+	}
+
+	return "", "", false
+}
+
+// portConflictError returns the error of the start of a container wrapped in a PortConflictError, if a host port
+// is already in use, with the container, or the local process if the daemon runs on this host, listening on it.
+// Otherwise, the error is returned as is.
+func (p *DockerProvider) portConflictError(ctx context.Context, err error) error {
+	hostIP, hostPort, ok := parsePortConflict(err)
+	if !ok {
+		return err
+	}
+
+	conflict := &PortConflictError{HostIP: hostIP, HostPort: hostPort, Err: err}
+
+	port, _ := strconv.Atoi(hostPort)
+	conflict.Holder = p.portHolderContainer(ctx, port)
+	if conflict.Holder == "" && strings.HasPrefix(p.host, "unix://") {
+		conflict.Holder = localPortHolder("/proc", port)
+	}
+
+	return conflict
+}
+
+// portHolderContainer returns the running container publishing the host port, if any.
+func (p *DockerProvider) portHolderContainer(ctx context.Context, port int) string {
+	containers, err := p.client.ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		return ""
+	}
+
+	for _, c := range containers {
+		for _, cp := range c.Ports {
+			if int(cp.PublicPort) != port {
+				continue
+			}
+
+			var name string
+			if len(c.Names) > 0 {
+				name = strings.TrimPrefix(c.Names[0], "/")
+			}
+
+			return fmt.Sprintf("container %s (%s, image %s)", name, shortID(c.ID), c.Image)
+		}
+	}
+
+	return ""
+}
+
+// localPortHolder returns the local process listening on the TCP port, if any, reading the proc filesystem
+// at the given root, i.e. on Linux only. The processes of other users can't be identified.
+func localPortHolder(procRoot string, port int) string {
+	inodes := map[string]bool{}
+	for _, name := range []string{"tcp", "tcp6"} {
+		b, err := os.ReadFile(filepath.Join(procRoot, "net", name))
+		if err != nil {
+			continue
+		}
+
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+		for _, line := range strings.Split(string(b), "\n")[1:] {
+			fields := strings.Fields(line)
+			// 0A is the listening state
+			if len(fields) < 10 || fields[3] != "0A" {
+				continue
+			}
+
+			_, hexPort, _ := strings.Cut(fields[1], ":")
+			if p, err := strconv.ParseUint(hexPort, 16, 16); err == nil && int(p) == port {
+				inodes[fields[9]] = true
+			}
+		}
+	}
+
+	if len(inodes) == 0 {
+		return ""
+	}
+
+	fds, _ := filepath.Glob(filepath.Join(procRoot, "[0-9]*", "fd", "*"))
+	for _, fd := range fds {
+		link, err := os.Readlink(fd)
+		if err != nil || !strings.HasPrefix(link, "socket:[") || !inodes[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")] {
+			continue
+		}
+
+		pid := filepath.Base(filepath.Dir(filepath.Dir(fd)))
+		comm, _ := os.ReadFile(filepath.Join(procRoot, pid, "comm"))
+
+		return fmt.Sprintf("process %s (pid %s)", strings.TrimSpace(string(comm)), pid)
+	}
+
+	return "a process of another user"
+}
+
+// nextFreePort returns the first port after the given one that can be listened on, on this host.
+func nextFreePort(hostPort string) (string, error) {
+	port, err := strconv.Atoi(hostPort)
+	if err != nil {
+		return "", fmt.Errorf("invalid host port %q: %w", hostPort, err)
+	}
+
+	for p := port + 1; p <= port+maxFreePortLookups && p <= 65535; p++ {
+		l, err := net.Listen("tcp", ":"+strconv.Itoa(p))
+		if err != nil {
+			continue
+		}
+		_ = l.Close()
+
+		return strconv.Itoa(p), nil
+	}
+
+	return "", fmt.Errorf("no free port after %s", hostPort)
+}
+
+// remapHostPort makes the request bind the host port to another one, or to a random one if empty, in its exposed ports
+// and in the port bindings set by its host config modifier, returning false if the request doesn't bind the host port,
+// e.g. for a random host port.
+func remapHostPort(req *ContainerRequest, from string, to string) bool {
+	var remapped bool
+
+	// the exposed ports may be shared with the request of the caller
+	req.ExposedPorts = slices.Clone(req.ExposedPorts)
+	for i, spec := range req.ExposedPorts {
+		mappings, err := nat.ParsePortSpec(spec)
+		// the port ranges are not remapped
+		if err != nil || len(mappings) != 1 || mappings[0].Binding.HostPort != from {
+			continue
+		}
+
+		m := mappings[0]
+		req.ExposedPorts[i] = fmt.Sprintf("%s:%s", to, m.Port)
+		if m.Binding.HostIP != "" {
+			req.ExposedPorts[i] = net.JoinHostPort(m.Binding.HostIP, to) + ":" + string(m.Port)
+		}
+		remapped = true
+	}
+
+	modifier := req.HostConfigModifier
+	if modifier == nil {
+		modifier = defaultHostConfigModifier(*req)
+	}
+
+	probe := &container.HostConfig{}
+	modifier(probe)
+	for _, bindings := range probe.PortBindings {
+		for _, b := range bindings {
+			remapped = remapped || b.HostPort == from
+		}
+	}
+
+	req.HostConfigModifier = func(hc *container.HostConfig) {
+		modifier(hc)
+
+		for _, bindings := range hc.PortBindings {
+			for i := range bindings {
+				if bindings[i].HostPort == from {
+					bindings[i].HostPort = to
+				}
+			}
+		}
+	}
+
+	return remapped
+}
+
+// createAndStartContainerOnFreePorts creates the container of the request, starting it if needed, as createAndStartContainer
+// does, and creates it again with the next free host port if one of its host ports is already in use, up to the port
+// conflict retries of the request. The random host ports are retried as is, as the runtime picks another one.
+// If the request remaps the privileged ports, the container is also created again with a random host port
+// when the rootless runtime can't publish one of its privileged host ports.
+func createAndStartContainerOnFreePorts(ctx context.Context, provider GenericProvider, logging Logging, req GenericContainerRequest) (Container, error) {
+	remapped := map[string]string{}
+	var privileged []string
+
+	for retries := 0; ; {
+		c, err := createAndStartContainer(ctx, provider, req)
+		attrs := []slog.Attr{slog.String(LogKeyImage, req.Image), slog.String(LogKeyOperation, "start")}
+
+		var privilegedErr *PrivilegedPortError
+		if err != nil && req.RemapPrivilegedPorts && !req.Reuse && errors.As(err, &privilegedErr) {
+			if termErr := terminateToRetry(ctx, c); termErr != nil {
+				return c, errors.Join(err, termErr)
+			}
+
+			// the random host port can't be privileged, so each host port is remapped once
+			if !remapHostPort(&req.ContainerRequest, privilegedErr.HostPort, "") {
+				return nil, err
+			}
+
+			logf(ctx, logging, slog.LevelWarn, attrs, "🔀 %s, retrying with a random host port", privilegedErr)
+			privileged = append(privileged, privilegedErr.HostPort)
+
+			continue
+		}
+
+		var conflict *PortConflictError
+		if err != nil && retries < req.PortConflictRetries && !req.Reuse && errors.As(err, &conflict) {
+			retries++
+
+			if termErr := terminateToRetry(ctx, c); termErr != nil {
+				return c, errors.Join(err, termErr)
+			}
+
+			next, portErr := nextFreePort(conflict.HostPort)
+			if portErr != nil {
+				return nil, errors.Join(err, portErr)
+			}
+
+			if remapHostPort(&req.ContainerRequest, conflict.HostPort, next) {
+				logf(ctx, logging, slog.LevelWarn, attrs, "🔀 %s, retrying with the host port %s", conflict, next)
+
+				// the host port of the request, which may have been remapped already
+				original := conflict.HostPort
+				for from, to := range remapped {
+					if to == conflict.HostPort {
+						original = from
+					}
+				}
+				remapped[original] = next
+			} else {
+				logf(ctx, logging, slog.LevelWarn, attrs, "🔀 %s, retrying", conflict)
+			}
+
+			continue
+		}
+
+		if err == nil {
+			for from, to := range remapped {
+				logf(ctx, logging, slog.LevelInfo, attrs,
+					"🔀 The host port %s of the request was in use, the container is bound to the host port %s instead", from, to)
+			}
+			for _, from := range privileged {
+				logf(ctx, logging, slog.LevelInfo, attrs,
+					"🔀 The host port %s of the request is privileged, the container is bound to a random host port instead", from)
+			}
+		}
+
+		return c, err
+	}
+}
+
+// terminateToRetry terminates the container of a failed attempt to start it, if it was created, before creating it again.
+func terminateToRetry(ctx context.Context, c Container) error {
+	if c == nil {
+		return nil
+	}
+
+	if err := c.Terminate(ctx); err != nil {
+		return fmt.Errorf("terminate container to retry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestParsePortConflict(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    string
+		hostIP string
+		port   string
+		ok     bool
+	}{
+		{
+			name:   "docker",
+			err:    "Error response from daemon: driver failed programming external connectivity on endpoint redis: Bind for 0.0.0.0:16379 failed: port is already allocated",
+			hostIP: "0.0.0.0",
+			port:   "16379",
+			ok:     true,
+		},
+		{
+			name:   "docker ipv6",
+			err:    "Error response from daemon: driver failed programming external connectivity on endpoint redis: Bind for :::16379 failed: port is already allocated",
+			hostIP: "::",
+			port:   "16379",
+			ok:     true,
+		},
+		{
+			name:   "docker proxy",
+			err:    "Error response from daemon: driver failed programming external connectivity on endpoint redis: Error starting userland proxy: listen tcp4 0.0.0.0:16379: bind: address already in use",
+			hostIP: "0.0.0.0",
+			port:   "16379",
+			ok:     true,
+		},
+		{
+			name:   "docker desktop",
+			err:    "Error response from daemon: Ports are not available: exposing port TCP 127.0.0.1:16379 -> 0.0.0.0:0: listen tcp 127.0.0.1:16379: bind: address already in use",
+			hostIP: "127.0.0.1",
+			port:   "16379",
+			ok:     true,
+		},
+		{
+			name: "other error",
+			err:  "Error response from daemon: No such container: redis",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostIP, port, ok := parsePortConflict(errors.New(tt.err))
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.hostIP, hostIP)
+			require.Equal(t, tt.port, port)
+		})
+	}
+}
+
+// portHolderClient is a mock implementation of client.APIClient, with a running container publishing a host port.
+type portHolderClient struct {
+	client.APIClient
+}
+
+func (m *portHolderClient) ContainerList(context.Context, container.ListOptions) ([]types.Container, error) {
+	return []types.Container{
+		{
+			ID:    "0123456789abcdef",
+			Names: []string{"/other-redis"},
+			Image: "redis:7-alpine",
+			Ports: []types.Port{{PrivatePort: 6379, PublicPort: 16379, Type: "tcp"}},
+		},
+	}, nil
+}
+
+func TestPortConflictError(t *testing.T) {
+	p := &DockerProvider{client: &portHolderClient{}, host: "tcp://remote:2375"}
+
+	daemonErr := errors.New("Bind for 0.0.0.0:16379 failed: port is already allocated")
+	err := p.portConflictError(context.Background(), daemonErr)
+	require.ErrorIs(t, err, ErrPortConflict)
+	require.ErrorIs(t, err, daemonErr)
+	require.EqualError(t, err, "host port 0.0.0.0:16379 is already in use by container other-redis (0123456789ab, image redis:7-alpine): Bind for 0.0.0.0:16379 failed: port is already allocated")
+
+	var conflict *PortConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, "16379", conflict.HostPort)
+
+	// the holder is not found on a remote daemon
+	err = p.portConflictError(context.Background(), errors.New("Bind for 0.0.0.0:8080 failed: port is already allocated"))
+	require.EqualError(t, err, "host port 0.0.0.0:8080 is already in use: Bind for 0.0.0.0:8080 failed: port is already allocated")
+
+	// the other errors are returned as is
+	otherErr := errors.New("No such container: redis")
+	require.Equal(t, otherErr, p.portConflictError(context.Background(), otherErr))
+}
+
+func TestLocalPortHolder(t *testing.T) {
+	procRoot := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(procRoot, "net"), 0o755))
+	tcp := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:3FFB 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 5678 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:1F90 0100007F:C350 01 00000000:00000000 00:00000000 00000000  1000        0 4321 1 0000000000000000 20 4 30 10 -1
+   2: 00000000:1F40 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 9999 1 0000000000000000 100 0 0 10 0
+`
+	require.NoError(t, os.WriteFile(filepath.Join(procRoot, "net", "tcp"), []byte(tcp), 0o644))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(procRoot, "1234", "fd"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(procRoot, "1234", "comm"), []byte("redis-server\n"), 0o644))
+	require.NoError(t, os.Symlink("socket:[5678]", filepath.Join(procRoot, "1234", "fd", "3")))
+	require.NoError(t, os.Symlink("/dev/null", filepath.Join(procRoot, "1234", "fd", "0")))
+
+	// 0x3FFB
+	require.Equal(t, "process redis-server (pid 1234)", localPortHolder(procRoot, 16379))
+	// the socket of 0x1F40 is not found in the processes
+	require.Equal(t, "a process of another user", localPortHolder(procRoot, 8000))
+	// 0x1F90 is not listening
+	require.Empty(t, localPortHolder(procRoot, 8080))
+}
+
+func TestNextFreePort(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	port := l.Addr().(*net.TCPAddr).Port
+	next, err := nextFreePort(strconv.Itoa(port - 1))
+	require.NoError(t, err)
+	require.NotEqual(t, strconv.Itoa(port), next)
+
+	_, err = nextFreePort("not-a-port")
+	require.Error(t, err)
+}
+
+func TestRemapHostPort(t *testing.T) {
+	exposedPorts := []string{"16379:6379/tcp", "127.0.0.1:16379:6380/tcp", "[::1]:16379:6381", "8080/tcp", "18000-18010:8000"}
+	req := ContainerRequest{
+		ExposedPorts: exposedPorts,
+		HostConfigModifier: func(hc *container.HostConfig) {
+			hc.PortBindings = nat.PortMap{"9090/tcp": {{HostPort: "16379"}}, "9091/tcp": {{HostPort: "19091"}}}
+		},
+	}
+
+	require.True(t, remapHostPort(&req, "16379", "16380"))
+	require.Equal(t, []string{"16380:6379/tcp", "127.0.0.1:16380:6380/tcp", "[::1]:16380:6381/tcp", "8080/tcp", "18000-18010:8000"}, req.ExposedPorts)
+	// the request of the caller is not modified
+	require.Equal(t, "16379:6379/tcp", exposedPorts[0])
+
+	hc := &container.HostConfig{}
+	req.HostConfigModifier(hc)
+	require.Equal(t, nat.PortMap{"9090/tcp": {{HostPort: "16380"}}, "9091/tcp": {{HostPort: "19091"}}}, hc.PortBindings)
+
+	// a random host port is not bound by the request
+	require.False(t, remapHostPort(&ContainerRequest{ExposedPorts: []string{"6379/tcp"}}, "32768", "32769"))
+}
+
+func TestPortConflictRetries(t *testing.T) {
+	ctx := context.Background()
+
+	// a process of the host listens on the host port of the container
+	l, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	hostPort := strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{hostPort + ":80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	}
+
+	c, err := GenericContainer(ctx, req)
+	terminateContainerOnEnd(t, ctx, c)
+	require.ErrorIs(t, err, ErrPortConflict)
+
+	// portConflictRetries {
+	c, err = GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{hostPort + ":80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+		// bind the next free host port if the fixed one is in use
+		PortConflictRetries: 3,
+	})
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	port, err := c.MappedPort(ctx, "80/tcp")
+	require.NoError(t, err)
+	require.NotEqual(t, hostPort, port.Port())
+}
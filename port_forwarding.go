@@ -0,0 +1,281 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// HostInternal is the hostname a container can use to reach the host ports exposed
+	// to it with the ExposedHostPorts field of the ContainerRequest, e.g. "http://host.testcontainers.internal:8080".
+	HostInternal = "host.testcontainers.internal"
+
+	sshdImage = "testcontainers/sshd:1.2.0"
+	sshdPort  = "22/tcp"
+	sshdUser  = "root"
+
+	socatImage = "alpine/socat:1.7.4.3-r0"
+)
+
+// sshdContainer is the container running the SSH server used to expose the host ports to other containers.
+// For each exposed port, a reverse tunnel forwards the connections accepted by the SSH server to the host port.
+type sshdContainer struct {
+	*DockerContainer
+	client    *ssh.Client
+	listeners []net.Listener
+	wg        sync.WaitGroup
+}
+
+// exposeHostPorts starts the SSH server in a container attached to the first network of the request,
+// and opens a reverse tunnel for each of the host ports to expose. The request is modified so that
+// HostInternal resolves to the SSH server from within the container.
+// The returned hooks terminate the SSH server once the container is terminated.
+func (p *DockerProvider) exposeHostPorts(ctx context.Context, req *ContainerRequest) (*sshdContainer, ContainerLifecycleHooks, error) {
+	var hooks ContainerLifecycleHooks
+
+	for _, port := range req.ExposedHostPorts {
+		if port < 1 || port > 65535 {
+			return nil, hooks, fmt.Errorf("invalid host port to expose: %d", port)
+		}
+	}
+
+	password := uuid.NewString()
+
+	sshdReq := ContainerRequest{
+		Image:        sshdImage,
+		ExposedPorts: []string{sshdPort},
+		Env:          map[string]string{"PASSWORD": password},
+		WaitingFor:   wait.ForListeningPort(sshdPort),
+	}
+
+	// the SSH server must be reachable from the container, so it's attached to the same network
+	var networkName string
+	if len(req.Networks) > 0 {
+		networkName = req.Networks[0]
+		sshdReq.Networks = []string{networkName}
+	}
+
+	c, err := p.RunContainer(ctx, sshdReq)
+	if err != nil {
+		return nil, hooks, fmt.Errorf("start SSH server: %w", err)
+	}
+
+	sshd := &sshdContainer{DockerContainer: c.(*DockerContainer)}
+
+	// from now on, the SSH server must be terminated if something goes wrong
+	if err := sshd.connect(ctx, password); err != nil {
+		return nil, hooks, errors.Join(err, sshd.Terminate(ctx))
+	}
+
+	if err := sshd.forward(req.ExposedHostPorts...); err != nil {
+		return nil, hooks, errors.Join(err, sshd.Terminate(ctx))
+	}
+
+	sshdIP, err := sshd.ipInNetwork(ctx, networkName)
+	if err != nil {
+		return nil, hooks, errors.Join(err, sshd.Terminate(ctx))
+	}
+
+	// the original modifier is called first, so that the extra host is not overridden
+	modifier := req.HostConfigModifier
+	if modifier == nil {
+		modifier = defaultHostConfigModifier(*req)
+	}
+	req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+		modifier(hostConfig)
+		hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, fmt.Sprintf("%s:%s", HostInternal, sshdIP))
+	}
+
+	hooks.PostTerminates = []ContainerHook{
+		func(ctx context.Context, _ Container) error {
+			return sshd.Terminate(ctx)
+		},
+	}
+
+	return sshd, hooks, nil
+}
+
+// connect opens the SSH connection used to create the reverse tunnels.
+func (s *sshdContainer) connect(ctx context.Context, password string) error {
+	host, err := s.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	port, err := s.MappedPort(ctx, sshdPort)
+	if err != nil {
+		return err
+	}
+
+	s.client, err = ssh.Dial("tcp", net.JoinHostPort(host, port.Port()), &ssh.ClientConfig{
+		User: sshdUser,
+		Auth: []ssh.AuthMethod{ssh.Password(password)},
+		// the SSH server is a throw-away container started by the library itself
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("connect to SSH server: %w", err)
+	}
+
+	return nil
+}
+
+// forward listens on the given ports of the SSH server, forwarding the accepted connections to the same ports of the host.
+func (s *sshdContainer) forward(ports ...int) error {
+	for _, port := range ports {
+		listener, err := s.client.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+		if err != nil {
+			return fmt.Errorf("listen on port %d of the SSH server: %w", port, err)
+		}
+		s.listeners = append(s.listeners, listener)
+
+		s.wg.Add(1)
+		go func(port int) {
+			defer s.wg.Done()
+
+			for {
+				remote, err := listener.Accept()
+				if err != nil {
+					// the listener has been closed
+					return
+				}
+
+				go tunnel(remote, port)
+			}
+		}(port)
+	}
+
+	return nil
+}
+
+// tunnel copies the data between the connection accepted by the SSH server and the given port of the host,
+// until one of the sides closes the connection.
+func tunnel(remote net.Conn, port int) {
+	defer remote.Close()
+
+	local, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return
+	}
+	defer local.Close()
+
+	done := make(chan struct{}, 2)
+
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+
+	<-done
+}
+
+// ipInNetwork returns the IP address of the SSH server in the given network,
+// or in its primary network if no network is given.
+func (s *sshdContainer) ipInNetwork(ctx context.Context, networkName string) (string, error) {
+	if networkName == "" {
+		return s.ContainerIP(ctx)
+	}
+
+	return s.ContainerIPInNetwork(ctx, networkName)
+}
+
+// Terminate closes the tunnels and the SSH connection, and then terminates the container.
+func (s *sshdContainer) Terminate(ctx context.Context) error {
+	for _, l := range s.listeners {
+		_ = l.Close()
+	}
+	s.listeners = nil
+
+	if s.client != nil {
+		_ = s.client.Close()
+		s.client = nil
+	}
+
+	s.wg.Wait()
+
+	return s.DockerContainer.Terminate(ctx)
+}
+
+// ForwardPort makes the given TCP port of the container reachable from the host, even if it was not exposed
+// when the container was created, e.g. to reach an admin port. It returns the host:port address to connect to.
+// The connections are forwarded by a socat container attached to the same network as the container,
+// which is terminated before the container is terminated.
+func (c *DockerContainer) ForwardPort(ctx context.Context, port nat.Port) (string, error) {
+	if port.Proto() != "tcp" {
+		return "", fmt.Errorf("only TCP ports can be forwarded: %s", port)
+	}
+
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// use the first network, by name, the container has an IP address in
+	networkNames := make([]string, 0, len(inspect.NetworkSettings.Networks))
+	for name, settings := range inspect.NetworkSettings.Networks {
+		if settings.IPAddress != "" {
+			networkNames = append(networkNames, name)
+		}
+	}
+	if len(networkNames) == 0 {
+		return "", fmt.Errorf("container %s has no IP address to forward port %s to", c.ID, port)
+	}
+	sort.Strings(networkNames)
+
+	networkName := networkNames[0]
+	targetIP := inspect.NetworkSettings.Networks[networkName].IPAddress
+
+	forwarder, err := c.provider.RunContainer(ctx, ContainerRequest{
+		Image:        socatImage,
+		ExposedPorts: []string{string(port)},
+		Entrypoint:   []string{"socat"},
+		Cmd: []string{
+			fmt.Sprintf("TCP-LISTEN:%s,fork,reuseaddr", port.Port()),
+			fmt.Sprintf("TCP-CONNECT:%s", net.JoinHostPort(targetIP, port.Port())),
+		},
+		Networks:   []string{networkName},
+		WaitingFor: wait.ForListeningPort(port),
+	})
+	if err != nil {
+		return "", fmt.Errorf("start port forwarder: %w", err)
+	}
+
+	host, err := forwarder.Host(ctx)
+	if err != nil {
+		return "", errors.Join(err, forwarder.Terminate(ctx))
+	}
+
+	mappedPort, err := forwarder.MappedPort(ctx, port)
+	if err != nil {
+		return "", errors.Join(err, forwarder.Terminate(ctx))
+	}
+
+	c.lifecycleHooks = append(c.lifecycleHooks, ContainerLifecycleHooks{
+		PreTerminates: []ContainerHook{
+			func(ctx context.Context, _ Container) error {
+				return forwarder.Terminate(ctx)
+			},
+		},
+	})
+
+	return net.JoinHostPort(host, mappedPort.Port()), nil
+}
@@ -0,0 +1,154 @@
+package testcontainers_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/network"
+)
+
+const expectedResponse = "Hello, World!"
+
+func TestExposeHostPorts(t *testing.T) {
+	tests := []struct {
+		name          string
+		numberOfPorts int
+		hasNetwork    bool
+	}{
+		{
+			name:          "single port",
+			numberOfPorts: 1,
+		},
+		{
+			name:          "single port using a network",
+			numberOfPorts: 1,
+			hasNetwork:    true,
+		},
+		{
+			name:          "multiple ports",
+			numberOfPorts: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ports := make([]int, 0, tc.numberOfPorts)
+			for i := 0; i < tc.numberOfPorts; i++ {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					_, _ = fmt.Fprint(w, expectedResponse)
+				}))
+				t.Cleanup(server.Close)
+
+				_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+				require.NoError(t, err)
+
+				p, err := strconv.Atoi(port)
+				require.NoError(t, err)
+
+				ports = append(ports, p)
+			}
+
+			req := testcontainers.GenericContainerRequest{
+				// hostAccessPorts {
+				ContainerRequest: testcontainers.ContainerRequest{
+					Image:            "alpine:3.17",
+					ExposedHostPorts: ports,
+					Cmd:              []string{"top"},
+				},
+				// }
+				Started: true,
+			}
+
+			if tc.hasNetwork {
+				nw, err := network.New(ctx)
+				require.NoError(t, err)
+				t.Cleanup(func() {
+					require.NoError(t, nw.Remove(context.Background()))
+				})
+
+				network.WithNetwork([]string{"alpine"}, nw)(&req)
+			}
+
+			c, err := testcontainers.GenericContainer(ctx, req)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, c.Terminate(context.Background()))
+			})
+
+			for _, port := range ports {
+				// hostAccessPortsRequest {
+				code, reader, err := c.Exec(ctx,
+					[]string{"wget", "-q", "-O", "-", fmt.Sprintf("http://%s:%d", testcontainers.HostInternal, port)},
+					tcexec.Multiplexed(),
+				)
+				// }
+				require.NoError(t, err)
+				require.Equal(t, 0, code)
+
+				bs, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				assert.Equal(t, expectedResponse, string(bs))
+			}
+		})
+	}
+}
+
+func TestExposeHostPorts_invalidPort(t *testing.T) {
+	_, err := testcontainers.GenericContainer(context.Background(), testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:            "alpine:3.17",
+			ExposedHostPorts: []int{65536},
+			Cmd:              []string{"top"},
+		},
+		Started: true,
+	})
+	require.Error(t, err)
+}
+
+func TestForwardPort(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "nginx:alpine",
+			// only the given ports are published, so the HTTP port of nginx is not reachable from the host
+			ExposedPorts: []string{"8080/tcp"},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nginx.Terminate(context.Background()))
+	})
+
+	_, err = nginx.MappedPort(ctx, "80/tcp")
+	require.Error(t, err)
+
+	// forwardPort {
+	// ForwardPort is not part of the Container interface
+	address, err := nginx.(*testcontainers.DockerContainer).ForwardPort(ctx, "80/tcp")
+	// }
+	require.NoError(t, err)
+
+	resp, err := http.Get("http://" + address)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = nginx.(*testcontainers.DockerContainer).ForwardPort(ctx, "53/udp")
+	require.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+)
+
+// ErrPrivilegedPort is the error of a container that can't be started because one of its host ports is privileged,
+// i.e. lower than 1024, and the container runtime is rootless, e.g. rootless Docker or rootless Podman.
+var ErrPrivilegedPort = errors.New("privileged host port")
+
+// privilegedPortRegex matches the errors of the rootless container runtimes when a host port is privileged,
+// capturing its IP and its number, e.g. the one of rootless Docker:
+//
+//	Error starting userland proxy: error while calling PortManager.AddPort(): cannot expose privileged port 80,
+//	you can add 'net.ipv4.ip_unprivileged_port_start=80' to /etc/sysctl.conf (currently 1024), or set CAP_NET_BIND_SERVICE
+//	on rootlesskit binary, or choose a larger port number (>= 1024): listen tcp4 0.0.0.0:80: bind: permission denied
+var privilegedPortRegex = regexp.MustCompile(`listen (?:tcp|udp)[46]? \[?([0-9a-fA-F.:]*?)\]?:(\d+): bind: permission denied`)
+
+// PrivilegedPortError is the error of a container that can't be started because one of its host ports is privileged
+// and the container runtime is rootless. It matches ErrPrivilegedPort with errors.Is.
+type PrivilegedPortError struct {
+	// HostIP is the IP the port is bound to, e.g. 0.0.0.0.
+	HostIP string
+	// HostPort is the privileged host port.
+	HostPort string
+	Err      error
+}
+
+// Error implements the error interface.
+func (e *PrivilegedPortError) Error() string {
+	return fmt.Sprintf("host port %s is privileged and can't be published by the rootless container runtime, "+
+		"use a host port greater than 1023, a random one, or the WithPrivilegedPortRemapping option: %s", net.JoinHostPort(e.HostIP, e.HostPort), e.Err)
+}
+
+// Unwrap returns the error of the container runtime.
+func (e *PrivilegedPortError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes the error match ErrPrivilegedPort.
+func (e *PrivilegedPortError) Is(target error) bool {
+	return target == ErrPrivilegedPort
+}
+
+// privilegedPortError returns the error of the start of a container wrapped in a PrivilegedPortError,
+// if the rootless container runtime can't publish one of its host ports. Otherwise, the error is returned as is.
+func privilegedPortError(err error) error {
+	m := privilegedPortRegex.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+
+	return &PrivilegedPortError{HostIP: m[1], HostPort: m[2], Err: err}
+}
@@ -0,0 +1,164 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivilegedPortError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    string
+		hostIP string
+		port   string
+	}{
+		{
+			name:   "rootless docker",
+			err:    "Error response from daemon: driver failed programming external connectivity on endpoint nginx: Error starting userland proxy: error while calling PortManager.AddPort(): cannot expose privileged port 80, you can add 'net.ipv4.ip_unprivileged_port_start=80' to /etc/sysctl.conf (currently 1024), or set CAP_NET_BIND_SERVICE on rootlesskit binary, or choose a larger port number (>= 1024): listen tcp4 0.0.0.0:80: bind: permission denied",
+			hostIP: "0.0.0.0",
+			port:   "80",
+		},
+		{
+			name:   "rootless podman",
+			err:    "rootlessport cannot expose privileged port 443, you can add 'net.ipv4.ip_unprivileged_port_start=443' to /etc/sysctl.conf (currently 1024), or choose a larger port number (>= 1024): listen tcp [::]:443: bind: permission denied",
+			hostIP: "::",
+			port:   "443",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daemonErr := errors.New(tt.err)
+			err := privilegedPortError(daemonErr)
+			require.ErrorIs(t, err, ErrPrivilegedPort)
+			require.ErrorIs(t, err, daemonErr)
+
+			var privileged *PrivilegedPortError
+			require.ErrorAs(t, err, &privileged)
+			require.Equal(t, tt.hostIP, privileged.HostIP)
+			require.Equal(t, tt.port, privileged.HostPort)
+		})
+	}
+
+	err := privilegedPortError(errors.New("listen tcp4 0.0.0.0:80: bind: permission denied"))
+	require.EqualError(t, err, "host port 0.0.0.0:80 is privileged and can't be published by the rootless container runtime, "+
+		"use a host port greater than 1023, a random one, or the WithPrivilegedPortRemapping option: listen tcp4 0.0.0.0:80: bind: permission denied")
+
+	// the other errors are returned as is
+	otherErr := errors.New("Bind for 0.0.0.0:80 failed: port is already allocated")
+	require.Equal(t, otherErr, privilegedPortError(otherErr))
+}
+
+// privilegedPortProvider is a GenericProvider creating containers which fail to start when they bind
+// a privileged host port, as with a rootless container runtime.
+type privilegedPortProvider struct {
+	GenericProvider
+
+	requests   []ContainerRequest
+	terminated int
+}
+
+func (p *privilegedPortProvider) CreateContainer(_ context.Context, req ContainerRequest) (Container, error) {
+	p.requests = append(p.requests, req)
+	return &privilegedPortContainer{provider: p, req: req}, nil
+}
+
+type privilegedPortContainer struct {
+	Container
+
+	provider *privilegedPortProvider
+	req      ContainerRequest
+}
+
+func (c *privilegedPortContainer) IsRunning() bool {
+	return false
+}
+
+func (c *privilegedPortContainer) Start(context.Context) error {
+	exposed, bindings, err := nat.ParsePortSpecs(c.req.ExposedPorts)
+	if err != nil {
+		return err
+	}
+
+	hc := &container.HostConfig{PortBindings: bindings}
+	if c.req.HostConfigModifier != nil {
+		c.req.HostConfigModifier(hc)
+	}
+
+	for port := range exposed {
+		for _, b := range hc.PortBindings[port] {
+			if port, err := strconv.Atoi(b.HostPort); err == nil && port < 1024 {
+				return privilegedPortError(errors.New("listen tcp4 0.0.0.0:" + b.HostPort + ": bind: permission denied"))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *privilegedPortContainer) Terminate(context.Context) error {
+	c.provider.terminated++
+	return nil
+}
+
+func TestCreateAndStartContainerOnFreePorts_privileged(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error", func(t *testing.T) {
+		provider := &privilegedPortProvider{}
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{ExposedPorts: []string{"80:80/tcp"}},
+			Started:          true,
+		}
+
+		_, err := createAndStartContainerOnFreePorts(ctx, provider, TestLogger(t), req)
+		require.ErrorIs(t, err, ErrPrivilegedPort)
+		require.Len(t, provider.requests, 1)
+	})
+
+	t.Run("remapped", func(t *testing.T) {
+		provider := &privilegedPortProvider{}
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{ExposedPorts: []string{"80:80/tcp", "127.0.0.1:443:443/tcp", "8080:8080/tcp"}},
+			Started:          true,
+		}
+		WithPrivilegedPortRemapping().Customize(&req)
+
+		c, err := createAndStartContainerOnFreePorts(ctx, provider, TestLogger(t), req)
+		require.NoError(t, err)
+		require.NotNil(t, c)
+
+		// each privileged host port is remapped to a random one, the container of each failed attempt being terminated
+		require.Len(t, provider.requests, 3)
+		require.Equal(t, 2, provider.terminated)
+		require.Equal(t, []string{":80/tcp", "127.0.0.1::443/tcp", "8080:8080/tcp"}, provider.requests[2].ExposedPorts)
+	})
+
+	t.Run("reused", func(t *testing.T) {
+		req := GenericContainerRequest{
+			ContainerRequest:     ContainerRequest{ExposedPorts: []string{"80:80/tcp"}},
+			Started:              true,
+			Reuse:                true,
+			RemapPrivilegedPorts: true,
+		}
+
+		// a reused container could be used by other tests, so it's not created again
+		_, err := createAndStartContainerOnFreePorts(ctx, &reusedPrivilegedPortProvider{}, TestLogger(t), req)
+		require.ErrorIs(t, err, ErrPrivilegedPort)
+	})
+}
+
+// reusedPrivilegedPortProvider is a privilegedPortProvider reusing the containers.
+type reusedPrivilegedPortProvider struct {
+	privilegedPortProvider
+}
+
+func (p *reusedPrivilegedPortProvider) ReuseOrCreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	return p.CreateContainer(ctx, req)
+}
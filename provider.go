@@ -25,6 +25,12 @@ type (
 	GenericProviderOptions struct {
 		Logger         Logging
 		DefaultNetwork string
+		// HostOverride is the host where the ports of the containers are exposed, taking precedence over the host override
+		// of the configuration and over the host derived from the Docker host.
+		HostOverride string
+		// ConcurrencyLimits are the limits of the operations on the Docker daemon, taking precedence over the limits
+		// of the configuration.
+		ConcurrencyLimits ConcurrencyLimits
 	}
 
 	// GenericProviderOption defines a common interface to modify GenericProviderOptions
@@ -82,6 +88,71 @@ func (f GenericProviderOptionFunc) ApplyGenericTo(opts *GenericProviderOptions)
 	f(opts)
 }
 
+// WithHostOverride overrides the host where the ports of the containers are exposed, returned by the Host and Endpoint
+// methods of the containers, e.g. the IP of the VM running a remote Docker daemon, or of a cloud runner, instead of the host
+// derived from the Docker host. It takes precedence over the TESTCONTAINERS_HOST_OVERRIDE environment variable and the
+// host.override property.
+func WithHostOverride(host string) HostOverrideOption {
+	return HostOverrideOption{
+		host: host,
+	}
+}
+
+// HostOverrideOption is a generic option that overrides the host where the ports of the containers are exposed.
+//
+// It can be used to set the host for providers and containers.
+type HostOverrideOption struct {
+	host string
+}
+
+// ApplyGenericTo implements GenericProviderOption.
+func (o HostOverrideOption) ApplyGenericTo(opts *GenericProviderOptions) {
+	opts.HostOverride = o.host
+}
+
+// ApplyDockerTo implements DockerProviderOption.
+func (o HostOverrideOption) ApplyDockerTo(opts *DockerProviderOptions) {
+	opts.HostOverride = o.host
+}
+
+// Customize implements ContainerCustomizer.
+func (o HostOverrideOption) Customize(req *GenericContainerRequest) {
+	req.HostOverride = o.host
+}
+
+// WithConcurrencyLimits limits the images pulled or built, and the containers created or started, at the same time
+// by the test process, and the containers of Testcontainers running on the Docker daemon, e.g. to stay within
+// the quota of a shared CI runner. The limits are shared by all the providers of the process using the same daemon,
+// and they take precedence over the TESTCONTAINERS_MAX_CONCURRENT_CREATES and TESTCONTAINERS_MAX_RUNNING_CONTAINERS
+// environment variables, and the max.concurrent.creates and max.running.containers properties.
+func WithConcurrencyLimits(limits ConcurrencyLimits) ConcurrencyLimitsOption {
+	return ConcurrencyLimitsOption{
+		limits: limits,
+	}
+}
+
+// ConcurrencyLimitsOption is a generic option that limits the operations on the Docker daemon.
+//
+// It can be used to set the limits for providers and containers.
+type ConcurrencyLimitsOption struct {
+	limits ConcurrencyLimits
+}
+
+// ApplyGenericTo implements GenericProviderOption.
+func (o ConcurrencyLimitsOption) ApplyGenericTo(opts *GenericProviderOptions) {
+	opts.ConcurrencyLimits = o.limits
+}
+
+// ApplyDockerTo implements DockerProviderOption.
+func (o ConcurrencyLimitsOption) ApplyDockerTo(opts *DockerProviderOptions) {
+	opts.ConcurrencyLimits = o.limits
+}
+
+// Customize implements ContainerCustomizer.
+func (o ConcurrencyLimitsOption) Customize(req *GenericContainerRequest) {
+	req.ConcurrencyLimits = o.limits
+}
+
 // ContainerProvider allows the creation of containers on an arbitrary system
 type ContainerProvider interface {
 	Close() error                                                                // close the provider
@@ -146,6 +217,10 @@ func NewDockerProvider(provOpts ...DockerProviderOption) (*DockerProvider, error
 
 	tcConfig := ReadConfig()
 
+	if err := setUpSSHTunnel(ctx, tcConfig.Config.SSHTunnel); err != nil {
+		return nil, fmt.Errorf("%w: setting up the SSH tunnel failed", err)
+	}
+
 	dockerHost := core.ExtractDockerHost(ctx)
 
 	p := &DockerProvider{
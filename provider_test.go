@@ -4,6 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
 	"github.com/testcontainers/testcontainers-go/internal/core"
 )
 
@@ -79,3 +82,54 @@ func TestProviderTypeGetProviderAutodetect(t *testing.T) {
 		})
 	}
 }
+
+func TestWithHostOverride(t *testing.T) {
+	t.Run("provider", func(t *testing.T) {
+		// the option takes precedence over the configuration
+		opts := &DockerProviderOptions{GenericProviderOptions: &GenericProviderOptions{}}
+		WithHostOverride("192.168.64.2").ApplyDockerTo(opts)
+
+		p := &DockerProvider{
+			DockerProviderOptions: opts,
+			config:                TestcontainersConfig{Config: config.Config{HostOverride: "172.17.0.1"}},
+		}
+
+		host, err := p.DaemonHost(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "192.168.64.2", host)
+
+		c := &DockerContainer{provider: p}
+		host, err = c.Host(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "192.168.64.2", host)
+	})
+
+	t.Run("configuration", func(t *testing.T) {
+		p := &DockerProvider{
+			DockerProviderOptions: &DockerProviderOptions{GenericProviderOptions: &GenericProviderOptions{}},
+			config:                TestcontainersConfig{Config: config.Config{HostOverride: "172.17.0.1"}},
+		}
+
+		host, err := p.DaemonHost(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "172.17.0.1", host)
+	})
+
+	t.Run("generic options", func(t *testing.T) {
+		opts := &GenericProviderOptions{}
+		WithHostOverride("192.168.64.2").ApplyGenericTo(opts)
+		require.Equal(t, "192.168.64.2", opts.HostOverride)
+
+		// the option is kept as a Docker option, applying to the Docker provider
+		converted := Generic2DockerOptions(WithHostOverride("192.168.64.2"))
+		dockerOpts := &DockerProviderOptions{GenericProviderOptions: &GenericProviderOptions{}}
+		converted[0].ApplyDockerTo(dockerOpts)
+		require.Equal(t, "192.168.64.2", dockerOpts.HostOverride)
+	})
+
+	t.Run("request", func(t *testing.T) {
+		req := GenericContainerRequest{}
+		WithHostOverride("192.168.64.2").Customize(&req)
+		require.Equal(t, "192.168.64.2", req.HostOverride)
+	})
+}
@@ -3,6 +3,7 @@ package testcontainers
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net"
@@ -28,6 +29,9 @@ const (
 	TestcontainerLabelSessionID = TestcontainerLabel + ".sessionId"
 	// Deprecated: it has been replaced by the internal core.LabelReaper
 	TestcontainerLabelIsReaper = TestcontainerLabel + ".reaper"
+
+	// reaperPortConflictAttempts is the number of attempts to start the reaper when its host port is in use.
+	reaperPortConflictAttempts = 3
 )
 
 var (
@@ -248,6 +252,14 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (
 	}
 
 	c, err := provider.RunContainer(ctx, req)
+	// the random host port of the reaper may be in use by a process the runtime doesn't know about
+	for attempt := 1; attempt < reaperPortConflictAttempts && errors.Is(err, ErrPortConflict); attempt++ {
+		if c != nil {
+			_ = c.Terminate(ctx)
+		}
+		Logger.Printf("🔀 %s, retrying to start the reaper", err)
+		c, err = provider.RunContainer(ctx, req)
+	}
 	if err != nil {
 		// We need to check whether the error is caused by a container with the same name
 		// already existing due to race conditions. We manually match the error message
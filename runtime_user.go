@@ -0,0 +1,105 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+)
+
+// copyToContainerOptions returns the options of the copies of files to the container, which are owned by the user
+// the container runs as if the request set ChownToRuntimeUser, or else by root.
+func (c *DockerContainer) copyToContainerOptions() types.CopyToContainerOptions {
+	return types.CopyToContainerOptions{CopyUIDGID: c.chownToRuntimeUser}
+}
+
+// defaultChownMountPointsHook is a hook that makes the writable directories mounted in the container owned by the user
+// the container runs as, after it's created but before it's started, if enabled.
+func defaultChownMountPointsHook(enabled bool) ContainerLifecycleHooks {
+	if !enabled {
+		return ContainerLifecycleHooks{}
+	}
+
+	return ContainerLifecycleHooks{
+		PostCreates: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				dc, ok := c.(*DockerContainer)
+				if !ok {
+					return nil
+				}
+
+				return dc.chownMountPoints(ctx)
+			},
+		},
+	}
+}
+
+// chownMountPoints makes the writable bind-mounted directories and volumes of the container owned by the user it runs as,
+// copying an entry for each of them with their current mode, which the Docker daemon chowns to the user, mapping it
+// to the user namespace of the container, if any. The entries they contain are left untouched.
+func (c *DockerContainer) chownMountPoints(ctx context.Context) error {
+	info, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return fmt.Errorf("inspect container: %w", err)
+	}
+
+	for _, m := range info.Mounts {
+		if !m.RW || (m.Type != mount.TypeBind && m.Type != mount.TypeVolume) {
+			continue
+		}
+
+		stat, err := c.provider.client.ContainerStatPath(ctx, c.ID, m.Destination)
+		if err != nil {
+			return fmt.Errorf("stat mount point %s: %w", m.Destination, err)
+		}
+
+		// the bind-mounted files are owned by the user of the host
+		if !stat.Mode.IsDir() {
+			continue
+		}
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		err = tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     strings.TrimPrefix(m.Destination, "/") + "/",
+			Mode:     tarMode(stat.Mode),
+			ModTime:  stat.Mtime,
+		})
+		if err == nil {
+			err = tw.Close()
+		}
+		if err != nil {
+			return fmt.Errorf("archive mount point %s: %w", m.Destination, err)
+		}
+
+		err = c.provider.client.CopyToContainer(ctx, c.ID, "/", &buf, types.CopyToContainerOptions{CopyUIDGID: true})
+		if err != nil {
+			return fmt.Errorf("chown mount point %s: %w", m.Destination, err)
+		}
+	}
+	defer c.provider.Close()
+
+	return nil
+}
+
+// tarMode returns the mode of a tar header with the permissions and the special bits of the file mode.
+func tarMode(mode os.FileMode) int64 {
+	m := int64(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		m |= 0o4000
+	}
+	if mode&os.ModeSetgid != 0 {
+		m |= 0o2000
+	}
+	if mode&os.ModeSticky != 0 {
+		m |= 0o1000
+	}
+
+	return m
+}
@@ -0,0 +1,147 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// mountsClient is a mock implementation of client.APIClient, with a created container mounting directories and files,
+// recording the tar entries copied to it, and the options of the copies.
+type mountsClient struct {
+	client.APIClient
+
+	mounts  []types.MountPoint
+	modes   map[string]os.FileMode
+	copied  []*tar.Header
+	options []types.CopyToContainerOptions
+}
+
+func (m *mountsClient) ContainerInspect(_ context.Context, containerID string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: containerID, State: &types.ContainerState{Status: "created"}},
+		Mounts:            m.mounts,
+	}, nil
+}
+
+func (m *mountsClient) ContainerStatPath(_ context.Context, _ string, path string) (types.ContainerPathStat, error) {
+	return types.ContainerPathStat{Name: path, Mode: m.modes[path], Mtime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, nil
+}
+
+func (m *mountsClient) CopyToContainer(_ context.Context, _ string, _ string, content io.Reader, options types.CopyToContainerOptions) error {
+	// the files are gzipped, unlike the mount points
+	br := bufio.NewReader(content)
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		content = zr
+	} else {
+		content = br
+	}
+
+	tr := tar.NewReader(content)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		m.copied = append(m.copied, header)
+		m.options = append(m.options, options)
+	}
+
+	return nil
+}
+
+func (m *mountsClient) Close() error {
+	return nil
+}
+
+func TestChownMountPoints(t *testing.T) {
+	ctx := context.Background()
+
+	cli := &mountsClient{
+		mounts: []types.MountPoint{
+			{Type: mount.TypeVolume, Destination: "/var/lib/postgresql/data", RW: true},
+			{Type: mount.TypeBind, Destination: "/fixtures", RW: true},
+			{Type: mount.TypeBind, Destination: "/etc/app.conf", RW: true},
+			{Type: mount.TypeBind, Destination: "/readonly", RW: false},
+			{Type: mount.TypeTmpfs, Destination: "/tmp", RW: true},
+		},
+		modes: map[string]os.FileMode{
+			"/var/lib/postgresql/data": os.ModeDir | 0o700,
+			"/fixtures":                os.ModeDir | os.ModeSetgid | 0o775,
+			"/etc/app.conf":            0o644,
+			"/readonly":                os.ModeDir | 0o755,
+			"/tmp":                     os.ModeDir | os.ModeSticky | 0o777,
+		},
+	}
+	c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}}
+
+	require.NoError(t, c.chownMountPoints(ctx))
+
+	// only the writable directories are chowned, keeping their mode
+	require.Len(t, cli.copied, 2)
+	require.Equal(t, "var/lib/postgresql/data/", cli.copied[0].Name)
+	require.Equal(t, int64(0o700), cli.copied[0].Mode)
+	require.Equal(t, "fixtures/", cli.copied[1].Name)
+	require.Equal(t, int64(0o2775), cli.copied[1].Mode)
+	require.Equal(t, []types.CopyToContainerOptions{{CopyUIDGID: true}, {CopyUIDGID: true}}, cli.options)
+}
+
+func TestCopyToContainerOwnership(t *testing.T) {
+	ctx := context.Background()
+
+	for _, chown := range []bool{false, true} {
+		cli := &mountsClient{}
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}, chownToRuntimeUser: chown}
+
+		require.NoError(t, c.CopyToContainer(ctx, []byte("secret"), "/fixtures/secret.txt", 0o600))
+		require.Equal(t, []types.CopyToContainerOptions{{CopyUIDGID: chown}}, cli.options)
+	}
+}
+
+func TestContainerWithChownToRuntimeUser(t *testing.T) {
+	ctx := context.Background()
+
+	// chownToRuntimeUser {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+			// the nobody user can read the secret, and write to the volume, which would be owned by root otherwise
+			User: "nobody",
+			Cmd:  []string{"sh", "-c", "cat /fixtures/secret.txt && touch /data/ready && echo owned && sleep infinity"},
+			Files: []ContainerFile{
+				{Reader: strings.NewReader("s3cr3t"), ContainerFilePath: "/fixtures/secret.txt", FileMode: 0o600},
+			},
+			Mounts:     ContainerMounts{VolumeMount("testcontainers-chown-data", "/data")},
+			WaitingFor: wait.ForLog("owned"),
+		},
+		Started: true,
+	}
+	WithChownToRuntimeUser().Customize(&req)
+
+	c, err := GenericContainer(ctx, req)
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+}
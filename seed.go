@@ -0,0 +1,147 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path"
+	"path/filepath"
+
+	"github.com/docker/go-connections/nat"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// Seeder loads data into a container once it's ready, e.g. the fixtures of the tests.
+type Seeder interface {
+	Seed(ctx context.Context, c Container) error
+}
+
+// SeederFunc is a function implementing Seeder.
+type SeederFunc func(ctx context.Context, c Container) error
+
+// Seed calls the function.
+func (f SeederFunc) Seed(ctx context.Context, c Container) error {
+	return f(ctx, c)
+}
+
+// SeedWith appends the given seeders to the ones loading data into the container, in order, once it's ready,
+// i.e. after its wait strategy passes and before the PostReadies hooks of the request.
+// The seeders are run once, when the container is created: not when it's restarted, nor when it's reused.
+func SeedWith(seeders ...Seeder) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Seeders = append(req.Seeders, seeders...)
+	}
+}
+
+// seedersHook is a hook running the seeders after the container is ready, the first time only.
+func seedersHook(seeders []Seeder) ContainerLifecycleHooks {
+	if len(seeders) == 0 {
+		return ContainerLifecycleHooks{}
+	}
+
+	// the container could be started again after being stopped, with its data
+	var seeded bool
+
+	return ContainerLifecycleHooks{
+		PostReadies: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if seeded {
+					return nil
+				}
+
+				for i, s := range seeders {
+					if err := s.Seed(ctx, c); err != nil {
+						return fmt.Errorf("seeder %d: %w", i, err)
+					}
+				}
+				seeded = true
+
+				return nil
+			},
+		},
+	}
+}
+
+// SeedExec returns a seeder running the command in the container,
+// failing if it exits with a non-zero code, with its output in the error.
+func SeedExec(cmd ...string) Seeder {
+	return SeederFunc(func(ctx context.Context, c Container) error {
+		code, r, err := c.Exec(ctx, cmd, tcexec.Multiplexed())
+		if err != nil {
+			return fmt.Errorf("exec %q: %w", cmd, err)
+		}
+
+		if code != 0 {
+			output, _ := io.ReadAll(r)
+			return fmt.Errorf("exec %q: exit code %d: %s", cmd, code, output)
+		}
+
+		return nil
+	})
+}
+
+// SeedScript returns a seeder copying the script from the host into the container, and running the command
+// with the path of the script in the container as last argument, e.g. a SQL script run by the client of the database:
+//
+//	SeedScript("testdata/fixtures.sql", "psql", "-U", "postgres", "-f")
+func SeedScript(hostFilePath string, cmd ...string) Seeder {
+	return SeederFunc(func(ctx context.Context, c Container) error {
+		containerFilePath := path.Join("/tmp", filepath.Base(hostFilePath))
+		if err := c.CopyFileToContainer(ctx, hostFilePath, containerFilePath, 0o644); err != nil {
+			return fmt.Errorf("copy %s to container: %w", hostFilePath, err)
+		}
+
+		return SeedExec(append(cmd, containerFilePath)...).Seed(ctx, c)
+	})
+}
+
+// SeedFiles returns a seeder copying the files into the container, once it's ready,
+// unlike the Files of the request, which are copied before it starts.
+func SeedFiles(files ...ContainerFile) Seeder {
+	return SeederFunc(func(ctx context.Context, c Container) error {
+		return defaultCopyFileToContainerHook(files).PostCreates[0](ctx, c)
+	})
+}
+
+// SeedHTTP returns a seeder sending a request with the given method, body and headers, which can be nil,
+// to the path of the given port of the container, e.g. to create the resources of an API,
+// failing if the response status is not a 2xx one.
+func SeedHTTP(port string, method string, urlPath string, body []byte, header http.Header) Seeder {
+	return SeederFunc(func(ctx context.Context, c Container) error {
+		host, err := c.Host(ctx)
+		if err != nil {
+			return err
+		}
+
+		mappedPort, err := c.MappedPort(ctx, nat.Port(port))
+		if err != nil {
+			return err
+		}
+
+		url := fmt.Sprintf("http://%s%s", net.JoinHostPort(host, mappedPort.Port()), urlPath)
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			respBody, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("%s %s: status %d: %s", method, urlPath, resp.StatusCode, respBody)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,152 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// seededContainer is a container recording the commands run by the seeders, and exposing a port of the host.
+type seededContainer struct {
+	Container
+
+	port     string
+	exitCode int
+	commands [][]string
+}
+
+func (c *seededContainer) Exec(_ context.Context, cmd []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	c.commands = append(c.commands, cmd)
+	return c.exitCode, strings.NewReader("ERROR: relation \"users\" does not exist"), nil
+}
+
+func (c *seededContainer) Host(context.Context) (string, error) {
+	return "127.0.0.1", nil
+}
+
+func (c *seededContainer) MappedPort(context.Context, nat.Port) (nat.Port, error) {
+	return nat.NewPort("tcp", c.port)
+}
+
+func TestSeedersHook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("in order, once", func(t *testing.T) {
+		var seeded []string
+		seeder := func(name string) Seeder {
+			return SeederFunc(func(context.Context, Container) error {
+				seeded = append(seeded, name)
+				return nil
+			})
+		}
+
+		hook := seedersHook([]Seeder{seeder("schema"), seeder("fixtures")})
+		require.Len(t, hook.PostReadies, 1)
+
+		require.NoError(t, hook.PostReadies[0](ctx, &seededContainer{}))
+		// the container is restarted with its data
+		require.NoError(t, hook.PostReadies[0](ctx, &seededContainer{}))
+		require.Equal(t, []string{"schema", "fixtures"}, seeded)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var seeded []string
+		hook := seedersHook([]Seeder{
+			SeederFunc(func(context.Context, Container) error {
+				return errors.New("no schema")
+			}),
+			SeederFunc(func(context.Context, Container) error {
+				seeded = append(seeded, "fixtures")
+				return nil
+			}),
+		})
+
+		require.EqualError(t, hook.PostReadies[0](ctx, &seededContainer{}), "seeder 0: no schema")
+		require.Empty(t, seeded)
+	})
+
+	t.Run("no seeders", func(t *testing.T) {
+		require.Empty(t, seedersHook(nil).PostReadies)
+	})
+}
+
+func TestSeedExec(t *testing.T) {
+	ctx := context.Background()
+
+	c := &seededContainer{}
+	require.NoError(t, SeedExec("psql", "-c", "SELECT 1").Seed(ctx, c))
+	require.Equal(t, [][]string{{"psql", "-c", "SELECT 1"}}, c.commands)
+
+	c = &seededContainer{exitCode: 1}
+	err := SeedExec("psql", "-c", "SELECT * FROM users").Seed(ctx, c)
+	require.EqualError(t, err, `exec ["psql" "-c" "SELECT * FROM users"]: exit code 1: ERROR: relation "users" does not exist`)
+}
+
+func TestSeedHTTP(t *testing.T) {
+	ctx := context.Background()
+
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, r.Method+" "+r.URL.Path+" "+r.Header.Get("Content-Type")+" "+string(body))
+
+		if r.URL.Path != "/users" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+	c := &seededContainer{port: port}
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	require.NoError(t, SeedHTTP("8080/tcp", http.MethodPost, "/users", []byte(`{"name":"john"}`), header).Seed(ctx, c))
+	require.Equal(t, []string{`POST /users application/json {"name":"john"}`}, received)
+
+	err = SeedHTTP("8080/tcp", http.MethodPost, "/groups", nil, nil).Seed(ctx, c)
+	require.EqualError(t, err, "POST /groups: status 404: not found\n")
+}
+
+func TestSeedWith(t *testing.T) {
+	ctx := context.Background()
+
+	// seedWith {
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        "redis:7-alpine",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections"),
+			// the seeders run in order, once the wait strategy passes
+			Seeders: []Seeder{
+				SeedExec("redis-cli", "set", "greeting", "hello"),
+				SeedExec("redis-cli", "rpush", "users", "john", "jane"),
+			},
+		},
+		Started: true,
+	})
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	code, r, err := c.Exec(ctx, []string{"redis-cli", "llen", "users"}, tcexec.Multiplexed())
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "2\n", string(output))
+}
@@ -0,0 +1,206 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// ReleaseFunc releases a reference to a shared container, terminating the container
+// when its last reference is released. Calling it more than once is a no-op.
+type ReleaseFunc func(ctx context.Context) error
+
+// sharedContainer is a container shared by the identical requests of the process.
+type sharedContainer struct {
+	// ready is closed once the container is started, or failed to start.
+	ready     chan struct{}
+	container Container
+	err       error
+	refs      int
+}
+
+// sharedContainers are the containers shared by the identical requests of the process, by request hash.
+var (
+	sharedContainersMu sync.Mutex
+	sharedContainers   = map[string]*sharedContainer{}
+)
+
+// SharedContainer returns the started container of the request, shared by all the identical requests of the process,
+// along with a function to release the reference to it: the container is created by the first request, and terminated
+// when the last reference to it is released, so that many tests can share one container without global variables.
+// The requests are identical if all their values are equal, except for the functions, e.g. the lifecycle hooks and the
+// modifiers, the logger, and the options of GenericContainerRequest about the startup, e.g. the deadline.
+// If the container fails to start, the error is returned to all the requests waiting for it, and the next request
+// tries to create it again.
+func SharedContainer(ctx context.Context, req GenericContainerRequest) (Container, ReleaseFunc, error) {
+	req.Started = true
+	key := requestHash(req)
+
+	sharedContainersMu.Lock()
+	shared, ok := sharedContainers[key]
+	if !ok {
+		shared = &sharedContainer{ready: make(chan struct{})}
+		sharedContainers[key] = shared
+	}
+	shared.refs++
+	sharedContainersMu.Unlock()
+
+	if !ok {
+		shared.container, shared.err = GenericContainer(ctx, req)
+		if shared.err != nil {
+			// the container is not shared, so it's terminated instead of returned to the caller
+			if shared.container != nil {
+				_ = shared.container.Terminate(context.WithoutCancel(ctx))
+				shared.container = nil
+			}
+
+			// the next request creates the container again
+			sharedContainersMu.Lock()
+			delete(sharedContainers, key)
+			sharedContainersMu.Unlock()
+		}
+		close(shared.ready)
+	}
+
+	select {
+	case <-shared.ready:
+	case <-ctx.Done():
+		releaseSharedContainer(ctx, key, shared)
+		return nil, nil, ctx.Err()
+	}
+
+	if shared.err != nil {
+		releaseSharedContainer(ctx, key, shared)
+		return nil, nil, shared.err
+	}
+
+	var once sync.Once
+	release := func(ctx context.Context) error {
+		var err error
+		once.Do(func() {
+			err = releaseSharedContainer(ctx, key, shared)
+		})
+
+		return err
+	}
+
+	return shared.container, release, nil
+}
+
+// SharedContainerForTest returns the started container of the request, shared by all the identical requests of the process,
+// as SharedContainer does, failing the test if the container can't be started. The reference to the container is released
+// when the test and all its subtests complete.
+func SharedContainerForTest(ctx context.Context, t testing.TB, req GenericContainerRequest) Container {
+	t.Helper()
+
+	c, release, err := SharedContainer(ctx, req)
+	if err != nil {
+		t.Fatalf("failed to start shared container: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if err := release(context.Background()); err != nil {
+			t.Errorf("failed to release shared container %s: %s", c.GetContainerID(), err)
+		}
+	})
+
+	return c
+}
+
+// releaseSharedContainer releases a reference to the shared container, terminating it if it was the last one.
+func releaseSharedContainer(ctx context.Context, key string, shared *sharedContainer) error {
+	sharedContainersMu.Lock()
+	shared.refs--
+	last := shared.refs == 0
+	if last && sharedContainers[key] == shared {
+		delete(sharedContainers, key)
+	}
+	sharedContainersMu.Unlock()
+
+	if !last || shared.container == nil {
+		return nil
+	}
+
+	return shared.container.Terminate(ctx)
+}
+
+// requestHash returns the hash of the values of the container request, and of its provider type.
+func requestHash(req GenericContainerRequest) string {
+	h := sha256.New()
+	writeHashValue(h, reflect.ValueOf(req.ContainerRequest), map[uintptr]bool{})
+	fmt.Fprintf(h, "provider:%d", req.ProviderType)
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// writeHashValue writes a representation of the value to the writer of a hash, following the pointers and
+// the interfaces, and sorting the keys of the maps. The functions and the channels are not represented, as they
+// can't be compared.
+func writeHashValue(w io.Writer, v reflect.Value, visited map[uintptr]bool) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		fmt.Fprint(w, "nil")
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		fmt.Fprint(w, v.Kind())
+	case reflect.Pointer:
+		if v.IsNil() {
+			fmt.Fprint(w, "nil")
+			return
+		}
+
+		// the cycles are not followed: the visited pointers are the ones of the current path
+		ptr := v.Pointer()
+		if visited[ptr] {
+			fmt.Fprint(w, "cycle")
+			return
+		}
+		visited[ptr] = true
+		defer delete(visited, ptr)
+
+		writeHashValue(w, v.Elem(), visited)
+	case reflect.Interface:
+		if v.IsNil() {
+			fmt.Fprint(w, "nil")
+			return
+		}
+
+		fmt.Fprint(w, v.Elem().Type().String())
+		writeHashValue(w, v.Elem(), visited)
+	case reflect.Struct:
+		fmt.Fprintf(w, "%s{", v.Type().String())
+		for i := 0; i < v.NumField(); i++ {
+			fmt.Fprintf(w, "%s:", v.Type().Field(i).Name)
+			writeHashValue(w, v.Field(i), visited)
+			fmt.Fprint(w, ",")
+		}
+		fmt.Fprint(w, "}")
+	case reflect.Slice, reflect.Array:
+		fmt.Fprint(w, "[")
+		for i := 0; i < v.Len(); i++ {
+			writeHashValue(w, v.Index(i), visited)
+			fmt.Fprint(w, ",")
+		}
+		fmt.Fprint(w, "]")
+	case reflect.Map:
+		entries := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			buf := &bytes.Buffer{}
+			writeHashValue(buf, iter.Key(), visited)
+			fmt.Fprint(buf, ":")
+			writeHashValue(buf, iter.Value(), visited)
+			entries = append(entries, buf.String())
+		}
+		sort.Strings(entries)
+
+		fmt.Fprintf(w, "map%v", entries)
+	default:
+		fmt.Fprintf(w, "%v", v)
+	}
+}
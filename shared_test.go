@@ -0,0 +1,129 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestRequestHash(t *testing.T) {
+	newRequest := func() GenericContainerRequest {
+		return GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{nginxDefaultPort},
+				Env:          map[string]string{"A": "1", "B": "2", "C": "3"},
+				WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+			},
+		}
+	}
+
+	t.Run("identical requests", func(t *testing.T) {
+		require.Equal(t, requestHash(newRequest()), requestHash(newRequest()))
+	})
+
+	t.Run("different env", func(t *testing.T) {
+		req := newRequest()
+		req.Env["A"] = "2"
+
+		require.NotEqual(t, requestHash(newRequest()), requestHash(req))
+	})
+
+	t.Run("different wait strategy", func(t *testing.T) {
+		req := newRequest()
+		req.WaitingFor = wait.ForLog("ready")
+
+		require.NotEqual(t, requestHash(newRequest()), requestHash(req))
+	})
+
+	t.Run("different provider", func(t *testing.T) {
+		req := newRequest()
+		req.ProviderType = ProviderPodman
+
+		require.NotEqual(t, requestHash(newRequest()), requestHash(req))
+	})
+
+	t.Run("functions are ignored", func(t *testing.T) {
+		req := newRequest()
+		req.LifecycleHooks = []ContainerLifecycleHooks{
+			{
+				PostStarts: []ContainerHook{
+					func(ctx context.Context, c Container) error {
+						return nil
+					},
+				},
+			},
+		}
+
+		other := newRequest()
+		other.LifecycleHooks = []ContainerLifecycleHooks{
+			{
+				PostStarts: []ContainerHook{
+					func(ctx context.Context, c Container) error {
+						return ctx.Err()
+					},
+				},
+			},
+		}
+
+		require.Equal(t, requestHash(other), requestHash(req))
+	})
+
+	t.Run("startup options are ignored", func(t *testing.T) {
+		req := newRequest()
+		req.Started = true
+		req.Logger = TestLogger(t)
+
+		require.Equal(t, requestHash(newRequest()), requestHash(req))
+	})
+}
+
+func TestSharedContainer(t *testing.T) {
+	ctx := context.Background()
+
+	// sharedContainer {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+	}
+
+	var ids []string
+	t.Run("first", func(t *testing.T) {
+		c := SharedContainerForTest(ctx, t, req)
+		ids = append(ids, c.GetContainerID())
+	})
+
+	first, release, err := SharedContainer(ctx, req)
+	require.NoError(t, err)
+
+	t.Run("second", func(t *testing.T) {
+		c := SharedContainerForTest(ctx, t, req)
+		ids = append(ids, c.GetContainerID())
+	})
+	// }
+
+	require.Len(t, ids, 2)
+
+	// the container of the first test was terminated with its last reference
+	require.NotEqual(t, ids[0], first.GetContainerID())
+
+	// the container of the second test is the one shared with the test
+	require.Equal(t, first.GetContainerID(), ids[1])
+
+	state, err := first.State(ctx)
+	require.NoError(t, err)
+	require.True(t, state.Running)
+
+	require.NoError(t, release(ctx))
+	// releasing again is a no-op
+	require.NoError(t, release(ctx))
+
+	_, err = first.State(ctx)
+	require.Error(t, err, "the container should be terminated after the last release")
+}
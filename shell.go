@@ -0,0 +1,84 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// ErrNoShell is the error of running a shell script in a container without a POSIX shell, e.g. a distroless one.
+var ErrNoShell = errors.New("no shell found in the container")
+
+// shellCandidates are the POSIX shells looked for in the containers, in order, e.g. the one of the busybox
+// debug images of distroless, which is not in the default path.
+var shellCandidates = []string{"sh", "bash", "/busybox/sh"}
+
+// safeShellArg matches the arguments that don't need to be quoted in a POSIX shell.
+var safeShellArg = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// ShellQuote quotes the argument with single quotes, if needed, so that it's passed as is to a command of a script
+// run by a POSIX shell, e.g. a value with spaces or quotes interpolated in the script of ExecShell.
+func ShellQuote(arg string) string {
+	if safeShellArg.MatchString(arg) {
+		return arg
+	}
+
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// ExecShell runs the script with the POSIX shell of the container, detected once, passing it as a single argument
+// of the shell, so that its pipes, redirections and quotes are interpreted by the shell as written, without the
+// "sh -c" boilerplate. It returns the exit code of the script and its output, with the standard output and the
+// standard error in the order they were written. The options of the exec package customize how the script is run,
+// e.g. its user or environment. It returns ErrNoShell if the container has no shell.
+func (c *DockerContainer) ExecShell(ctx context.Context, script string, options ...tcexec.ProcessOption) (int, string, error) {
+	shell, err := c.detectShell(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+
+	// the output is demultiplexed last, once the options set how the script is run
+	options = append(options, tcexec.WithDemux(nil, nil))
+
+	exitCode, r, err := c.Exec(ctx, []string{shell, "-c", script}, options...)
+	if err != nil {
+		return 0, "", err
+	}
+
+	output, err := io.ReadAll(r)
+	if err != nil {
+		return exitCode, "", fmt.Errorf("read the output of the script: %w", err)
+	}
+
+	return exitCode, string(output), nil
+}
+
+// detectShell returns the first of the shell candidates which runs in the container, caching it.
+func (c *DockerContainer) detectShell(ctx context.Context) (string, error) {
+	c.shellMutex.Lock()
+	defer c.shellMutex.Unlock()
+
+	if c.shell != "" {
+		return c.shell, nil
+	}
+
+	for _, candidate := range shellCandidates {
+		// a missing shell fails to start, with the 126 or 127 exit code depending on the runtime
+		exitCode, _, err := c.exec(ctx, []string{candidate, "-c", "exit 0"}, tcexec.WithDemux(nil, nil))
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		if err == nil && exitCode == 0 {
+			c.shell = candidate
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: tried %s", ErrNoShell, strings.Join(shellCandidates, ", "))
+}
@@ -0,0 +1,97 @@
+package testcontainers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// shellClient is a mock implementation of client.APIClient running the commands with the given shells only,
+// the other ones exiting with the 127 exit code, as a missing executable does.
+type shellClient struct {
+	client.APIClient
+
+	shells   []string
+	commands [][]string
+}
+
+func (m *shellClient) ContainerExecCreate(_ context.Context, _ string, config types.ExecConfig) (types.IDResponse, error) {
+	m.commands = append(m.commands, config.Cmd)
+	return types.IDResponse{ID: strings.Join(config.Cmd, "\x00")}, nil
+}
+
+func (m *shellClient) ContainerExecAttach(_ context.Context, execID string, _ types.ExecStartCheck) (types.HijackedResponse, error) {
+	var buf bytes.Buffer
+	if cmd := strings.Split(execID, "\x00"); m.found(cmd[0]) && cmd[2] != "exit 0" {
+		_, _ = stdcopy.NewStdWriter(&buf, stdcopy.Stdout).Write([]byte("hello\n"))
+		_, _ = stdcopy.NewStdWriter(&buf, stdcopy.Stderr).Write([]byte("warning\n"))
+	}
+
+	return types.HijackedResponse{Reader: bufio.NewReader(&buf)}, nil
+}
+
+func (m *shellClient) ContainerExecInspect(_ context.Context, execID string) (types.ContainerExecInspect, error) {
+	if !m.found(strings.Split(execID, "\x00")[0]) {
+		return types.ContainerExecInspect{ExitCode: 127}, nil
+	}
+
+	return types.ContainerExecInspect{}, nil
+}
+
+func (m *shellClient) found(shell string) bool {
+	for _, s := range m.shells {
+		if s == shell {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestExecShell(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("detected shell", func(t *testing.T) {
+		cli := &shellClient{shells: []string{"bash"}}
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}}
+
+		exitCode, output, err := c.ExecShell(ctx, `echo "hello" && echo warning >&2`, tcexec.WithUser("nobody"))
+		require.NoError(t, err)
+		require.Zero(t, exitCode)
+		require.Equal(t, "hello\nwarning\n", output)
+
+		// the shell is detected once
+		_, _, err = c.ExecShell(ctx, "true")
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{"sh", "-c", "exit 0"},
+			{"bash", "-c", "exit 0"},
+			{"bash", "-c", `echo "hello" && echo warning >&2`},
+			{"bash", "-c", "true"},
+		}, cli.commands)
+	})
+
+	t.Run("no shell", func(t *testing.T) {
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: &shellClient{}}}
+
+		_, _, err := c.ExecShell(ctx, "true")
+		require.ErrorIs(t, err, ErrNoShell)
+		require.EqualError(t, err, "no shell found in the container: tried sh, bash, /busybox/sh")
+	})
+}
+
+func TestShellQuote(t *testing.T) {
+	require.Equal(t, "nginx:alpine", ShellQuote("nginx:alpine"))
+	require.Equal(t, "'daemon off;'", ShellQuote("daemon off;"))
+	require.Equal(t, `'it'\''s'`, ShellQuote("it's"))
+	require.Equal(t, "''", ShellQuote(""))
+}
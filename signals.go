@@ -0,0 +1,120 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// signalCleanupTimeout is the time to remove the resources of the session once the test process is interrupted.
+const signalCleanupTimeout = 30 * time.Second
+
+// HandleSignals installs a handler of the SIGINT and SIGTERM signals that, when the test process is interrupted,
+// e.g. with Ctrl-C during a local run, removes the containers, networks and volumes of the test session before exiting,
+// instead of leaving them running until Ryuk notices the process is gone, or forever if Ryuk is disabled.
+// Once they're removed, the signal is raised again, so the process exits as it would have without the handler.
+// It's meant to be called at the beginning of TestMain, and returns a function uninstalling the handler.
+func HandleSignals() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go handleSignals(signals, done, func(ctx context.Context) error {
+		return removeSessionResources(ctx, core.SessionID())
+	}, raiseSignal)
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// handleSignals waits for a signal, calling the cleanup function and then the exit function with it,
+// until the done channel is closed.
+func handleSignals(signals <-chan os.Signal, done <-chan struct{}, cleanup func(ctx context.Context) error, exit func(sig os.Signal)) {
+	select {
+	case sig := <-signals:
+		Logger.Printf("🛑 Received %s, removing the resources of the test session %s", sig, core.SessionID())
+
+		ctx, cancel := context.WithTimeout(context.Background(), signalCleanupTimeout)
+		if err := cleanup(ctx); err != nil {
+			Logger.Printf("Failed to remove the resources of the test session: %v", err)
+		}
+		cancel()
+
+		exit(sig)
+	case <-done:
+	}
+}
+
+// raiseSignal raises the signal again with its default behaviour, exiting the process.
+func raiseSignal(sig os.Signal) {
+	signal.Reset(sig)
+
+	if p, err := os.FindProcess(os.Getpid()); err == nil {
+		if err := p.Signal(sig); err == nil {
+			// give the signal the time to be delivered
+			time.Sleep(time.Second)
+		}
+	}
+
+	// e.g. on Windows, where the signals can't be sent
+	os.Exit(1)
+}
+
+// removeSessionResources removes the containers, networks and volumes with the label of the given session.
+func removeSessionResources(ctx context.Context, sessionID string) error {
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	sessionFilter := filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", core.LabelSessionID, sessionID)))
+
+	var errs []error
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: sessionFilter})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, c := range containers {
+		if err := cli.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true, RemoveVolumes: true}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// the networks and volumes are removed once their containers are gone
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: sessionFilter})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, n := range networks {
+		if err := cli.NetworkRemove(ctx, n.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: sessionFilter})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, v := range volumes.Volumes {
+		if err := cli.VolumeRemove(ctx, v.Name, true); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
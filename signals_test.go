@@ -0,0 +1,73 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleSignals(t *testing.T) {
+	t.Run("signal", func(t *testing.T) {
+		signals := make(chan os.Signal, 1)
+		done := make(chan struct{})
+		exited := make(chan os.Signal, 1)
+
+		var cleanedUp bool
+		go handleSignals(signals, done, func(ctx context.Context) error {
+			_, ok := ctx.Deadline()
+			require.True(t, ok, "the cleanup must have a deadline")
+
+			cleanedUp = true
+			return errors.New("network in use")
+		}, func(sig os.Signal) {
+			exited <- sig
+		})
+
+		signals <- syscall.SIGTERM
+
+		select {
+		case sig := <-exited:
+			require.Equal(t, syscall.SIGTERM, sig)
+			// the process exits even if the cleanup fails
+			require.True(t, cleanedUp)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the handler did not exit")
+		}
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		signals := make(chan os.Signal, 1)
+		done := make(chan struct{})
+		returned := make(chan struct{})
+
+		go func() {
+			handleSignals(signals, done, func(context.Context) error {
+				t.Error("the cleanup must not be called")
+				return nil
+			}, func(os.Signal) {
+				t.Error("the exit must not be called")
+			})
+			close(returned)
+		}()
+
+		close(done)
+
+		select {
+		case <-returned:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the handler did not return")
+		}
+	})
+
+	t.Run("install and uninstall", func(t *testing.T) {
+		// handleSignals {
+		stop := HandleSignals()
+		defer stop()
+		// }
+	})
+}
@@ -0,0 +1,200 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+const snapshotRepository = "testcontainers/snapshot"
+
+// snapshotNameRegex matches the names of the snapshots, which are part of the tags of their images.
+var snapshotNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,63}$`)
+
+// Snapshot saves the current state of the filesystem of the container under the given name, so that it can be restored
+// with RestoreSnapshot, e.g. to reset a seeded database between tests far faster than seeding it again.
+// Taking a snapshot with the name of an existing one replaces it. The snapshot is an image created with Commit,
+// so the data stored in volumes, including the anonymous volumes declared by the image, is not part of it.
+// The images of the snapshots are removed when the container is terminated, or by Ryuk when the test session ends.
+func (c *DockerContainer) Snapshot(ctx context.Context, name string) error {
+	if !snapshotNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name %q: it must be made of letters, digits, '_', '.' and '-'", name)
+	}
+
+	shortID := c.ID
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+
+	// the labels of the session let Ryuk remove the image, even if the container was created by another session
+	image, err := c.commit(ctx, snapshotRepository, shortID+"-"+name, core.DefaultLabels(core.SessionID()))
+	if err != nil {
+		return fmt.Errorf("snapshot %s: %w", name, err)
+	}
+
+	c.snapshotsMutex.Lock()
+	defer c.snapshotsMutex.Unlock()
+
+	if c.snapshots == nil {
+		c.snapshots = make(map[string]string)
+	}
+	c.snapshots[name] = image
+
+	return nil
+}
+
+// RestoreSnapshot replaces the container with a new one created from the snapshot with the given name,
+// with the same configuration, and starts it, running the lifecycle hooks of the container, so it returns
+// once the wait strategy of the container is satisfied again. The container keeps its name, its networks
+// and aliases, and the host ports its exposed ports are mapped to, but it gets a new ID and new IP addresses.
+// Please note that the containers sharing the network namespace of the replaced container, e.g. a packet capture,
+// are not moved to the new one.
+func (c *DockerContainer) RestoreSnapshot(ctx context.Context, name string) error {
+	c.snapshotsMutex.Lock()
+	image, ok := c.snapshots[name]
+	c.snapshotsMutex.Unlock()
+	if !ok {
+		return fmt.Errorf("snapshot %s of container %s not found", name, c.ID)
+	}
+
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the state of the container is discarded, so there is no need to stop it gracefully
+	var timeout time.Duration
+	if inspect.State.Running {
+		if err := c.Stop(ctx, &timeout); err != nil {
+			return fmt.Errorf("stop container %s: %w", c.ID, err)
+		}
+	}
+
+	defer c.provider.Close()
+
+	err = c.provider.client.ContainerRemove(ctx, c.ID, container.RemoveOptions{RemoveVolumes: true, Force: true})
+	if err != nil {
+		return fmt.Errorf("remove container %s: %w", c.ID, err)
+	}
+
+	config := *inspect.Config
+	config.Image = image
+
+	hostConfig := *inspect.HostConfig
+	hostConfig.PortBindings = snapshotPortBindings(inspect.NetworkSettings.Ports, hostConfig.PortBindings)
+
+	primary, others := snapshotEndpoints(c.ID, hostConfig.NetworkMode, inspect.NetworkSettings.Networks)
+
+	resp, err := c.provider.client.ContainerCreate(ctx, &config, &hostConfig, &network.NetworkingConfig{EndpointsConfig: primary}, nil, inspect.Name)
+	if err != nil {
+		return fmt.Errorf("restore snapshot %s: %w", name, err)
+	}
+
+	c.ID = resp.ID
+	c.invalidateInfo()
+
+	for networkName, settings := range others {
+		if err := c.provider.client.NetworkConnect(ctx, networkName, c.ID, settings); err != nil {
+			return errors.Join(
+				fmt.Errorf("connect container %s to network %s: %w", c.ID, networkName, err),
+				c.Terminate(ctx),
+			)
+		}
+	}
+
+	if err := c.Start(ctx); err != nil {
+		return fmt.Errorf("start container %s: %w", c.ID, err)
+	}
+
+	return nil
+}
+
+// removeSnapshots removes the images of the snapshots of the container, which must be removed first.
+func (c *DockerContainer) removeSnapshots(ctx context.Context) error {
+	c.snapshotsMutex.Lock()
+	defer c.snapshotsMutex.Unlock()
+
+	var errs []error
+	for name, image := range c.snapshots {
+		_, err := c.provider.client.ImageRemove(ctx, image, types.ImageRemoveOptions{
+			Force:         true,
+			PruneChildren: true,
+		})
+		if err != nil && !errdefs.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("remove snapshot %s: %w", name, err))
+			continue
+		}
+
+		delete(c.snapshots, name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// snapshotPortBindings returns the port bindings of the container restored from a snapshot, binding its exposed ports
+// to the host ports they were mapped to, so that the clients connected to the replaced container can reconnect.
+func snapshotPortBindings(mapped nat.PortMap, requested nat.PortMap) nat.PortMap {
+	bindings := nat.PortMap{}
+
+	for port, portBindings := range requested {
+		bindings[port] = portBindings
+	}
+
+	for port, portBindings := range mapped {
+		if len(portBindings) > 0 {
+			bindings[port] = portBindings
+		}
+	}
+
+	return bindings
+}
+
+// snapshotEndpoints returns the endpoints of the container restored from a snapshot: the endpoint of the primary network,
+// which is set when the container is created, and the endpoints of the other networks, which it must be connected to afterwards.
+// The aliases Docker added for the ID of the replaced container are removed.
+func snapshotEndpoints(containerID string, networkMode container.NetworkMode, networks map[string]*network.EndpointSettings) (map[string]*network.EndpointSettings, map[string]*network.EndpointSettings) {
+	primary := map[string]*network.EndpointSettings{}
+	others := map[string]*network.EndpointSettings{}
+
+	if networkMode.IsHost() || networkMode.IsNone() || networkMode.IsContainer() {
+		// the container has no endpoint of its own
+		return primary, others
+	}
+
+	shortID := containerID
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+
+	for networkName, settings := range networks {
+		aliases := []string{}
+		for _, alias := range settings.Aliases {
+			if alias != shortID && alias != containerID {
+				aliases = append(aliases, alias)
+			}
+		}
+
+		endpoint := &network.EndpointSettings{
+			Aliases:   aliases,
+			NetworkID: settings.NetworkID,
+		}
+
+		if networkName == networkMode.NetworkName() || (networkMode.IsDefault() && networkName == Bridge) {
+			primary[networkName] = endpoint
+		} else {
+			others[networkName] = endpoint
+		}
+	}
+
+	return primary, others
+}
@@ -0,0 +1,240 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestSnapshotPortBindings(t *testing.T) {
+	requested := nat.PortMap{
+		"80/tcp":   []nat.PortBinding{{HostPort: ""}},
+		"8080/tcp": []nat.PortBinding{{HostPort: "8080"}},
+	}
+	mapped := nat.PortMap{
+		"80/tcp":   []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "32768"}},
+		"8080/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "8080"}},
+		"9090/tcp": nil,
+	}
+
+	bindings := snapshotPortBindings(mapped, requested)
+
+	assert.Equal(t, nat.PortMap{
+		"80/tcp":   []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "32768"}},
+		"8080/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "8080"}},
+	}, bindings)
+}
+
+func TestSnapshotEndpoints(t *testing.T) {
+	containerID := "0123456789abcdef0123456789abcdef"
+
+	networks := map[string]*network.EndpointSettings{
+		"backend":  {NetworkID: "backend-id", Aliases: []string{"db", "0123456789ab"}},
+		"frontend": {NetworkID: "frontend-id", Aliases: []string{"api"}},
+	}
+
+	t.Run("user-defined network", func(t *testing.T) {
+		primary, others := snapshotEndpoints(containerID, "backend", networks)
+
+		assert.Equal(t, map[string]*network.EndpointSettings{
+			"backend": {NetworkID: "backend-id", Aliases: []string{"db"}},
+		}, primary)
+		assert.Equal(t, map[string]*network.EndpointSettings{
+			"frontend": {NetworkID: "frontend-id", Aliases: []string{"api"}},
+		}, others)
+	})
+
+	t.Run("default network", func(t *testing.T) {
+		primary, others := snapshotEndpoints(containerID, "default", map[string]*network.EndpointSettings{
+			Bridge: {NetworkID: "bridge-id"},
+		})
+
+		assert.Contains(t, primary, Bridge)
+		assert.Empty(t, others)
+	})
+
+	t.Run("host network", func(t *testing.T) {
+		primary, others := snapshotEndpoints(containerID, container.NetworkMode("host"), networks)
+
+		assert.Empty(t, primary)
+		assert.Empty(t, others)
+	})
+}
+
+// commitClient is a mock implementation of client.APIClient, which commits containers without a Docker daemon,
+// recording the options of the commits and the removed images.
+type commitClient struct {
+	client.APIClient
+
+	mutex   sync.Mutex
+	commits []container.CommitOptions
+	removed []string
+}
+
+func (m *commitClient) ContainerCommit(_ context.Context, _ string, options container.CommitOptions) (types.IDResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.commits = append(m.commits, options)
+
+	return types.IDResponse{ID: "sha256:snapshot"}, nil
+}
+
+func (m *commitClient) ContainerRemove(_ context.Context, _ string, _ container.RemoveOptions) error {
+	return nil
+}
+
+func (m *commitClient) ImageRemove(_ context.Context, image string, _ types.ImageRemoveOptions) ([]types.ImageDeleteResponseItem, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.removed = append(m.removed, image)
+
+	return []types.ImageDeleteResponseItem{{Untagged: image}}, nil
+}
+
+func (m *commitClient) Close() error {
+	return nil
+}
+
+func TestSnapshotConcurrently(t *testing.T) {
+	ctx := context.Background()
+
+	c := &DockerContainer{
+		ID:       "0123456789abcdef0123456789abcdef",
+		provider: &DockerProvider{client: &commitClient{}},
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, c.Snapshot(ctx, fmt.Sprintf("seeded-%d", i)))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, c.snapshots, 10)
+	assert.Equal(t, snapshotRepository+":0123456789ab-seeded-0", c.snapshots["seeded-0"])
+}
+
+func TestSnapshotRemovedOnTerminate(t *testing.T) {
+	ctx := context.Background()
+
+	cli := &commitClient{}
+	c := &DockerContainer{
+		ID:       "0123456789abcdef0123456789abcdef",
+		provider: &DockerProvider{client: cli},
+	}
+
+	require.NoError(t, c.Snapshot(ctx, "seeded"))
+
+	// the image is labeled for Ryuk to remove it when the test session ends
+	require.Len(t, cli.commits, 1)
+	require.NotNil(t, cli.commits[0].Config)
+	assert.Equal(t, core.SessionID(), cli.commits[0].Config.Labels[core.LabelSessionID])
+	assert.Equal(t, "true", cli.commits[0].Config.Labels[core.LabelBase])
+
+	require.NoError(t, c.Terminate(ctx))
+
+	assert.Equal(t, []string{snapshotRepository + ":0123456789ab-seeded"}, cli.removed)
+	assert.Empty(t, c.snapshots)
+}
+
+func TestContainerSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	// Snapshot and RestoreSnapshot are not part of the Container interface
+	nginx := c.(*DockerContainer)
+
+	port, err := nginx.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	err = nginx.CopyToContainer(ctx, []byte("seeded"), "/data/state.txt", 0o644)
+	require.NoError(t, err)
+
+	// snapshotContainer {
+	err = nginx.Snapshot(ctx, "seeded")
+	// }
+	require.NoError(t, err)
+
+	err = nginx.CopyToContainer(ctx, []byte("modified"), "/data/state.txt", 0o644)
+	require.NoError(t, err)
+
+	// restoreSnapshot {
+	err = nginx.RestoreSnapshot(ctx, "seeded")
+	// }
+	require.NoError(t, err)
+
+	bs, err := nginx.ReadFile(ctx, "/data/state.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "seeded", string(bs))
+
+	restoredPort, err := nginx.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	assert.Equal(t, port, restoredPort)
+
+	err = nginx.RestoreSnapshot(ctx, "unknown")
+	require.Error(t, err)
+
+	err = nginx.Snapshot(ctx, "invalid name")
+	require.Error(t, err)
+}
+
+func TestContainerSnapshot_removedOnTerminate(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err, "get docker provider should not fail")
+	defer func() { _ = provider.Close() }()
+	cli := provider.Client()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	require.NoError(t, err, "create container should not fail")
+	defer func() { _ = c.Terminate(context.Background()) }()
+
+	nginx := c.(*DockerContainer)
+	require.NoError(t, nginx.Snapshot(ctx, "seeded"))
+	image := nginx.snapshots["seeded"]
+
+	inspect, _, err := cli.ImageInspectWithRaw(ctx, image)
+	require.NoError(t, err, "the snapshot image should exist")
+	assert.Equal(t, core.SessionID(), inspect.Config.Labels[core.LabelSessionID])
+
+	// Now, we terminate the container and check whether the snapshot image still exists.
+	require.NoError(t, c.Terminate(ctx), "terminate container should not fail")
+	_, _, err = cli.ImageInspectWithRaw(ctx, image)
+	require.Error(t, err, "the snapshot image should not exist anymore")
+}
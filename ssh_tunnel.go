@@ -0,0 +1,146 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHTransport is a transport tunneling the ports of the containers of a remote Docker daemon over an SSH connection
+// to the machine of the daemon, so that the ports are reachable from the tests even if they're not reachable through the network,
+// e.g. behind a firewall. The ports are dialed on the loopback interface of the SSH server, where the daemon publishes them.
+type SSHTransport struct {
+	client *ssh.Client
+}
+
+var _ Transport = (*SSHTransport)(nil)
+
+// NewSSHTransport connects to the SSH server at the given address, e.g. "docker.example.com:22", of the machine of the Docker daemon.
+func NewSSHTransport(ctx context.Context, address string, config *ssh.ClientConfig) (*SSHTransport, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("dial SSH server %s: %w", address, err)
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("connect to SSH server %s: %w", address, err)
+	}
+
+	return &SSHTransport{client: ssh.NewClient(c, chans, reqs)}, nil
+}
+
+// DialContext implements the Transport interface, connecting to the port of the address on the loopback interface of the SSH server.
+func (t *SSHTransport) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.client.DialContext(ctx, network, net.JoinHostPort("127.0.0.1", port))
+}
+
+// Close closes the SSH connection.
+func (t *SSHTransport) Close() error {
+	return t.client.Close()
+}
+
+var (
+	sshTunnelMu        sync.Mutex
+	sshTunnelTransport *SSHTransport
+)
+
+// setUpSSHTunnel sets the transport of the containers to the SSH server of the ssh.tunnel property of the configuration,
+// e.g. "user@docker.example.com:22", once per process. It authenticates with the keys of the SSH agent of the SSH_AUTH_SOCK
+// environment variable, and verifies the key of the server with the ~/.ssh/known_hosts file.
+func setUpSSHTunnel(ctx context.Context, target string) error {
+	if target == "" {
+		return nil
+	}
+
+	sshTunnelMu.Lock()
+	defer sshTunnelMu.Unlock()
+
+	if sshTunnelTransport != nil {
+		return nil
+	}
+
+	username, address, err := parseSSHTarget(target)
+	if err != nil {
+		return err
+	}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return errors.New("SSH_AUTH_SOCK is not set: the SSH tunnel authenticates with the keys of the SSH agent")
+	}
+
+	agentConn, err := net.Dial("unix", socket)
+	if err != nil {
+		return fmt.Errorf("connect to SSH agent: %w", err)
+	}
+	// the keys of the agent are only used during the handshake
+	defer agentConn.Close()
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+
+	hostKeyCallback, err := knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		return fmt.Errorf("read known hosts: %w", err)
+	}
+
+	t, err := NewSSHTransport(ctx, address, &ssh.ClientConfig{
+		User:            username,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(agentConn).Signers)},
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		return err
+	}
+
+	sshTunnelTransport = t
+	SetTransport(t)
+	Logger.Printf("🚇 Tunneling the ports of the containers through the SSH server %s", address)
+
+	return nil
+}
+
+// parseSSHTarget parses an SSH target with the [user@]host[:port] format, returning the user, which defaults to
+// the current user, and the address of the SSH server, whose port defaults to 22.
+func parseSSHTarget(target string) (string, string, error) {
+	username, host, found := strings.Cut(target, "@")
+	if !found {
+		host = username
+
+		current, err := user.Current()
+		if err != nil {
+			return "", "", fmt.Errorf("current user: %w", err)
+		}
+		username = current.Username
+	}
+
+	if host == "" {
+		return "", "", fmt.Errorf("invalid SSH target %q: missing host", target)
+	}
+
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+
+	return username, host, nil
+}
@@ -0,0 +1,81 @@
+package testcontainers
+
+import (
+	"context"
+	"os/user"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSSHTarget(t *testing.T) {
+	current, err := user.Current()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		target          string
+		expectedUser    string
+		expectedAddress string
+		expectedErr     bool
+	}{
+		{
+			name:            "user, host and port",
+			target:          "docker@docker.example.com:2222",
+			expectedUser:    "docker",
+			expectedAddress: "docker.example.com:2222",
+		},
+		{
+			name:            "default port",
+			target:          "docker@docker.example.com",
+			expectedUser:    "docker",
+			expectedAddress: "docker.example.com:22",
+		},
+		{
+			name:            "default user",
+			target:          "docker.example.com:2222",
+			expectedUser:    current.Username,
+			expectedAddress: "docker.example.com:2222",
+		},
+		{
+			name:            "IPv6 host",
+			target:          "docker@[::1]",
+			expectedUser:    "docker",
+			expectedAddress: "[::1]:22",
+		},
+		{
+			name:        "missing host",
+			target:      "docker@",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			username, address, err := parseSSHTarget(tt.target)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedUser, username)
+			require.Equal(t, tt.expectedAddress, address)
+		})
+	}
+}
+
+func TestSetUpSSHTunnel(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		require.NoError(t, setUpSSHTunnel(context.Background(), ""))
+		require.Nil(t, currentTransport())
+	})
+
+	t.Run("no SSH agent", func(t *testing.T) {
+		t.Setenv("SSH_AUTH_SOCK", "")
+
+		err := setUpSSHTunnel(context.Background(), "docker@docker.example.com")
+		require.ErrorContains(t, err, "SSH_AUTH_SOCK is not set")
+		require.Nil(t, currentTransport())
+	})
+}
@@ -0,0 +1,136 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ContainerStats is a sample of the resource usage of a container, parsed from the stats returned by the Docker API.
+type ContainerStats struct {
+	// Read is the time the sample was taken.
+	Read time.Time
+	// CPUPercent is the CPU usage since the previous sample, where 100% is one fully used CPU,
+	// so it can be greater than 100% if the container uses several CPUs.
+	CPUPercent float64
+	// MemoryUsage is the memory used by the container, in bytes, excluding the page cache that can be reclaimed.
+	MemoryUsage uint64
+	// MemoryLimit is the memory limit of the container, in bytes, or the memory of the host if it has no limit.
+	MemoryLimit uint64
+	// NetworkRxBytes is the number of bytes received on all the network interfaces of the container.
+	NetworkRxBytes uint64
+	// NetworkTxBytes is the number of bytes sent on all the network interfaces of the container.
+	NetworkTxBytes uint64
+	// BlockReadBytes is the number of bytes read from the block devices.
+	BlockReadBytes uint64
+	// BlockWriteBytes is the number of bytes written to the block devices.
+	BlockWriteBytes uint64
+	// PIDs is the number of processes and threads of the container.
+	PIDs uint64
+}
+
+// newContainerStats parses the stats returned by the Docker API, computing the same values as the "docker stats" command.
+func newContainerStats(s types.StatsJSON) ContainerStats {
+	stats := ContainerStats{
+		Read:        s.Read,
+		MemoryLimit: s.MemoryStats.Limit,
+		PIDs:        s.PidsStats.Current,
+	}
+
+	cpuDelta := float64(s.CPUStats.CPUUsage.TotalUsage) - float64(s.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(s.CPUStats.SystemUsage) - float64(s.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(s.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(s.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		stats.CPUPercent = cpuDelta / systemDelta * onlineCPUs * 100
+	}
+
+	// the page cache is reported as "total_inactive_file" in cgroup v1, and as "inactive_file" in cgroup v2
+	cache, cgroupV1 := s.MemoryStats.Stats["total_inactive_file"]
+	if !cgroupV1 {
+		cache = s.MemoryStats.Stats["inactive_file"]
+	}
+	if s.MemoryStats.Usage > cache {
+		stats.MemoryUsage = s.MemoryStats.Usage - cache
+	}
+
+	for _, nw := range s.Networks {
+		stats.NetworkRxBytes += nw.RxBytes
+		stats.NetworkTxBytes += nw.TxBytes
+	}
+
+	for _, entry := range s.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			stats.BlockReadBytes += entry.Value
+		case "write":
+			stats.BlockWriteBytes += entry.Value
+		}
+	}
+
+	return stats
+}
+
+// Stats returns a sample of the resource usage of the container. It takes around a second,
+// as the Docker daemon needs two samples to compute the CPU usage.
+func (c *DockerContainer) Stats(ctx context.Context) (*ContainerStats, error) {
+	defer c.provider.Close()
+	resp, err := c.provider.client.ContainerStats(ctx, c.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("get stats of container %s: %w", c.ID, err)
+	}
+	defer resp.Body.Close()
+
+	var s types.StatsJSON
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return nil, fmt.Errorf("decode stats of container %s: %w", c.ID, err)
+	}
+
+	stats := newContainerStats(s)
+	c.memory.record(s, stats)
+
+	return &stats, nil
+}
+
+// StatsStream returns a channel receiving a sample of the resource usage of the container every second,
+// e.g. to detect leaks over the duration of a test. The channel is closed when the context is done,
+// or when the container is stopped.
+func (c *DockerContainer) StatsStream(ctx context.Context) (<-chan ContainerStats, error) {
+	resp, err := c.provider.client.ContainerStats(ctx, c.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("get stats of container %s: %w", c.ID, err)
+	}
+
+	ch := make(chan ContainerStats)
+
+	go func() {
+		defer close(ch)
+		defer resp.Body.Close()
+
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var s types.StatsJSON
+			if err := decoder.Decode(&s); err != nil {
+				// the stream has been closed
+				return
+			}
+
+			stats := newContainerStats(s)
+			c.memory.record(s, stats)
+
+			select {
+			case ch <- stats:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,117 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContainerStats(t *testing.T) {
+	read := time.Now()
+
+	s := types.StatsJSON{
+		Stats: types.Stats{
+			Read: read,
+			CPUStats: types.CPUStats{
+				CPUUsage:    types.CPUUsage{TotalUsage: 300},
+				SystemUsage: 2000,
+				OnlineCPUs:  2,
+			},
+			PreCPUStats: types.CPUStats{
+				CPUUsage:    types.CPUUsage{TotalUsage: 100},
+				SystemUsage: 1000,
+			},
+			MemoryStats: types.MemoryStats{
+				Usage: 1000,
+				Limit: 4000,
+				Stats: map[string]uint64{"inactive_file": 200},
+			},
+			BlkioStats: types.BlkioStats{
+				IoServiceBytesRecursive: []types.BlkioStatEntry{
+					{Op: "read", Value: 10},
+					{Op: "write", Value: 20},
+					{Op: "Read", Value: 1},
+					{Op: "Total", Value: 31},
+				},
+			},
+			PidsStats: types.PidsStats{Current: 3},
+		},
+		Networks: map[string]types.NetworkStats{
+			"eth0": {RxBytes: 100, TxBytes: 50},
+			"eth1": {RxBytes: 10, TxBytes: 5},
+		},
+	}
+
+	stats := newContainerStats(s)
+
+	assert.Equal(t, read, stats.Read)
+	// (300 - 100) / (2000 - 1000) * 2 CPUs
+	assert.InDelta(t, 40.0, stats.CPUPercent, 0.001)
+	assert.Equal(t, uint64(800), stats.MemoryUsage)
+	assert.Equal(t, uint64(4000), stats.MemoryLimit)
+	assert.Equal(t, uint64(110), stats.NetworkRxBytes)
+	assert.Equal(t, uint64(55), stats.NetworkTxBytes)
+	assert.Equal(t, uint64(11), stats.BlockReadBytes)
+	assert.Equal(t, uint64(20), stats.BlockWriteBytes)
+	assert.Equal(t, uint64(3), stats.PIDs)
+}
+
+func TestNewContainerStats_cgroupV1(t *testing.T) {
+	s := types.StatsJSON{
+		Stats: types.Stats{
+			MemoryStats: types.MemoryStats{
+				Usage: 1000,
+				Stats: map[string]uint64{"total_inactive_file": 300, "inactive_file": 100},
+			},
+		},
+	}
+
+	stats := newContainerStats(s)
+
+	assert.Equal(t, uint64(700), stats.MemoryUsage)
+	// there is no previous sample
+	assert.Zero(t, stats.CPUPercent)
+}
+
+func TestContainerStats(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginx)
+
+	// containerStats {
+	// Stats and StatsStream are not part of the Container interface
+	stats, err := nginx.(*DockerContainer).Stats(ctx)
+	// }
+	require.NoError(t, err)
+	assert.NotZero(t, stats.MemoryUsage)
+	assert.NotZero(t, stats.PIDs)
+
+	// containerStatsStream {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	samples, err := nginx.(*DockerContainer).StatsStream(streamCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		sample := <-samples
+		if sample.MemoryUsage > 512*1024*1024 {
+			t.Fatalf("nginx is using too much memory: %d bytes", sample.MemoryUsage)
+		}
+	}
+	// }
+}
@@ -0,0 +1,256 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// defaultSyncInterval is the interval between the synchronizations of a directory, unless set with WithSyncInterval.
+const defaultSyncInterval = time.Second
+
+// syncDirOptions are the options of the synchronization of a directory.
+type syncDirOptions struct {
+	interval time.Duration
+}
+
+// SyncDirOption is an option of SyncDir.
+type SyncDirOption func(*syncDirOptions)
+
+// WithSyncInterval sets the interval between the synchronizations of the directory, one second by default.
+// The directory is only synchronized on demand, with the Sync method, if the interval is zero.
+func WithSyncInterval(interval time.Duration) SyncDirOption {
+	return func(o *syncDirOptions) {
+		o.interval = interval
+	}
+}
+
+// syncedEntry is the state of an entry of a synchronized directory, when it was last copied.
+type syncedEntry struct {
+	modTime  time.Time
+	size     int64
+	mode     int64
+	linkname string
+}
+
+// DirSync copies the files of a directory of a container to a directory of the host while the container runs,
+// as they are created or changed. It's returned by SyncDir.
+type DirSync struct {
+	container     *DockerContainer
+	containerPath string
+	hostPath      string
+
+	// mutex serializes the synchronizations, periodic and on demand.
+	mutex  sync.Mutex
+	synced map[string]syncedEntry
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// SyncDir copies the files of a directory of the container to a directory of the host, which is created if it does
+// not exist, and keeps copying them as they are created or changed, every second unless set with WithSyncInterval,
+// until Stop is called or the context is done, e.g. to inspect the logs and the artifacts of a long-running soak test
+// while it runs. The files are copied once before it returns, and can be copied on demand with the Sync method.
+//
+// The changed files are detected by their modification time, size and mode, and replaced atomically on the host,
+// so that they are never read half written. The files removed from the container are kept on the host.
+// The directory of the container may not exist yet, e.g. until the service writes its first report:
+// it's synchronized once it's created.
+func (c *DockerContainer) SyncDir(ctx context.Context, containerPath string, hostPath string, opts ...SyncDirOption) (*DirSync, error) {
+	o := syncDirOptions{interval: defaultSyncInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := os.MkdirAll(hostPath, 0o755); err != nil {
+		return nil, fmt.Errorf("create directory %s: %w", hostPath, err)
+	}
+
+	s := &DirSync{
+		container:     c,
+		containerPath: containerPath,
+		hostPath:      hostPath,
+		synced:        map[string]syncedEntry{},
+		done:          make(chan struct{}),
+	}
+
+	if err := s.Sync(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	if o.interval <= 0 {
+		close(s.done)
+		return s, nil
+	}
+
+	go s.run(ctx, o.interval)
+
+	return s, nil
+}
+
+// run synchronizes the directory at the given interval until the context is done, logging the errors
+// once until the synchronization succeeds again, as the next synchronizations may fail the same way.
+func (s *DirSync) run(ctx context.Context, interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var failed string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.Sync(ctx)
+			switch {
+			case err == nil:
+				failed = ""
+			case ctx.Err() == nil && err.Error() != failed:
+				failed = err.Error()
+				logf(ctx, s.container.logger, slog.LevelWarn, containerAttrs(s.container, "sync"),
+					"Failed to synchronize the directory %s of the container: %s", s.containerPath, err)
+			}
+		}
+	}
+}
+
+// Sync copies the files of the directory of the container created or changed since the last synchronization
+// to the directory of the host, e.g. right before asserting them.
+func (s *DirSync) Sync(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r, stat, err := s.container.provider.client.CopyFromContainer(ctx, s.container.ID, s.containerPath)
+	if errdefs.IsNotFound(err) {
+		// the directory is not created yet
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("synchronize directory %s: %w", s.containerPath, err)
+	}
+	defer s.container.provider.Close()
+	defer r.Close()
+
+	if !stat.Mode.IsDir() {
+		return fmt.Errorf("path %s is not a directory", s.containerPath)
+	}
+
+	if err := s.extract(r); err != nil {
+		return fmt.Errorf("synchronize directory %s: %w", s.containerPath, err)
+	}
+
+	return nil
+}
+
+// extract extracts the entries of the tar stream of the directory which changed since they were last copied.
+func (s *DirSync) extract(r io.Reader) error {
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading tar file: %w", err)
+		}
+
+		name := copiedDirEntry(header.Name)
+		entry := syncedEntry{modTime: header.ModTime, size: header.Size, mode: header.Mode, linkname: header.Linkname}
+		if synced, ok := s.synced[name]; ok && synced == entry {
+			continue
+		}
+
+		target := filepath.Join(s.hostPath, name)
+		if !isWithinDir(s.hostPath, target) {
+			return fmt.Errorf("invalid path in tar file: %s", header.Name)
+		}
+
+		// a symlink synchronized before is replaced, but none of the parents of the target can be one
+		if err := checkNoSymlinkInPath(s.hostPath, filepath.Dir(target)); err != nil {
+			return fmt.Errorf("invalid path in tar file: %s: %w", header.Name, err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("error creating directory: %w", err)
+			}
+		case tar.TypeReg:
+			if err := replaceFile(target, tr, os.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) || !isWithinDir(s.hostPath, filepath.Join(filepath.Dir(target), header.Linkname)) {
+				return fmt.Errorf("invalid symlink in tar file: %s -> %s", header.Name, header.Linkname)
+			}
+
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("error replacing symlink: %w", err)
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return fmt.Errorf("error creating symlink: %w", err)
+			}
+		default:
+			continue
+		}
+
+		s.synced[name] = entry
+	}
+}
+
+// replaceFile writes the file atomically, writing a temporary file in the same directory and renaming it.
+func replaceFile(target string, r io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".sync-*")
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error extracting file: %w", err)
+	}
+
+	if err := os.Chmod(f.Name(), perm); err != nil {
+		return fmt.Errorf("error setting the mode of the file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), target); err != nil {
+		return fmt.Errorf("error replacing file: %w", err)
+	}
+
+	return nil
+}
+
+// Stop stops synchronizing the directory periodically, once the synchronization in progress is done,
+// and copies the files created or changed since the last synchronization, unless the context is done.
+// It must be called before the container is terminated, for its last files to be copied.
+func (s *DirSync) Stop(ctx context.Context) error {
+	s.cancel()
+	<-s.done
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return s.Sync(ctx)
+}
@@ -0,0 +1,154 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/require"
+)
+
+// syncClient is a mock implementation of client.APIClient, copying a directory of the container
+// with the given files, by path, or failing as a missing directory if there are none.
+type syncClient struct {
+	client.APIClient
+
+	mutex  sync.Mutex
+	files  map[string]syncFile
+	copies int
+}
+
+type syncFile struct {
+	content string
+	modTime time.Time
+}
+
+func (m *syncClient) CopyFromContainer(_ context.Context, _ string, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.files == nil {
+		return nil, types.ContainerPathStat{}, errdefs.NotFound(errors.New("Could not find the file " + srcPath))
+	}
+	m.copies++
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	_ = tw.WriteHeader(&tar.Header{Name: "reports/", Typeflag: tar.TypeDir, Mode: 0o755})
+	for _, name := range names {
+		f := m.files[name]
+		_ = tw.WriteHeader(&tar.Header{Name: "reports/" + name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(f.content)), ModTime: f.modTime})
+		_, _ = tw.Write([]byte(f.content))
+	}
+	_ = tw.Close()
+
+	return io.NopCloser(&buf), types.ContainerPathStat{Name: "reports", Mode: os.ModeDir | 0o755}, nil
+}
+
+func (m *syncClient) Close() error {
+	return nil
+}
+
+// update sets the content of the file, changing its modification time.
+func (m *syncClient) update(name string, content string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.files == nil {
+		m.files = map[string]syncFile{}
+	}
+	m.files[name] = syncFile{content: content, modTime: m.files[name].modTime.Add(time.Second)}
+}
+
+func TestSyncDir(t *testing.T) {
+	ctx := context.Background()
+
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	t.Run("on demand", func(t *testing.T) {
+		cli := &syncClient{}
+		cli.update("summary.txt", "1 passed")
+		cli.update("logs/app.log", "starting")
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}}
+		hostPath := filepath.Join(t.TempDir(), "reports")
+
+		s, err := c.SyncDir(ctx, "/reports", hostPath, WithSyncInterval(0))
+		require.NoError(t, err)
+		require.Equal(t, "1 passed", readFile(t, filepath.Join(hostPath, "summary.txt")))
+		require.Equal(t, "starting", readFile(t, filepath.Join(hostPath, "logs", "app.log")))
+
+		// the unchanged files are not copied again
+		require.NoError(t, os.WriteFile(filepath.Join(hostPath, "summary.txt"), []byte("edited on the host"), 0o644))
+		cli.update("logs/app.log", "starting\nstarted")
+		cli.update("results.xml", "<testsuite/>")
+
+		require.NoError(t, s.Sync(ctx))
+		require.Equal(t, "edited on the host", readFile(t, filepath.Join(hostPath, "summary.txt")))
+		require.Equal(t, "starting\nstarted", readFile(t, filepath.Join(hostPath, "logs", "app.log")))
+		require.Equal(t, "<testsuite/>", readFile(t, filepath.Join(hostPath, "results.xml")))
+
+		require.NoError(t, s.Stop(ctx))
+		require.Equal(t, 3, cli.copies)
+	})
+
+	t.Run("periodic", func(t *testing.T) {
+		// the directory is created later by the container
+		cli := &syncClient{}
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}}
+		hostPath := t.TempDir()
+
+		s, err := c.SyncDir(ctx, "/reports", hostPath, WithSyncInterval(10*time.Millisecond))
+		require.NoError(t, err)
+
+		cli.update("soak.log", "iteration 1")
+		require.Eventually(t, func() bool {
+			b, err := os.ReadFile(filepath.Join(hostPath, "soak.log"))
+			return err == nil && string(b) == "iteration 1"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, s.Stop(ctx))
+
+		// the files changed since the last synchronization are copied when it stops, but not after
+		cli.update("soak.log", "iteration 2")
+		require.NoError(t, s.Stop(ctx))
+		require.Equal(t, "iteration 2", readFile(t, filepath.Join(hostPath, "soak.log")))
+
+		cli.update("soak.log", "iteration 3")
+		time.Sleep(50 * time.Millisecond)
+		require.Equal(t, "iteration 2", readFile(t, filepath.Join(hostPath, "soak.log")))
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		cli := &syncClient{}
+		cli.update("../escaped.txt", "outside")
+		c := &DockerContainer{ID: "0123456789ab", provider: &DockerProvider{client: cli}}
+		hostPath := filepath.Join(t.TempDir(), "reports")
+
+		_, err := c.SyncDir(ctx, "/reports", hostPath)
+		require.ErrorContains(t, err, "invalid path in tar file: reports/../escaped.txt")
+		require.NoFileExists(t, filepath.Join(filepath.Dir(hostPath), "escaped.txt"))
+	})
+}
@@ -0,0 +1,102 @@
+package tcassert
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// pollInterval is the interval between two reads of the logs of a container.
+const pollInterval = 100 * time.Millisecond
+
+// TestingT is the subset of testing.TB used by the assertions, so that this package does not depend on the testing package.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertLogContains asserts that the logs of the container contain the given text within the timeout,
+// reading them again until they do, e.g. to wait for a message logged after an action of the test.
+// It returns whether the assertion succeeded, marking the test as failed otherwise.
+func AssertLogContains(t TestingT, c testcontainers.Container, substr string, timeout time.Duration) bool {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var logs string
+	var err error
+	for {
+		logs, err = readLogs(ctx, c)
+		if err == nil && strings.Contains(logs, substr) {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				t.Errorf("logs of container %s do not contain %q after %s: %s", c.GetContainerID(), substr, timeout, err)
+				return false
+			}
+
+			t.Errorf("logs of container %s do not contain %q after %s:\n%s", c.GetContainerID(), substr, timeout, logs)
+			return false
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// readLogs returns the logs of the container.
+func readLogs(ctx context.Context, c testcontainers.Container) (string, error) {
+	rc, err := c.Logs(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// AssertExec asserts that the command exits with the given exit code in the container, and that its standard output,
+// without its trailing newlines, matches the given regular expression, e.g. `^PONG$`, or `(?m)^ready$` for multi-line outputs.
+// An empty expression matches any output.
+// It returns whether the assertion succeeded, marking the test as failed otherwise.
+func AssertExec(t TestingT, c testcontainers.Container, cmd []string, wantExit int, wantStdoutRegexp string) bool {
+	t.Helper()
+
+	re, err := regexp.Compile(wantStdoutRegexp)
+	if err != nil {
+		t.Errorf("invalid regular expression %q: %s", wantStdoutRegexp, err)
+		return false
+	}
+
+	var stdout, stderr bytes.Buffer
+	exitCode, _, err := c.Exec(context.Background(), cmd, tcexec.WithDemux(&stdout, &stderr))
+	if err != nil {
+		t.Errorf("exec %q in container %s: %s", cmd, c.GetContainerID(), err)
+		return false
+	}
+
+	if exitCode != wantExit {
+		t.Errorf("exec %q in container %s: exit code %d, want %d\nstdout:\n%s\nstderr:\n%s", cmd, c.GetContainerID(), exitCode, wantExit, stdout.String(), stderr.String())
+		return false
+	}
+
+	if !re.MatchString(strings.TrimRight(stdout.String(), "\r\n")) {
+		t.Errorf("exec %q in container %s: stdout does not match %q\nstdout:\n%s\nstderr:\n%s", cmd, c.GetContainerID(), wantStdoutRegexp, stdout.String(), stderr.String())
+		return false
+	}
+
+	return true
+}
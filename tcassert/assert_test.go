@@ -0,0 +1,177 @@
+package tcassert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// recordingT is a TestingT recording the failures of the assertions.
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+// fakeContainer is a container whose logs grow over time, and whose commands write the given outputs.
+type fakeContainer struct {
+	testcontainers.Container
+
+	mu   sync.Mutex
+	logs string
+
+	exitCode int
+	stdout   string
+	stderr   string
+}
+
+func (c *fakeContainer) GetContainerID() string {
+	return "fake"
+}
+
+func (c *fakeContainer) log(line string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.logs += line + "\n"
+}
+
+func (c *fakeContainer) Logs(context.Context) (io.ReadCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return io.NopCloser(strings.NewReader(c.logs)), nil
+}
+
+func (c *fakeContainer) Exec(_ context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	// the output of the command is multiplexed, as the one of the Docker daemon
+	var output bytes.Buffer
+	_, _ = stdcopy.NewStdWriter(&output, stdcopy.Stdout).Write([]byte(c.stdout))
+	_, _ = stdcopy.NewStdWriter(&output, stdcopy.Stderr).Write([]byte(c.stderr))
+
+	opts := tcexec.NewProcessOptions(cmd)
+	opts.Reader = &output
+	for _, o := range options {
+		o.Apply(opts)
+	}
+
+	return c.exitCode, opts.Reader, nil
+}
+
+func TestAssertLogContains(t *testing.T) {
+	t.Run("logged later", func(t *testing.T) {
+		c := &fakeContainer{}
+		c.log("starting")
+		time.AfterFunc(200*time.Millisecond, func() {
+			c.log("ready to accept connections")
+		})
+
+		rt := &recordingT{}
+		require.True(t, AssertLogContains(rt, c, "ready to accept", 5*time.Second))
+		require.Empty(t, rt.errors)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := &fakeContainer{}
+		c.log("starting")
+
+		rt := &recordingT{}
+		require.False(t, AssertLogContains(rt, c, "ready to accept", 300*time.Millisecond))
+		require.Len(t, rt.errors, 1)
+		require.Contains(t, rt.errors[0], `do not contain "ready to accept"`)
+		require.Contains(t, rt.errors[0], "starting")
+	})
+}
+
+func TestAssertExec(t *testing.T) {
+	c := &fakeContainer{stdout: "PONG\n", stderr: "warning: no password\n"}
+
+	tests := []struct {
+		name           string
+		exitCode       int
+		wantExit       int
+		wantStdout     string
+		expectedErrors string
+	}{
+		{
+			name:       "success",
+			wantStdout: "^PONG$",
+		},
+		{
+			name:       "any output",
+			wantStdout: "",
+		},
+		{
+			name:           "exit code",
+			exitCode:       1,
+			wantStdout:     "^PONG$",
+			expectedErrors: "exit code 1, want 0",
+		},
+		{
+			name:           "stdout",
+			wantStdout:     "^OK$",
+			expectedErrors: `stdout does not match "^OK$"`,
+		},
+		{
+			name:           "invalid regexp",
+			wantStdout:     "(",
+			expectedErrors: "invalid regular expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.exitCode = tt.exitCode
+
+			rt := &recordingT{}
+			ok := AssertExec(rt, c, []string{"redis-cli", "ping"}, tt.wantExit, tt.wantStdout)
+			if tt.expectedErrors == "" {
+				require.True(t, ok)
+				require.Empty(t, rt.errors)
+				return
+			}
+
+			require.False(t, ok)
+			require.Len(t, rt.errors, 1)
+			require.Contains(t, rt.errors[0], tt.expectedErrors)
+		})
+	}
+}
+
+func TestAssertions(t *testing.T) {
+	ctx := context.Background()
+
+	redis, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7-alpine",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForListeningPort("6379/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, redis.Terminate(ctx))
+	})
+
+	// assertions {
+	AssertLogContains(t, redis, "Ready to accept connections", 10*time.Second)
+	AssertExec(t, redis, []string{"redis-cli", "ping"}, 0, `^PONG$`)
+	// }
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
 )
 
 // SkipIfProviderIsNotHealthy is a utility function capable of skipping tests
@@ -40,6 +43,50 @@ func SkipIfDockerDesktop(t *testing.T, ctx context.Context) {
 	}
 }
 
+// WithTestCleanup labels the container with the name of the given test, and terminates it
+// when the test and all its subtests complete, failing the test if the container can't be terminated.
+// The termination is registered as soon as the container is created, so the container is also
+// terminated when it fails to start, e.g. when its wait strategy times out.
+func WithTestCleanup(t testing.TB) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		WithLabels(map[string]string{core.LabelTest: t.Name()})(req)
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PostCreates: []ContainerHook{
+				func(_ context.Context, c Container) error {
+					t.Cleanup(func() {
+						if err := c.Terminate(context.Background()); err != nil {
+							t.Errorf("failed to terminate container: %s", err)
+						}
+					})
+
+					return nil
+				},
+			},
+		})
+	}
+}
+
+// testDeadlineStartupRatio is the ratio of the time left before the deadline of the test
+// that the container can use to start, leaving the rest to the test to terminate it and report the failures.
+const testDeadlineStartupRatio = 0.9
+
+// WithTestDeadline sets the startup deadline of the container from the deadline of the given test,
+// set with the -timeout flag of go test, so that the container fails to start before the test times out.
+// The container can use 90% of the time left before the deadline of the test to start, including the image pull
+// and the wait strategy. It's a no-op if the test has no deadline.
+func WithTestDeadline(t *testing.T) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		deadline, ok := t.Deadline()
+		if !ok {
+			return
+		}
+
+		left := time.Until(deadline)
+		WithStartupDeadline(time.Now().Add(time.Duration(float64(left) * testDeadlineStartupRatio)))(req)
+	}
+}
+
 // exampleLogConsumer {
 
 // StdoutLogConsumer is a LogConsumer that prints the log to stdout
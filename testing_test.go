@@ -1,7 +1,58 @@
 package testcontainers
 
-import "testing"
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
 
 func ExampleSkipIfProviderIsNotHealthy() {
 	SkipIfProviderIsNotHealthy(&testing.T{})
 }
+
+func TestWithTestCleanup(t *testing.T) {
+	ctx := context.Background()
+
+	var containerID string
+
+	t.Run("subtest", func(t *testing.T) {
+		// withTestCleanup {
+		c, err := Run(ctx, nginxAlpineImage, WithTestCleanup(t))
+		require.NoError(t, err)
+		// }
+
+		inspect, err := c.(*DockerContainer).Info(ctx)
+		require.NoError(t, err)
+		require.Equal(t, t.Name(), inspect.Config.Labels[core.LabelTest])
+
+		containerID = c.GetContainerID()
+	})
+
+	cli, err := NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	// the container is terminated when the subtest completes
+	_, err = cli.ContainerInspect(ctx, containerID)
+	require.Error(t, err)
+
+}
+
+func TestWithTestDeadline(t *testing.T) {
+	req := GenericContainerRequest{}
+	WithTestDeadline(t).Customize(&req)
+
+	deadline, ok := t.Deadline()
+	if !ok {
+		require.True(t, req.Deadline.IsZero())
+		return
+	}
+
+	// part of the time left is kept to terminate the container and report the failures
+	require.True(t, req.Deadline.Before(deadline))
+	require.True(t, req.Deadline.After(time.Now()))
+}
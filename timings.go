@@ -0,0 +1,111 @@
+package testcontainers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// StartupTimings is the time spent in each phase of the startup of a container, to find out which
+// dependencies dominate the startup of a test suite. The phases that didn't happen take no time,
+// e.g. the pull of an image that was already present, or the build of an image that was pulled.
+type StartupTimings struct {
+	// Pull is the time spent pulling the image.
+	Pull time.Duration
+	// Build is the time spent building the image from a Dockerfile.
+	Build time.Duration
+	// Create is the time spent creating the container, including its pre-create and post-create lifecycle hooks,
+	// e.g. copying the files to the container.
+	Create time.Duration
+	// Start is the time spent starting the container, including its pre-start, post-start and post-ready
+	// lifecycle hooks, but not the wait strategy.
+	Start time.Duration
+	// Readiness is the time spent waiting for the container to be ready, with its wait strategy.
+	Readiness time.Duration
+}
+
+// Total returns the time spent in all the phases of the startup.
+func (t StartupTimings) Total() time.Duration {
+	return t.Pull + t.Build + t.Create + t.Start + t.Readiness
+}
+
+// StartupTimings returns the time spent in each phase of the startup of the container.
+// The start and readiness timings are the ones of the last time the container was started.
+func (c *DockerContainer) StartupTimings() StartupTimings {
+	c.timingsMutex.Lock()
+	defer c.timingsMutex.Unlock()
+
+	return c.timings
+}
+
+// updateTimings updates the startup timings of the container with the given function.
+func (c *DockerContainer) updateTimings(update func(t *StartupTimings)) {
+	c.timingsMutex.Lock()
+	defer c.timingsMutex.Unlock()
+
+	update(&c.timings)
+}
+
+// startupRecord is the record of the startup timings of a container, for the summary of the process.
+type startupRecord struct {
+	id      string
+	image   string
+	timings StartupTimings
+}
+
+// startupRecords are the startup timings of the containers started by the process, by container ID.
+var (
+	startupRecordsMu sync.Mutex
+	startupRecords   = map[string]startupRecord{}
+)
+
+// recordStartup records the startup timings of the container, once it's started, for the summary of the process.
+func recordStartup(c *DockerContainer) {
+	startupRecordsMu.Lock()
+	defer startupRecordsMu.Unlock()
+
+	startupRecords[c.ID] = startupRecord{id: c.ID, image: c.Image, timings: c.StartupTimings()}
+}
+
+// PrintStartupTimings writes a summary of the startup timings of all the containers started by the process
+// to the given writer, sorted from the slowest to the fastest, e.g. at the end of TestMain, after running the tests.
+func PrintStartupTimings(w io.Writer) error {
+	startupRecordsMu.Lock()
+	records := make([]startupRecord, 0, len(startupRecords))
+	for _, r := range startupRecords {
+		records = append(records, r)
+	}
+	startupRecordsMu.Unlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].timings.Total() != records[j].timings.Total() {
+			return records[i].timings.Total() > records[j].timings.Total()
+		}
+
+		return records[i].id < records[j].id
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CONTAINER\tIMAGE\tPULL\tBUILD\tCREATE\tSTART\tREADINESS\tTOTAL")
+	for _, r := range records {
+		id := r.id
+		if len(id) > 12 {
+			id = id[:12]
+		}
+
+		t := r.timings
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", id, r.image,
+			roundTiming(t.Pull), roundTiming(t.Build), roundTiming(t.Create),
+			roundTiming(t.Start), roundTiming(t.Readiness), roundTiming(t.Total()))
+	}
+
+	return tw.Flush()
+}
+
+// roundTiming rounds the timing to the millisecond, for display.
+func roundTiming(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
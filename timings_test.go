@@ -0,0 +1,78 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestStartupTimings_Total(t *testing.T) {
+	timings := StartupTimings{
+		Pull:      3 * time.Second,
+		Create:    200 * time.Millisecond,
+		Start:     300 * time.Millisecond,
+		Readiness: 2 * time.Second,
+	}
+
+	require.Equal(t, 5500*time.Millisecond, timings.Total())
+}
+
+func TestPrintStartupTimings(t *testing.T) {
+	startupRecordsMu.Lock()
+	previous := startupRecords
+	startupRecords = map[string]startupRecord{
+		"fast1234567890": {id: "fast1234567890", image: "redis:7", timings: StartupTimings{Create: 100 * time.Millisecond, Start: 200 * time.Millisecond}},
+		"slow1234567890": {id: "slow1234567890", image: "kafka:3", timings: StartupTimings{Pull: 5 * time.Second, Readiness: 10*time.Second + 1234567}},
+	}
+	startupRecordsMu.Unlock()
+	t.Cleanup(func() {
+		startupRecordsMu.Lock()
+		startupRecords = previous
+		startupRecordsMu.Unlock()
+	})
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, PrintStartupTimings(buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"CONTAINER", "IMAGE", "PULL", "BUILD", "CREATE", "START", "READINESS", "TOTAL"}, strings.Fields(lines[0]))
+	// the slowest container goes first
+	require.Equal(t, []string{"slow12345678", "kafka:3", "5s", "0s", "0s", "0s", "10.001s", "15.001s"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"fast12345678", "redis:7", "0s", "0s", "100ms", "200ms", "0s", "300ms"}, strings.Fields(lines[2]))
+}
+
+func TestDockerContainer_StartupTimings(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForHTTP("/").WithPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	// startupTimings {
+	timings := c.(*DockerContainer).StartupTimings()
+	t.Logf("create: %s, start: %s, readiness: %s", timings.Create, timings.Start, timings.Readiness)
+	// }
+
+	require.Positive(t, timings.Create)
+	require.Positive(t, timings.Start)
+	require.Positive(t, timings.Readiness)
+	require.Zero(t, timings.Build)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, PrintStartupTimings(buf))
+	require.Contains(t, buf.String(), c.GetContainerID()[:12])
+}
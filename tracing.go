@@ -0,0 +1,54 @@
+package testcontainers
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/testcontainers/testcontainers-go/internal"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// tracerName is the name of the OpenTelemetry tracer of the library.
+const tracerName = "github.com/testcontainers/testcontainers-go"
+
+// The keys of the attributes of the spans.
+const (
+	spanKeyContainerID = attribute.Key("container.id")
+	spanKeyImage       = attribute.Key("container.image.name")
+	spanKeyExitCode    = attribute.Key("process.exit_code")
+	spanKeySessionID   = attribute.Key("testcontainers.session.id")
+)
+
+// startSpan starts a span for the given operation of the library, e.g. "pull" or "start", as a child of the span of the context,
+// with the given attributes and the ID of the test session. The spans are created with the global tracer provider,
+// which discards them unless the tests set one, e.g. with otel.SetTracerProvider.
+func startSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := otel.Tracer(tracerName, trace.WithInstrumentationVersion(internal.Version))
+
+	return tracer.Start(ctx, "testcontainers."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, spanKeySessionID.String(core.SessionID()))...),
+	)
+}
+
+// endSpan ends the span, recording the error of the operation, if any.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// containerSpanAttrs returns the attributes of the spans of the operations on the given container.
+func containerSpanAttrs(c *DockerContainer) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		spanKeyContainerID.String(c.ID),
+		spanKeyImage.String(c.Image),
+	}
+}
@@ -0,0 +1,167 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// recordingTracerProvider is a tracer provider recording the spans started with its tracers.
+type recordingTracerProvider struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return recordingTracer{provider: p}
+}
+
+// names returns the names of the ended spans, in the order they were started.
+func (p *recordingTracerProvider) names() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var names []string
+	for _, s := range p.spans {
+		if s.ended {
+			names = append(names, s.name)
+		}
+	}
+
+	return names
+}
+
+type recordingTracer struct {
+	provider *recordingTracerProvider
+}
+
+func (t recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+
+	span := &recordedSpan{
+		Span:  trace.SpanFromContext(ctx),
+		name:  name,
+		attrs: cfg.Attributes(),
+	}
+
+	t.provider.mu.Lock()
+	t.provider.spans = append(t.provider.spans, span)
+	t.provider.mu.Unlock()
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// recordedSpan is a span recording its attributes, error and status, delegating the rest of the methods
+// to a non-recording span.
+type recordedSpan struct {
+	trace.Span
+	name   string
+	attrs  []attribute.KeyValue
+	err    error
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attrs = append(s.attrs, kv...)
+}
+
+func (s *recordedSpan) RecordError(err error, _ ...trace.EventOption) {
+	s.err = err
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func (s *recordedSpan) End(...trace.SpanEndOption) {
+	s.ended = true
+}
+
+func (s *recordedSpan) attr(key attribute.Key) string {
+	for _, kv := range s.attrs {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+
+	return ""
+}
+
+// setTracerProvider sets the global tracer provider for the test, restoring the previous one at the end of the test.
+func setTracerProvider(t *testing.T) *recordingTracerProvider {
+	t.Helper()
+
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	tp := &recordingTracerProvider{}
+	otel.SetTracerProvider(tp)
+
+	return tp
+}
+
+func TestStartSpan(t *testing.T) {
+	tp := setTracerProvider(t)
+
+	ctx, span := startSpan(context.Background(), "pull", spanKeyImage.String("nginx:alpine"))
+	require.Equal(t, span, trace.SpanFromContext(ctx))
+	endSpan(span, nil)
+
+	_, span = startSpan(ctx, "exec", containerSpanAttrs(&DockerContainer{ID: "1234567890ab", Image: "nginx:alpine"})...)
+	endSpan(span, errors.New("exec failed"))
+
+	require.Equal(t, []string{"testcontainers.pull", "testcontainers.exec"}, tp.names())
+
+	pull := tp.spans[0]
+	require.Equal(t, "nginx:alpine", pull.attr(spanKeyImage))
+	require.Equal(t, core.SessionID(), pull.attr(spanKeySessionID))
+	require.NoError(t, pull.err)
+	require.Equal(t, codes.Unset, pull.status)
+
+	exec := tp.spans[1]
+	require.Equal(t, "1234567890ab", exec.attr(spanKeyContainerID))
+	require.EqualError(t, exec.err, "exec failed")
+	require.Equal(t, codes.Error, exec.status)
+}
+
+func TestTracing(t *testing.T) {
+	tp := setTracerProvider(t)
+
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	_, _, err = c.Exec(ctx, []string{"nginx", "-t"})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Terminate(ctx))
+
+	require.Subset(t, tp.names(), []string{
+		"testcontainers.create",
+		"testcontainers.start",
+		"testcontainers.wait",
+		"testcontainers.exec",
+		"testcontainers.terminate",
+	})
+}
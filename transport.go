@@ -0,0 +1,214 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// tunnelHost is the host of the local tunnels to the ports of the containers, when a transport is set.
+const tunnelHost = "127.0.0.1"
+
+// Transport connects the tests to the ports published by a remote container-running service, which runs the containers
+// instead of a local Docker daemon, e.g. to offload heavy containers from laptops and thin CI runners to a shared backend.
+// The Docker API of the service is the Docker host of the tc.host property, e.g. the socket of a local agent of the service,
+// authenticated with the token of the tc.host.token property.
+type Transport interface {
+	// DialContext connects to the given address of the service, e.g. "10.0.0.5:32768", where a port of a container is published.
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// TransportFunc is a shorthand to implement the Transport interface with a function, e.g. the DialContext method of an SSH client.
+type TransportFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// DialContext implements the Transport interface.
+func (f TransportFunc) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return f(ctx, network, address)
+}
+
+var (
+	transportMu sync.Mutex
+	transport   Transport
+)
+
+// SetTransport sets the transport to the ports of the containers of a remote container-running service, returning a function
+// restoring the previous one. Once set, the Host and MappedPort methods of the containers return the local end of a tunnel
+// to each TCP port, forwarding its connections through the transport, so the tests and the wait strategies reach the ports
+// as if the containers were local. PortEndpoint, Endpoint and Ports return the tunnels too.
+// The UDP ports are not tunneled, so MappedPort returns ErrPortNotTunneled for them, as MappedPortIPv6 does for any port.
+func SetTransport(t Transport) (restore func()) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+
+	previous := transport
+	transport = t
+
+	return func() {
+		transportMu.Lock()
+		defer transportMu.Unlock()
+
+		transport = previous
+	}
+}
+
+// currentTransport returns the transport set with SetTransport, if any.
+func currentTransport() Transport {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+
+	return transport
+}
+
+// portTunnel forwards the connections to a local port to an address of a remote container-running service, through a transport.
+type portTunnel struct {
+	listener net.Listener
+}
+
+// newPortTunnel listens on a random local port, forwarding its connections to the given address through the transport.
+func newPortTunnel(t Transport, address string) (*portTunnel, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(tunnelHost, "0"))
+	if err != nil {
+		return nil, err
+	}
+
+	pt := &portTunnel{listener: listener}
+	go pt.serve(t, address)
+
+	return pt, nil
+}
+
+// serve accepts the connections to the local port until the tunnel is closed.
+func (pt *portTunnel) serve(t Transport, address string) {
+	for {
+		local, err := pt.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer local.Close()
+
+			remote, err := t.DialContext(context.Background(), "tcp", address)
+			if err != nil {
+				return
+			}
+			defer remote.Close()
+
+			forward(local, remote)
+		}()
+	}
+}
+
+// forward copies the data between the connections, until both directions are done.
+func forward(local net.Conn, remote net.Conn) {
+	done := make(chan struct{}, 2)
+	cp := func(dst net.Conn, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		// half-close the connection, so that the other end knows there's no more data
+		if c, ok := dst.(interface{ CloseWrite() error }); ok {
+			_ = c.CloseWrite()
+		} else {
+			_ = dst.Close()
+		}
+		done <- struct{}{}
+	}
+
+	go cp(remote, local)
+	go cp(local, remote)
+
+	<-done
+	<-done
+}
+
+// port returns the local port of the tunnel.
+func (pt *portTunnel) port() nat.Port {
+	return nat.Port(strconv.Itoa(pt.listener.Addr().(*net.TCPAddr).Port) + "/tcp")
+}
+
+// Close stops accepting connections to the local port. The connections already accepted are forwarded until they're closed.
+func (pt *portTunnel) Close() error {
+	return pt.listener.Close()
+}
+
+// tunnelPort returns the local port of the tunnel to the given port of the Docker host, creating the tunnel if needed,
+// or the port itself if no transport is set. It returns ErrPortNotTunneled if it's not a TCP port.
+func (c *DockerContainer) tunnelPort(ctx context.Context, port nat.Port) (nat.Port, error) {
+	t := currentTransport()
+	if t == nil {
+		return port, nil
+	}
+	if port.Proto() != "tcp" {
+		return "", fmt.Errorf("%w: %s", ErrPortNotTunneled, port)
+	}
+
+	host, err := c.provider.DaemonHost(ctx)
+	if err != nil {
+		return "", err
+	}
+	address := net.JoinHostPort(host, port.Port())
+
+	c.tunnelsMutex.Lock()
+	defer c.tunnelsMutex.Unlock()
+
+	if pt, ok := c.tunnels[address]; ok {
+		return pt.port(), nil
+	}
+
+	pt, err := newPortTunnel(t, address)
+	if err != nil {
+		return "", err
+	}
+
+	if c.tunnels == nil {
+		c.tunnels = map[string]*portTunnel{}
+	}
+	c.tunnels[address] = pt
+
+	return pt.port(), nil
+}
+
+// tunnelPorts returns a copy of the given port map binding each published TCP port to the local port of its tunnel,
+// creating the tunnels if needed, or the port map itself if no transport is set. The other ports have no bindings.
+func (c *DockerContainer) tunnelPorts(ctx context.Context, ports nat.PortMap) (nat.PortMap, error) {
+	if currentTransport() == nil {
+		return ports, nil
+	}
+
+	tunneled := make(nat.PortMap, len(ports))
+	for port, bindings := range ports {
+		if port.Proto() != "tcp" || len(bindings) == 0 {
+			tunneled[port] = nil
+			continue
+		}
+
+		mapped, err := nat.NewPort(port.Proto(), bindings[0].HostPort)
+		if err != nil {
+			return nil, err
+		}
+
+		local, err := c.tunnelPort(ctx, mapped)
+		if err != nil {
+			return nil, err
+		}
+
+		tunneled[port] = []nat.PortBinding{{HostIP: tunnelHost, HostPort: local.Port()}}
+	}
+
+	return tunneled, nil
+}
+
+// closeTunnels closes the tunnels to the ports of the container.
+func (c *DockerContainer) closeTunnels() {
+	c.tunnelsMutex.Lock()
+	defer c.tunnelsMutex.Unlock()
+
+	for address, pt := range c.tunnels {
+		_ = pt.Close()
+		delete(c.tunnels, address)
+	}
+}
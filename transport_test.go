@@ -0,0 +1,127 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+// startEchoServer starts a TCP server echoing the data of its connections, returning its port.
+func startEchoServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	return strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func TestSetTransport(t *testing.T) {
+	ctx := context.Background()
+	echoPort := startEchoServer(t)
+
+	c := &DockerContainer{provider: &DockerProvider{hostCache: "127.0.0.1"}}
+
+	t.Run("no transport", func(t *testing.T) {
+		port, err := c.tunnelPort(ctx, nat.Port(echoPort+"/tcp"))
+		require.NoError(t, err)
+		require.Equal(t, nat.Port(echoPort+"/tcp"), port)
+	})
+
+	// setTransport {
+	dialed := make(chan string, 1)
+	restore := SetTransport(TransportFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		dialed <- address
+
+		// e.g. the DialContext method of an SSH client connected to the remote service
+		var d net.Dialer
+		return d.DialContext(ctx, network, address)
+	}))
+	defer restore()
+	// }
+
+	t.Run("tcp port", func(t *testing.T) {
+		port, err := c.tunnelPort(ctx, nat.Port(echoPort+"/tcp"))
+		require.NoError(t, err)
+		require.NotEqual(t, nat.Port(echoPort+"/tcp"), port)
+
+		// the tunnel of the port is reused
+		again, err := c.tunnelPort(ctx, nat.Port(echoPort+"/tcp"))
+		require.NoError(t, err)
+		require.Equal(t, port, again)
+
+		host, err := c.Host(ctx)
+		require.NoError(t, err)
+
+		conn, err := net.Dial("tcp", net.JoinHostPort(host, port.Port()))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("ping"))
+		require.NoError(t, err)
+
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		require.Equal(t, "ping", string(buf))
+
+		require.Equal(t, "127.0.0.1:"+echoPort, <-dialed)
+
+		c.closeTunnels()
+
+		_, err = net.Dial("tcp", net.JoinHostPort(host, port.Port()))
+		require.Error(t, err)
+	})
+
+	t.Run("udp port", func(t *testing.T) {
+		_, err := c.tunnelPort(ctx, "53/udp")
+		require.ErrorIs(t, err, ErrPortNotTunneled)
+		require.EqualError(t, err, "port not tunneled: 53/udp")
+	})
+
+	t.Run("port accessors", func(t *testing.T) {
+		c := &DockerContainer{
+			ID:       "0123456789ab",
+			provider: &DockerProvider{client: &inspectCountingClient{hostPort: echoPort}, hostCache: "127.0.0.1"},
+		}
+		defer c.closeTunnels()
+
+		port, err := c.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.NotEqual(t, nat.Port(echoPort+"/tcp"), port)
+
+		// the ports are bound to the local end of their tunnels
+		ports, err := c.Ports(ctx)
+		require.NoError(t, err)
+		require.Equal(t, nat.PortMap{"80/tcp": []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: port.Port()}}}, ports)
+
+		endpoint, err := c.PortEndpoint(ctx, "80/tcp", "")
+		require.NoError(t, err)
+		require.Equal(t, "127.0.0.1:"+port.Port(), endpoint)
+
+		_, err = c.MappedPortIPv6(ctx, "80/tcp")
+		require.ErrorIs(t, err, ErrPortNotTunneled)
+	})
+}
@@ -2,6 +2,8 @@ package wait
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"time"
 
@@ -76,22 +78,26 @@ func (ws *ExecStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		timeout = *ws.timeout
 	}
 
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	var lastErr error
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return timeoutError(ctx, ws, start, lastErr)
 		case <-time.After(ws.PollInterval):
 			exitCode, resp, err := target.Exec(ctx, ws.cmd, tcexec.Multiplexed())
 			if err != nil {
 				return err
 			}
 			if !ws.ExitCodeMatcher(exitCode) {
+				lastErr = fmt.Errorf("unexpected exit code %d", exitCode)
 				continue
 			}
 			if ws.ResponseMatcher != nil && !ws.ResponseMatcher(resp) {
+				lastErr = errors.New("unexpected response")
 				continue
 			}
 
@@ -61,6 +61,7 @@ func (ws *ExitStrategy) Timeout() *time.Duration {
 
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *ExitStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	start := time.Now()
 	if ws.timeout != nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, *ws.timeout)
@@ -70,7 +71,7 @@ func (ws *ExitStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return timeoutError(ctx, ws, start, nil)
 		default:
 			state, err := target.State(ctx)
 			if err != nil {
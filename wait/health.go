@@ -2,6 +2,7 @@ package wait
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -67,13 +68,15 @@ func (ws *HealthStrategy) WaitUntilReady(ctx context.Context, target StrategyTar
 		timeout = *ws.timeout
 	}
 
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	var lastErr error
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return timeoutError(ctx, ws, start, lastErr)
 		default:
 			state, err := target.State(ctx)
 			if err != nil {
@@ -83,6 +86,9 @@ func (ws *HealthStrategy) WaitUntilReady(ctx context.Context, target StrategyTar
 				return err
 			}
 			if state.Health == nil || state.Health.Status != types.Healthy {
+				if state.Health != nil {
+					lastErr = fmt.Errorf("health status %q", state.Health.Status)
+				}
 				time.Sleep(ws.PollInterval)
 				continue
 			}
@@ -28,6 +28,8 @@ type HostPortStrategy struct {
 	// all WaitStrategies should have a startupTimeout to avoid waiting infinitely
 	timeout      *time.Duration
 	PollInterval time.Duration
+	// skipInternalCheck skips the check of the port from inside the container, which runs a shell
+	skipInternalCheck bool
 }
 
 // NewHostPortStrategy constructs a default host port strategy
@@ -66,6 +68,13 @@ func (hp *HostPortStrategy) WithPollInterval(pollInterval time.Duration) *HostPo
 	return hp
 }
 
+// SkipInternalCheck skips the check of the port from inside the container, which runs /bin/sh,
+// e.g. for Windows containers, where the shell can't be executed and the exec fails.
+func (hp *HostPortStrategy) SkipInternalCheck() *HostPortStrategy {
+	hp.skipInternalCheck = true
+	return hp
+}
+
 func (hp *HostPortStrategy) Timeout() *time.Duration {
 	return hp.timeout
 }
@@ -77,6 +86,7 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 		timeout = *hp.timeout
 	}
 
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -115,7 +125,7 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("%w: %w", ctx.Err(), err)
+			return timeoutError(ctx, hp, start, err)
 		case <-time.After(waitInterval):
 			if err := checkTarget(ctx, target); err != nil {
 				return err
@@ -128,12 +138,21 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 	}
 
 	if err := externalCheck(ctx, ipAddress, port, target, waitInterval); err != nil {
+		if ctx.Err() != nil {
+			return timeoutError(ctx, hp, start, err)
+		}
 		return err
 	}
 
+	if hp.skipInternalCheck {
+		return nil
+	}
+
 	err = internalCheck(ctx, internalPort, target)
 	if err != nil && errors.Is(errShellNotExecutable, err) {
 		log.Println("Shell not executable in container, only external port check will be performed")
+	} else if err != nil && ctx.Err() != nil {
+		return timeoutError(ctx, hp, start, err)
 	} else {
 		return err
 	}
@@ -148,7 +167,11 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 
 	dialer := net.Dialer{}
 	address := net.JoinHostPort(ipAddress, portString)
+	var lastErr error
 	for {
+		if ctx.Err() != nil && lastErr != nil {
+			return lastErr
+		}
 		if err := checkTarget(ctx, target); err != nil {
 			return err
 		}
@@ -159,6 +182,7 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 				var v2 *os.SyscallError
 				if errors.As(v.Err, &v2) {
 					if isConnRefusedErr(v2.Err) {
+						lastErr = err
 						time.Sleep(waitInterval)
 						continue
 					}
@@ -175,8 +199,12 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 
 func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget) error {
 	command := buildInternalCheckCommand(internalPort.Int())
+	var lastErr error
 	for {
 		if ctx.Err() != nil {
+			if lastErr != nil {
+				return lastErr
+			}
 			return ctx.Err()
 		}
 		if err := checkTarget(ctx, target); err != nil {
@@ -192,6 +220,7 @@ func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTa
 		} else if exitCode == 126 {
 			return errShellNotExecutable
 		}
+		lastErr = fmt.Errorf("port %d not listening in the container", internalPort.Int())
 	}
 	return nil
 }
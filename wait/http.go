@@ -145,6 +145,7 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		timeout = *ws.timeout
 	}
 
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -165,7 +166,7 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		for err != nil || ports == nil {
 			select {
 			case <-ctx.Done():
-				return fmt.Errorf("%w: %w", ctx.Err(), err)
+				return timeoutError(ctx, ws, start, err)
 			case <-time.After(ws.PollInterval):
 				if err := checkTarget(ctx, target); err != nil {
 					return err
@@ -192,7 +193,7 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		for mappedPort == "" {
 			select {
 			case <-ctx.Done():
-				return fmt.Errorf("%w: %w", ctx.Err(), err)
+				return timeoutError(ctx, ws, start, err)
 			case <-time.After(ws.PollInterval):
 				if err := checkTarget(ctx, target); err != nil {
 					return err
@@ -269,10 +270,11 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		}
 	}
 
+	var lastErr error
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return timeoutError(ctx, ws, start, lastErr)
 		case <-time.After(ws.PollInterval):
 			if err := checkTarget(ctx, target); err != nil {
 				return err
@@ -283,17 +285,21 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 			}
 			resp, err := client.Do(req)
 			if err != nil {
+				lastErr = err
 				continue
 			}
 			if ws.StatusCodeMatcher != nil && !ws.StatusCodeMatcher(resp.StatusCode) {
 				_ = resp.Body.Close()
+				lastErr = fmt.Errorf("unexpected status code %d", resp.StatusCode)
 				continue
 			}
 			if ws.ResponseMatcher != nil && !ws.ResponseMatcher(resp.Body) {
 				_ = resp.Body.Close()
+				lastErr = errors.New("unexpected response")
 				continue
 			}
 			if err := resp.Body.Close(); err != nil {
+				lastErr = err
 				continue
 			}
 			return nil
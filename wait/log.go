@@ -89,30 +89,35 @@ func (ws *LogStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget
 		timeout = *ws.timeout
 	}
 
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	length := 0
+	var lastErr error
 
 LOOP:
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return timeoutError(ctx, ws, start, lastErr)
 		default:
 			checkErr := checkTarget(ctx, target)
 
 			reader, err := target.Logs(ctx)
 			if err != nil {
+				lastErr = err
 				time.Sleep(ws.PollInterval)
 				continue
 			}
 
 			b, err := io.ReadAll(reader)
 			if err != nil {
+				lastErr = err
 				time.Sleep(ws.PollInterval)
 				continue
 			}
+			lastErr = nil
 
 			logs := string(b)
 
@@ -0,0 +1,168 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*NoLogStrategy)(nil)
+	_ StrategyTimeout = (*NoLogStrategy)(nil)
+)
+
+// ErrLogFound is the error of a NoLogStrategy whose log entry showed up in the container logs.
+var ErrLogFound = errors.New("unexpected log entry found")
+
+// LogFoundError is the error of a NoLogStrategy whose log entry showed up in the container logs,
+// with the offending line. It matches ErrLogFound with errors.Is.
+type LogFoundError struct {
+	// Log is the log entry of the strategy, as a plain text or a regular expression.
+	Log string
+	// Line is the first line of the container logs containing the log entry.
+	Line string
+}
+
+// Error implements the error interface.
+func (e *LogFoundError) Error() string {
+	return fmt.Sprintf("%s: %q in line: %s", ErrLogFound, e.Log, e.Line)
+}
+
+// Is makes the error match ErrLogFound.
+func (e *LogFoundError) Is(target error) bool {
+	return target == ErrLogFound
+}
+
+// NoLogStrategy will wait until the observation window elapses without a given log entry showing up in the docker logs,
+// failing as soon as it shows up, e.g. an ERROR or a stack trace of a misconfigured dependency.
+type NoLogStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	// additional properties
+	Log               string
+	IsRegexp          bool
+	ObservationWindow time.Duration
+	PollInterval      time.Duration
+}
+
+// NewNoLogStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
+func NewNoLogStrategy(log string, observationWindow time.Duration) *NoLogStrategy {
+	return &NoLogStrategy{
+		Log:               log,
+		IsRegexp:          false,
+		ObservationWindow: observationWindow,
+		PollInterval:      defaultPollInterval(),
+	}
+}
+
+// AsRegexp can be used to change the default behavior of the no log strategy to use regexp instead of plain text
+func (ws *NoLogStrategy) AsRegexp() *NoLogStrategy {
+	ws.IsRegexp = true
+	return ws
+}
+
+// WithStartupTimeout can be used to change the default startup timeout, which must be longer than the observation window
+func (ws *NoLogStrategy) WithStartupTimeout(timeout time.Duration) *NoLogStrategy {
+	ws.timeout = &timeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *NoLogStrategy) WithPollInterval(pollInterval time.Duration) *NoLogStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+// ForNoLog is the default construction for the fluid interface. The container is ready once the observation window,
+// starting when the strategy runs, elapses without the log entry showing up in its logs, including the ones written
+// before the strategy runs. It fails with a *LogFoundError as soon as the log entry shows up, so that a misconfigured
+// container fails fast with the offending line, instead of timing out later in the test.
+//
+// For Example:
+//
+//	wait.ForAll(
+//		wait.ForLog("started"),
+//		wait.ForNoLog(`ERROR|FATAL|panic:`, 5*time.Second).AsRegexp(),
+//	)
+func ForNoLog(log string, observationWindow time.Duration) *NoLogStrategy {
+	return NewNoLogStrategy(log, observationWindow)
+}
+
+func (ws *NoLogStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *NoLogStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout()
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	match := func(line string) bool { return strings.Contains(line, ws.Log) }
+	if ws.IsRegexp {
+		re, err := regexp.Compile(ws.Log)
+		if err != nil {
+			return fmt.Errorf("invalid log regexp %q: %w", ws.Log, err)
+		}
+		match = re.MatchString
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		// the state is checked before the logs are read, so that the logs of an exited container are complete
+		checkErr := checkTarget(ctx, target)
+
+		line, found, err := ws.findLine(ctx, target, match)
+		switch {
+		case err != nil:
+			lastErr = err
+		case found:
+			return &LogFoundError{Log: ws.Log, Line: line}
+		case checkErr != nil:
+			return checkErr
+		case time.Since(start) >= ws.ObservationWindow:
+			return nil
+		default:
+			lastErr = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return timeoutError(ctx, ws, start, lastErr)
+		case <-time.After(ws.PollInterval):
+		}
+	}
+}
+
+// findLine returns the first line of the container logs matching the log entry, if any.
+func (ws *NoLogStrategy) findLine(ctx context.Context, target StrategyTarget, match func(string) bool) (string, bool, error) {
+	reader, err := target.Logs(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	defer reader.Close()
+
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, line := range strings.Split(string(b), "\n") {
+		if match(line) {
+			return strings.TrimRight(line, "\r"), true, nil
+		}
+	}
+
+	return "", false, nil
+}
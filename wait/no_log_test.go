@@ -0,0 +1,99 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+)
+
+// growingLogsTarget is a target whose logs grow by one line each time they are read.
+type growingLogsTarget struct {
+	NopStrategyTarget
+
+	lines []string
+	reads atomic.Int32
+}
+
+func (st *growingLogsTarget) Logs(_ context.Context) (io.ReadCloser, error) {
+	n := int(st.reads.Add(1))
+	if n > len(st.lines) {
+		n = len(st.lines)
+	}
+
+	return io.NopCloser(strings.NewReader(strings.Join(st.lines[:n], "\n"))), nil
+}
+
+func TestWaitForNoLog(t *testing.T) {
+	running := types.ContainerState{Running: true}
+
+	t.Run("absent", func(t *testing.T) {
+		target := &growingLogsTarget{
+			NopStrategyTarget: NopStrategyTarget{ContainerState: running},
+			lines:             []string{"starting", "listening on :8080"},
+		}
+
+		start := time.Now()
+		err := ForNoLog("ERROR", 200*time.Millisecond).WithPollInterval(10*time.Millisecond).WaitUntilReady(context.Background(), target)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	})
+
+	t.Run("found", func(t *testing.T) {
+		target := &growingLogsTarget{
+			NopStrategyTarget: NopStrategyTarget{ContainerState: running},
+			lines:             []string{"starting", "ERROR: connection to db:5432 refused\r", "retrying"},
+		}
+
+		err := ForNoLog("ERROR", time.Minute).WithPollInterval(10*time.Millisecond).WaitUntilReady(context.Background(), target)
+		require.ErrorIs(t, err, ErrLogFound)
+
+		var logErr *LogFoundError
+		require.True(t, errors.As(err, &logErr))
+		require.Equal(t, "ERROR: connection to db:5432 refused", logErr.Line)
+		require.EqualError(t, err, `unexpected log entry found: "ERROR" in line: ERROR: connection to db:5432 refused`)
+	})
+
+	t.Run("found as regexp", func(t *testing.T) {
+		target := &growingLogsTarget{
+			NopStrategyTarget: NopStrategyTarget{ContainerState: running},
+			lines:             []string{"starting", "panic: runtime error: invalid memory address", "goroutine 1 [running]:"},
+		}
+
+		err := ForNoLog(`^(ERROR|FATAL|panic:)`, time.Minute).AsRegexp().WithPollInterval(10*time.Millisecond).WaitUntilReady(context.Background(), target)
+		require.ErrorIs(t, err, ErrLogFound)
+		require.Equal(t, "panic: runtime error: invalid memory address", err.(*LogFoundError).Line)
+	})
+
+	t.Run("invalid regexp", func(t *testing.T) {
+		err := ForNoLog(`(ERROR`, time.Minute).AsRegexp().WaitUntilReady(context.Background(), &growingLogsTarget{})
+		require.ErrorContains(t, err, `invalid log regexp "(ERROR"`)
+	})
+
+	t.Run("container exited", func(t *testing.T) {
+		target := &growingLogsTarget{
+			NopStrategyTarget: NopStrategyTarget{ContainerState: types.ContainerState{Status: "exited", ExitCode: 1}},
+			lines:             []string{"starting"},
+		}
+
+		err := ForNoLog("ERROR", time.Minute).WaitUntilReady(context.Background(), target)
+		require.EqualError(t, err, "container exited with code 1")
+	})
+
+	t.Run("startup timeout shorter than the observation window", func(t *testing.T) {
+		target := &growingLogsTarget{
+			NopStrategyTarget: NopStrategyTarget{ContainerState: running},
+			lines:             []string{"starting"},
+		}
+
+		err := ForNoLog("ERROR", time.Minute).WithStartupTimeout(50*time.Millisecond).WithPollInterval(10*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		require.ErrorIs(t, err, ErrTimeout)
+	})
+}
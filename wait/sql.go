@@ -70,6 +70,7 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		timeout = *w.timeout
 	}
 
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -87,7 +88,7 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 	for port == "" {
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("%w: %w", ctx.Err(), err)
+			return timeoutError(ctx, w, start, err)
 		case <-ticker.C:
 			if err := checkTarget(ctx, target); err != nil {
 				return err
@@ -101,15 +102,17 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		return fmt.Errorf("sql.Open: %w", err)
 	}
 	defer db.Close()
+	var lastErr error
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return timeoutError(ctx, w, start, lastErr)
 		case <-ticker.C:
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
 			if _, err := db.ExecContext(ctx, w.query); err != nil {
+				lastErr = err
 				continue
 			}
 			return nil
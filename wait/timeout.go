@@ -0,0 +1,69 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimeout is the error of a strategy whose startup timeout, or the deadline of its context, was exceeded
+// before the container was ready.
+var ErrTimeout = errors.New("wait strategy timed out")
+
+// TimeoutError is the error of a strategy which timed out, with the error of its last probe, if any,
+// e.g. the refused connection of a port which is not listening yet. It matches ErrTimeout and
+// context.DeadlineExceeded with errors.Is.
+type TimeoutError struct {
+	// Strategy is the strategy which timed out.
+	Strategy Strategy
+	// Elapsed is the time the strategy waited for.
+	Elapsed time.Duration
+	// LastProbeErr is the error of the last probe of the strategy, or nil if it's unknown.
+	LastProbeErr error
+	// Err is the error of the context of the strategy.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *TimeoutError) Error() string {
+	msg := fmt.Sprintf("%T timed out after %s", e.Strategy, e.Elapsed.Round(time.Millisecond))
+	if e.LastProbeErr != nil {
+		msg += ", last probe: " + e.LastProbeErr.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", msg, e.Err)
+}
+
+// Unwrap returns the error of the context and the error of the last probe.
+func (e *TimeoutError) Unwrap() []error {
+	if e.LastProbeErr == nil {
+		return []error{e.Err}
+	}
+
+	return []error{e.Err, e.LastProbeErr}
+}
+
+// Is makes the error match ErrTimeout.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// timeoutError returns the error of a strategy whose context is done: a TimeoutError if the deadline of the context
+// was exceeded, or else the error of the context, e.g. if it was canceled, along with the error of the last probe.
+func timeoutError(ctx context.Context, strategy Strategy, start time.Time, lastProbeErr error) error {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if lastProbeErr == nil {
+			return ctx.Err()
+		}
+
+		return fmt.Errorf("%w: %w", ctx.Err(), lastProbeErr)
+	}
+
+	// a probe cut by the deadline tells nothing about why the container is not ready
+	if errors.Is(lastProbeErr, context.DeadlineExceeded) {
+		lastProbeErr = nil
+	}
+
+	return &TimeoutError{Strategy: strategy, Elapsed: time.Since(start), LastProbeErr: lastProbeErr, Err: ctx.Err()}
+}
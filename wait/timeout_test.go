@@ -0,0 +1,79 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+func TestTimeoutError(t *testing.T) {
+	t.Run("health", func(t *testing.T) {
+		target := healthStrategyTarget{
+			state: &types.ContainerState{
+				Running: true,
+				Health:  &types.Health{Status: types.Unhealthy},
+			},
+		}
+		strategy := NewHealthStrategy().WithStartupTimeout(100 * time.Millisecond)
+
+		err := strategy.WaitUntilReady(context.Background(), target)
+		require.ErrorIs(t, err, ErrTimeout)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		var timeoutErr *TimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		require.Same(t, strategy, timeoutErr.Strategy)
+		require.GreaterOrEqual(t, timeoutErr.Elapsed, 100*time.Millisecond)
+		require.EqualError(t, timeoutErr.LastProbeErr, `health status "unhealthy"`)
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		target := MockStrategyTarget{
+			ExecImpl: func(context.Context, []string, ...tcexec.ProcessOption) (int, io.Reader, error) {
+				return 1, nil, nil
+			},
+		}
+		strategy := ForExec([]string{"true"}).WithStartupTimeout(100 * time.Millisecond).WithPollInterval(10 * time.Millisecond)
+
+		err := strategy.WaitUntilReady(context.Background(), target)
+		require.ErrorIs(t, err, ErrTimeout)
+
+		var timeoutErr *TimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		require.EqualError(t, timeoutErr.LastProbeErr, "unexpected exit code 1")
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		target := healthStrategyTarget{state: &types.ContainerState{Running: true}}
+
+		// a canceled context is not a timeout
+		err := NewHealthStrategy().WaitUntilReady(ctx, target)
+		require.ErrorIs(t, err, context.Canceled)
+		require.NotErrorIs(t, err, ErrTimeout)
+	})
+
+	t.Run("message", func(t *testing.T) {
+		probeErr := errors.New("connection refused")
+		err := &TimeoutError{
+			Strategy:     ForListeningPort("5432/tcp"),
+			Elapsed:      1500 * time.Millisecond,
+			LastProbeErr: probeErr,
+			Err:          context.DeadlineExceeded,
+		}
+		require.EqualError(t, err, "*wait.HostPortStrategy timed out after 1.5s, last probe: connection refused: context deadline exceeded")
+		require.ErrorIs(t, err, probeErr)
+
+		err.LastProbeErr = nil
+		require.EqualError(t, err, "*wait.HostPortStrategy timed out after 1.5s: context deadline exceeded")
+	})
+}
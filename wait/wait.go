@@ -11,6 +11,7 @@ import (
 	"github.com/docker/go-connections/nat"
 
 	"github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/internal/config"
 )
 
 // Strategy defines the basic interface for a Wait Strategy
@@ -54,7 +55,13 @@ func checkState(state *types.ContainerState) error {
 	}
 }
 
+// defaultStartupTimeout returns the startup timeout of the strategies without an explicit one,
+// which is the startup.timeout property of the configuration, or 60 seconds if it's not set.
 func defaultStartupTimeout() time.Duration {
+	if timeout := config.Read().StartupTimeout; timeout > 0 {
+		return timeout
+	}
+
 	return 60 * time.Second
 }
 
@@ -0,0 +1,93 @@
+package testcontainers
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// windowsReaperWarning logs once that the reaper is not started for the Windows containers.
+var windowsReaperWarning sync.Once
+
+// isWindowsDaemon returns true if the Docker daemon runs Windows containers, i.e. it's in Windows mode.
+func (p *DockerProvider) isWindowsDaemon(ctx context.Context) bool {
+	info, err := p.client.Info(ctx)
+	if err != nil {
+		return false
+	}
+
+	return info.OSType == "windows"
+}
+
+// removeLinuxOnlySettings removes the settings of the host config that Windows containers don't support,
+// returning the names of the removed ones, so that the requests shared with Linux containers work as is.
+func removeLinuxOnlySettings(hc *container.HostConfig) []string {
+	var removed []string
+	remove := func(name string, set bool, reset func()) {
+		if set {
+			removed = append(removed, name)
+			reset()
+		}
+	}
+
+	remove("Privileged", hc.Privileged, func() { hc.Privileged = false })
+	remove("ShmSize", hc.ShmSize != 0, func() { hc.ShmSize = 0 })
+	remove("Tmpfs", len(hc.Tmpfs) > 0, func() { hc.Tmpfs = nil })
+	remove("CapAdd", len(hc.CapAdd) > 0, func() { hc.CapAdd = nil })
+	remove("CapDrop", len(hc.CapDrop) > 0, func() { hc.CapDrop = nil })
+	remove("Sysctls", len(hc.Sysctls) > 0, func() { hc.Sysctls = nil })
+	remove("SecurityOpt", len(hc.SecurityOpt) > 0, func() { hc.SecurityOpt = nil })
+	remove("OomScoreAdj", hc.OomScoreAdj != 0, func() { hc.OomScoreAdj = 0 })
+	remove("OomKillDisable", hc.OomKillDisable != nil, func() { hc.OomKillDisable = nil })
+	remove("MemorySwap", hc.MemorySwap != 0, func() { hc.MemorySwap = 0 })
+	remove("MemorySwappiness", hc.MemorySwappiness != nil, func() { hc.MemorySwappiness = nil })
+	remove("PidsLimit", hc.PidsLimit != nil, func() { hc.PidsLimit = nil })
+	remove("CgroupnsMode", hc.CgroupnsMode != "", func() { hc.CgroupnsMode = "" })
+
+	return removed
+}
+
+// isWindowsPath returns true if the path is a Windows path, i.e. it starts with a drive letter, e.g. "C:\app" or "c:/app".
+func isWindowsPath(p string) bool {
+	return len(p) >= 2 && p[1] == ':' && (p[0] >= 'a' && p[0] <= 'z' || p[0] >= 'A' && p[0] <= 'Z')
+}
+
+// containerPathDir returns the parent directory of a path in a container, which is a Windows path for Windows containers,
+// regardless of the operating system running the tests.
+func containerPathDir(p string) string {
+	if !isWindowsPath(p) && !strings.Contains(p, `\`) {
+		return path.Dir(p)
+	}
+
+	i := strings.LastIndexAny(p, `\/`)
+	switch {
+	case i < 0:
+		return "."
+	case i == 2 && isWindowsPath(p):
+		// the root of the drive, e.g. "C:\"
+		return p[:3]
+	default:
+		return p[:i]
+	}
+}
+
+// splitBind splits a bind mount in the "source:target[:options]" format into its parts, keeping the drive letters
+// of the Windows paths, e.g. "C:\data:C:\app".
+func splitBind(bind string) []string {
+	var parts []string
+	for _, part := range strings.Split(bind, ":") {
+		last := len(parts) - 1
+		// a drive letter is part of the next path, which has backslashes, as "a:/srv" is a volume named "a"
+		if last >= 0 && len(parts[last]) == 1 && strings.HasPrefix(part, `\`) && isWindowsPath(parts[last]+":") {
+			parts[last] += ":" + part
+			continue
+		}
+
+		parts = append(parts, part)
+	}
+
+	return parts
+}
@@ -0,0 +1,71 @@
+package testcontainers
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveLinuxOnlySettings(t *testing.T) {
+	oomKillDisable := true
+	hc := &container.HostConfig{
+		Privileged: true,
+		ShmSize:    1024,
+		Tmpfs:      map[string]string{"/tmp": "rw"},
+		CapAdd:     []string{"NET_ADMIN"},
+		Binds:      []string{`C:\data:C:\data`},
+	}
+	hc.OomKillDisable = &oomKillDisable
+
+	removed := removeLinuxOnlySettings(hc)
+	require.Equal(t, []string{"Privileged", "ShmSize", "Tmpfs", "CapAdd", "OomKillDisable"}, removed)
+	require.Equal(t, &container.HostConfig{Binds: []string{`C:\data:C:\data`}}, hc)
+
+	require.Empty(t, removeLinuxOnlySettings(hc))
+}
+
+func TestContainerPathDir(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/tmp/data", want: "/tmp"},
+		{path: "/tmp/data/", want: "/tmp/data"},
+		{path: "/data", want: "/"},
+		{path: `C:\app\data`, want: `C:\app`},
+		{path: `C:\app\data\`, want: `C:\app\data`},
+		{path: `C:\data`, want: `C:\`},
+		{path: "C:/app/data", want: "C:/app"},
+		{path: `app\data`, want: "app"},
+		{path: "data", want: "."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			require.Equal(t, tt.want, containerPathDir(tt.path))
+		})
+	}
+}
+
+func TestSplitBind(t *testing.T) {
+	tests := []struct {
+		bind string
+		want []string
+	}{
+		{bind: "/data:/srv", want: []string{"/data", "/srv"}},
+		{bind: "/data:/srv:ro", want: []string{"/data", "/srv", "ro"}},
+		{bind: "my-volume:/srv", want: []string{"my-volume", "/srv"}},
+		{bind: `C:\data:C:\app`, want: []string{`C:\data`, `C:\app`}},
+		{bind: `c:\data:c:\app:ro`, want: []string{`c:\data`, `c:\app`, "ro"}},
+		{bind: "a:/srv", want: []string{"a", "/srv"}},
+		{bind: `my-volume:C:\app`, want: []string{"my-volume", `C:\app`}},
+		{bind: `\\.\pipe\docker_engine:\\.\pipe\docker_engine`, want: []string{`\\.\pipe\docker_engine`, `\\.\pipe\docker_engine`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bind, func(t *testing.T) {
+			require.Equal(t, tt.want, splitBind(tt.bind))
+		})
+	}
+}